@@ -0,0 +1,30 @@
+package automation
+
+import (
+	"awesomeProject2/cmd/model"
+	"time"
+)
+
+type RuleStorage interface {
+	GetRules(boardID int) ([]model.AutomationRule, error)
+	GetRule(id int) (model.AutomationRule, error)
+	CreateRule(input model.AutomationRuleInput) (model.AutomationRule, error)
+	UpdateRule(id int, input model.AutomationRuleInput) (model.AutomationRule, error)
+	DeleteRule(id int) error
+	CreateRun(run model.AutomationRun) (model.AutomationRun, error)
+	GetRuns(boardID int, limit int) ([]model.AutomationRun, error)
+}
+
+// BoardReader нужен для проверки, что списки и метки правила лежат на его доске.
+type BoardReader interface {
+	GetBoard(id int) (model.Board, error)
+	GetLists(boardID *int) ([]model.List, error)
+	GetLabels(boardID int) ([]model.Label, error)
+}
+
+type DueStorage interface {
+	GetDueCards(before time.Time) ([]model.Card, error)
+	MarkDueTriggered(id int) error
+	GetDueSoonCards(before time.Time) ([]model.Card, error)
+	MarkDueSoonTriggered(id int) error
+}
@@ -0,0 +1,86 @@
+package automation
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// DueWatcher периодически ищет карточки с наступившим сроком и публикует
+// для каждой событие due_passed, а за SoonWindow до срока — due_soon.
+// Срок отмечается обработанным до публикации, поэтому каждое событие
+// приходит не больше одного раза.
+type DueWatcher struct {
+	Storage  DueStorage
+	Events   service.EventPublisher
+	Interval time.Duration
+	// SoonWindow — за сколько до срока публиковать due_soon; 0 отключает.
+	SoonWindow time.Duration
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+func NewDueWatcher(storage DueStorage, events service.EventPublisher, interval, soonWindow time.Duration, logger *zap.Logger) *DueWatcher {
+	return &DueWatcher{
+		Storage:    storage,
+		Events:     events,
+		Interval:   interval,
+		SoonWindow: soonWindow,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// CheckOnce возвращает число опубликованных событий. Если срок уже
+// прошёл, due_soon не публикуется: хватает due_passed.
+func (w *DueWatcher) CheckOnce() (int, error) {
+	now := w.now()
+	published := 0
+	if w.SoonWindow > 0 {
+		soon, err := w.Storage.GetDueSoonCards(now.Add(w.SoonWindow))
+		if err != nil {
+			return 0, err
+		}
+		for _, c := range soon {
+			if err := w.Storage.MarkDueSoonTriggered(c.ID); err != nil {
+				return published, err
+			}
+			if c.DueAt.After(now) {
+				w.Events.Publish(model.Event{Type: model.EventDueSoon, Card: c})
+				published++
+			}
+		}
+	}
+	cards, err := w.Storage.GetDueCards(now)
+	if err != nil {
+		return published, err
+	}
+	for _, c := range cards {
+		if err := w.Storage.MarkDueTriggered(c.ID); err != nil {
+			return published, err
+		}
+		w.Events.Publish(model.Event{Type: model.EventDuePassed, Card: c})
+		published++
+	}
+	return published, nil
+}
+
+// Run проверяет сроки сразу и затем каждые Interval, пока не отменён ctx.
+func (w *DueWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if n, err := w.CheckOnce(); err != nil {
+			w.logger.Error("Ошибка проверки сроков карточек", zap.Error(err))
+		} else if n > 0 {
+			w.logger.Info("Обработаны сроки карточек", zap.Int("events", n))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package automation
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"slices"
+	"strings"
+)
+
+// maxChainDepth ограничивает цепочку «действие правила → событие → правило».
+const maxChainDepth = 5
+
+// commentAuthor подписывает комментарии, которые пишут правила.
+const commentAuthor = "automation"
+
+// Engine выполняет правила досок в ответ на доменные события. Каждое
+// правило выполняется в своей транзакции, итог попадает в журнал.
+//
+// Защита от зацикливания: в одной цепочке событий правило срабатывает для
+// карточки не больше одного раза, а глубина цепочки ограничена
+// maxChainDepth. Пропущенные запуски тоже пишутся в журнал.
+type Engine struct {
+	Storage RuleStorage
+	Tx      service.Transactor
+	// Forward получает события, порождённые правилами, — для остальных
+	// подписчиков (уведомлений). Сам движок в Forward входить не должен.
+	Forward service.EventPublisher
+	// DoneLists — названия списков «готово», как у сервиса карточек:
+	// правило не переносит туда карточку с открытыми блокерами и не
+	// ставит ей статус done.
+	DoneLists []string
+	logger    *zap.Logger
+}
+
+var _ service.EventPublisher = (*Engine)(nil)
+
+func NewEngine(storage RuleStorage, tx service.Transactor, logger *zap.Logger) *Engine {
+	return &Engine{
+		Storage: storage,
+		Tx:      tx,
+		logger:  logger,
+	}
+}
+
+// Publish синхронно обрабатывает событие и всю порождённую им цепочку.
+func (e *Engine) Publish(event model.Event) {
+	fired := map[[2]int]bool{}
+	queue := []model.Event{event}
+	for len(queue) > 0 {
+		ev := queue[0]
+		queue = queue[1:]
+		produced := e.handle(ev, fired)
+		if e.Forward != nil {
+			for _, p := range produced {
+				e.Forward.Publish(p)
+			}
+		}
+		queue = append(queue, produced...)
+	}
+}
+
+func (e *Engine) handle(ev model.Event, fired map[[2]int]bool) []model.Event {
+	rules, err := e.Storage.GetRules(ev.Card.BoardID)
+	if err != nil {
+		e.logger.Error("Не удалось загрузить правила автоматизации", zap.Error(err), zap.Int("boardID", ev.Card.BoardID))
+		return nil
+	}
+	var produced []model.Event
+	for _, rule := range rules {
+		if !rule.Enabled || !triggerMatches(rule.Trigger, ev) {
+			continue
+		}
+		run := model.AutomationRun{RuleID: rule.ID, BoardID: rule.BoardID, CardID: ev.Card.ID, Event: ev.Type}
+		key := [2]int{rule.ID, ev.Card.ID}
+		switch {
+		case ev.Depth >= maxChainDepth:
+			run.Status = model.RunStatusSkipped
+			run.Error = fmt.Sprintf("chain depth limit %d reached", maxChainDepth)
+		case fired[key]:
+			run.Status = model.RunStatusSkipped
+			run.Error = "rule already ran for this card in the same chain"
+		default:
+			fired[key] = true
+			matched, events, err := e.apply(rule, ev)
+			if err == nil && !matched {
+				continue
+			}
+			if err != nil {
+				run.Status = model.RunStatusFailed
+				run.Error = err.Error()
+			} else {
+				run.Status = model.RunStatusOK
+				produced = append(produced, events...)
+			}
+		}
+		if _, err := e.Storage.CreateRun(run); err != nil {
+			e.logger.Error("Не удалось записать журнал автоматизации", zap.Error(err), zap.Int("ruleID", rule.ID))
+		}
+		if run.Status != model.RunStatusOK {
+			e.logger.Warn("Правило автоматизации не выполнено", zap.Int("ruleID", rule.ID), zap.Int("cardID", ev.Card.ID), zap.String("status", run.Status), zap.String("error", run.Error))
+		}
+	}
+	return produced
+}
+
+// apply проверяет условия по актуальному состоянию карточки и выполняет
+// действия. Если хоть одно действие не удалось, откатываются все.
+func (e *Engine) apply(rule model.AutomationRule, ev model.Event) (bool, []model.Event, error) {
+	var matched bool
+	var events []model.Event
+	err := e.Tx.InTx(func(tx service.Tx) error {
+		matched, events = false, nil
+		card, err := tx.GetCard(ev.Card.ID)
+		if err != nil {
+			return err
+		}
+		labels, err := tx.GetCardLabels(card.ID)
+		if err != nil {
+			return err
+		}
+		if !conditionsMet(rule.Conditions, card, labels) {
+			return nil
+		}
+		matched = true
+		a := actionRunner{tx: tx, doneLists: e.DoneLists, card: card, labels: labels, depth: ev.Depth + 1}
+		for i, action := range rule.Actions {
+			if err := a.run(action); err != nil {
+				return fmt.Errorf("action %d (%s): %w", i, action.Type, err)
+			}
+		}
+		events = a.events
+		return nil
+	})
+	if err != nil {
+		return matched, nil, err
+	}
+	return matched, events, nil
+}
+
+func triggerMatches(t model.AutomationTrigger, ev model.Event) bool {
+	if t.Type != ev.Type {
+		return false
+	}
+	switch t.Type {
+	case model.EventCardMoved:
+		return t.ListID == 0 || t.ListID == ev.Card.ListID
+	case model.EventLabelAdded:
+		return t.LabelID == 0 || t.LabelID == ev.LabelID
+	}
+	return true
+}
+
+func conditionsMet(conditions []model.AutomationCondition, card model.Card, labels []model.Label) bool {
+	for _, c := range conditions {
+		var ok bool
+		switch c.Type {
+		case model.ConditionInList:
+			ok = card.ListID == c.ListID
+		case model.ConditionHasLabel:
+			ok = hasLabel(labels, c.LabelID)
+		case model.ConditionStatusIs:
+			ok = card.Status == c.Status
+		case model.ConditionTitleContains:
+			ok = strings.Contains(strings.ToLower(card.Title), strings.ToLower(c.Text))
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// actionRunner выполняет действия одного правила и копит события, которые
+// они порождают.
+type actionRunner struct {
+	tx        service.Tx
+	doneLists []string
+	card      model.Card
+	labels    []model.Label
+	depth     int
+	events    []model.Event
+}
+
+func (a *actionRunner) run(action model.AutomationAction) error {
+	var err error
+	switch action.Type {
+	case model.ActionMoveCard:
+		if a.card.ListID == action.ListID {
+			return nil
+		}
+		list, err := a.tx.GetList(action.ListID)
+		if err != nil {
+			return err
+		}
+		if list.BoardID != a.card.BoardID {
+			return fmt.Errorf("%w: list %d is on another board", model.ErrInvalidInput, list.ID)
+		}
+		if err := service.CheckBlockers(a.tx, a.doneLists, a.card, list); err != nil {
+			return err
+		}
+		// Мягкий лимит правило не останавливает: предупреждение
+		// показать некому.
+		if _, err := service.CheckWIPLimit(a.tx, list); err != nil {
+			return err
+		}
+		fromListID := a.card.ListID
+		a.card.ListID = list.ID
+		if a.card, err = a.tx.UpdateCard(a.card); err != nil {
+			return err
+		}
+		a.events = append(a.events, model.Event{Type: model.EventCardMoved, Card: a.card, FromListID: fromListID, Depth: a.depth})
+	case model.ActionSetStatus:
+		if err := service.CheckDoneStatus(a.tx, a.doneLists, a.card, action.Status); err != nil {
+			return err
+		}
+		a.card, err = a.tx.SetCardStatus(a.card.ID, action.Status)
+	case model.ActionAddLabel:
+		if hasLabel(a.labels, action.LabelID) {
+			return nil
+		}
+		if err := a.tx.AddCardLabel(a.card.ID, action.LabelID); err != nil {
+			return err
+		}
+		a.labels = append(a.labels, model.Label{ID: action.LabelID})
+		a.events = append(a.events, model.Event{Type: model.EventLabelAdded, Card: a.card, LabelID: action.LabelID, Depth: a.depth})
+	case model.ActionAssignUser:
+		assignees, err := a.tx.GetCardAssignees(a.card.ID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(assignees, action.Username) {
+			return nil
+		}
+		if err := a.tx.AddCardAssignee(a.card.ID, action.Username); err != nil {
+			return err
+		}
+		a.events = append(a.events, model.Event{Type: model.EventCardAssigned, Card: a.card, Username: action.Username, Depth: a.depth})
+	case model.ActionPostComment:
+		_, err = a.tx.CreateComment(model.CommentInputCreate{CardID: a.card.ID, Author: commentAuthor, Text: action.Text})
+	default:
+		err = errors.New("unknown action")
+	}
+	return err
+}
+
+func hasLabel(labels []model.Label, id int) bool {
+	return slices.ContainsFunc(labels, func(l model.Label) bool { return l.ID == id })
+}
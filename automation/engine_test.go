@@ -0,0 +1,382 @@
+package automation
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type fixture struct {
+	store  *storage.Storage
+	engine *Engine
+	cards  *service.CardService
+	board  model.Board
+	todo   model.List
+	done   model.List
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	store := storage.NewStorage()
+	engine := NewEngine(store, store, zap.NewNop())
+	f := fixture{store: store, engine: engine, cards: service.NewCardService(store, store, engine, zap.NewNop())}
+	var err error
+	f.board, err = store.CreateBoard("b")
+	require.NoError(t, err)
+	f.todo, err = store.CreateList(model.ListInputCreate{BoardID: f.board.ID, Title: "todo"})
+	require.NoError(t, err)
+	f.done, err = store.CreateList(model.ListInputCreate{BoardID: f.board.ID, Title: "done"})
+	require.NoError(t, err)
+	return f
+}
+
+func (f fixture) rule(t *testing.T, input model.AutomationRuleInput) model.AutomationRule {
+	t.Helper()
+	input.BoardID = f.board.ID
+	input.Enabled = true
+	rule, err := NewService(f.store, f.store).CreateRule(input)
+	require.NoError(t, err)
+	return rule
+}
+
+func (f fixture) card(t *testing.T, title string) model.Card {
+	t.Helper()
+	c, err := f.cards.CreateCard(model.CardInputCreate{ListID: f.todo.ID, Title: title})
+	require.NoError(t, err)
+	return c
+}
+
+func (f fixture) runStatuses(t *testing.T) []string {
+	t.Helper()
+	runs, err := f.store.GetRuns(f.board.ID, 100)
+	require.NoError(t, err)
+	statuses := []string{}
+	for i := len(runs) - 1; i >= 0; i-- {
+		statuses = append(statuses, runs[i].Status)
+	}
+	return statuses
+}
+
+func TestEngineMoveToDone(t *testing.T) {
+	f := newFixture(t)
+	f.rule(t, model.AutomationRuleInput{
+		Name:    "close",
+		Trigger: model.AutomationTrigger{Type: model.EventCardMoved, ListID: f.done.ID},
+		Actions: []model.AutomationAction{
+			{Type: model.ActionSetStatus, Status: "done"},
+			{Type: model.ActionAssignUser, Username: "anna"},
+			{Type: model.ActionPostComment, Text: "закрыто"},
+		},
+	})
+	c := f.card(t, "task")
+
+	_, err := f.cards.MoveCard(c.ID, model.CardMoveInput{ListID: f.done.ID})
+	require.NoError(t, err)
+
+	got, err := f.store.GetCard(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, "done", got.Status)
+	assignees, err := f.store.GetCardAssignees(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna"}, assignees)
+	comments, err := f.store.GetComments(c.ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, commentAuthor, comments[0].Author)
+	require.Equal(t, "закрыто", comments[0].Text)
+	require.Equal(t, []string{model.RunStatusOK}, f.runStatuses(t))
+
+	runs, err := f.store.GetRuns(f.board.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, c.ID, runs[0].CardID)
+	require.Equal(t, model.EventCardMoved, runs[0].Event)
+}
+
+func TestEngineConditions(t *testing.T) {
+	f := newFixture(t)
+	bug, err := f.store.CreateLabel(model.LabelInputCreate{BoardID: f.board.ID, Name: "bug", Color: "red"})
+	require.NoError(t, err)
+	f.rule(t, model.AutomationRuleInput{
+		Name:    "bugs",
+		Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+		Conditions: []model.AutomationCondition{
+			{Type: model.ConditionTitleContains, Text: "BUG"},
+		},
+		Actions: []model.AutomationAction{{Type: model.ActionAddLabel, LabelID: bug.ID}},
+	})
+
+	plain := f.card(t, "feature")
+	labels, err := f.store.GetCardLabels(plain.ID)
+	require.NoError(t, err)
+	require.Empty(t, labels)
+	require.Empty(t, f.runStatuses(t), "unmatched conditions are not logged")
+
+	matching := f.card(t, "Bug in login")
+	labels, err = f.store.GetCardLabels(matching.ID)
+	require.NoError(t, err)
+	require.Len(t, labels, 1)
+	require.Equal(t, bug.ID, labels[0].ID)
+	require.Equal(t, []string{model.RunStatusOK}, f.runStatuses(t))
+}
+
+func TestEngineLoopProtection(t *testing.T) {
+	f := newFixture(t)
+	f.rule(t, model.AutomationRuleInput{
+		Name:    "to done",
+		Trigger: model.AutomationTrigger{Type: model.EventCardMoved, ListID: f.todo.ID},
+		Actions: []model.AutomationAction{{Type: model.ActionMoveCard, ListID: f.done.ID}},
+	})
+	f.rule(t, model.AutomationRuleInput{
+		Name:    "to todo",
+		Trigger: model.AutomationTrigger{Type: model.EventCardMoved, ListID: f.done.ID},
+		Actions: []model.AutomationAction{{Type: model.ActionMoveCard, ListID: f.todo.ID}},
+	})
+	c := f.card(t, "ping-pong")
+
+	_, err := f.cards.MoveCard(c.ID, model.CardMoveInput{ListID: f.done.ID})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{model.RunStatusOK, model.RunStatusOK, model.RunStatusSkipped}, f.runStatuses(t))
+	got, err := f.store.GetCard(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, f.done.ID, got.ListID)
+}
+
+func TestEngineFailedActionRollsBack(t *testing.T) {
+	f := newFixture(t)
+	other, err := f.store.CreateBoard("other")
+	require.NoError(t, err)
+	foreign, err := f.store.CreateList(model.ListInputCreate{BoardID: other.ID, Title: "foreign"})
+	require.NoError(t, err)
+	// Список мог уехать на другую доску после сохранения правила, поэтому
+	// правило пишется в хранилище в обход валидации.
+	_, err = f.store.CreateRule(model.AutomationRuleInput{
+		BoardID: f.board.ID,
+		Name:    "broken",
+		Enabled: true,
+		Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+		Actions: []model.AutomationAction{
+			{Type: model.ActionSetStatus, Status: "new"},
+			{Type: model.ActionMoveCard, ListID: foreign.ID},
+		},
+	})
+	require.NoError(t, err)
+
+	c := f.card(t, "task")
+
+	got, err := f.store.GetCard(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, got.Status, "status change is rolled back")
+	runs, err := f.store.GetRuns(f.board.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, model.RunStatusFailed, runs[0].Status)
+	require.Contains(t, runs[0].Error, "action 1 (move_card)")
+}
+
+func TestEngineRespectsBlockers(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func(f fixture) model.AutomationAction
+		want   func(t *testing.T, f fixture, card model.Card)
+	}{
+		{
+			name: "move to done list",
+			action: func(f fixture) model.AutomationAction {
+				return model.AutomationAction{Type: model.ActionMoveCard, ListID: f.done.ID}
+			},
+			want: func(t *testing.T, f fixture, card model.Card) { require.NotEqual(t, f.done.ID, card.ListID) },
+		},
+		{
+			name: "set status done",
+			action: func(f fixture) model.AutomationAction {
+				return model.AutomationAction{Type: model.ActionSetStatus, Status: "done"}
+			},
+			want: func(t *testing.T, f fixture, card model.Card) { require.Empty(t, card.Status) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.engine.DoneLists = []string{"done"}
+			blocker := f.card(t, "blocker")
+			blocked := f.card(t, "blocked")
+			_, err := f.store.CreateCardLink(model.CardLink{SourceID: blocker.ID, TargetID: blocked.ID, Type: model.LinkBlocks})
+			require.NoError(t, err)
+			review, err := f.store.CreateList(model.ListInputCreate{BoardID: f.board.ID, Title: "review"})
+			require.NoError(t, err)
+			f.rule(t, model.AutomationRuleInput{
+				Name:    "close",
+				Trigger: model.AutomationTrigger{Type: model.EventCardMoved, ListID: review.ID},
+				Actions: []model.AutomationAction{tt.action(f)},
+			})
+
+			_, err = f.cards.MoveCard(blocked.ID, model.CardMoveInput{ListID: review.ID})
+			require.NoError(t, err)
+
+			got, err := f.store.GetCard(blocked.ID)
+			require.NoError(t, err)
+			tt.want(t, f, got)
+			runs, err := f.store.GetRuns(f.board.ID, 10)
+			require.NoError(t, err)
+			require.Len(t, runs, 1)
+			require.Equal(t, model.RunStatusFailed, runs[0].Status)
+			require.Contains(t, runs[0].Error, "blocked by open cards")
+		})
+	}
+}
+
+func TestEngineRespectsWIPLimit(t *testing.T) {
+	f := newFixture(t)
+	one := 1
+	full, err := f.store.CreateList(model.ListInputCreate{BoardID: f.board.ID, Title: "full", WIPLimit: &one})
+	require.NoError(t, err)
+	_, err = f.cards.CreateCard(model.CardInputCreate{ListID: full.ID, Title: "occupant"})
+	require.NoError(t, err)
+	f.rule(t, model.AutomationRuleInput{
+		Name:    "push",
+		Trigger: model.AutomationTrigger{Type: model.EventCardCreated, ListID: f.todo.ID},
+		Actions: []model.AutomationAction{{Type: model.ActionMoveCard, ListID: full.ID}},
+	})
+
+	c := f.card(t, "task")
+
+	got, err := f.store.GetCard(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, f.todo.ID, got.ListID)
+	runs, err := f.store.GetRuns(f.board.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, model.RunStatusFailed, runs[0].Status)
+	require.Contains(t, runs[0].Error, "WIP limit")
+}
+
+func TestEngineDisabledRule(t *testing.T) {
+	f := newFixture(t)
+	rule := f.rule(t, model.AutomationRuleInput{
+		Name:    "status",
+		Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+		Actions: []model.AutomationAction{{Type: model.ActionSetStatus, Status: "new"}},
+	})
+	_, err := f.store.UpdateRule(rule.ID, model.AutomationRuleInput{
+		BoardID: f.board.ID,
+		Name:    rule.Name,
+		Trigger: rule.Trigger,
+		Actions: rule.Actions,
+	})
+	require.NoError(t, err)
+
+	c := f.card(t, "task")
+
+	got, err := f.store.GetCard(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, got.Status)
+	require.Empty(t, f.runStatuses(t))
+}
+
+func TestDueWatcher(t *testing.T) {
+	f := newFixture(t)
+	f.rule(t, model.AutomationRuleInput{
+		Name:    "overdue",
+		Trigger: model.AutomationTrigger{Type: model.EventDuePassed},
+		Actions: []model.AutomationAction{{Type: model.ActionSetStatus, Status: "overdue"}},
+	})
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	overdue := f.card(t, "overdue")
+	upcoming := f.card(t, "upcoming")
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	_, err := f.cards.SetDueDate(overdue.ID, &past)
+	require.NoError(t, err)
+	_, err = f.cards.SetDueDate(upcoming.ID, &future)
+	require.NoError(t, err)
+
+	w := NewDueWatcher(f.store, f.engine, time.Minute, 0, zap.NewNop())
+	w.now = func() time.Time { return now }
+
+	n, err := w.CheckOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	got, err := f.store.GetCard(overdue.ID)
+	require.NoError(t, err)
+	require.Equal(t, "overdue", got.Status)
+	got, err = f.store.GetCard(upcoming.ID)
+	require.NoError(t, err)
+	require.Empty(t, got.Status)
+
+	n, err = w.CheckOnce()
+	require.NoError(t, err)
+	require.Zero(t, n, "a due date fires only once")
+	require.Equal(t, []string{model.RunStatusOK}, f.runStatuses(t))
+}
+
+type eventRecorder struct {
+	events []model.Event
+}
+
+func (r *eventRecorder) Publish(event model.Event) {
+	r.events = append(r.events, event)
+}
+
+func TestDueWatcherSoon(t *testing.T) {
+	f := newFixture(t)
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	tomorrow := f.card(t, "tomorrow")
+	nextWeek := f.card(t, "next week")
+	overdue := f.card(t, "overdue")
+	for c, due := range map[int]time.Time{
+		tomorrow.ID: now.Add(20 * time.Hour),
+		nextWeek.ID: now.Add(7 * 24 * time.Hour),
+		overdue.ID:  now.Add(-time.Hour),
+	} {
+		_, err := f.cards.SetDueDate(c, &due)
+		require.NoError(t, err)
+	}
+
+	events := &eventRecorder{}
+	w := NewDueWatcher(f.store, events, time.Minute, 24*time.Hour, zap.NewNop())
+	w.now = func() time.Time { return now }
+
+	n, err := w.CheckOnce()
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Len(t, events.events, 2)
+	require.Equal(t, model.EventDueSoon, events.events[0].Type)
+	require.Equal(t, tomorrow.ID, events.events[0].Card.ID)
+	require.Equal(t, model.EventDuePassed, events.events[1].Type, "overdue cards get only due_passed")
+	require.Equal(t, overdue.ID, events.events[1].Card.ID)
+
+	now = now.Add(21 * time.Hour)
+	events.events = nil
+	n, err = w.CheckOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, model.EventDuePassed, events.events[0].Type)
+	require.Equal(t, tomorrow.ID, events.events[0].Card.ID)
+}
+
+func TestEngineForwardsProducedEvents(t *testing.T) {
+	f := newFixture(t)
+	forwarded := &eventRecorder{}
+	f.engine.Forward = forwarded
+	f.rule(t, model.AutomationRuleInput{
+		Name:    "triage",
+		Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+		Actions: []model.AutomationAction{
+			{Type: model.ActionAssignUser, Username: "anna"},
+			{Type: model.ActionMoveCard, ListID: f.done.ID},
+		},
+	})
+
+	c := f.card(t, "task")
+
+	require.Len(t, forwarded.events, 2)
+	require.Equal(t, model.EventCardAssigned, forwarded.events[0].Type)
+	require.Equal(t, "anna", forwarded.events[0].Username)
+	require.Equal(t, c.ID, forwarded.events[0].Card.ID)
+	require.Equal(t, model.EventCardMoved, forwarded.events[1].Type)
+	require.Equal(t, f.todo.ID, forwarded.events[1].FromListID)
+}
@@ -0,0 +1,204 @@
+package automation
+
+import (
+	"awesomeProject2/cmd/model"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxRuleNameLength = 200
+	maxRuleActions    = 20
+	defaultRunsLimit  = 50
+	maxRunsLimit      = 500
+)
+
+type Service struct {
+	Storage RuleStorage
+	Boards  BoardReader
+}
+
+func NewService(storage RuleStorage, boards BoardReader) *Service {
+	return &Service{
+		Storage: storage,
+		Boards:  boards,
+	}
+}
+
+func (s Service) GetRules(boardID int) ([]model.AutomationRule, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetRules(boardID)
+}
+
+func (s Service) CreateRule(input model.AutomationRuleInput) (model.AutomationRule, error) {
+	if _, err := s.Boards.GetBoard(input.BoardID); err != nil {
+		return model.AutomationRule{}, err
+	}
+	input, err := s.validate(input)
+	if err != nil {
+		return model.AutomationRule{}, err
+	}
+	return s.Storage.CreateRule(input)
+}
+
+// UpdateRule заменяет правило целиком, не меняя его доску.
+func (s Service) UpdateRule(id int, input model.AutomationRuleInput) (model.AutomationRule, error) {
+	current, err := s.Storage.GetRule(id)
+	if err != nil {
+		return model.AutomationRule{}, err
+	}
+	input.BoardID = current.BoardID
+	input, err = s.validate(input)
+	if err != nil {
+		return model.AutomationRule{}, err
+	}
+	return s.Storage.UpdateRule(id, input)
+}
+
+func (s Service) DeleteRule(id int) error {
+	return s.Storage.DeleteRule(id)
+}
+
+// GetRuns возвращает журнал доски; limit вне (0, maxRunsLimit] заменяется
+// значением по умолчанию или обрезается.
+func (s Service) GetRuns(boardID int, limit int) ([]model.AutomationRun, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultRunsLimit
+	}
+	limit = min(limit, maxRunsLimit)
+	return s.Storage.GetRuns(boardID, limit)
+}
+
+func (s Service) validate(input model.AutomationRuleInput) (model.AutomationRuleInput, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return input, invalid("name is required")
+	}
+	if utf8.RuneCountInString(input.Name) > maxRuleNameLength {
+		return input, invalid("name is longer than %d characters", maxRuleNameLength)
+	}
+	lists, err := s.Boards.GetLists(&input.BoardID)
+	if err != nil {
+		return input, err
+	}
+	labels, err := s.Boards.GetLabels(input.BoardID)
+	if err != nil {
+		return input, err
+	}
+	b := boardRefs{boardID: input.BoardID, lists: lists, labels: labels}
+
+	t := input.Trigger
+	switch t.Type {
+	case model.EventCardCreated, model.EventDuePassed:
+	case model.EventCardMoved:
+		if t.ListID != 0 && !b.hasList(t.ListID) {
+			return input, invalid("trigger: %s", b.listError(t.ListID))
+		}
+	case model.EventLabelAdded:
+		if t.LabelID != 0 && !b.hasLabel(t.LabelID) {
+			return input, invalid("trigger: %s", b.labelError(t.LabelID))
+		}
+	default:
+		return input, invalid("trigger: unknown type %q", t.Type)
+	}
+
+	for i, c := range input.Conditions {
+		if err := b.validateCondition(c); err != nil {
+			return input, invalid("conditions[%d]: %s", i, err)
+		}
+	}
+	if len(input.Actions) == 0 {
+		return input, invalid("at least one action is required")
+	}
+	if len(input.Actions) > maxRuleActions {
+		return input, invalid("at most %d actions per rule", maxRuleActions)
+	}
+	for i := range input.Actions {
+		input.Actions[i].Username = strings.TrimSpace(input.Actions[i].Username)
+		if err := b.validateAction(input.Actions[i]); err != nil {
+			return input, invalid("actions[%d]: %s", i, err)
+		}
+	}
+	return input, nil
+}
+
+// boardRefs — списки и метки доски правила.
+type boardRefs struct {
+	boardID int
+	lists   []model.List
+	labels  []model.Label
+}
+
+func (b boardRefs) hasList(id int) bool {
+	return slices.ContainsFunc(b.lists, func(l model.List) bool { return l.ID == id })
+}
+
+func (b boardRefs) hasLabel(id int) bool {
+	return slices.ContainsFunc(b.labels, func(l model.Label) bool { return l.ID == id })
+}
+
+func (b boardRefs) listError(id int) error {
+	return fmt.Errorf("list %d is not on board %d", id, b.boardID)
+}
+
+func (b boardRefs) labelError(id int) error {
+	return fmt.Errorf("label %d is not on board %d", id, b.boardID)
+}
+
+func (b boardRefs) validateCondition(c model.AutomationCondition) error {
+	switch c.Type {
+	case model.ConditionInList:
+		if !b.hasList(c.ListID) {
+			return b.listError(c.ListID)
+		}
+	case model.ConditionHasLabel:
+		if !b.hasLabel(c.LabelID) {
+			return b.labelError(c.LabelID)
+		}
+	case model.ConditionStatusIs:
+	case model.ConditionTitleContains:
+		if strings.TrimSpace(c.Text) == "" {
+			return errors.New("text is required")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", c.Type)
+	}
+	return nil
+}
+
+func (b boardRefs) validateAction(a model.AutomationAction) error {
+	switch a.Type {
+	case model.ActionMoveCard:
+		if !b.hasList(a.ListID) {
+			return b.listError(a.ListID)
+		}
+	case model.ActionSetStatus:
+	case model.ActionAddLabel:
+		if !b.hasLabel(a.LabelID) {
+			return b.labelError(a.LabelID)
+		}
+	case model.ActionAssignUser:
+		if a.Username == "" {
+			return errors.New("username is required")
+		}
+	case model.ActionPostComment:
+		if strings.TrimSpace(a.Text) == "" {
+			return errors.New("text is required")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", a.Type)
+	}
+	return nil
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{model.ErrInvalidInput}, args...)...)
+}
@@ -0,0 +1,156 @@
+package automation
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCreateRuleValidation(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	other, err := store.CreateBoard("other")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	foreignList, err := store.CreateList(model.ListInputCreate{BoardID: other.ID, Title: "todo"})
+	require.NoError(t, err)
+	label, err := store.CreateLabel(model.LabelInputCreate{BoardID: board.ID, Name: "bug", Color: "red"})
+	require.NoError(t, err)
+	foreignLabel, err := store.CreateLabel(model.LabelInputCreate{BoardID: other.ID, Name: "bug", Color: "red"})
+	require.NoError(t, err)
+
+	status := []model.AutomationAction{{Type: model.ActionSetStatus, Status: "done"}}
+	tests := []struct {
+		name        string
+		input       model.AutomationRuleInput
+		expectError error
+		errorText   string
+	}{
+		{
+			name: "valid",
+			input: model.AutomationRuleInput{
+				Name:       " close ",
+				Trigger:    model.AutomationTrigger{Type: model.EventCardMoved, ListID: list.ID},
+				Conditions: []model.AutomationCondition{{Type: model.ConditionHasLabel, LabelID: label.ID}},
+				Actions:    []model.AutomationAction{{Type: model.ActionAssignUser, Username: " anna "}},
+			},
+		},
+		{
+			name:        "unknown board",
+			input:       model.AutomationRuleInput{BoardID: other.ID + 1000, Name: "r", Trigger: model.AutomationTrigger{Type: model.EventCardCreated}, Actions: status},
+			expectError: model.ErrNotFound,
+		},
+		{
+			name:        "empty name",
+			input:       model.AutomationRuleInput{Name: "  ", Trigger: model.AutomationTrigger{Type: model.EventCardCreated}, Actions: status},
+			expectError: model.ErrInvalidInput,
+			errorText:   "name is required",
+		},
+		{
+			name:        "long name",
+			input:       model.AutomationRuleInput{Name: strings.Repeat("я", maxRuleNameLength+1), Trigger: model.AutomationTrigger{Type: model.EventCardCreated}, Actions: status},
+			expectError: model.ErrInvalidInput,
+			errorText:   "name is longer",
+		},
+		{
+			name:        "unknown trigger",
+			input:       model.AutomationRuleInput{Name: "r", Trigger: model.AutomationTrigger{Type: "card_deleted"}, Actions: status},
+			expectError: model.ErrInvalidInput,
+			errorText:   `trigger: unknown type "card_deleted"`,
+		},
+		{
+			name:        "trigger list on another board",
+			input:       model.AutomationRuleInput{Name: "r", Trigger: model.AutomationTrigger{Type: model.EventCardMoved, ListID: foreignList.ID}, Actions: status},
+			expectError: model.ErrInvalidInput,
+			errorText:   "trigger: list",
+		},
+		{
+			name: "condition label on another board",
+			input: model.AutomationRuleInput{
+				Name:       "r",
+				Trigger:    model.AutomationTrigger{Type: model.EventCardCreated},
+				Conditions: []model.AutomationCondition{{Type: model.ConditionHasLabel, LabelID: foreignLabel.ID}},
+				Actions:    status,
+			},
+			expectError: model.ErrInvalidInput,
+			errorText:   "conditions[0]: label",
+		},
+		{
+			name:        "no actions",
+			input:       model.AutomationRuleInput{Name: "r", Trigger: model.AutomationTrigger{Type: model.EventCardCreated}},
+			expectError: model.ErrInvalidInput,
+			errorText:   "at least one action",
+		},
+		{
+			name: "empty comment",
+			input: model.AutomationRuleInput{
+				Name:    "r",
+				Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+				Actions: []model.AutomationAction{{Type: model.ActionSetStatus}, {Type: model.ActionPostComment, Text: " "}},
+			},
+			expectError: model.ErrInvalidInput,
+			errorText:   "actions[1]: text is required",
+		},
+		{
+			name: "move to another board",
+			input: model.AutomationRuleInput{
+				Name:    "r",
+				Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+				Actions: []model.AutomationAction{{Type: model.ActionMoveCard, ListID: foreignList.ID}},
+			},
+			expectError: model.ErrInvalidInput,
+			errorText:   "actions[0]: list",
+		},
+	}
+
+	s := NewService(store, store)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.input.BoardID == 0 {
+				tt.input.BoardID = board.ID
+			}
+			rule, err := s.CreateRule(tt.input)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+				require.ErrorContains(t, err, tt.errorText)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "close", rule.Name)
+			require.Equal(t, "anna", rule.Actions[0].Username)
+		})
+	}
+}
+
+func TestUpdateRuleKeepsBoard(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	other, err := store.CreateBoard("other")
+	require.NoError(t, err)
+	s := NewService(store, store)
+	rule, err := s.CreateRule(model.AutomationRuleInput{
+		BoardID: board.ID,
+		Name:    "r",
+		Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+		Actions: []model.AutomationAction{{Type: model.ActionSetStatus, Status: "new"}},
+	})
+	require.NoError(t, err)
+
+	updated, err := s.UpdateRule(rule.ID, model.AutomationRuleInput{
+		BoardID: other.ID,
+		Name:    "renamed",
+		Trigger: rule.Trigger,
+		Actions: rule.Actions,
+	})
+	require.NoError(t, err)
+	require.Equal(t, board.ID, updated.BoardID)
+	require.Equal(t, "renamed", updated.Name)
+
+	_, err = s.UpdateRule(rule.ID+1000, model.AutomationRuleInput{})
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
@@ -0,0 +1,19 @@
+package calendar
+
+import "awesomeProject2/cmd/model"
+
+type TokenStorage interface {
+	SetCalendarToken(username, hash string) (model.CalendarToken, error)
+	GetCalendarTokenUser(hash string) (string, error)
+	DeleteCalendarToken(username string) error
+}
+
+type BoardReader interface {
+	GetBoard(id int) (model.Board, error)
+	GetLists(boardID *int) ([]model.List, error)
+	GetMemberBoards(username string) ([]model.Board, error)
+}
+
+type CardFinder interface {
+	GetCards(filter model.CardFilter) ([]model.Card, error)
+}
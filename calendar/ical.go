@@ -0,0 +1,100 @@
+package calendar
+
+import (
+	"awesomeProject2/cmd/model"
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Формат iCalendar (RFC 5545): строки через CRLF, не длиннее 75 байт,
+// продолжение строки начинается с пробела.
+const (
+	calendarName = "Сроки карточек"
+	productID    = "-//awesomeProject2//Kanban//RU"
+	maxLineBytes = 75
+	icalTime     = "20060102T150405Z"
+)
+
+// event — карточка как событие без длительности в момент срока.
+type event struct {
+	uid         string
+	stamp       time.Time
+	start       time.Time
+	summary     string
+	description string
+	category    string
+}
+
+func cardEvent(c model.Card, board, list string) event {
+	summary := c.Title
+	if c.Status == model.CardStatusDone {
+		summary = "✓ " + summary
+	}
+	description := fmt.Sprintf("Доска «%s», список «%s»", board, list)
+	if c.Description != "" {
+		description += "\n\n" + c.Description
+	}
+	return event{
+		uid:         fmt.Sprintf("card-%d@awesomeProject2", c.ID),
+		stamp:       c.UpdatedAt,
+		start:       *c.DueAt,
+		summary:     summary,
+		description: description,
+		category:    board,
+	}
+}
+
+func writeCalendar(w io.Writer, events []event) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(bw, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", productID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeText(calendarName))
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.uid)
+		line("DTSTAMP", e.stamp.UTC().Format(icalTime))
+		line("DTSTART", e.start.UTC().Format(icalTime))
+		line("SUMMARY", escapeText(e.summary))
+		line("DESCRIPTION", escapeText(e.description))
+		line("CATEGORIES", escapeText(e.category))
+		// Срок не занимает время в календаре.
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "")
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeLine пишет строку, перенося её по границе символа, чтобы каждая
+// физическая строка была не длиннее maxLineBytes.
+func writeLine(w *bufio.Writer, s string) {
+	limit := maxLineBytes
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineBytes - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
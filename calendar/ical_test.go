@@ -0,0 +1,67 @@
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEscapeText(t *testing.T) {
+	require.Equal(t, `a\, b\; c\\d\ne`, escapeText("a, b; c\\d\r\ne"))
+}
+
+func TestWriteLine(t *testing.T) {
+	for _, s := range []string{
+		"SUMMARY:short",
+		"DESCRIPTION:" + strings.Repeat("x", 200),
+		"DESCRIPTION:" + strings.Repeat("ж", 100),
+	} {
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		writeLine(w, s)
+		require.NoError(t, w.Flush())
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+		for i, l := range lines {
+			require.LessOrEqual(t, len(l), maxLineBytes)
+			require.True(t, strings.ToValidUTF8(l, "") == l, "line %d splits a character", i)
+			if i > 0 {
+				require.True(t, strings.HasPrefix(l, " "))
+			}
+		}
+		require.Equal(t, s, strings.ReplaceAll(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n ", ""), "unfolds back")
+	}
+}
+
+func TestWriteCalendar(t *testing.T) {
+	due := time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	var buf bytes.Buffer
+	require.NoError(t, writeCalendar(&buf, []event{{
+		uid:         "card-1@awesomeProject2",
+		stamp:       due,
+		start:       due,
+		summary:     "Релиз",
+		description: "Доска «b», список «todo»",
+		category:    "b",
+	}}))
+	want := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//awesomeProject2//Kanban//RU\r\n" +
+		"CALSCALE:GREGORIAN\r\n" +
+		"METHOD:PUBLISH\r\n" +
+		"X-WR-CALNAME:Сроки карточек\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:card-1@awesomeProject2\r\n" +
+		"DTSTAMP:20260301T063000Z\r\n" +
+		"DTSTART:20260301T063000Z\r\n" +
+		"SUMMARY:Релиз\r\n" +
+		"DESCRIPTION:Доска «b»\\, список «todo»\r\n" +
+		"CATEGORIES:b\r\n" +
+		"TRANSP:TRANSPARENT\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	require.Equal(t, want, buf.String())
+}
@@ -0,0 +1,166 @@
+package calendar
+
+import (
+	"awesomeProject2/cmd/model"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+	// В образе alpine нет базы часовых поясов, а параметр tz календаря
+	// принимает любой пояс IANA.
+	_ "time/tzdata"
+)
+
+const (
+	maxCalendarDays = 366
+	// feedHistory — как давно мог истечь срок карточки, чтобы она ещё
+	// попала в ленту.
+	feedHistory = 90 * 24 * time.Hour
+	tokenBytes  = 32
+)
+
+// Service строит календарь сроков доски и iCalendar-ленту пользователя.
+// Лента собирается из хранилища карточек при каждом запросе, поэтому
+// архивные и удалённые карточки пропадают из неё сразу.
+type Service struct {
+	Tokens TokenStorage
+	Boards BoardReader
+	Cards  CardFinder
+	now    func() time.Time
+}
+
+func NewService(tokens TokenStorage, boards BoardReader, cards CardFinder) *Service {
+	return &Service{
+		Tokens: tokens,
+		Boards: boards,
+		Cards:  cards,
+		now:    time.Now,
+	}
+}
+
+// BoardCalendar возвращает карточки доски со сроком с from по to
+// включительно (даты YYYY-MM-DD в поясе tz, по умолчанию UTC),
+// разложенные по дням. Дни без карточек пропускаются.
+func (s Service) BoardCalendar(boardID int, from, to, tz string) ([]model.CalendarDay, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("%w: unknown time zone %q", model.ErrInvalidInput, tz)
+		}
+	}
+	start, err := parseDay("from", from, loc)
+	if err != nil {
+		return nil, err
+	}
+	last, err := parseDay("to", to, loc)
+	if err != nil {
+		return nil, err
+	}
+	end := last.AddDate(0, 0, 1)
+	if !start.Before(end) {
+		return nil, fmt.Errorf("%w: to is before from", model.ErrInvalidInput)
+	}
+	if start.AddDate(0, 0, maxCalendarDays).Before(end) {
+		return nil, fmt.Errorf("%w: calendar range is longer than %d days", model.ErrInvalidInput, maxCalendarDays)
+	}
+
+	cards, err := s.Cards.GetCards(model.CardFilter{
+		BoardID: &boardID,
+		DueFrom: &start,
+		DueTo:   &end,
+		Sort:    &model.CardSort{By: model.CardSortDue},
+	})
+	if err != nil {
+		return nil, err
+	}
+	days := []model.CalendarDay{}
+	for _, c := range cards {
+		y, m, d := c.DueAt.In(loc).Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, loc)
+		if n := len(days); n == 0 || !days[n-1].Date.Equal(day) {
+			days = append(days, model.CalendarDay{Date: day})
+		}
+		days[len(days)-1].Cards = append(days[len(days)-1].Cards, c)
+	}
+	return days, nil
+}
+
+func parseDay(name, value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%w: %s is required", model.ErrInvalidInput, name)
+	}
+	day, err := time.ParseInLocation(model.FieldDateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be a date %s", model.ErrInvalidInput, name, model.FieldDateLayout)
+	}
+	return day, nil
+}
+
+// IssueToken выпускает новый токен ленты пользователя; прежний перестаёт
+// работать. Сам токен не хранится, поэтому повторно его не получить.
+func (s Service) IssueToken(username string) (string, model.CalendarToken, error) {
+	if err := model.ValidateUsername(username); err != nil {
+		return "", model.CalendarToken{}, err
+	}
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", model.CalendarToken{}, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	issued, err := s.Tokens.SetCalendarToken(username, hashToken(token))
+	return token, issued, err
+}
+
+func (s Service) RevokeToken(username string) error {
+	return s.Tokens.DeleteCalendarToken(username)
+}
+
+// Feed пишет в w iCalendar-ленту владельца токена: карточки со сроком
+// со всех досок, где он участник, кроме архивных и просроченных больше
+// чем на feedHistory.
+func (s Service) Feed(token string, w io.Writer) error {
+	username, err := s.Tokens.GetCalendarTokenUser(hashToken(token))
+	if err != nil {
+		return err
+	}
+	boards, err := s.Boards.GetMemberBoards(username)
+	if err != nil {
+		return err
+	}
+	since := s.now().Add(-feedHistory)
+	var events []event
+	for _, b := range boards {
+		lists, err := s.Boards.GetLists(&b.ID)
+		if err != nil {
+			return err
+		}
+		titles := map[int]string{}
+		for _, l := range lists {
+			titles[l.ID] = l.Title
+		}
+		cards, err := s.Cards.GetCards(model.CardFilter{
+			BoardID: &b.ID,
+			DueFrom: &since,
+			Sort:    &model.CardSort{By: model.CardSortDue},
+		})
+		if err != nil {
+			return err
+		}
+		for _, c := range cards {
+			events = append(events, cardEvent(c, b.Title, titles[c.ListID]))
+		}
+	}
+	return writeCalendar(w, events)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,135 @@
+package calendar
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fixture struct {
+	store *storage.Storage
+	svc   *Service
+	board model.Board
+	list  model.List
+}
+
+func newFixture(t *testing.T) fixture {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	svc := NewService(store, store, store)
+	svc.now = func() time.Time { return time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) }
+	return fixture{store: store, svc: svc, board: board, list: list}
+}
+
+func (f fixture) card(t *testing.T, listID int, title string, due time.Time) model.Card {
+	t.Helper()
+	c, err := f.store.CreateCard(model.CardInputCreate{ListID: listID, Title: title})
+	require.NoError(t, err)
+	c, err = f.store.SetCardDue(c.ID, &due)
+	require.NoError(t, err)
+	return c
+}
+
+func TestBoardCalendar(t *testing.T) {
+	f := newFixture(t)
+	early := f.card(t, f.list.ID, "early", time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	late := f.card(t, f.list.ID, "late", time.Date(2026, 3, 1, 22, 30, 0, 0, time.UTC))
+	next := f.card(t, f.list.ID, "next", time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC))
+	f.card(t, f.list.ID, "outside", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+	archived := f.card(t, f.list.ID, "archived", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	_, err := f.store.ArchiveCard(archived.ID)
+	require.NoError(t, err)
+	_, err = f.store.CreateCard(model.CardInputCreate{ListID: f.list.ID, Title: "no due"})
+	require.NoError(t, err)
+
+	days := func(days []model.CalendarDay) map[string][]int {
+		result := map[string][]int{}
+		for _, d := range days {
+			for _, c := range d.Cards {
+				result[d.Date.Format(model.FieldDateLayout)] = append(result[d.Date.Format(model.FieldDateLayout)], c.ID)
+			}
+		}
+		return result
+	}
+	got, err := f.svc.BoardCalendar(f.board.ID, "2026-03-01", "2026-03-31", "")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]int{"2026-03-01": {early.ID, late.ID}, "2026-03-02": {next.ID}}, days(got))
+	require.Len(t, got, 2, "one bucket per day")
+
+	got, err = f.svc.BoardCalendar(f.board.ID, "2026-03-01", "2026-03-02", "Europe/Moscow")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]int{"2026-03-01": {early.ID}, "2026-03-02": {late.ID, next.ID}}, days(got), "days in the requested zone")
+
+	for name, r := range map[string][3]string{
+		"missing from":   {"", "2026-03-01", ""},
+		"bad date":       {"2026-03-01", "March", ""},
+		"reversed range": {"2026-03-02", "2026-03-01", ""},
+		"too long":       {"2026-01-01", "2027-01-02", ""},
+		"unknown zone":   {"2026-03-01", "2026-03-02", "Mars/Olympus"},
+	} {
+		_, err := f.svc.BoardCalendar(f.board.ID, r[0], r[1], r[2])
+		require.ErrorIs(t, err, model.ErrInvalidInput, name)
+	}
+	_, err = f.svc.BoardCalendar(4242, "2026-03-01", "2026-03-02", "")
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestFeed(t *testing.T) {
+	f := newFixture(t)
+	other, err := f.store.CreateBoard("other")
+	require.NoError(t, err)
+	otherList, err := f.store.CreateList(model.ListInputCreate{BoardID: other.ID, Title: "todo"})
+	require.NoError(t, err)
+	_, err = f.store.AddBoardMember(f.board.ID, "anna")
+	require.NoError(t, err)
+	soon := f.card(t, f.list.ID, "soon", time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC))
+	old := f.card(t, f.list.ID, "old", time.Date(2025, 11, 1, 9, 0, 0, 0, time.UTC))
+	archived := f.card(t, f.list.ID, "archived", time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC))
+	_, err = f.store.ArchiveCard(archived.ID)
+	require.NoError(t, err)
+	foreign := f.card(t, otherList.ID, "foreign", time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC))
+
+	feed := func(token string) (string, error) {
+		var buf bytes.Buffer
+		err := f.svc.Feed(token, &buf)
+		return buf.String(), err
+	}
+	token, issued, err := f.svc.IssueToken("anna")
+	require.NoError(t, err)
+	require.Equal(t, "anna", issued.Username)
+	ics, err := feed(token)
+	require.NoError(t, err)
+	require.Contains(t, ics, "SUMMARY:soon")
+	require.NotContains(t, ics, "card-"+strconv.Itoa(old.ID)+"@", "expired too long ago")
+	require.NotContains(t, ics, "SUMMARY:archived")
+	require.NotContains(t, ics, "SUMMARY:foreign", "not a member of the board")
+	require.Equal(t, 1, strings.Count(ics, "BEGIN:VEVENT"))
+
+	// Вступив в доску, пользователь видит и её карточки.
+	_, err = f.store.AddBoardMember(other.ID, "anna")
+	require.NoError(t, err)
+	ics, err = feed(token)
+	require.NoError(t, err)
+	require.Contains(t, ics, "UID:card-"+strconv.Itoa(foreign.ID)+"@awesomeProject2")
+	require.Contains(t, ics, "UID:card-"+strconv.Itoa(soon.ID)+"@awesomeProject2")
+
+	rotated, _, err := f.svc.IssueToken("anna")
+	require.NoError(t, err)
+	_, err = feed(token)
+	require.ErrorIs(t, err, model.ErrNotFound, "old token stops working")
+	require.NoError(t, f.svc.RevokeToken("anna"))
+	_, err = feed(rotated)
+	require.ErrorIs(t, err, model.ErrNotFound)
+	require.ErrorIs(t, f.svc.RevokeToken("anna"), model.ErrNotFound)
+
+	_, _, err = f.svc.IssueToken("not a name")
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+}
@@ -1,50 +1,385 @@
 package main
 
 import (
+	"awesomeProject2/cmd/automation"
+	"awesomeProject2/cmd/calendar"
 	"awesomeProject2/cmd/config"
 	"awesomeProject2/cmd/db"
+	"awesomeProject2/cmd/email"
 	"awesomeProject2/cmd/handler"
+	"awesomeProject2/cmd/importexport"
+	"awesomeProject2/cmd/lane"
+	"awesomeProject2/cmd/mention"
+	"awesomeProject2/cmd/metrics"
+	"awesomeProject2/cmd/migrations"
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/notification"
+	"awesomeProject2/cmd/recurrence"
 	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/sprint"
+	"awesomeProject2/cmd/sqlite"
+	memory "awesomeProject2/cmd/storage"
+	"awesomeProject2/cmd/view"
+	"awesomeProject2/cmd/watch"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"io/fs"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	logger, err := zap.NewDevelopment()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Ошибка конфигурации: %v", err)
+	}
+	logger, err := newLogger(cfg.LogLevel)
 	if err != nil {
 		log.Fatalf("Не удалось создать логгер: %v", err)
 	}
 	defer logger.Sync()
 
-	env := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		config.GetOrDefault("DB_HOST", "db"),
-		config.GetOrDefault("DB_PORT", "5432"),
-		config.GetOrDefault("DB_USER", "admin"),
-		config.GetOrDefault("DB_PASSWORD", "3228"),
-		config.GetOrDefault("DB_NAME", "mydb"),
-		config.GetOrDefault("SSL_MODE", "disable"),
+	var (
+		boardStore   service.BoardStorage
+		listStore    service.ListStorage
+		cardStore    service.CardStorage
+		cardTx       service.Transactor
+		trashStore   service.TrashStorage
+		exportStore  importexport.Storage
+		ruleStore    automation.RuleStorage
+		boardReader  automation.BoardReader
+		dueStore     automation.DueStorage
+		recurStore   recurrence.Storage
+		cardReader   recurrence.CardReader
+		notifStore   notification.Storage
+		emailStore   email.Storage
+		memberStore  service.MemberStorage
+		mentions     mention.Storage
+		commentStore service.CommentStorage
+		watches      watch.Storage
+		linkStore    service.CardLinkStorage
+		fieldStore   service.FieldStorage
+		viewStore    view.Storage
+		viewBoards   view.BoardReader
+		cardDetails  view.CardDetails
+		calTokens    calendar.TokenStorage
+		calBoards    calendar.BoardReader
+		cardMoves    metrics.Storage
+		flowBoards   metrics.BoardReader
+		laneStore    lane.Storage
+		laneBoards   lane.BoardReader
+		sprintStore  sprint.Storage
 	)
+	switch cfg.StorageDriver {
+	case config.DriverMemory:
+		if len(os.Args) > 1 {
+			logger.Fatal("Команды недоступны для STORAGE_DRIVER=memory", zap.Strings("args", os.Args[1:]))
+		}
+		mem := memory.NewStorage()
+		boardStore, listStore, cardStore, cardTx, trashStore, exportStore = mem, mem, mem, mem, mem, mem
+		ruleStore, boardReader, dueStore = mem, mem, mem
+		recurStore, cardReader = mem, mem
+		notifStore, emailStore = mem, mem
+		memberStore, mentions, commentStore, watches, linkStore = mem, mem, mem, mem, mem
+		fieldStore = mem
+		viewStore, viewBoards, cardDetails = mem, mem, mem
+		calTokens, calBoards = mem, mem
+		cardMoves, flowBoards = mem, mem
+		laneStore, laneBoards = mem, mem
+		sprintStore = mem
+		logger.Warn("Используется хранилище в памяти, данные не сохранятся после перезапуска")
+	case config.DriverPostgres, config.DriverSQLite:
+		db, migrationsFS, err := openDB(cfg)
+		if err != nil {
+			logger.Fatal("Не удалось подключиться к БД", zap.Error(err), zap.String("driver", cfg.StorageDriver))
+		}
+		defer db.Close()
 
-	db, err := sqlx.Open("postgres", env)
-	if err != nil {
-		logger.Fatal("Не удалось подключиться к БД", zap.Any("env", env))
+		migrator, err := migrations.NewMigrator(db, migrationsFS)
+		if err != nil {
+			logger.Fatal("Не удалось загрузить миграции", zap.Error(err))
+		}
+		if len(os.Args) > 1 {
+			if err := runCommand(migrator, os.Args[1:]); err != nil {
+				logger.Fatal("Ошибка выполнения команды", zap.Error(err), zap.Strings("args", os.Args[1:]))
+			}
+			return
+		}
+		// Файл SQLite принадлежит только приложению, поэтому схему
+		// обновляем сами; для Postgres миграции запускаются отдельно.
+		if cfg.StorageDriver == config.DriverSQLite {
+			applied, err := migrator.Up()
+			if err != nil {
+				logger.Fatal("Не удалось применить миграции", zap.Error(err))
+			}
+			for _, m := range applied {
+				logger.Info("Применена миграция", zap.Int("version", m.Version), zap.String("name", m.Name))
+			}
+		}
+		if err := migrator.Check(); err != nil {
+			logger.Fatal("Схема БД не актуальна, выполните `app migrate up`", zap.Error(err))
+		}
+
+		stores := storage.NewStores(db)
+		boardStore, listStore, cardStore, cardTx, trashStore, exportStore = stores.BoardStorage, stores.ListStorage, stores.CardStorage, stores, stores.CardStorage, stores
+		ruleStore, boardReader, dueStore = stores.AutomationStorage, stores, stores.CardStorage
+		recurStore, cardReader = stores.RecurrenceStorage, stores
+		notifStore, emailStore = stores, stores.EmailStorage
+		memberStore, mentions, commentStore, watches, linkStore = stores.MemberStorage, stores, stores.CommentStorage, stores.WatchStorage, stores.LinkStorage
+		fieldStore = stores.FieldStorage
+		viewStore, viewBoards, cardDetails = stores.ViewStorage, stores, stores
+		calTokens, calBoards = stores.CalendarStorage, stores
+		cardMoves, flowBoards = stores.MetricsStorage, stores
+		laneStore, laneBoards = stores.LaneStorage, stores
+		sprintStore = stores.SprintStorage
 	}
-	boardStore := storage.NewBoardStorage(db)
-	listStore := storage.NewListStorage(db)
-	cardStore := storage.NewCardStorage(db)
-	boardService := service.NewBoardService(boardStore, logger)
+
+	boardService := service.NewBoardService(boardStore, cardTx, logger)
 	listService := service.NewListService(listStore, logger)
-	cardService := service.NewCardService(cardStore, logger)
+	// События сначала обрабатывает автоматизация, затем остальные
+	// подписчики; события, порождённые правилами, движок сам передаёт им.
+	notifier := notification.NewNotifier(notifStore, watches, logger)
+	if cfg.Email.Enabled() {
+		notifier.Mail = email.NewMailer(emailStore)
+	}
+	listeners := service.NewDispatcher(notifier)
+	listeners.Subscribe(mention.NewTracker(mentions, listeners, logger))
+	listeners.Subscribe(watch.NewAutoWatcher(watches, logger))
+	listeners.Subscribe(metrics.NewRecorder(cardMoves, logger))
+	automationEngine := automation.NewEngine(ruleStore, cardTx, logger)
+	automationEngine.Forward = listeners
+	automationEngine.DoneLists = cfg.Cards.DoneLists
+	events := service.NewDispatcher(automationEngine, listeners)
+	automationService := automation.NewService(ruleStore, boardReader)
+	cardService := service.NewCardService(cardStore, cardTx, events, logger)
+	cardService.Mentions = mentions
+	cardService.DoneLists = cfg.Cards.DoneLists
+	cardService.Fields = fieldStore
+	commentService := service.NewCommentService(commentStore, cardStore, events, logger)
+	commentService.Mentions = mentions
+	memberService := service.NewMemberService(memberStore, boardStore)
+	importExportService := importexport.NewService(exportStore, cardService, cardTx, logger)
+	recurrenceService := recurrence.NewService(recurStore, cardReader)
+	notificationService := notification.NewService(notifStore)
+	watchService := watch.NewService(watches)
+	linkService := service.NewLinkService(linkStore, cardStore, cardTx)
+	fieldService := service.NewFieldService(fieldStore, boardStore)
+	viewService := view.NewService(viewStore, viewBoards, cardService, cardDetails)
+	calendarService := calendar.NewService(calTokens, calBoards, cardService)
+	metricsService := metrics.NewService(cardMoves, flowBoards, cardService)
+	metricsService.DoneLists = cfg.Cards.DoneLists
+	metricsService.Sprints = sprintStore
+	laneService := lane.NewService(laneStore, laneBoards, cardService, cardDetails)
+	sprintService := sprint.NewService(sprintStore, boardStore, cardService, cardTx)
+	sprintService.DoneLists = cfg.Cards.DoneLists
+
 	boardHandler := handler.NewBoardHandler(boardService, logger)
 	listHandler := handler.NewListHandler(listService, logger)
 	cardHandler := handler.NewCardHandler(cardService, logger)
-	http.HandleFunc("/boards", boardHandler.HandleBoards)
-	http.HandleFunc("/lists", listHandler.HandleLists)
-	http.HandleFunc("/cards", cardHandler.HandleCards)
-	logger.Info("Приложение успешно стартовало")
-	http.ListenAndServe(":8080", nil)
+	importExportHandler := handler.NewImportExportHandler(importExportService, logger)
+	automationHandler := handler.NewAutomationHandler(automationService, logger)
+	recurrenceHandler := handler.NewRecurrenceHandler(recurrenceService, logger)
+	notificationHandler := handler.NewNotificationHandler(notificationService, logger)
+	memberHandler := handler.NewMemberHandler(memberService, logger)
+	commentHandler := handler.NewCommentHandler(commentService, logger)
+	watchHandler := handler.NewWatchHandler(watchService, logger)
+	linkHandler := handler.NewLinkHandler(linkService, logger)
+	fieldHandler := handler.NewFieldHandler(fieldService, logger)
+	viewHandler := handler.NewViewHandler(viewService, logger)
+	calendarHandler := handler.NewCalendarHandler(calendarService, logger)
+	metricsHandler := handler.NewMetricsHandler(metricsService, logger)
+	laneHandler := handler.NewLaneHandler(laneService, logger)
+	sprintHandler := handler.NewSprintHandler(sprintService, logger)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/boards", boardHandler.HandleBoards)
+	mux.HandleFunc("GET /boards/templates", boardHandler.GetTemplates)
+	mux.HandleFunc("GET /boards/{id}", laneHandler.GetBoard)
+	mux.HandleFunc("PUT /boards/{id}/template", boardHandler.SetTemplate)
+	mux.HandleFunc("POST /boards/{id}/copy", boardHandler.CopyBoard)
+	mux.HandleFunc("PUT /boards/{id}/swimlanes", laneHandler.SetSwimlanes)
+	mux.HandleFunc("GET /boards/{id}/lanes", laneHandler.GetLanes)
+	mux.HandleFunc("POST /boards/{id}/lanes", laneHandler.CreateLane)
+	mux.HandleFunc("PUT /boards/{id}/lanes/order", laneHandler.ReorderLanes)
+	mux.HandleFunc("PUT /lanes/{id}", laneHandler.RenameLane)
+	mux.HandleFunc("DELETE /lanes/{id}", laneHandler.DeleteLane)
+	mux.HandleFunc("GET /boards/{id}/sprints", sprintHandler.GetSprints)
+	mux.HandleFunc("POST /boards/{id}/sprints", sprintHandler.CreateSprint)
+	mux.HandleFunc("GET /sprints/{id}", sprintHandler.GetSprint)
+	mux.HandleFunc("PUT /sprints/{id}", sprintHandler.UpdateSprint)
+	mux.HandleFunc("DELETE /sprints/{id}", sprintHandler.DeleteSprint)
+	mux.HandleFunc("GET /sprints/{id}/cards", sprintHandler.GetSprintCards)
+	mux.HandleFunc("POST /sprints/{id}/start", sprintHandler.StartSprint)
+	mux.HandleFunc("POST /sprints/{id}/complete", sprintHandler.CompleteSprint)
+	mux.HandleFunc("GET /sprints/{id}/burndown", metricsHandler.GetSprintBurndown)
+	mux.HandleFunc("/lists", listHandler.HandleLists)
+	mux.HandleFunc("PUT /lists/{id}/wip-limit", listHandler.SetWIPLimit)
+	mux.HandleFunc("/cards", cardHandler.HandleCards)
+	mux.HandleFunc("POST /cards/bulk", cardHandler.BulkCards)
+	mux.HandleFunc("POST /cards/{id}/archive", cardHandler.ArchiveCard)
+	mux.HandleFunc("POST /cards/{id}/unarchive", cardHandler.UnarchiveCard)
+	mux.HandleFunc("POST /cards/{id}/restore", cardHandler.RestoreCard)
+	mux.HandleFunc("POST /cards/{id}/move", cardHandler.MoveCard)
+	mux.HandleFunc("POST /cards/{id}/copy", cardHandler.CopyCard)
+	mux.HandleFunc("PUT /cards/{id}/lane", cardHandler.SetCardLane)
+	mux.HandleFunc("PUT /cards/{id}/sprint", sprintHandler.SetCardSprint)
+	mux.HandleFunc("PUT /cards/{id}/due", cardHandler.SetDueDate)
+	mux.HandleFunc("GET /cards/{id}/recurrence", recurrenceHandler.GetRecurrence)
+	mux.HandleFunc("PUT /cards/{id}/recurrence", recurrenceHandler.SetRecurrence)
+	mux.HandleFunc("DELETE /cards/{id}/recurrence", recurrenceHandler.StopRecurrence)
+	mux.HandleFunc("GET /cards/{id}/comments", commentHandler.GetComments)
+	mux.HandleFunc("POST /cards/{id}/comments", commentHandler.AddComment)
+	mux.HandleFunc("PUT /cards/{id}/fields/{fieldID}", cardHandler.SetFieldValue)
+	mux.HandleFunc("GET /cards/{id}/links", linkHandler.GetLinks)
+	mux.HandleFunc("POST /cards/{id}/links", linkHandler.AddLink)
+	mux.HandleFunc("DELETE /cards/{id}/links/{linkID}", linkHandler.RemoveLink)
+	mux.HandleFunc("GET /cards/{id}/watchers", watchHandler.GetCardWatchers)
+	mux.HandleFunc("PUT /cards/{id}/watch", watchHandler.Watch(model.WatchCard))
+	mux.HandleFunc("DELETE /cards/{id}/watch", watchHandler.Unwatch(model.WatchCard))
+	mux.HandleFunc("PUT /lists/{id}/watch", watchHandler.Watch(model.WatchList))
+	mux.HandleFunc("DELETE /lists/{id}/watch", watchHandler.Unwatch(model.WatchList))
+	mux.HandleFunc("GET /boards/{id}/trash", cardHandler.GetTrash)
+	mux.HandleFunc("GET /boards/{id}/members", memberHandler.GetMembers)
+	mux.HandleFunc("PUT /boards/{id}/members/{username}", memberHandler.AddMember)
+	mux.HandleFunc("DELETE /boards/{id}/members/{username}", memberHandler.RemoveMember)
+	mux.HandleFunc("GET /boards/{id}/fields", fieldHandler.GetFields)
+	mux.HandleFunc("POST /boards/{id}/fields", fieldHandler.CreateField)
+	mux.HandleFunc("PUT /fields/{id}", fieldHandler.UpdateField)
+	mux.HandleFunc("DELETE /fields/{id}", fieldHandler.DeleteField)
+	mux.HandleFunc("GET /boards/{id}/cards", viewHandler.SearchCards)
+	mux.HandleFunc("GET /boards/{id}/calendar", calendarHandler.GetBoardCalendar)
+	mux.HandleFunc("GET /boards/{id}/metrics", metricsHandler.GetBoardMetrics)
+	mux.HandleFunc("GET /boards/{id}/views", viewHandler.GetViews)
+	mux.HandleFunc("POST /boards/{id}/views", viewHandler.CreateView)
+	mux.HandleFunc("PUT /views/{id}", viewHandler.UpdateView)
+	mux.HandleFunc("DELETE /views/{id}", viewHandler.DeleteView)
+	mux.HandleFunc("GET /views/{id}/cards", viewHandler.ViewCards)
+	mux.HandleFunc("PUT /boards/{id}/watch", watchHandler.Watch(model.WatchBoard))
+	mux.HandleFunc("DELETE /boards/{id}/watch", watchHandler.Unwatch(model.WatchBoard))
+	mux.HandleFunc("GET /boards/{id}/export", importExportHandler.ExportBoard)
+	mux.HandleFunc("POST /boards/import", importExportHandler.ImportBoard)
+	mux.HandleFunc("GET /boards/{id}/cards.csv", importExportHandler.ExportCardsCSV)
+	mux.HandleFunc("POST /lists/{id}/cards/import", importExportHandler.ImportCardsCSV)
+	mux.HandleFunc("GET /boards/{id}/automations", automationHandler.GetRules)
+	mux.HandleFunc("POST /boards/{id}/automations", automationHandler.CreateRule)
+	mux.HandleFunc("GET /boards/{id}/automations/runs", automationHandler.GetRuns)
+	mux.HandleFunc("PUT /automations/{id}", automationHandler.UpdateRule)
+	mux.HandleFunc("DELETE /automations/{id}", automationHandler.DeleteRule)
+	mux.HandleFunc("GET /me/notifications", notificationHandler.GetInbox)
+	mux.HandleFunc("POST /me/notifications/{id}/read", notificationHandler.MarkRead)
+	mux.HandleFunc("POST /me/notifications/read-all", notificationHandler.MarkAllRead)
+	mux.HandleFunc("GET /me/notification-preferences", notificationHandler.GetPreferences)
+	mux.HandleFunc("PUT /me/notification-preferences", notificationHandler.SetPreferences)
+	mux.HandleFunc("GET /me/email-settings", notificationHandler.GetEmailSettings)
+	mux.HandleFunc("PUT /me/email-settings", notificationHandler.SetEmailSettings)
+	mux.HandleFunc("GET /me/watching", watchHandler.GetWatching)
+	mux.HandleFunc("POST /me/calendar-token", calendarHandler.IssueToken)
+	mux.HandleFunc("DELETE /me/calendar-token", calendarHandler.RevokeToken)
+	mux.HandleFunc("GET /calendar/{file}", calendarHandler.GetFeed)
+
+	server := &http.Server{
+		Addr:         cfg.ListenAddr,
+		Handler:      mux,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	purger := service.NewTrashPurger(trashStore, cfg.Trash.Retention, cfg.Trash.PurgeInterval, logger)
+	go purger.Run(ctx)
+	dueWatcher := automation.NewDueWatcher(dueStore, events, cfg.Automation.DueCheckInterval, cfg.Automation.DueSoonWindow, logger)
+	go dueWatcher.Run(ctx)
+	recurrenceScheduler := recurrence.NewScheduler(recurStore, cardReader, cardService, cfg.Recurrence.CheckInterval, logger)
+	go recurrenceScheduler.Run(ctx)
+	if cfg.Email.Enabled() {
+		sender := email.NewSMTPSender(cfg.Email.SMTPHost, cfg.Email.SMTPPort, cfg.Email.Username, cfg.Email.Password, cfg.Email.From)
+		go email.NewOutbox(emailStore, sender, cfg.Email.SendInterval, cfg.Email.MaxAttempts, logger).Run(ctx)
+		go email.NewDigest(emailStore, cfg.Email.DigestPeriod, cfg.Email.SendInterval, logger).Run(ctx)
+	} else {
+		logger.Info("SMTP не настроен, уведомления на почту не отправляются")
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Ошибка остановки сервера", zap.Error(err))
+		}
+	}()
+
+	logger.Info("Приложение успешно стартовало", zap.String("addr", cfg.ListenAddr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatal("Ошибка HTTP-сервера", zap.Error(err))
+	}
+}
+
+func openDB(cfg config.Config) (*sqlx.DB, fs.FS, error) {
+	if cfg.StorageDriver == config.DriverSQLite {
+		db, err := sqlite.Open(cfg.SQLite.Path)
+		return db, sqlite.Migrations, err
+	}
+	db, err := sqlx.Open("postgres", cfg.DB.DSN())
+	if err != nil {
+		return nil, nil, err
+	}
+	db.SetMaxOpenConns(cfg.DB.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.DB.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DB.ConnMaxLifetime)
+	return db, migrations.FS, nil
+}
+
+func runCommand(migrator *migrations.Migrator, args []string) error {
+	if args[0] != "migrate" || len(args) != 2 {
+		return fmt.Errorf("usage: app [migrate up|down|status]")
+	}
+	switch args[1] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		return err
+	case "down":
+		m, err := migrator.Down()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %d_%s\n", m.Version, m.Name)
+		return nil
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("current version: %d (latest %d, dirty %t)\n", status.Current, status.Latest, status.Dirty)
+		for _, m := range status.Pending {
+			fmt.Printf("pending %d_%s\n", m.Version, m.Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down or status", args[1])
+	}
+}
+
+func newLogger(level string) (*zap.Logger, error) {
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	zapCfg := zap.NewDevelopmentConfig()
+	zapCfg.Level = zap.NewAtomicLevelAt(lvl)
+	return zapCfg.Build()
 }
@@ -0,0 +1,374 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+)
+
+type Config struct {
+	ListenAddr    string           `yaml:"listen_addr"`
+	LogLevel      string           `yaml:"log_level"`
+	StorageDriver string           `yaml:"storage_driver"`
+	DB            DBConfig         `yaml:"db"`
+	SQLite        SQLiteConfig     `yaml:"sqlite"`
+	HTTP          HTTPConfig       `yaml:"http"`
+	Trash         TrashConfig      `yaml:"trash"`
+	Automation    AutomationConfig `yaml:"automation"`
+	Recurrence    RecurrenceConfig `yaml:"recurrence"`
+	Email         EmailConfig      `yaml:"email"`
+	Cards         CardsConfig      `yaml:"cards"`
+}
+
+type DBConfig struct {
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	Name            string        `yaml:"name"`
+	SSLMode         string        `yaml:"ssl_mode"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+}
+
+type SQLiteConfig struct {
+	Path string `yaml:"path"`
+}
+
+type HTTPConfig struct {
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// TrashConfig задаёт, сколько карточки лежат в корзине до окончательного
+// удаления и как часто это проверяется.
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+type AutomationConfig struct {
+	DueCheckInterval time.Duration `yaml:"due_check_interval"`
+	// DueSoonWindow — за сколько до срока предупреждать; 0 отключает.
+	DueSoonWindow time.Duration `yaml:"due_soon_window"`
+}
+
+type RecurrenceConfig struct {
+	CheckInterval time.Duration `yaml:"check_interval"`
+}
+
+// EmailConfig — отправка уведомлений на почту; без SMTPHost письма не
+// отправляются.
+type EmailConfig struct {
+	SMTPHost     string        `yaml:"smtp_host"`
+	SMTPPort     int           `yaml:"smtp_port"`
+	Username     string        `yaml:"username"`
+	Password     string        `yaml:"password"`
+	From         string        `yaml:"from"`
+	SendInterval time.Duration `yaml:"send_interval"`
+	MaxAttempts  int           `yaml:"max_attempts"`
+	DigestPeriod time.Duration `yaml:"digest_period"`
+}
+
+func (c EmailConfig) Enabled() bool {
+	return c.SMTPHost != ""
+}
+
+// CardsConfig — правила работы с карточками.
+type CardsConfig struct {
+	// DoneLists — названия списков «готово»; в них нельзя перенести
+	// карточку с открытыми блокерами. Пустой список отключает проверку.
+	DoneLists []string `yaml:"done_lists"`
+}
+
+// ValidationError перечисляет все некорректные настройки сразу,
+// чтобы не чинить конфиг по одной ошибке за запуск.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+var logLevels = []string{"debug", "info", "warn", "error"}
+var storageDrivers = []string{DriverPostgres, DriverMemory, DriverSQLite}
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+func Default() Config {
+	return Config{
+		ListenAddr:    ":8080",
+		LogLevel:      "info",
+		StorageDriver: DriverPostgres,
+		DB: DBConfig{
+			Host:            "db",
+			Port:            5432,
+			User:            "admin",
+			Name:            "mydb",
+			SSLMode:         "disable",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
+		SQLite: SQLiteConfig{
+			Path: "trello.db",
+		},
+		HTTP: HTTPConfig{
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Automation: AutomationConfig{
+			DueCheckInterval: time.Minute,
+			DueSoonWindow:    24 * time.Hour,
+		},
+		Recurrence: RecurrenceConfig{
+			CheckInterval: time.Minute,
+		},
+		Email: EmailConfig{
+			SMTPPort:     25,
+			SendInterval: 30 * time.Second,
+			MaxAttempts:  5,
+			DigestPeriod: 24 * time.Hour,
+		},
+	}
+}
+
+// Load собирает конфигурацию: значения по умолчанию, затем YAML-файл из
+// CONFIG_FILE (если задан), затем переменные окружения.
+func Load() (Config, error) {
+	cfg := Default()
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+	problems := applyEnv(&cfg)
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return Config{}, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file %s: %w", path, err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func applyEnv(cfg *Config) []string {
+	var problems []string
+	str := func(name string, dst *string) {
+		if val, ok := os.LookupEnv(name); ok {
+			*dst = val
+		}
+	}
+	num := func(name string, dst *int) {
+		if val, ok := os.LookupEnv(name); ok {
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not an integer", name, val))
+				return
+			}
+			*dst = n
+		}
+	}
+	list := func(name string, dst *[]string) {
+		if val, ok := os.LookupEnv(name); ok {
+			*dst = nil
+			for _, item := range strings.Split(val, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					*dst = append(*dst, item)
+				}
+			}
+		}
+	}
+	dur := func(name string, dst *time.Duration) {
+		if val, ok := os.LookupEnv(name); ok {
+			d, err := time.ParseDuration(val)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a duration", name, val))
+				return
+			}
+			*dst = d
+		}
+	}
+
+	str("HTTP_ADDR", &cfg.ListenAddr)
+	str("LOG_LEVEL", &cfg.LogLevel)
+	str("STORAGE_DRIVER", &cfg.StorageDriver)
+	str("DB_HOST", &cfg.DB.Host)
+	num("DB_PORT", &cfg.DB.Port)
+	str("DB_USER", &cfg.DB.User)
+	str("DB_PASSWORD", &cfg.DB.Password)
+	str("DB_NAME", &cfg.DB.Name)
+	str("SSL_MODE", &cfg.DB.SSLMode)
+	str("SQLITE_PATH", &cfg.SQLite.Path)
+	num("DB_MAX_OPEN_CONNS", &cfg.DB.MaxOpenConns)
+	num("DB_MAX_IDLE_CONNS", &cfg.DB.MaxIdleConns)
+	dur("DB_CONN_MAX_LIFETIME", &cfg.DB.ConnMaxLifetime)
+	dur("HTTP_READ_TIMEOUT", &cfg.HTTP.ReadTimeout)
+	dur("HTTP_WRITE_TIMEOUT", &cfg.HTTP.WriteTimeout)
+	dur("HTTP_IDLE_TIMEOUT", &cfg.HTTP.IdleTimeout)
+	dur("HTTP_SHUTDOWN_TIMEOUT", &cfg.HTTP.ShutdownTimeout)
+	dur("TRASH_RETENTION", &cfg.Trash.Retention)
+	dur("TRASH_PURGE_INTERVAL", &cfg.Trash.PurgeInterval)
+	dur("AUTOMATION_DUE_CHECK_INTERVAL", &cfg.Automation.DueCheckInterval)
+	dur("AUTOMATION_DUE_SOON_WINDOW", &cfg.Automation.DueSoonWindow)
+	dur("RECURRENCE_CHECK_INTERVAL", &cfg.Recurrence.CheckInterval)
+	str("SMTP_HOST", &cfg.Email.SMTPHost)
+	num("SMTP_PORT", &cfg.Email.SMTPPort)
+	str("SMTP_USERNAME", &cfg.Email.Username)
+	str("SMTP_PASSWORD", &cfg.Email.Password)
+	str("EMAIL_FROM", &cfg.Email.From)
+	dur("EMAIL_SEND_INTERVAL", &cfg.Email.SendInterval)
+	num("EMAIL_MAX_ATTEMPTS", &cfg.Email.MaxAttempts)
+	dur("EMAIL_DIGEST_PERIOD", &cfg.Email.DigestPeriod)
+	list("CARD_DONE_LISTS", &cfg.Cards.DoneLists)
+	return problems
+}
+
+func (c Config) validate() []string {
+	var problems []string
+	if c.ListenAddr == "" {
+		problems = append(problems, "listen address (HTTP_ADDR) is required")
+	}
+	if !slices.Contains(logLevels, c.LogLevel) {
+		problems = append(problems, fmt.Sprintf("log level (LOG_LEVEL) %q must be one of %s", c.LogLevel, strings.Join(logLevels, ", ")))
+	}
+	if !slices.Contains(storageDrivers, c.StorageDriver) {
+		problems = append(problems, fmt.Sprintf("storage driver (STORAGE_DRIVER) %q must be one of %s", c.StorageDriver, strings.Join(storageDrivers, ", ")))
+	}
+	if c.StorageDriver == DriverPostgres {
+		problems = append(problems, c.DB.validate()...)
+	}
+	if c.StorageDriver == DriverSQLite && c.SQLite.Path == "" {
+		problems = append(problems, "sqlite database path (SQLITE_PATH) is required")
+	}
+	timeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"HTTP_READ_TIMEOUT", c.HTTP.ReadTimeout},
+		{"HTTP_WRITE_TIMEOUT", c.HTTP.WriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", c.HTTP.IdleTimeout},
+		{"HTTP_SHUTDOWN_TIMEOUT", c.HTTP.ShutdownTimeout},
+	}
+	for _, t := range timeouts {
+		if t.value <= 0 {
+			problems = append(problems, fmt.Sprintf("timeout %s must be positive", t.name))
+		}
+	}
+	if c.Trash.Retention <= 0 {
+		problems = append(problems, "trash retention (TRASH_RETENTION) must be positive")
+	}
+	if c.Trash.PurgeInterval <= 0 {
+		problems = append(problems, "trash purge interval (TRASH_PURGE_INTERVAL) must be positive")
+	}
+	if c.Automation.DueCheckInterval <= 0 {
+		problems = append(problems, "due check interval (AUTOMATION_DUE_CHECK_INTERVAL) must be positive")
+	}
+	if c.Automation.DueSoonWindow < 0 {
+		problems = append(problems, "due soon window (AUTOMATION_DUE_SOON_WINDOW) must not be negative")
+	}
+	if c.Recurrence.CheckInterval <= 0 {
+		problems = append(problems, "recurrence check interval (RECURRENCE_CHECK_INTERVAL) must be positive")
+	}
+	if c.Email.Enabled() {
+		problems = append(problems, c.Email.validate()...)
+	}
+	return problems
+}
+
+func (c EmailConfig) validate() []string {
+	var problems []string
+	if c.SMTPPort <= 0 || c.SMTPPort > 65535 {
+		problems = append(problems, fmt.Sprintf("smtp port (SMTP_PORT) %d is out of range", c.SMTPPort))
+	}
+	if c.From == "" {
+		problems = append(problems, "sender address (EMAIL_FROM) is required")
+	}
+	if c.SendInterval <= 0 {
+		problems = append(problems, "email send interval (EMAIL_SEND_INTERVAL) must be positive")
+	}
+	if c.MaxAttempts < 1 {
+		problems = append(problems, "email max attempts (EMAIL_MAX_ATTEMPTS) must be positive")
+	}
+	if c.DigestPeriod <= 0 {
+		problems = append(problems, "email digest period (EMAIL_DIGEST_PERIOD) must be positive")
+	}
+	return problems
+}
+
+func (c DBConfig) validate() []string {
+	var problems []string
+	if c.Host == "" {
+		problems = append(problems, "database host (DB_HOST) is required")
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("database port (DB_PORT) %d is out of range", c.Port))
+	}
+	if c.User == "" {
+		problems = append(problems, "database user (DB_USER) is required")
+	}
+	if c.Password == "" {
+		problems = append(problems, "database password (DB_PASSWORD) is required")
+	}
+	if c.Name == "" {
+		problems = append(problems, "database name (DB_NAME) is required")
+	}
+	if !slices.Contains(sslModes, c.SSLMode) {
+		problems = append(problems, fmt.Sprintf("ssl mode (SSL_MODE) %q must be one of %s", c.SSLMode, strings.Join(sslModes, ", ")))
+	}
+	if c.MaxOpenConns < 1 {
+		problems = append(problems, "max open connections (DB_MAX_OPEN_CONNS) must be positive")
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConns > c.MaxOpenConns {
+		problems = append(problems, "max idle connections (DB_MAX_IDLE_CONNS) must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+	if c.ConnMaxLifetime < 0 {
+		problems = append(problems, "connection lifetime (DB_CONN_MAX_LIFETIME) must not be negative")
+	}
+	return problems
+}
+
+// DSN собирает строку подключения lib/pq. Значения берутся в кавычки,
+// иначе пароль с пробелом или кавычкой ломает разбор строки.
+func (c DBConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		dsnValue(c.Host), c.Port, dsnValue(c.User), dsnValue(c.Password), dsnValue(c.Name), dsnValue(c.SSLMode))
+}
+
+// dsnValue экранирует \ и ' и заключает значение в одинарные кавычки.
+func dsnValue(v string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		file         string
+		expectError  bool
+		wantProblems []string
+		check        func(t *testing.T, cfg Config)
+	}{
+		{
+			name: "defaults with password",
+			env:  map[string]string{"DB_PASSWORD": "secret"},
+			check: func(t *testing.T, cfg Config) {
+				require.Equal(t, ":8080", cfg.ListenAddr)
+				require.Equal(t, "info", cfg.LogLevel)
+				require.Equal(t, "host='db' port=5432 user='admin' password='secret' dbname='mydb' sslmode='disable'", cfg.DB.DSN())
+			},
+		},
+		{
+			name: "password with spaces and quotes",
+			env:  map[string]string{"DB_PASSWORD": `it's a \ secret`},
+			check: func(t *testing.T, cfg Config) {
+				require.Equal(t, `host='db' port=5432 user='admin' password='it\'s a \\ secret' dbname='mydb' sslmode='disable'`, cfg.DB.DSN())
+			},
+		},
+		{
+			name:         "missing password",
+			expectError:  true,
+			wantProblems: []string{"database password (DB_PASSWORD) is required"},
+		},
+		{
+			name: "memory driver needs no database",
+			env:  map[string]string{"STORAGE_DRIVER": "memory"},
+			check: func(t *testing.T, cfg Config) {
+				require.Equal(t, DriverMemory, cfg.StorageDriver)
+			},
+		},
+		{
+			name: "sqlite driver",
+			env:  map[string]string{"STORAGE_DRIVER": "sqlite", "SQLITE_PATH": "/data/board.db"},
+			check: func(t *testing.T, cfg Config) {
+				require.Equal(t, DriverSQLite, cfg.StorageDriver)
+				require.Equal(t, "/data/board.db", cfg.SQLite.Path)
+			},
+		},
+		{
+			name:         "sqlite without path",
+			env:          map[string]string{"STORAGE_DRIVER": "sqlite", "SQLITE_PATH": ""},
+			expectError:  true,
+			wantProblems: []string{"sqlite database path (SQLITE_PATH) is required"},
+		},
+		{
+			name:         "unknown driver",
+			env:          map[string]string{"STORAGE_DRIVER": "mongo", "DB_PASSWORD": "secret"},
+			expectError:  true,
+			wantProblems: []string{`storage driver (STORAGE_DRIVER) "mongo" must be one of postgres, memory, sqlite`},
+		},
+		{
+			name: "env overrides",
+			env: map[string]string{
+				"DB_PASSWORD":                   "secret",
+				"DB_HOST":                       "localhost",
+				"DB_PORT":                       "6543",
+				"HTTP_ADDR":                     ":9090",
+				"LOG_LEVEL":                     "debug",
+				"DB_MAX_OPEN_CONNS":             "20",
+				"HTTP_READ_TIMEOUT":             "3s",
+				"TRASH_RETENTION":               "168h",
+				"AUTOMATION_DUE_CHECK_INTERVAL": "30s",
+				"RECURRENCE_CHECK_INTERVAL":     "5m",
+				"CARD_DONE_LISTS":               "Done, Готово,",
+			},
+			check: func(t *testing.T, cfg Config) {
+				require.Equal(t, "localhost", cfg.DB.Host)
+				require.Equal(t, 6543, cfg.DB.Port)
+				require.Equal(t, ":9090", cfg.ListenAddr)
+				require.Equal(t, "debug", cfg.LogLevel)
+				require.Equal(t, 20, cfg.DB.MaxOpenConns)
+				require.Equal(t, 3*time.Second, cfg.HTTP.ReadTimeout)
+				require.Equal(t, 7*24*time.Hour, cfg.Trash.Retention)
+				require.Equal(t, time.Hour, cfg.Trash.PurgeInterval)
+				require.Equal(t, 30*time.Second, cfg.Automation.DueCheckInterval)
+				require.Equal(t, 5*time.Minute, cfg.Recurrence.CheckInterval)
+				require.Equal(t, []string{"Done", "Готово"}, cfg.Cards.DoneLists)
+			},
+		},
+		{
+			name: "every invalid setting is reported",
+			env: map[string]string{
+				"DB_PORT":            "abc",
+				"LOG_LEVEL":          "loud",
+				"SSL_MODE":           "maybe",
+				"HTTP_WRITE_TIMEOUT": "0s",
+				"TRASH_RETENTION":    "-1h",
+			},
+			expectError: true,
+			wantProblems: []string{
+				`DB_PORT: "abc" is not an integer`,
+				`log level (LOG_LEVEL) "loud" must be one of debug, info, warn, error`,
+				"database password (DB_PASSWORD) is required",
+				`ssl mode (SSL_MODE) "maybe" must be one of disable, allow, prefer, require, verify-ca, verify-full`,
+				"timeout HTTP_WRITE_TIMEOUT must be positive",
+				"trash retention (TRASH_RETENTION) must be positive",
+			},
+		},
+		{
+			name: "email is validated once smtp host is set",
+			env: map[string]string{
+				"DB_PASSWORD":        "secret",
+				"SMTP_HOST":          "mail.local",
+				"EMAIL_MAX_ATTEMPTS": "0",
+			},
+			expectError: true,
+			wantProblems: []string{
+				"sender address (EMAIL_FROM) is required",
+				"email max attempts (EMAIL_MAX_ATTEMPTS) must be positive",
+			},
+		},
+		{
+			name: "file values, env wins",
+			file: `
+listen_addr: ":7070"
+db:
+  host: filehost
+  password: frompass
+  max_open_conns: 4
+  max_idle_conns: 2
+http:
+  idle_timeout: 2m
+`,
+			env: map[string]string{"DB_HOST": "envhost"},
+			check: func(t *testing.T, cfg Config) {
+				require.Equal(t, ":7070", cfg.ListenAddr)
+				require.Equal(t, "envhost", cfg.DB.Host)
+				require.Equal(t, "frompass", cfg.DB.Password)
+				require.Equal(t, 4, cfg.DB.MaxOpenConns)
+				require.Equal(t, 2*time.Minute, cfg.HTTP.IdleTimeout)
+			},
+		},
+		{
+			name:        "unknown key in file",
+			file:        "db:\n  pasword: typo\n",
+			env:         map[string]string{"DB_PASSWORD": "secret"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"CONFIG_FILE", "DB_PASSWORD", "STORAGE_DRIVER", "SQLITE_PATH"} {
+				t.Setenv(name, "")
+				os.Unsetenv(name)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if tt.file != "" {
+				path := filepath.Join(t.TempDir(), "config.yaml")
+				require.NoError(t, os.WriteFile(path, []byte(tt.file), 0o600))
+				t.Setenv("CONFIG_FILE", path)
+			}
+
+			cfg, err := Load()
+			if tt.expectError {
+				require.Error(t, err)
+				if tt.wantProblems != nil {
+					var verr *ValidationError
+					require.ErrorAs(t, err, &verr)
+					require.Equal(t, tt.wantProblems, verr.Problems)
+				}
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, cfg)
+		})
+	}
+}
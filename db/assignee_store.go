@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+type AssigneeStorage struct {
+	DB Querier
+}
+
+func NewAssigneeStorage(db Querier) *AssigneeStorage { return &AssigneeStorage{db} }
+
+func (s *AssigneeStorage) GetCardAssignees(cardID int) ([]string, error) {
+	var usernames []string
+	err := s.DB.Select(&usernames, `SELECT username FROM card_assignees WHERE card_id = $1 ORDER BY username`, cardID)
+	return usernames, err
+}
+
+func (s *AssigneeStorage) AddCardAssignee(cardID int, username string) error {
+	var count int
+	if err := s.DB.Get(&count, `SELECT COUNT(*) FROM cards WHERE id = $1`, cardID); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("card %d: %w", cardID, model.ErrNotFound)
+	}
+	_, err := s.DB.Exec(`INSERT INTO card_assignees (card_id, username) VALUES ($1, $2) ON CONFLICT DO NOTHING`, cardID, username)
+	return err
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type AutomationStorage struct {
+	DB Querier
+}
+
+func NewAutomationStorage(db Querier) *AutomationStorage { return &AutomationStorage{db} }
+
+const automationRuleColumns = `id, board_id, name, enabled, trigger_type, trigger_list_id, trigger_label_id, conditions, actions, created_at`
+
+const automationRunColumns = `id, rule_id, board_id, card_id, event, status, error, created_at`
+
+// automationRuleRow — строка automation_rules: условия и действия
+// хранятся в JSON, их набор полей меняется чаще схемы.
+type automationRuleRow struct {
+	ID             int       `db:"id"`
+	BoardID        int       `db:"board_id"`
+	Name           string    `db:"name"`
+	Enabled        bool      `db:"enabled"`
+	TriggerType    string    `db:"trigger_type"`
+	TriggerListID  int       `db:"trigger_list_id"`
+	TriggerLabelID int       `db:"trigger_label_id"`
+	Conditions     string    `db:"conditions"`
+	Actions        string    `db:"actions"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+func (r automationRuleRow) rule() (model.AutomationRule, error) {
+	rule := model.AutomationRule{
+		ID:      r.ID,
+		BoardID: r.BoardID,
+		Name:    r.Name,
+		Enabled: r.Enabled,
+		Trigger: model.AutomationTrigger{
+			Type:    r.TriggerType,
+			ListID:  r.TriggerListID,
+			LabelID: r.TriggerLabelID,
+		},
+		CreatedAt: r.CreatedAt,
+	}
+	if err := json.Unmarshal([]byte(r.Conditions), &rule.Conditions); err != nil {
+		return model.AutomationRule{}, fmt.Errorf("rule %d conditions: %w", r.ID, err)
+	}
+	if err := json.Unmarshal([]byte(r.Actions), &rule.Actions); err != nil {
+		return model.AutomationRule{}, fmt.Errorf("rule %d actions: %w", r.ID, err)
+	}
+	return rule, nil
+}
+
+func (s *AutomationStorage) GetRules(boardID int) ([]model.AutomationRule, error) {
+	var rows []automationRuleRow
+	err := s.DB.Select(&rows, "SELECT "+automationRuleColumns+" FROM automation_rules WHERE board_id = $1 ORDER BY id", boardID)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]model.AutomationRule, 0, len(rows))
+	for _, row := range rows {
+		rule, err := row.rule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (s *AutomationStorage) GetRule(id int) (model.AutomationRule, error) {
+	var row automationRuleRow
+	err := s.DB.Get(&row, "SELECT "+automationRuleColumns+" FROM automation_rules WHERE id = $1", id)
+	if err != nil {
+		return model.AutomationRule{}, notFound(err, "automation rule", id)
+	}
+	return row.rule()
+}
+
+func (s *AutomationStorage) CreateRule(input model.AutomationRuleInput) (model.AutomationRule, error) {
+	conditions, actions, err := marshalRuleParts(input)
+	if err != nil {
+		return model.AutomationRule{}, err
+	}
+	query := `INSERT INTO automation_rules (board_id, name, enabled, trigger_type, trigger_list_id, trigger_label_id, conditions, actions)
+		SELECT id, $2, $3, $4, $5, $6, $7, $8 FROM boards WHERE id = $1
+		RETURNING ` + automationRuleColumns
+	var row automationRuleRow
+	err = s.DB.Get(&row, query, input.BoardID, input.Name, input.Enabled,
+		input.Trigger.Type, input.Trigger.ListID, input.Trigger.LabelID, conditions, actions)
+	if err != nil {
+		return model.AutomationRule{}, notFound(err, "board", input.BoardID)
+	}
+	return row.rule()
+}
+
+// UpdateRule заменяет правило целиком; доска правила не меняется.
+func (s *AutomationStorage) UpdateRule(id int, input model.AutomationRuleInput) (model.AutomationRule, error) {
+	conditions, actions, err := marshalRuleParts(input)
+	if err != nil {
+		return model.AutomationRule{}, err
+	}
+	query := `UPDATE automation_rules SET name = $1, enabled = $2, trigger_type = $3, trigger_list_id = $4,
+			trigger_label_id = $5, conditions = $6, actions = $7
+		WHERE id = $8
+		RETURNING ` + automationRuleColumns
+	var row automationRuleRow
+	err = s.DB.Get(&row, query, input.Name, input.Enabled,
+		input.Trigger.Type, input.Trigger.ListID, input.Trigger.LabelID, conditions, actions, id)
+	if err != nil {
+		return model.AutomationRule{}, notFound(err, "automation rule", id)
+	}
+	return row.rule()
+}
+
+func (s *AutomationStorage) DeleteRule(id int) error {
+	res, err := s.DB.Exec(`DELETE FROM automation_rules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("automation rule %d: %w", id, model.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *AutomationStorage) CreateRun(run model.AutomationRun) (model.AutomationRun, error) {
+	query := `INSERT INTO automation_runs (rule_id, board_id, card_id, event, status, error)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + automationRunColumns
+	var created model.AutomationRun
+	err := s.DB.Get(&created, query, run.RuleID, run.BoardID, run.CardID, run.Event, run.Status, run.Error)
+	return created, err
+}
+
+// GetRuns возвращает последние limit записей журнала доски, новые первыми.
+func (s *AutomationStorage) GetRuns(boardID int, limit int) ([]model.AutomationRun, error) {
+	var runs []model.AutomationRun
+	err := s.DB.Select(&runs, "SELECT "+automationRunColumns+" FROM automation_runs WHERE board_id = $1 ORDER BY id DESC LIMIT $2", boardID, limit)
+	return runs, err
+}
+
+func marshalRuleParts(input model.AutomationRuleInput) (string, string, error) {
+	conditions := input.Conditions
+	if conditions == nil {
+		conditions = []model.AutomationCondition{}
+	}
+	actions := input.Actions
+	if actions == nil {
+		actions = []model.AutomationAction{}
+	}
+	c, err := json.Marshal(conditions)
+	if err != nil {
+		return "", "", err
+	}
+	a, err := json.Marshal(actions)
+	if err != nil {
+		return "", "", err
+	}
+	return string(c), string(a), nil
+}
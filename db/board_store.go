@@ -2,22 +2,44 @@ package storage
 
 import (
 	"awesomeProject2/cmd/model"
-	"github.com/jmoiron/sqlx"
 )
 
 type BoardStorage struct {
-	DB *sqlx.DB
+	DB Querier
 }
 
-func NewBoardStorage(db *sqlx.DB) *BoardStorage { return &BoardStorage{db} }
+func NewBoardStorage(db Querier) *BoardStorage { return &BoardStorage{db} }
+
+const boardColumns = `id, title, is_template, created_at, updated_at`
+
 func (s *BoardStorage) GetBoards() ([]model.Board, error) {
 	var boards []model.Board
-	err := s.DB.Select(&boards, "SELECT * FROM boards")
+	err := s.DB.Select(&boards, "SELECT "+boardColumns+" FROM boards ORDER BY id")
 	return boards, err
 }
+
+func (s *BoardStorage) GetBoard(id int) (model.Board, error) {
+	var board model.Board
+	err := s.DB.Get(&board, "SELECT "+boardColumns+" FROM boards WHERE id = $1", id)
+	return board, notFound(err, "board", id)
+}
+
 func (s *BoardStorage) CreateBoard(title string) (model.Board, error) {
 	var board model.Board
-	query := `INSERT INTO boards (title) VALUES ($1) RETURNING id, title`
+	query := `INSERT INTO boards (title) VALUES ($1) RETURNING ` + boardColumns
 	err := s.DB.Get(&board, query, title)
 	return board, err
 }
+
+func (s *BoardStorage) GetTemplates() ([]model.Board, error) {
+	var boards []model.Board
+	err := s.DB.Select(&boards, "SELECT "+boardColumns+" FROM boards WHERE is_template ORDER BY id")
+	return boards, err
+}
+
+func (s *BoardStorage) SetBoardTemplate(id int, isTemplate bool) (model.Board, error) {
+	var board model.Board
+	query := `UPDATE boards SET is_template = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 RETURNING ` + boardColumns
+	err := s.DB.Get(&board, query, isTemplate, id)
+	return board, notFound(err, "board", id)
+}
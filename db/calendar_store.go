@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type CalendarStorage struct {
+	DB Querier
+}
+
+func NewCalendarStorage(db Querier) *CalendarStorage { return &CalendarStorage{db} }
+
+// SetCalendarToken сохраняет хэш нового токена пользователя, заменяя
+// прежний.
+func (s *CalendarStorage) SetCalendarToken(username, hash string) (model.CalendarToken, error) {
+	query := `INSERT INTO calendar_tokens (username, token_hash) VALUES ($1, $2)
+		ON CONFLICT (username) DO UPDATE SET token_hash = excluded.token_hash, created_at = CURRENT_TIMESTAMP
+		RETURNING username, created_at`
+	var token model.CalendarToken
+	err := s.DB.Get(&token, query, username, hash)
+	return token, err
+}
+
+// GetCalendarTokenUser возвращает владельца токена с хэшем hash.
+func (s *CalendarStorage) GetCalendarTokenUser(hash string) (string, error) {
+	var username string
+	err := s.DB.Get(&username, `SELECT username FROM calendar_tokens WHERE token_hash = $1`, hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("calendar token: %w", model.ErrNotFound)
+	}
+	return username, err
+}
+
+func (s *CalendarStorage) DeleteCalendarToken(username string) error {
+	res, err := s.DB.Exec(`DELETE FROM calendar_tokens WHERE username = $1`, username)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("calendar token of %s: %w", username, model.ErrNotFound)
+	}
+	return nil
+}
@@ -2,40 +2,247 @@ package storage
 
 import (
 	"awesomeProject2/cmd/model"
-	"github.com/jmoiron/sqlx"
+	"fmt"
+	"strings"
+	"time"
 )
 
 type CardStorage struct {
-	DB *sqlx.DB
+	DB Querier
 }
 
-func NewCardStorage(db *sqlx.DB) *CardStorage { return &CardStorage{db} }
-func (s *CardStorage) GetCards(listID *int) ([]model.Card, error) {
-	var cards []model.Card
-	var err error
-	if listID != nil {
-		err = s.DB.Select(&cards, "SELECT * FROM cards WHERE list_id = $1", *listID)
-	} else {
-		err = s.DB.Select(&cards, "SELECT * FROM cards")
+func NewCardStorage(db Querier) *CardStorage { return &CardStorage{db} }
+
+const cardColumns = `id, board_id, list_id, title, description, status, due_at, archived_at, deleted_at, created_at, updated_at`
+
+func (s *CardStorage) GetCards(filter model.CardFilter) ([]model.Card, error) {
+	conditions := []string{"cards.deleted_at IS NULL"}
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "cards.archived_at IS NULL")
+	}
+	if filter.ListID != nil {
+		conditions = append(conditions, "cards.list_id = "+arg(*filter.ListID))
+	}
+	if filter.BoardID != nil {
+		conditions = append(conditions, "cards.board_id = "+arg(*filter.BoardID))
+	}
+	for _, label := range filter.Labels {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM card_labels cl JOIN labels l ON l.id = cl.label_id WHERE cl.card_id = cards.id AND LOWER(l.name) = "+arg(strings.ToLower(label))+")")
+	}
+	for _, username := range filter.Assignees {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM card_assignees a WHERE a.card_id = cards.id AND a.username = "+arg(username)+")")
 	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			placeholders[i] = arg(status)
+		}
+		conditions = append(conditions, "cards.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.DueFrom != nil {
+		conditions = append(conditions, "cards.due_at >= "+arg(filter.DueFrom.UTC()))
+	}
+	if filter.DueTo != nil {
+		conditions = append(conditions, "cards.due_at < "+arg(filter.DueTo.UTC()))
+	}
+	if filter.Text != "" {
+		// LOWER в SQLite понимает только ASCII, поэтому там поиск
+		// кириллицы чувствителен к регистру.
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(filter.Text)) + "%"
+		conditions = append(conditions, fmt.Sprintf(`(LOWER(cards.title) LIKE %s ESCAPE '\' OR LOWER(COALESCE(cards.description, '')) LIKE %s ESCAPE '\')`, arg(pattern), arg(pattern)))
+	}
+	for _, f := range filter.Fields {
+		match := f.Match
+		column, value := "value_text", any(match.Text)
+		switch {
+		case match.Number != nil:
+			column, value = "value_number", match.Number
+		case match.Date != nil:
+			column, value = "value_date", match.Date.UTC()
+		case match.Bool != nil:
+			column, value = "value_bool", match.Bool
+		}
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM card_field_values f WHERE f.card_id = cards.id AND f.field_id = %s AND f.%s = %s)", arg(f.FieldID), column, arg(value)))
+	}
+	from, order, err := cardOrder(filter.Sort, arg)
+	if err != nil {
+		return nil, err
+	}
+	var cards []model.Card
+	err = s.DB.Select(&cards, "SELECT "+cardColumns+" FROM "+from+" WHERE "+strings.Join(conditions, " AND ")+" ORDER BY "+order, args...)
 	return cards, err
 }
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// cardOrder возвращает FROM и ORDER BY для сортировки карточек; пустые
+// значения идут последними в обоих направлениях.
+func cardOrder(sort *model.CardSort, arg func(any) string) (string, string, error) {
+	if sort == nil {
+		return "cards", "cards.id", nil
+	}
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+	switch sort.By {
+	case model.CardSortTitle:
+		return "cards", "LOWER(cards.title) " + direction + ", cards.id", nil
+	case model.CardSortCreated, model.CardSortUpdated:
+		return "cards", fmt.Sprintf("cards.%s %s, cards.id", sort.By, direction), nil
+	case model.CardSortDue:
+		return "cards", "cards.due_at IS NULL, cards.due_at " + direction + ", cards.id", nil
+	case model.CardSortField:
+		column, ok := fieldValueColumns[sort.Type]
+		if !ok {
+			return "", "", fmt.Errorf("%w: unknown field type %q", model.ErrInvalidInput, sort.Type)
+		}
+		from := "cards LEFT JOIN card_field_values s ON s.card_id = cards.id AND s.field_id = " + arg(sort.FieldID)
+		return from, fmt.Sprintf("s.%s IS NULL, s.%s %s, cards.id", column, column, direction), nil
+	}
+	return "", "", fmt.Errorf("%w: unknown sort %q", model.ErrInvalidInput, sort.By)
+}
+
+func (s *CardStorage) GetCard(id int) (model.Card, error) {
+	var card model.Card
+	err := s.DB.Get(&card, "SELECT "+cardColumns+" FROM cards WHERE id = $1 AND deleted_at IS NULL", id)
+	return card, notFound(err, "card", id)
+}
+
 func (s *CardStorage) CreateCard(input model.CardInputCreate) (model.Card, error) {
-	query := `INSERT INTO cards(title,board_id,list_id, description) VALUES ($1, $2, $3) RETURNING  title, board_id, description, list_id`
+	query := `INSERT INTO cards (title, description, list_id, board_id)
+		SELECT $1, $2, id, board_id FROM lists WHERE id = $3
+		RETURNING ` + cardColumns
 	var card model.Card
 	err := s.DB.Get(&card, query, input.Title, input.Description, input.ListID)
-	return card, err
+	return card, notFound(err, "list", input.ListID)
 }
+
+// DeleteCard переносит карточку в корзину; окончательно её удаляет
+// PurgeDeletedCards.
 func (s *CardStorage) DeleteCard(listID int, cardID int) (model.Card, error) {
-	query := `DELETE FROM cards WHERE id = $1 AND list_id = $2 RETURNING id, list_id`
+	query := `UPDATE cards SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND list_id = $2 AND deleted_at IS NULL
+		RETURNING ` + cardColumns
 	var card model.Card
 	err := s.DB.Get(&card, query, cardID, listID)
-	return card, err
+	return card, notFound(err, "card", cardID)
 }
 
 func (s *CardStorage) UpdateCard(updated model.Card) (model.Card, error) {
-	query := `UPDATE cards SET title = $1, description = $2, list_id = $3 WHERE id = $4 RETURNING id, title, description, list_id`
+	query := `UPDATE cards SET title = $1, description = $2, list_id = lists.id, board_id = lists.board_id, updated_at = CURRENT_TIMESTAMP
+		FROM lists WHERE cards.id = $4 AND lists.id = $3 AND cards.deleted_at IS NULL
+		RETURNING cards.id, cards.board_id, cards.list_id, cards.title, cards.description, cards.status, cards.due_at,
+			cards.archived_at, cards.deleted_at, cards.created_at, cards.updated_at`
 	var card model.Card
 	err := s.DB.Get(&card, query, updated.Title, updated.Description, updated.ListID, updated.ID)
-	return card, err
+	return card, notFound(err, "card", updated.ID)
+}
+
+func (s *CardStorage) ArchiveCard(id int) (model.Card, error) {
+	query := `UPDATE cards SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + cardColumns
+	var card model.Card
+	err := s.DB.Get(&card, query, id)
+	return card, notFound(err, "card", id)
+}
+
+func (s *CardStorage) UnarchiveCard(id int) (model.Card, error) {
+	query := `UPDATE cards SET archived_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + cardColumns
+	var card model.Card
+	err := s.DB.Get(&card, query, id)
+	return card, notFound(err, "card", id)
+}
+
+// RestoreCard возвращает карточку из корзины.
+func (s *CardStorage) RestoreCard(id int) (model.Card, error) {
+	query := `UPDATE cards SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING ` + cardColumns
+	var card model.Card
+	err := s.DB.Get(&card, query, id)
+	return card, notFound(err, "deleted card", id)
+}
+
+// GetDeletedCards возвращает корзину доски, недавно удалённые первыми.
+func (s *CardStorage) GetDeletedCards(boardID int) ([]model.Card, error) {
+	var cards []model.Card
+	err := s.DB.Select(&cards, "SELECT "+cardColumns+" FROM cards WHERE board_id = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC", boardID)
+	return cards, err
+}
+
+// PurgeDeletedCards окончательно удаляет карточки, попавшие в корзину
+// раньше before; связанные записи удаляются каскадом.
+func (s *CardStorage) PurgeDeletedCards(before time.Time) (int, error) {
+	res, err := s.DB.Exec(`DELETE FROM cards WHERE deleted_at IS NOT NULL AND deleted_at < $1`, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (s *CardStorage) SetCardStatus(id int, status string) (model.Card, error) {
+	query := `UPDATE cards SET status = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND deleted_at IS NULL
+		RETURNING ` + cardColumns
+	var card model.Card
+	err := s.DB.Get(&card, query, status, id)
+	return card, notFound(err, "card", id)
+}
+
+// SetCardDue меняет срок карточки; новый срок снова может сработать.
+func (s *CardStorage) SetCardDue(id int, due *time.Time) (model.Card, error) {
+	if due != nil {
+		utc := due.UTC()
+		due = &utc
+	}
+	query := `UPDATE cards SET due_at = $1, due_triggered = FALSE, due_soon_triggered = FALSE, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND deleted_at IS NULL
+		RETURNING ` + cardColumns
+	var card model.Card
+	err := s.DB.Get(&card, query, due, id)
+	return card, notFound(err, "card", id)
+}
+
+// GetDueCards возвращает активные карточки, срок которых наступил не
+// позже before и ещё не был обработан.
+func (s *CardStorage) GetDueCards(before time.Time) ([]model.Card, error) {
+	var cards []model.Card
+	query := `SELECT ` + cardColumns + ` FROM cards
+		WHERE due_at IS NOT NULL AND due_at <= $1 AND NOT due_triggered
+			AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY due_at, id`
+	err := s.DB.Select(&cards, query, before.UTC())
+	return cards, err
+}
+
+func (s *CardStorage) MarkDueTriggered(id int) error {
+	_, err := s.DB.Exec(`UPDATE cards SET due_triggered = TRUE WHERE id = $1`, id)
+	return err
+}
+
+// GetDueSoonCards возвращает активные карточки со сроком не позже before,
+// о приближении которого ещё не предупреждали.
+func (s *CardStorage) GetDueSoonCards(before time.Time) ([]model.Card, error) {
+	var cards []model.Card
+	query := `SELECT ` + cardColumns + ` FROM cards
+		WHERE due_at IS NOT NULL AND due_at <= $1 AND NOT due_soon_triggered
+			AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY due_at, id`
+	err := s.DB.Select(&cards, query, before.UTC())
+	return cards, err
+}
+
+func (s *CardStorage) MarkDueSoonTriggered(id int) error {
+	_, err := s.DB.Exec(`UPDATE cards SET due_soon_triggered = TRUE WHERE id = $1`, id)
+	return err
 }
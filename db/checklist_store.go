@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+)
+
+type ChecklistStorage struct {
+	DB Querier
+}
+
+func NewChecklistStorage(db Querier) *ChecklistStorage { return &ChecklistStorage{db} }
+
+func (s *ChecklistStorage) GetChecklists(cardID int) ([]model.Checklist, error) {
+	var checklists []model.Checklist
+	if err := s.DB.Select(&checklists, `SELECT id, card_id, title FROM checklists WHERE card_id = $1 ORDER BY id`, cardID); err != nil {
+		return nil, err
+	}
+	var items []model.ChecklistItem
+	query := `SELECT i.id, i.checklist_id, i.text, i.checked FROM checklist_items i
+		JOIN checklists c ON c.id = i.checklist_id
+		WHERE c.card_id = $1 ORDER BY i.id`
+	if err := s.DB.Select(&items, query, cardID); err != nil {
+		return nil, err
+	}
+	for i := range checklists {
+		for _, item := range items {
+			if item.ChecklistID == checklists[i].ID {
+				checklists[i].Items = append(checklists[i].Items, item)
+			}
+		}
+	}
+	return checklists, nil
+}
+
+func (s *ChecklistStorage) CreateChecklist(input model.ChecklistInputCreate) (model.Checklist, error) {
+	var checklist model.Checklist
+	query := `INSERT INTO checklists (card_id, title) SELECT id, $2 FROM cards WHERE id = $1 RETURNING id, card_id, title`
+	err := s.DB.Get(&checklist, query, input.CardID, input.Title)
+	return checklist, notFound(err, "card", input.CardID)
+}
+
+func (s *ChecklistStorage) CreateChecklistItem(input model.ChecklistItemInputCreate) (model.ChecklistItem, error) {
+	var item model.ChecklistItem
+	query := `INSERT INTO checklist_items (checklist_id, text, checked) SELECT id, $2, $3 FROM checklists WHERE id = $1
+		RETURNING id, checklist_id, text, checked`
+	err := s.DB.Get(&item, query, input.ChecklistID, input.Text, input.Checked)
+	return item, notFound(err, "checklist", input.ChecklistID)
+}
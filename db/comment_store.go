@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+)
+
+type CommentStorage struct {
+	DB Querier
+}
+
+func NewCommentStorage(db Querier) *CommentStorage { return &CommentStorage{db} }
+
+const commentColumns = `id, card_id, author, text, created_at`
+
+func (s *CommentStorage) GetComments(cardID int) ([]model.Comment, error) {
+	var comments []model.Comment
+	err := s.DB.Select(&comments, "SELECT "+commentColumns+" FROM comments WHERE card_id = $1 ORDER BY id", cardID)
+	return comments, err
+}
+
+func (s *CommentStorage) CreateComment(input model.CommentInputCreate) (model.Comment, error) {
+	var comment model.Comment
+	query := `INSERT INTO comments (card_id, author, text, created_at)
+		SELECT id, $2, $3, COALESCE($4, CURRENT_TIMESTAMP) FROM cards WHERE id = $1
+		RETURNING ` + commentColumns
+	err := s.DB.Get(&comment, query, input.CardID, input.Author, input.Text, input.CreatedAt)
+	return comment, notFound(err, "card", input.CardID)
+}
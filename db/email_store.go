@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type EmailStorage struct {
+	DB Querier
+}
+
+func NewEmailStorage(db Querier) *EmailStorage { return &EmailStorage{db} }
+
+const emailColumns = `id, recipient, subject, text_body, html_body, status, attempts, last_error, next_attempt_at, sent_at, created_at`
+
+func (s *EmailStorage) GetEmailSettings(username string) (model.EmailSettings, error) {
+	var settings model.EmailSettings
+	err := s.DB.Get(&settings, `SELECT username, address, digest, last_digest_at FROM email_settings WHERE username = $1`, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return settings, fmt.Errorf("email settings of %s: %w", username, model.ErrNotFound)
+	}
+	return settings, err
+}
+
+// SaveEmailSettings сохраняет адрес и способ доставки; время последней
+// сводки не трогает.
+func (s *EmailStorage) SaveEmailSettings(settings model.EmailSettings) (model.EmailSettings, error) {
+	query := `INSERT INTO email_settings (username, address, digest) VALUES ($1, $2, $3)
+		ON CONFLICT (username) DO UPDATE SET address = excluded.address, digest = excluded.digest
+		RETURNING username, address, digest, last_digest_at`
+	var saved model.EmailSettings
+	err := s.DB.Get(&saved, query, settings.Username, settings.Address, settings.Digest)
+	return saved, err
+}
+
+// GetDigestRecipients возвращает подписчиков сводки, которым её не
+// отправляли с момента before.
+func (s *EmailStorage) GetDigestRecipients(before time.Time) ([]model.EmailSettings, error) {
+	query := `SELECT username, address, digest, last_digest_at FROM email_settings
+		WHERE digest AND (last_digest_at IS NULL OR last_digest_at <= $1)
+		ORDER BY username`
+	var recipients []model.EmailSettings
+	err := s.DB.Select(&recipients, query, before.UTC())
+	return recipients, err
+}
+
+func (s *EmailStorage) SetLastDigestAt(username string, at time.Time) error {
+	_, err := s.DB.Exec(`UPDATE email_settings SET last_digest_at = $2 WHERE username = $1`, username, at.UTC())
+	return err
+}
+
+func (s *EmailStorage) AddDigestItem(item model.DigestItem) error {
+	_, err := s.DB.Exec(`INSERT INTO email_digest_items (username, kind, event, board_id, card_id, card_title)
+		VALUES ($1, $2, $3, $4, $5, $6)`, item.Username, item.Kind, item.Event, item.BoardID, item.CardID, item.CardTitle)
+	return err
+}
+
+func (s *EmailStorage) GetDigestItems(username string) ([]model.DigestItem, error) {
+	query := `SELECT id, username, kind, event, board_id, card_id, card_title, created_at
+		FROM email_digest_items WHERE username = $1 ORDER BY id`
+	var items []model.DigestItem
+	err := s.DB.Select(&items, query, username)
+	return items, err
+}
+
+// DeleteDigestItems удаляет отложенные уведомления пользователя до lastID
+// включительно — те, что уже попали в сводку.
+func (s *EmailStorage) DeleteDigestItems(username string, lastID int) error {
+	_, err := s.DB.Exec(`DELETE FROM email_digest_items WHERE username = $1 AND id <= $2`, username, lastID)
+	return err
+}
+
+func (s *EmailStorage) EnqueueEmail(e model.Email) (model.Email, error) {
+	query := `INSERT INTO email_outbox (recipient, subject, text_body, html_body, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + emailColumns
+	var created model.Email
+	err := s.DB.Get(&created, query, e.Recipient, e.Subject, e.TextBody, e.HTMLBody, e.NextAttemptAt.UTC())
+	return created, err
+}
+
+// GetPendingEmails возвращает письма, чья очередная попытка наступила к now.
+func (s *EmailStorage) GetPendingEmails(now time.Time, limit int) ([]model.Email, error) {
+	query := `SELECT ` + emailColumns + ` FROM email_outbox
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at, id LIMIT $2`
+	var emails []model.Email
+	err := s.DB.Select(&emails, query, now.UTC(), limit)
+	return emails, err
+}
+
+func (s *EmailStorage) MarkEmailSent(id int, at time.Time) error {
+	var updated int
+	err := s.DB.Get(&updated, `UPDATE email_outbox SET status = 'sent', attempts = attempts + 1, last_error = '', sent_at = $2
+		WHERE id = $1 RETURNING id`, id, at.UTC())
+	return notFound(err, "email", id)
+}
+
+// MarkEmailFailed записывает неудачную попытку. С nextAttemptAt письмо
+// остаётся в очереди, без него — отправка прекращается.
+func (s *EmailStorage) MarkEmailFailed(id int, reason string, nextAttemptAt *time.Time) error {
+	query := `UPDATE email_outbox SET status = 'failed', attempts = attempts + 1, last_error = $2
+		WHERE id = $1 RETURNING id`
+	args := []any{id, reason}
+	if nextAttemptAt != nil {
+		query = `UPDATE email_outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+			WHERE id = $1 RETURNING id`
+		args = append(args, nextAttemptAt.UTC())
+	}
+	var updated int
+	err := s.DB.Get(&updated, query, args...)
+	return notFound(err, "email", id)
+}
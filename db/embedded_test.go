@@ -0,0 +1,45 @@
+//go:build postgres
+
+package storage
+
+import (
+	"fmt"
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"os"
+	"testing"
+)
+
+// embeddedPort не совпадает со стандартным 5432, чтобы не задеть локальный
+// Postgres разработчика.
+const embeddedPort = 55433
+
+// TestMain поднимает встроенный Postgres на время тестов пакета, если
+// TEST_POSTGRES_DSN не задан. Бинарники скачиваются при первом запуске и
+// кешируются, поэтому в CI хватает `go test -tags postgres ./db/`.
+func TestMain(m *testing.M) {
+	if os.Getenv("TEST_POSTGRES_DSN") != "" {
+		os.Exit(m.Run())
+	}
+	dir, err := os.MkdirTemp("", "embedded-postgres")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	pg := embeddedpostgres.NewDatabase(embeddedpostgres.DefaultConfig().
+		Version(embeddedpostgres.V15).
+		Port(embeddedPort).
+		RuntimePath(dir).
+		Logger(nil))
+	if err := pg.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, "start embedded postgres:", err)
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+	os.Setenv("TEST_POSTGRES_DSN", fmt.Sprintf("host=localhost port=%d user=postgres password=postgres dbname=postgres sslmode=disable", embeddedPort))
+	code := m.Run()
+	if err := pg.Stop(); err != nil {
+		fmt.Fprintln(os.Stderr, "stop embedded postgres:", err)
+	}
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// notFound приводит sql.ErrNoRows к model.ErrNotFound, чтобы сервисы
+// не зависели от конкретного хранилища.
+func notFound(err error, what string, id int) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s %d: %w", what, id, model.ErrNotFound)
+	}
+	return err
+}
+
+// uniqueViolation сообщает, что запрос нарушил уникальный индекс:
+// Postgres возвращает код 23505, SQLite — ошибку «UNIQUE constraint
+// failed».
+func uniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
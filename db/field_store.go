@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+)
+
+type FieldStorage struct {
+	DB Querier
+}
+
+func NewFieldStorage(db Querier) *FieldStorage { return &FieldStorage{db} }
+
+const fieldColumns = `id, board_id, name, type, options, created_at`
+
+// fieldRow — строка custom_fields; варианты хранятся JSON-массивом.
+type fieldRow struct {
+	model.CustomField
+	Options string `db:"options"`
+}
+
+func (r fieldRow) field() (model.CustomField, error) {
+	field := r.CustomField
+	if err := json.Unmarshal([]byte(r.Options), &field.Options); err != nil {
+		return model.CustomField{}, fmt.Errorf("field %d options: %w", field.ID, err)
+	}
+	return field, nil
+}
+
+func encodeOptions(options []string) (string, error) {
+	if options == nil {
+		options = []string{}
+	}
+	data, err := json.Marshal(options)
+	return string(data), err
+}
+
+// fieldValueColumns — колонка card_field_values для значений каждого типа.
+var fieldValueColumns = map[string]string{
+	model.FieldText:     "value_text",
+	model.FieldDropdown: "value_text",
+	model.FieldNumber:   "value_number",
+	model.FieldDate:     "value_date",
+	model.FieldCheckbox: "value_bool",
+}
+
+func (s *FieldStorage) GetFields(boardID int) ([]model.CustomField, error) {
+	var rows []fieldRow
+	if err := s.DB.Select(&rows, `SELECT `+fieldColumns+` FROM custom_fields WHERE board_id = $1 ORDER BY id`, boardID); err != nil {
+		return nil, err
+	}
+	fields := make([]model.CustomField, 0, len(rows))
+	for _, r := range rows {
+		field, err := r.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func (s *FieldStorage) GetField(id int) (model.CustomField, error) {
+	var row fieldRow
+	if err := s.DB.Get(&row, `SELECT `+fieldColumns+` FROM custom_fields WHERE id = $1`, id); err != nil {
+		return model.CustomField{}, notFound(err, "field", id)
+	}
+	return row.field()
+}
+
+func (s *FieldStorage) CreateField(input model.CustomFieldInputCreate) (model.CustomField, error) {
+	options, err := encodeOptions(input.Options)
+	if err != nil {
+		return model.CustomField{}, err
+	}
+	query := `INSERT INTO custom_fields (board_id, name, type, options)
+		SELECT id, $2, $3, $4 FROM boards WHERE id = $1
+		RETURNING ` + fieldColumns
+	var row fieldRow
+	if err := s.DB.Get(&row, query, input.BoardID, input.Name, input.Type, options); err != nil {
+		return model.CustomField{}, notFound(err, "board", input.BoardID)
+	}
+	return row.field()
+}
+
+func (s *FieldStorage) UpdateField(id int, update model.CustomFieldUpdate) (model.CustomField, error) {
+	options, err := encodeOptions(update.Options)
+	if err != nil {
+		return model.CustomField{}, err
+	}
+	query := `UPDATE custom_fields SET name = $2, options = $3 WHERE id = $1 RETURNING ` + fieldColumns
+	var row fieldRow
+	if err := s.DB.Get(&row, query, id, update.Name, options); err != nil {
+		return model.CustomField{}, notFound(err, "field", id)
+	}
+	return row.field()
+}
+
+// DeleteField удаляет поле вместе со значениями на карточках.
+func (s *FieldStorage) DeleteField(id int) error {
+	res, err := s.DB.Exec(`DELETE FROM custom_fields WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("field %d: %w", id, model.ErrNotFound)
+	}
+	return nil
+}
+
+const fieldValueColumnList = `card_id, field_id, value_text, value_number, value_date, value_bool`
+
+func (s *FieldStorage) GetCardFieldValues(cardID int) ([]model.CardFieldValue, error) {
+	var values []model.CardFieldValue
+	err := s.DB.Select(&values, `SELECT `+fieldValueColumnList+` FROM card_field_values WHERE card_id = $1 ORDER BY field_id`, cardID)
+	for i := range values {
+		values[i] = utcValue(values[i])
+	}
+	return values, err
+}
+
+// SetCardFieldValue сохраняет значение, заменяя прежнее.
+func (s *FieldStorage) SetCardFieldValue(v model.CardFieldValue) (model.CardFieldValue, error) {
+	if v.Date != nil {
+		date := v.Date.UTC()
+		v.Date = &date
+	}
+	query := `INSERT INTO card_field_values (` + fieldValueColumnList + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (card_id, field_id) DO UPDATE SET value_text = excluded.value_text, value_number = excluded.value_number,
+			value_date = excluded.value_date, value_bool = excluded.value_bool
+		RETURNING ` + fieldValueColumnList
+	var saved model.CardFieldValue
+	err := s.DB.Get(&saved, query, v.CardID, v.FieldID, v.Text, v.Number, v.Date, v.Bool)
+	return utcValue(saved), err
+}
+
+func (s *FieldStorage) DeleteCardFieldValue(cardID, fieldID int) error {
+	_, err := s.DB.Exec(`DELETE FROM card_field_values WHERE card_id = $1 AND field_id = $2`, cardID, fieldID)
+	return err
+}
+
+func utcValue(v model.CardFieldValue) model.CardFieldValue {
+	if v.Date != nil {
+		date := v.Date.UTC()
+		v.Date = &date
+	}
+	return v
+}
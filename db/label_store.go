@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+type LabelStorage struct {
+	DB Querier
+}
+
+func NewLabelStorage(db Querier) *LabelStorage { return &LabelStorage{db} }
+
+const labelColumns = `id, board_id, name, color`
+
+func (s *LabelStorage) GetLabels(boardID int) ([]model.Label, error) {
+	var labels []model.Label
+	err := s.DB.Select(&labels, "SELECT "+labelColumns+" FROM labels WHERE board_id = $1 ORDER BY id", boardID)
+	return labels, err
+}
+
+func (s *LabelStorage) CreateLabel(input model.LabelInputCreate) (model.Label, error) {
+	var label model.Label
+	query := `INSERT INTO labels (board_id, name, color) SELECT id, $2, $3 FROM boards WHERE id = $1 RETURNING ` + labelColumns
+	err := s.DB.Get(&label, query, input.BoardID, input.Name, input.Color)
+	return label, notFound(err, "board", input.BoardID)
+}
+
+func (s *LabelStorage) GetCardLabels(cardID int) ([]model.Label, error) {
+	var labels []model.Label
+	query := `SELECT l.id, l.board_id, l.name, l.color FROM labels l
+		JOIN card_labels cl ON cl.label_id = l.id
+		WHERE cl.card_id = $1 ORDER BY l.id`
+	err := s.DB.Select(&labels, query, cardID)
+	return labels, err
+}
+
+func (s *LabelStorage) AddCardLabel(cardID int, labelID int) error {
+	var count int
+	check := `SELECT COUNT(*) FROM cards c JOIN labels l ON l.board_id = c.board_id WHERE c.id = $1 AND l.id = $2`
+	if err := s.DB.Get(&count, check, cardID, labelID); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("label %d for card %d: %w", labelID, cardID, model.ErrNotFound)
+	}
+	_, err := s.DB.Exec(`INSERT INTO card_labels (card_id, label_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, cardID, labelID)
+	return err
+}
+
+func (s *LabelStorage) RemoveCardLabel(cardID int, labelID int) error {
+	_, err := s.DB.Exec(`DELETE FROM card_labels WHERE card_id = $1 AND label_id = $2`, cardID, labelID)
+	return err
+}
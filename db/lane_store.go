@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+type LaneStorage struct {
+	DB Querier
+}
+
+func NewLaneStorage(db Querier) *LaneStorage { return &LaneStorage{db} }
+
+const laneColumns = `id, board_id, title, position, created_at`
+
+// GetSwimlanes возвращает режим дорожек доски; у доски без записи он
+// пустой.
+func (s *LaneStorage) GetSwimlanes(boardID int) (model.Swimlanes, error) {
+	query := `SELECT boards.id AS board_id, COALESCE(board_swimlanes.mode, '') AS mode, board_swimlanes.field_id
+		FROM boards LEFT JOIN board_swimlanes ON board_swimlanes.board_id = boards.id
+		WHERE boards.id = $1`
+	var swimlanes model.Swimlanes
+	err := s.DB.Get(&swimlanes, query, boardID)
+	return swimlanes, notFound(err, "board", boardID)
+}
+
+// SetSwimlanes сохраняет режим дорожек; пустой режим удаляет запись.
+func (s *LaneStorage) SetSwimlanes(swimlanes model.Swimlanes) (model.Swimlanes, error) {
+	if swimlanes.Mode == model.LaneNone {
+		if _, err := s.DB.Exec(`DELETE FROM board_swimlanes WHERE board_id = $1`, swimlanes.BoardID); err != nil {
+			return model.Swimlanes{}, err
+		}
+		return s.GetSwimlanes(swimlanes.BoardID)
+	}
+	query := `INSERT INTO board_swimlanes (board_id, mode, field_id) SELECT id, $2, $3 FROM boards WHERE id = $1
+		ON CONFLICT (board_id) DO UPDATE SET mode = excluded.mode, field_id = excluded.field_id
+		RETURNING board_id, mode, field_id`
+	var saved model.Swimlanes
+	err := s.DB.Get(&saved, query, swimlanes.BoardID, swimlanes.Mode, swimlanes.FieldID)
+	return saved, notFound(err, "board", swimlanes.BoardID)
+}
+
+func (s *LaneStorage) GetLanes(boardID int) ([]model.Lane, error) {
+	lanes := []model.Lane{}
+	err := s.DB.Select(&lanes, `SELECT `+laneColumns+` FROM board_lanes WHERE board_id = $1 ORDER BY position, id`, boardID)
+	return lanes, err
+}
+
+func (s *LaneStorage) GetLane(id int) (model.Lane, error) {
+	var lane model.Lane
+	err := s.DB.Get(&lane, `SELECT `+laneColumns+` FROM board_lanes WHERE id = $1`, id)
+	return lane, notFound(err, "lane", id)
+}
+
+// CreateLane добавляет дорожку в конец доски.
+func (s *LaneStorage) CreateLane(input model.LaneInputCreate) (model.Lane, error) {
+	query := `INSERT INTO board_lanes (board_id, title, position)
+		SELECT id, $2, (SELECT COALESCE(MAX(position) + 1, 0) FROM board_lanes WHERE board_id = $1) FROM boards WHERE id = $1
+		RETURNING ` + laneColumns
+	var lane model.Lane
+	err := s.DB.Get(&lane, query, input.BoardID, input.Title)
+	return lane, notFound(err, "board", input.BoardID)
+}
+
+func (s *LaneStorage) RenameLane(id int, title string) (model.Lane, error) {
+	var lane model.Lane
+	err := s.DB.Get(&lane, `UPDATE board_lanes SET title = $2 WHERE id = $1 RETURNING `+laneColumns, id, title)
+	return lane, notFound(err, "lane", id)
+}
+
+// DeleteLane удаляет дорожку; её карточки остаются вне дорожек.
+func (s *LaneStorage) DeleteLane(id int) error {
+	res, err := s.DB.Exec(`DELETE FROM board_lanes WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("lane %d: %w", id, model.ErrNotFound)
+	}
+	return nil
+}
+
+// SetLanePositions расставляет дорожки доски в порядке ids. Дорожки
+// других досок не трогает.
+func (s *LaneStorage) SetLanePositions(boardID int, ids []int) error {
+	for i, id := range ids {
+		if _, err := s.DB.Exec(`UPDATE board_lanes SET position = $1 WHERE id = $2 AND board_id = $3`, i, id, boardID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCardLanes возвращает дорожки карточек доски, включая архивные.
+func (s *LaneStorage) GetCardLanes(boardID int) ([]model.CardLane, error) {
+	query := `SELECT card_lanes.card_id, card_lanes.lane_id FROM card_lanes
+		JOIN cards ON cards.id = card_lanes.card_id
+		WHERE cards.board_id = $1 AND cards.deleted_at IS NULL
+		ORDER BY card_lanes.card_id`
+	lanes := []model.CardLane{}
+	err := s.DB.Select(&lanes, query, boardID)
+	return lanes, err
+}
+
+// SetCardLane кладёт карточку в дорожку laneID или, при nil, убирает из
+// дорожек.
+func (s *LaneStorage) SetCardLane(cardID int, laneID *int) error {
+	if laneID == nil {
+		_, err := s.DB.Exec(`DELETE FROM card_lanes WHERE card_id = $1`, cardID)
+		return err
+	}
+	var count int
+	if err := s.DB.Get(&count, `SELECT COUNT(*) FROM cards WHERE id = $1 AND deleted_at IS NULL`, cardID); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("card %d: %w", cardID, model.ErrNotFound)
+	}
+	_, err := s.DB.Exec(`INSERT INTO card_lanes (card_id, lane_id) VALUES ($1, $2)
+		ON CONFLICT (card_id) DO UPDATE SET lane_id = excluded.lane_id`, cardID, *laneID)
+	return err
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+type LinkStorage struct {
+	DB Querier
+}
+
+func NewLinkStorage(db Querier) *LinkStorage { return &LinkStorage{db} }
+
+const linkColumns = `id, source_id, target_id, type, created_at`
+
+// GetCardLinks возвращает связи, в которых карточка участвует с любой
+// стороны, в порядке создания.
+func (s *LinkStorage) GetCardLinks(cardID int) ([]model.CardLink, error) {
+	var links []model.CardLink
+	err := s.DB.Select(&links, `SELECT `+linkColumns+` FROM card_links WHERE source_id = $1 OR target_id = $1 ORDER BY id`, cardID)
+	return links, err
+}
+
+// CreateCardLink добавляет связь; повторное добавление возвращает
+// существующую.
+func (s *LinkStorage) CreateCardLink(link model.CardLink) (model.CardLink, error) {
+	query := `INSERT INTO card_links (source_id, target_id, type)
+		SELECT source.id, target.id, $3 FROM cards source, cards target WHERE source.id = $1 AND target.id = $2
+		ON CONFLICT (source_id, target_id, type) DO UPDATE SET type = excluded.type
+		RETURNING ` + linkColumns
+	var created model.CardLink
+	err := s.DB.Get(&created, query, link.SourceID, link.TargetID, link.Type)
+	return created, notFound(err, "card", link.TargetID)
+}
+
+func (s *LinkStorage) DeleteCardLink(id int) error {
+	res, err := s.DB.Exec(`DELETE FROM card_links WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("card link %d: %w", id, model.ErrNotFound)
+	}
+	return nil
+}
@@ -2,27 +2,71 @@ package storage
 
 import (
 	"awesomeProject2/cmd/model"
-	"github.com/jmoiron/sqlx"
+	"fmt"
 )
 
 type ListStorage struct {
-	DB *sqlx.DB
+	DB Querier
 }
 
-func NewListStorage(db *sqlx.DB) *ListStorage { return &ListStorage{db} }
+func NewListStorage(db Querier) *ListStorage { return &ListStorage{db} }
+
+const listColumns = `id, board_id, title, wip_limit, wip_soft, created_at, updated_at`
+
+// listSelect дополняет списки числом карточек в них.
+const listSelect = `SELECT ` + listColumns + `,
+	(SELECT COUNT(*) FROM cards WHERE cards.list_id = lists.id AND archived_at IS NULL AND deleted_at IS NULL) AS card_count
+	FROM lists`
+
 func (s *ListStorage) GetLists(boardID *int) ([]model.List, error) {
 	var lists []model.List
 	var err error
 	if boardID != nil {
-		err = s.DB.Select(&lists, "SELECT * FROM lists WHERE board_id = $1", *boardID)
+		err = s.DB.Select(&lists, listSelect+" WHERE board_id = $1 ORDER BY id", *boardID)
 	} else {
-		err = s.DB.Select(&lists, "SELECT * FROM lists")
+		err = s.DB.Select(&lists, listSelect+" ORDER BY id")
 	}
 	return lists, err
 }
+
+func (s *ListStorage) GetList(id int) (model.List, error) {
+	var list model.List
+	err := s.DB.Get(&list, listSelect+" WHERE id = $1", id)
+	return list, notFound(err, "list", id)
+}
+
+// LockList блокирует строку списка до конца транзакции и только потом
+// считает его карточки: подзапрос в одном запросе с FOR UPDATE видел бы
+// снимок до ожидания блокировки. В SQLite FOR UPDATE нет, а запись и так
+// идёт через одно соединение по очереди.
+func (s *ListStorage) LockList(id int) (model.List, error) {
+	if d, ok := s.DB.(interface{ DriverName() string }); ok && d.DriverName() == "postgres" {
+		var locked []int
+		if err := s.DB.Select(&locked, `SELECT id FROM lists WHERE id = $1 FOR UPDATE`, id); err != nil {
+			return model.List{}, err
+		}
+	}
+	return s.GetList(id)
+}
+
 func (s *ListStorage) CreateList(input model.ListInputCreate) (model.List, error) {
 	var list model.List
-	query := `INSERT INTO lists (title, board_id) VALUES ($1, $2) RETURNING id, title, board_id`
-	err := s.DB.Get(&list, query, input.Title, input.BoardID)
-	return list, err
+	query := `INSERT INTO lists (title, board_id, wip_limit, wip_soft) SELECT $1, id, $3, $4 FROM boards WHERE id = $2 RETURNING ` + listColumns
+	err := s.DB.Get(&list, query, input.Title, input.BoardID, input.WIPLimit, input.WIPSoft)
+	return list, notFound(err, "board", input.BoardID)
+}
+
+func (s *ListStorage) SetListWIPLimit(id int, limit model.ListWIPLimit) (model.List, error) {
+	res, err := s.DB.Exec(`UPDATE lists SET wip_limit = $1, wip_soft = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`, limit.Limit, limit.Soft, id)
+	if err != nil {
+		return model.List{}, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return model.List{}, err
+	}
+	if n == 0 {
+		return model.List{}, fmt.Errorf("list %d: %w", id, model.ErrNotFound)
+	}
+	return s.GetList(id)
 }
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+type MemberStorage struct {
+	DB Querier
+}
+
+func NewMemberStorage(db Querier) *MemberStorage { return &MemberStorage{db} }
+
+// GetMemberBoards возвращает доски, в которых состоит username.
+func (s *MemberStorage) GetMemberBoards(username string) ([]model.Board, error) {
+	boards := []model.Board{}
+	err := s.DB.Select(&boards, `SELECT `+boardColumns+` FROM boards
+		WHERE id IN (SELECT board_id FROM board_members WHERE username = $1) ORDER BY id`, username)
+	return boards, err
+}
+
+func (s *MemberStorage) GetBoardMembers(boardID int) ([]model.BoardMember, error) {
+	var members []model.BoardMember
+	err := s.DB.Select(&members, `SELECT board_id, username, added_at FROM board_members WHERE board_id = $1 ORDER BY username`, boardID)
+	return members, err
+}
+
+// AddBoardMember добавляет участника; повторное добавление возвращает
+// существующую запись.
+func (s *MemberStorage) AddBoardMember(boardID int, username string) (model.BoardMember, error) {
+	query := `INSERT INTO board_members (board_id, username)
+		SELECT id, $2 FROM boards WHERE id = $1
+		ON CONFLICT (board_id, username) DO UPDATE SET username = excluded.username
+		RETURNING board_id, username, added_at`
+	var member model.BoardMember
+	err := s.DB.Get(&member, query, boardID, username)
+	return member, notFound(err, "board", boardID)
+}
+
+func (s *MemberStorage) RemoveBoardMember(boardID int, username string) error {
+	res, err := s.DB.Exec(`DELETE FROM board_members WHERE board_id = $1 AND username = $2`, boardID, username)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("member %s of board %d: %w", username, boardID, model.ErrNotFound)
+	}
+	return nil
+}
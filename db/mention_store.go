@@ -0,0 +1,33 @@
+package storage
+
+import "awesomeProject2/cmd/model"
+
+type MentionStorage struct {
+	DB Querier
+}
+
+func NewMentionStorage(db Querier) *MentionStorage { return &MentionStorage{db} }
+
+const mentionColumns = `id, card_id, comment_id, username, author, created_at`
+
+func (s *MentionStorage) CreateMention(m model.Mention) (model.Mention, error) {
+	query := `INSERT INTO mentions (card_id, comment_id, username, author)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + mentionColumns
+	var created model.Mention
+	err := s.DB.Get(&created, query, m.CardID, m.CommentID, m.Username, m.Author)
+	return created, err
+}
+
+// GetMentions возвращает упоминания в описании карточки и её комментариях
+// в порядке появления.
+func (s *MentionStorage) GetMentions(cardID int) ([]model.Mention, error) {
+	var mentions []model.Mention
+	err := s.DB.Select(&mentions, `SELECT `+mentionColumns+` FROM mentions WHERE card_id = $1 ORDER BY id`, cardID)
+	return mentions, err
+}
+
+func (s *MentionStorage) DeleteMention(id int) error {
+	_, err := s.DB.Exec(`DELETE FROM mentions WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,31 @@
+package storage
+
+import "awesomeProject2/cmd/model"
+
+type MetricsStorage struct {
+	DB Querier
+}
+
+func NewMetricsStorage(db Querier) *MetricsStorage { return &MetricsStorage{db} }
+
+const cardMoveColumns = `id, card_id, COALESCE(from_list_id, 0) AS from_list_id, to_list_id, moved_at`
+
+func (s *MetricsStorage) AddCardMove(move model.CardMove) (model.CardMove, error) {
+	query := `INSERT INTO card_moves (card_id, from_list_id, to_list_id, moved_at)
+		VALUES ($1, NULLIF($2, 0), $3, $4)
+		RETURNING ` + cardMoveColumns
+	var created model.CardMove
+	err := s.DB.Get(&created, query, move.CardID, move.FromListID, move.ToListID, move.MovedAt)
+	return created, err
+}
+
+// GetCardMoves возвращает историю карточек, которые сейчас на доске
+// (включая архивные), по карточкам в порядке записи.
+func (s *MetricsStorage) GetCardMoves(boardID int) ([]model.CardMove, error) {
+	query := `SELECT ` + cardMoveColumns + ` FROM card_moves
+		WHERE card_id IN (SELECT id FROM cards WHERE board_id = $1 AND deleted_at IS NULL)
+		ORDER BY card_id, id`
+	moves := []model.CardMove{}
+	err := s.DB.Select(&moves, query, boardID)
+	return moves, err
+}
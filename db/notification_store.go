@@ -0,0 +1,72 @@
+package storage
+
+import "awesomeProject2/cmd/model"
+
+type NotificationStorage struct {
+	DB Querier
+}
+
+func NewNotificationStorage(db Querier) *NotificationStorage { return &NotificationStorage{db} }
+
+const notificationColumns = `id, username, kind, event, board_id, card_id, card_title, read_at, created_at`
+
+func (s *NotificationStorage) CreateNotification(n model.Notification) (model.Notification, error) {
+	query := `INSERT INTO notifications (username, kind, event, board_id, card_id, card_title)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + notificationColumns
+	var created model.Notification
+	err := s.DB.Get(&created, query, n.Username, n.Kind, n.Event, n.BoardID, n.CardID, n.CardTitle)
+	return created, err
+}
+
+// GetNotifications возвращает уведомления пользователя, новые первыми.
+func (s *NotificationStorage) GetNotifications(filter model.NotificationFilter) ([]model.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE username = $1`
+	if filter.UnreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	query += ` ORDER BY id DESC LIMIT $2`
+	var notifications []model.Notification
+	err := s.DB.Select(&notifications, query, filter.Username, filter.Limit)
+	return notifications, err
+}
+
+func (s *NotificationStorage) CountUnread(username string) (int, error) {
+	var count int
+	err := s.DB.Get(&count, `SELECT COUNT(*) FROM notifications WHERE username = $1 AND read_at IS NULL`, username)
+	return count, err
+}
+
+// MarkNotificationRead отмечает прочитанным уведомление пользователя;
+// чужое уведомление для него не существует.
+func (s *NotificationStorage) MarkNotificationRead(id int, username string) (model.Notification, error) {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND username = $2
+		RETURNING ` + notificationColumns
+	var n model.Notification
+	err := s.DB.Get(&n, query, id, username)
+	return n, notFound(err, "notification", id)
+}
+
+func (s *NotificationStorage) MarkAllNotificationsRead(username string) (int, error) {
+	res, err := s.DB.Exec(`UPDATE notifications SET read_at = CURRENT_TIMESTAMP WHERE username = $1 AND read_at IS NULL`, username)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// GetNotificationPreferences возвращает только сохранённые настройки.
+func (s *NotificationStorage) GetNotificationPreferences(username string) ([]model.NotificationPreference, error) {
+	var prefs []model.NotificationPreference
+	err := s.DB.Select(&prefs, `SELECT kind, in_app, email FROM notification_preferences WHERE username = $1 ORDER BY kind`, username)
+	return prefs, err
+}
+
+func (s *NotificationStorage) SetNotificationPreference(username string, pref model.NotificationPreference) error {
+	_, err := s.DB.Exec(`INSERT INTO notification_preferences (username, kind, in_app, email) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (username, kind) DO UPDATE SET in_app = excluded.in_app, email = excluded.email`,
+		username, pref.Kind, pref.InApp, pref.Email)
+	return err
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"time"
+)
+
+type RecurrenceStorage struct {
+	DB Querier
+}
+
+func NewRecurrenceStorage(db Querier) *RecurrenceStorage { return &RecurrenceStorage{db} }
+
+const recurrenceColumns = `card_recurrences.card_id, card_recurrences.list_id, card_recurrences.frequency,
+	card_recurrences.interval_count, card_recurrences.cron, card_recurrences.starts_at,
+	card_recurrences.next_run_at, card_recurrences.last_run_at, card_recurrences.created_at`
+
+// GetRecurrence возвращает повторение карточки; у карточки в корзине его
+// как будто нет.
+func (s *RecurrenceStorage) GetRecurrence(cardID int) (model.CardRecurrence, error) {
+	var r model.CardRecurrence
+	query := `SELECT ` + recurrenceColumns + ` FROM card_recurrences
+		JOIN cards ON cards.id = card_recurrences.card_id
+		WHERE card_recurrences.card_id = $1 AND cards.deleted_at IS NULL`
+	err := s.DB.Get(&r, query, cardID)
+	return r, notFound(err, "recurrence of card", cardID)
+}
+
+// SaveRecurrence создаёт повторение или заменяет существующее.
+func (s *RecurrenceStorage) SaveRecurrence(r model.CardRecurrence) (model.CardRecurrence, error) {
+	query := `INSERT INTO card_recurrences (card_id, list_id, frequency, interval_count, cron, starts_at, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (card_id) DO UPDATE SET list_id = excluded.list_id, frequency = excluded.frequency,
+			interval_count = excluded.interval_count, cron = excluded.cron,
+			starts_at = excluded.starts_at, next_run_at = excluded.next_run_at
+		RETURNING ` + recurrenceColumns
+	var saved model.CardRecurrence
+	err := s.DB.Get(&saved, query, r.CardID, r.ListID, r.Frequency, r.Interval, r.Cron, r.StartsAt.UTC(), r.NextRunAt.UTC())
+	return saved, err
+}
+
+func (s *RecurrenceStorage) DeleteRecurrence(cardID int) error {
+	res, err := s.DB.Exec(`DELETE FROM card_recurrences WHERE card_id = $1`, cardID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("recurrence of card %d: %w", cardID, model.ErrNotFound)
+	}
+	return nil
+}
+
+// GetDueRecurrences возвращает повторения, чей следующий запуск не позже
+// before; карточки в архиве и корзине пропускаются.
+func (s *RecurrenceStorage) GetDueRecurrences(before time.Time) ([]model.CardRecurrence, error) {
+	var rs []model.CardRecurrence
+	query := `SELECT ` + recurrenceColumns + ` FROM card_recurrences
+		JOIN cards ON cards.id = card_recurrences.card_id
+		WHERE card_recurrences.next_run_at <= $1 AND cards.archived_at IS NULL AND cards.deleted_at IS NULL
+		ORDER BY card_recurrences.next_run_at, card_recurrences.card_id`
+	err := s.DB.Select(&rs, query, before.UTC())
+	return rs, err
+}
+
+// AdvanceRecurrence переносит запуск с from на next, только если его ещё
+// никто не перенёс. false означает, что запуск from уже обработан.
+func (s *RecurrenceStorage) AdvanceRecurrence(cardID int, from, next, ranAt time.Time) (bool, error) {
+	res, err := s.DB.Exec(`UPDATE card_recurrences SET next_run_at = $1, last_run_at = $2
+		WHERE card_id = $3 AND next_run_at = $4`, next.UTC(), ranAt.UTC(), cardID, from.UTC())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// ReleaseRecurrence возвращает повторению запуск r.NextRunAt и прежнее
+// время последнего запуска, если с тех пор, как его перенесли на
+// claimed, запуск никто не менял.
+func (s *RecurrenceStorage) ReleaseRecurrence(r model.CardRecurrence, claimed time.Time) (bool, error) {
+	var lastRunAt *time.Time
+	if r.LastRunAt != nil {
+		t := r.LastRunAt.UTC()
+		lastRunAt = &t
+	}
+	res, err := s.DB.Exec(`UPDATE card_recurrences SET next_run_at = $1, last_run_at = $2
+		WHERE card_id = $3 AND next_run_at = $4`, r.NextRunAt.UTC(), lastRunAt, r.CardID, claimed.UTC())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
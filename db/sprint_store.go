@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+type SprintStorage struct {
+	DB Querier
+}
+
+func NewSprintStorage(db Querier) *SprintStorage { return &SprintStorage{db} }
+
+const (
+	sprintColumns     = `id, board_id, name, goal, start_date, end_date, status, started_at, completed_at, created_at`
+	sprintCardColumns = `id, sprint_id, card_id, added_at, removed_at`
+)
+
+// GetSprints возвращает спринты доски по дате начала.
+func (s *SprintStorage) GetSprints(boardID int) ([]model.Sprint, error) {
+	sprints := []model.Sprint{}
+	err := s.DB.Select(&sprints, `SELECT `+sprintColumns+` FROM sprints WHERE board_id = $1 ORDER BY start_date, id`, boardID)
+	return sprints, err
+}
+
+func (s *SprintStorage) GetSprint(id int) (model.Sprint, error) {
+	var sprint model.Sprint
+	err := s.DB.Get(&sprint, `SELECT `+sprintColumns+` FROM sprints WHERE id = $1`, id)
+	return sprint, notFound(err, "sprint", id)
+}
+
+func (s *SprintStorage) CreateSprint(sprint model.Sprint) (model.Sprint, error) {
+	query := `INSERT INTO sprints (board_id, name, goal, start_date, end_date)
+		SELECT id, $2, $3, $4, $5 FROM boards WHERE id = $1
+		RETURNING ` + sprintColumns
+	var created model.Sprint
+	err := s.DB.Get(&created, query, sprint.BoardID, sprint.Name, sprint.Goal, sprint.StartDate.UTC(), sprint.EndDate.UTC())
+	return created, notFound(err, "board", sprint.BoardID)
+}
+
+// UpdateSprint меняет название, цель и даты.
+func (s *SprintStorage) UpdateSprint(sprint model.Sprint) (model.Sprint, error) {
+	query := `UPDATE sprints SET name = $2, goal = $3, start_date = $4, end_date = $5
+		WHERE id = $1 RETURNING ` + sprintColumns
+	var updated model.Sprint
+	err := s.DB.Get(&updated, query, sprint.ID, sprint.Name, sprint.Goal, sprint.StartDate.UTC(), sprint.EndDate.UTC())
+	return updated, notFound(err, "sprint", sprint.ID)
+}
+
+func (s *SprintStorage) DeleteSprint(id int) error {
+	res, err := s.DB.Exec(`DELETE FROM sprints WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("sprint %d: %w", id, model.ErrNotFound)
+	}
+	return nil
+}
+
+// SetSprintStatus переводит спринт в status и отмечает время начала или
+// завершения. Второй идущий спринт доски не пропускает индекс
+// sprints_active_idx, в том числе при одновременном запуске; его ошибка
+// возвращается как конфликт.
+func (s *SprintStorage) SetSprintStatus(id int, status string) (model.Sprint, error) {
+	query := `UPDATE sprints SET status = $2,
+			started_at = CASE WHEN $2 = 'active' THEN CURRENT_TIMESTAMP ELSE started_at END,
+			completed_at = CASE WHEN $2 = 'completed' THEN CURRENT_TIMESTAMP ELSE completed_at END
+		WHERE id = $1 RETURNING ` + sprintColumns
+	var sprint model.Sprint
+	err := s.DB.Get(&sprint, query, id, status)
+	if uniqueViolation(err) {
+		return model.Sprint{}, fmt.Errorf("%w: board of sprint %d already has an active sprint", model.ErrConflict, id)
+	}
+	return sprint, notFound(err, "sprint", id)
+}
+
+// GetSprintCards возвращает всю историю состава спринта, кроме карточек
+// в корзине, в порядке записи.
+func (s *SprintStorage) GetSprintCards(sprintID int) ([]model.SprintCard, error) {
+	query := `SELECT ` + sprintCardColumns + ` FROM sprint_cards
+		WHERE sprint_id = $1 AND card_id IN (SELECT id FROM cards WHERE deleted_at IS NULL)
+		ORDER BY id`
+	cards := []model.SprintCard{}
+	err := s.DB.Select(&cards, query, sprintID)
+	return cards, err
+}
+
+// SetCardSprint закрывает текущую запись карточки и, если sprintID не
+// nil, открывает новую. Повторное добавление в тот же спринт ничего не
+// меняет.
+func (s *SprintStorage) SetCardSprint(cardID int, sprintID *int) error {
+	var current []int
+	if err := s.DB.Select(&current, `SELECT sprint_id FROM sprint_cards WHERE card_id = $1 AND removed_at IS NULL`, cardID); err != nil {
+		return err
+	}
+	if sprintID != nil && len(current) > 0 && current[0] == *sprintID {
+		return nil
+	}
+	if _, err := s.DB.Exec(`UPDATE sprint_cards SET removed_at = CURRENT_TIMESTAMP WHERE card_id = $1 AND removed_at IS NULL`, cardID); err != nil {
+		return err
+	}
+	if sprintID == nil {
+		return nil
+	}
+	var count int
+	if err := s.DB.Get(&count, `SELECT COUNT(*) FROM cards WHERE id = $1 AND deleted_at IS NULL`, cardID); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("card %d: %w", cardID, model.ErrNotFound)
+	}
+	res, err := s.DB.Exec(`INSERT INTO sprint_cards (sprint_id, card_id) SELECT id, $2 FROM sprints WHERE id = $1`, *sprintID, cardID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("sprint %d: %w", *sprintID, model.ErrNotFound)
+	}
+	return nil
+}
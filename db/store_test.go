@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/migrations"
+	"awesomeProject2/cmd/storage/storagetest"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+// С тегом postgres тесты сами поднимают встроенный Postgres (см.
+// embedded_test.go), так их запускает CI:
+//
+//	go test -tags postgres ./db/
+//
+// Можно указать и готовую базу, например из docker:
+//
+//	docker run --rm -e POSTGRES_PASSWORD=test -p 55432:5432 postgres:15
+//	TEST_POSTGRES_DSN="host=localhost port=55432 user=postgres password=test sslmode=disable" go test ./db/
+//
+// Таблицы очищаются перед каждой проверкой, поэтому не указывайте рабочую базу.
+func openTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	db, err := sqlx.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrations.NewMigrator(db, migrations.FS)
+	require.NoError(t, err)
+	_, err = migrator.Up()
+	require.NoError(t, err)
+	return db
+}
+
+func TestPostgres_Conformance(t *testing.T) {
+	db := openTestDB(t)
+	storagetest.Run(t, func(t *testing.T) storagetest.Store {
+		_, err := db.Exec(`TRUNCATE boards, lists, cards, labels, card_labels, card_assignees, checklists, checklist_items, comments, automation_rules, automation_runs, card_recurrences, notifications, notification_preferences, email_settings, email_digest_items, email_outbox, board_members, mentions, card_watchers, list_watchers, board_watchers, card_links, custom_fields, card_field_values, board_views, calendar_tokens, card_moves, board_swimlanes, board_lanes, card_lanes, sprints, sprint_cards RESTART IDENTITY CASCADE`)
+		require.NoError(t, err)
+		return NewStores(db)
+	})
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/service"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+// Querier — общее у *sqlx.DB и *sqlx.Tx, поэтому одни и те же хранилища
+// работают и с подключением, и внутри транзакции.
+type Querier interface {
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// Stores собирает все SQL-хранилища над одним подключением; так их
+// удобно передавать туда, где нужен полный набор (импорт, тесты).
+type Stores struct {
+	*BoardStorage
+	*ListStorage
+	*CardStorage
+	*LabelStorage
+	*AssigneeStorage
+	*ChecklistStorage
+	*CommentStorage
+	*AutomationStorage
+	*RecurrenceStorage
+	*NotificationStorage
+	*EmailStorage
+	*MemberStorage
+	*MentionStorage
+	*WatchStorage
+	*LinkStorage
+	*FieldStorage
+	*ViewStorage
+	*CalendarStorage
+	*MetricsStorage
+	*LaneStorage
+	*SprintStorage
+	db *sqlx.DB
+}
+
+var _ service.Transactor = Stores{}
+
+func NewStores(db *sqlx.DB) Stores {
+	stores := newStores(db)
+	stores.db = db
+	return stores
+}
+
+func newStores(q Querier) Stores {
+	return Stores{
+		BoardStorage:        NewBoardStorage(q),
+		ListStorage:         NewListStorage(q),
+		CardStorage:         NewCardStorage(q),
+		LabelStorage:        NewLabelStorage(q),
+		AssigneeStorage:     NewAssigneeStorage(q),
+		ChecklistStorage:    NewChecklistStorage(q),
+		CommentStorage:      NewCommentStorage(q),
+		AutomationStorage:   NewAutomationStorage(q),
+		RecurrenceStorage:   NewRecurrenceStorage(q),
+		NotificationStorage: NewNotificationStorage(q),
+		EmailStorage:        NewEmailStorage(q),
+		MemberStorage:       NewMemberStorage(q),
+		MentionStorage:      NewMentionStorage(q),
+		WatchStorage:        NewWatchStorage(q),
+		LinkStorage:         NewLinkStorage(q),
+		FieldStorage:        NewFieldStorage(q),
+		ViewStorage:         NewViewStorage(q),
+		CalendarStorage:     NewCalendarStorage(q),
+		MetricsStorage:      NewMetricsStorage(q),
+		LaneStorage:         NewLaneStorage(q),
+		SprintStorage:       NewSprintStorage(q),
+	}
+}
+
+// InTx открывает транзакцию и передаёт в fn хранилища, работающие в ней.
+func (s Stores) InTx(fn func(tx service.Tx) error) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(newStores(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
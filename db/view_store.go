@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+type ViewStorage struct {
+	DB Querier
+}
+
+func NewViewStorage(db Querier) *ViewStorage { return &ViewStorage{db} }
+
+const viewColumns = `id, board_id, owner, name, query, sort, sort_desc, group_by, shared, created_at, updated_at`
+
+// GetViews возвращает представления доски, которые видит username: свои
+// и общие.
+func (s *ViewStorage) GetViews(boardID int, username string) ([]model.View, error) {
+	views := []model.View{}
+	err := s.DB.Select(&views, `SELECT `+viewColumns+` FROM board_views
+		WHERE board_id = $1 AND (owner = $2 OR shared) ORDER BY id`, boardID, username)
+	return views, err
+}
+
+func (s *ViewStorage) GetView(id int) (model.View, error) {
+	var view model.View
+	err := s.DB.Get(&view, `SELECT `+viewColumns+` FROM board_views WHERE id = $1`, id)
+	return view, notFound(err, "view", id)
+}
+
+func (s *ViewStorage) CreateView(input model.ViewInput) (model.View, error) {
+	query := `INSERT INTO board_views (board_id, owner, name, query, sort, sort_desc, group_by, shared)
+		SELECT id, $2, $3, $4, $5, $6, $7, $8 FROM boards WHERE id = $1
+		RETURNING ` + viewColumns
+	var view model.View
+	err := s.DB.Get(&view, query, input.BoardID, input.Owner, input.Name, input.Query, input.Sort, input.Desc, input.Group, input.Shared)
+	return view, notFound(err, "board", input.BoardID)
+}
+
+// UpdateView меняет всё, кроме доски и владельца.
+func (s *ViewStorage) UpdateView(id int, input model.ViewInput) (model.View, error) {
+	query := `UPDATE board_views SET name = $2, query = $3, sort = $4, sort_desc = $5, group_by = $6, shared = $7,
+		updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 RETURNING ` + viewColumns
+	var view model.View
+	err := s.DB.Get(&view, query, id, input.Name, input.Query, input.Sort, input.Desc, input.Group, input.Shared)
+	return view, notFound(err, "view", id)
+}
+
+func (s *ViewStorage) DeleteView(id int) error {
+	res, err := s.DB.Exec(`DELETE FROM board_views WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("view %d: %w", id, model.ErrNotFound)
+	}
+	return nil
+}
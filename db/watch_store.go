@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+type WatchStorage struct {
+	DB Querier
+}
+
+func NewWatchStorage(db Querier) *WatchStorage { return &WatchStorage{db} }
+
+// watchTables описывает таблицу подписок и проверку цели для каждого вида.
+var watchTables = map[string]struct{ table, column, target string }{
+	model.WatchCard:  {"card_watchers", "card_id", `FROM cards WHERE id = $1 AND deleted_at IS NULL`},
+	model.WatchList:  {"list_watchers", "list_id", `FROM lists WHERE id = $1`},
+	model.WatchBoard: {"board_watchers", "board_id", `FROM boards WHERE id = $1`},
+}
+
+// Watch подписывает пользователя; повторная подписка возвращает
+// существующую запись.
+func (s *WatchStorage) Watch(username string, target model.WatchTarget) (model.Watch, error) {
+	t, ok := watchTables[target.Kind]
+	if !ok {
+		return model.Watch{}, fmt.Errorf("watch kind %q: %w", target.Kind, model.ErrInvalidInput)
+	}
+	query := `INSERT INTO ` + t.table + ` (` + t.column + `, username)
+		SELECT id, $2 ` + t.target + `
+		ON CONFLICT (` + t.column + `, username) DO UPDATE SET username = excluded.username
+		RETURNING username, ` + t.column + ` AS target_id, created_at`
+	watch := model.Watch{Kind: target.Kind}
+	err := s.DB.Get(&watch, query, target.ID, username)
+	return watch, notFound(err, target.Kind, target.ID)
+}
+
+func (s *WatchStorage) Unwatch(username string, target model.WatchTarget) error {
+	t, ok := watchTables[target.Kind]
+	if !ok {
+		return fmt.Errorf("watch kind %q: %w", target.Kind, model.ErrInvalidInput)
+	}
+	res, err := s.DB.Exec(`DELETE FROM `+t.table+` WHERE `+t.column+` = $1 AND username = $2`, target.ID, username)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("watch of %s %d by %s: %w", target.Kind, target.ID, username, model.ErrNotFound)
+	}
+	return nil
+}
+
+// GetWatching возвращает подписки пользователя по виду и идентификатору.
+func (s *WatchStorage) GetWatching(username string) ([]model.Watch, error) {
+	query := `SELECT username, 'board' AS kind, board_id AS target_id, created_at FROM board_watchers WHERE username = $1
+		UNION ALL
+		SELECT username, 'list', list_id, created_at FROM list_watchers WHERE username = $1
+		UNION ALL
+		SELECT username, 'card', card_id, created_at FROM card_watchers WHERE username = $1
+		ORDER BY kind, target_id`
+	var watches []model.Watch
+	err := s.DB.Select(&watches, query, username)
+	return watches, err
+}
+
+// GetCardWatchers возвращает всех, кто следит за карточкой напрямую или
+// через её список и доску.
+func (s *WatchStorage) GetCardWatchers(cardID int) ([]string, error) {
+	query := `SELECT username FROM card_watchers WHERE card_id = $1
+		UNION
+		SELECT w.username FROM list_watchers w JOIN cards c ON c.list_id = w.list_id WHERE c.id = $1
+		UNION
+		SELECT w.username FROM board_watchers w JOIN cards c ON c.board_id = w.board_id WHERE c.id = $1
+		ORDER BY username`
+	var watchers []string
+	err := s.DB.Select(&watchers, query, cardID)
+	return watchers, err
+}
@@ -1,16 +1,22 @@
 package dto
 
-import "awesomeProject2/cmd/model"
+import (
+	"awesomeProject2/cmd/model"
+	"math"
+	"time"
+)
 
 type BoardDTO struct {
-	ID    *int   `json:"id"`
-	Title string `json:"title"`
+	ID         *int   `json:"id"`
+	Title      string `json:"title"`
+	IsTemplate bool   `json:"is_template"`
 }
 
 func BoardToDTO(b model.Board) BoardDTO {
 	return BoardDTO{
-		ID:    &b.ID,
-		Title: b.Title,
+		ID:         &b.ID,
+		Title:      b.Title,
+		IsTemplate: b.IsTemplate,
 	}
 }
 
@@ -18,29 +24,63 @@ type ListDTO struct {
 	ID      *int   `json:"id"`
 	Title   string `json:"title"`
 	BoardID int    `json:"board_id"`
+	// WIPLimit — лимит карточек в списке, null — без лимита.
+	WIPLimit  *int `json:"wip_limit"`
+	WIPSoft   bool `json:"wip_soft"`
+	CardCount int  `json:"card_count"`
 }
 
 func ListToDTO(l model.List) ListDTO {
 	return ListDTO{
-		ID:      &l.ID,
-		Title:   l.Title,
-		BoardID: l.BoardID,
+		ID:        &l.ID,
+		Title:     l.Title,
+		BoardID:   l.BoardID,
+		WIPLimit:  l.WIPLimit,
+		WIPSoft:   l.WIPSoft,
+		CardCount: l.CardCount,
 	}
 }
 
+// WIPLimitDTO задаёт лимит списка; limit null снимает его.
+type WIPLimitDTO struct {
+	Limit *int `json:"limit"`
+	Soft  bool `json:"soft"`
+}
+
 type CreateBoardDTO struct {
 	Title string `json:"title"`
 }
+type CopyBoardDTO struct {
+	Title        string `json:"title"`
+	IncludeCards bool   `json:"include_cards"`
+	AsTemplate   bool   `json:"as_template"`
+}
+type BoardTemplateDTO struct {
+	IsTemplate bool `json:"is_template"`
+}
 type CreateListDTO struct {
-	Title   string `json:"title"`
-	BoardID int    `json:"board_id"`
+	Title    string `json:"title"`
+	BoardID  int    `json:"board_id"`
+	WIPLimit *int   `json:"wip_limit"`
+	WIPSoft  bool   `json:"wip_soft"`
 }
 type CardDTO struct {
-	ID          *int   `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	BoardID     int    `json:"board_id"`
-	ListID      int    `json:"list_id"`
+	ID          *int       `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	BoardID     int        `json:"board_id"`
+	ListID      int        `json:"list_id"`
+	Status      string     `json:"status,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	// Mentions — участники, упомянутые в описании.
+	Mentions []MentionDTO `json:"mentions,omitempty"`
+	// Fields — значения пользовательских полей доски.
+	Fields []CardFieldValueDTO `json:"fields,omitempty"`
+	// Warnings — предупреждения к изменению, например о превышенном
+	// мягком лимите списка.
+	Warnings []string `json:"warnings,omitempty"`
 }
 type UpdateCardDTO struct {
 	ID          int    `json:"id"`
@@ -56,6 +96,23 @@ type CreateCardDTO struct {
 	Description string `json:"description"`
 }
 
+// MoveCardDTO — тело POST /cards/{id}/move. lane_id меняет явную
+// дорожку карточки, 0 убирает её из дорожек.
+type MoveCardDTO struct {
+	ListID int  `json:"list_id"`
+	LaneID *int `json:"lane_id"`
+}
+
+type CopyCardDTO struct {
+	ListID int    `json:"list_id"`
+	Title  string `json:"title"`
+}
+
+// SetDueDTO задаёт срок карточки; null снимает его.
+type SetDueDTO struct {
+	DueAt *time.Time `json:"due_at"`
+}
+
 type DeleteCardDTO struct {
 	ListID int `json:"list_id"`
 	CardID int `json:"card_id"`
@@ -66,6 +123,819 @@ func CardToDTO(c model.Card) CardDTO {
 		ID:          &c.ID,
 		Title:       c.Title,
 		Description: c.Description,
+		BoardID:     c.BoardID,
 		ListID:      c.ListID,
+		Status:      c.Status,
+		DueAt:       c.DueAt,
+		ArchivedAt:  c.ArchivedAt,
+		DeletedAt:   c.DeletedAt,
+		Mentions:    MentionsToDTO(c.Mentions),
+		Fields:      CardFieldValuesToDTO(c.Fields),
+		Warnings:    c.Warnings,
+	}
+}
+
+type CardImportErrorDTO struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type CardImportResultDTO struct {
+	Created []CardDTO            `json:"created"`
+	Errors  []CardImportErrorDTO `json:"errors"`
+}
+
+const (
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best_effort"
+)
+
+type BulkCardsDTO struct {
+	// Mode — atomic (по умолчанию) или best_effort.
+	Mode       string                 `json:"mode"`
+	Operations []BulkCardOperationDTO `json:"operations"`
+}
+
+type BulkCardOperationDTO struct {
+	Op          string  `json:"op"`
+	CardID      int     `json:"card_id"`
+	ListID      int     `json:"list_id,omitempty"`
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	LabelID     int     `json:"label_id,omitempty"`
+	Username    string  `json:"username,omitempty"`
+}
+
+type BulkCardResultDTO struct {
+	Index  int      `json:"index"`
+	CardID int      `json:"card_id"`
+	Status string   `json:"status"`
+	Card   *CardDTO `json:"card,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+type BulkCardsResponseDTO struct {
+	Results []BulkCardResultDTO `json:"results"`
+	Error   string              `json:"error,omitempty"`
+}
+
+func BulkOperationFromDTO(op BulkCardOperationDTO) model.BulkCardOperation {
+	return model.BulkCardOperation{
+		Op:          op.Op,
+		CardID:      op.CardID,
+		ListID:      op.ListID,
+		Title:       op.Title,
+		Description: op.Description,
+		LabelID:     op.LabelID,
+		Username:    op.Username,
+	}
+}
+
+func BulkResultToDTO(index int, r model.BulkCardResult) BulkCardResultDTO {
+	result := BulkCardResultDTO{Index: index, CardID: r.CardID, Status: r.Status}
+	if r.Card != nil {
+		card := CardToDTO(*r.Card)
+		result.Card = &card
+	}
+	if r.Err != nil {
+		result.Error = r.Err.Error()
+	}
+	return result
+}
+
+type AutomationRuleDTO struct {
+	ID         int                         `json:"id"`
+	BoardID    int                         `json:"board_id"`
+	Name       string                      `json:"name"`
+	Enabled    bool                        `json:"enabled"`
+	Trigger    model.AutomationTrigger     `json:"trigger"`
+	Conditions []model.AutomationCondition `json:"conditions"`
+	Actions    []model.AutomationAction    `json:"actions"`
+	CreatedAt  time.Time                   `json:"created_at"`
+}
+
+func AutomationRuleToDTO(r model.AutomationRule) AutomationRuleDTO {
+	dto := AutomationRuleDTO{
+		ID:         r.ID,
+		BoardID:    r.BoardID,
+		Name:       r.Name,
+		Enabled:    r.Enabled,
+		Trigger:    r.Trigger,
+		Conditions: r.Conditions,
+		Actions:    r.Actions,
+		CreatedAt:  r.CreatedAt,
+	}
+	if dto.Conditions == nil {
+		dto.Conditions = []model.AutomationCondition{}
+	}
+	if dto.Actions == nil {
+		dto.Actions = []model.AutomationAction{}
+	}
+	return dto
+}
+
+// SaveAutomationRuleDTO — тело создания и замены правила. Без enabled
+// правило включено.
+type SaveAutomationRuleDTO struct {
+	Name       string                      `json:"name"`
+	Enabled    *bool                       `json:"enabled"`
+	Trigger    model.AutomationTrigger     `json:"trigger"`
+	Conditions []model.AutomationCondition `json:"conditions"`
+	Actions    []model.AutomationAction    `json:"actions"`
+}
+
+func AutomationRuleFromDTO(boardID int, in SaveAutomationRuleDTO) model.AutomationRuleInput {
+	enabled := true
+	if in.Enabled != nil {
+		enabled = *in.Enabled
+	}
+	return model.AutomationRuleInput{
+		BoardID:    boardID,
+		Name:       in.Name,
+		Enabled:    enabled,
+		Trigger:    in.Trigger,
+		Conditions: in.Conditions,
+		Actions:    in.Actions,
+	}
+}
+
+type AutomationRunDTO struct {
+	ID        int       `json:"id"`
+	RuleID    int       `json:"rule_id"`
+	CardID    int       `json:"card_id"`
+	Event     string    `json:"event"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func AutomationRunToDTO(r model.AutomationRun) AutomationRunDTO {
+	return AutomationRunDTO{
+		ID:        r.ID,
+		RuleID:    r.RuleID,
+		CardID:    r.CardID,
+		Event:     r.Event,
+		Status:    r.Status,
+		Error:     r.Error,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+type CardRecurrenceDTO struct {
+	CardID    int        `json:"card_id"`
+	ListID    int        `json:"list_id"`
+	Frequency string     `json:"frequency"`
+	Interval  int        `json:"interval,omitempty"`
+	Cron      string     `json:"cron,omitempty"`
+	StartsAt  time.Time  `json:"starts_at"`
+	NextRunAt time.Time  `json:"next_run_at"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+}
+
+func CardRecurrenceToDTO(r model.CardRecurrence) CardRecurrenceDTO {
+	return CardRecurrenceDTO{
+		CardID:    r.CardID,
+		ListID:    r.ListID,
+		Frequency: r.Frequency,
+		Interval:  r.Interval,
+		Cron:      r.Cron,
+		StartsAt:  r.StartsAt,
+		NextRunAt: r.NextRunAt,
+		LastRunAt: r.LastRunAt,
+	}
+}
+
+// SaveRecurrenceDTO — тело PUT /cards/{id}/recurrence. Без list_id
+// карточки создаются в списке образца, без starts_at отсчёт идёт от
+// момента запроса.
+type SaveRecurrenceDTO struct {
+	ListID    int        `json:"list_id"`
+	Frequency string     `json:"frequency"`
+	Interval  int        `json:"interval"`
+	Cron      string     `json:"cron"`
+	StartsAt  *time.Time `json:"starts_at"`
+}
+
+func CardRecurrenceFromDTO(in SaveRecurrenceDTO) model.CardRecurrenceInput {
+	return model.CardRecurrenceInput{
+		ListID:    in.ListID,
+		Frequency: in.Frequency,
+		Interval:  in.Interval,
+		Cron:      in.Cron,
+		StartsAt:  in.StartsAt,
+	}
+}
+
+type NotificationDTO struct {
+	ID        int        `json:"id"`
+	Kind      string     `json:"kind"`
+	Event     string     `json:"event"`
+	BoardID   int        `json:"board_id"`
+	CardID    int        `json:"card_id"`
+	CardTitle string     `json:"card_title"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NotificationToDTO(n model.Notification) NotificationDTO {
+	return NotificationDTO{
+		ID:        n.ID,
+		Kind:      n.Kind,
+		Event:     n.Event,
+		BoardID:   n.BoardID,
+		CardID:    n.CardID,
+		CardTitle: n.CardTitle,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+type NotificationInboxDTO struct {
+	Unread        int               `json:"unread"`
+	Notifications []NotificationDTO `json:"notifications"`
+}
+
+func NotificationInboxToDTO(inbox model.NotificationInbox) NotificationInboxDTO {
+	dto := NotificationInboxDTO{Unread: inbox.Unread, Notifications: []NotificationDTO{}}
+	for _, n := range inbox.Notifications {
+		dto.Notifications = append(dto.Notifications, NotificationToDTO(n))
+	}
+	return dto
+}
+
+type MarkAllReadDTO struct {
+	Marked int `json:"marked"`
+}
+
+type NotificationPreferenceDTO struct {
+	Kind  string `json:"kind"`
+	InApp bool   `json:"in_app"`
+	Email bool   `json:"email"`
+}
+
+func NotificationPreferencesToDTO(prefs []model.NotificationPreference) []NotificationPreferenceDTO {
+	dtos := []NotificationPreferenceDTO{}
+	for _, p := range prefs {
+		dtos = append(dtos, NotificationPreferenceDTO{Kind: p.Kind, InApp: p.InApp, Email: p.Email})
+	}
+	return dtos
+}
+
+// UpdateNotificationPreferenceDTO — изменение настройки; пропущенный канал
+// не меняется.
+type UpdateNotificationPreferenceDTO struct {
+	Kind  string `json:"kind"`
+	InApp *bool  `json:"in_app"`
+	Email *bool  `json:"email"`
+}
+
+func NotificationPreferenceUpdatesFromDTO(dtos []UpdateNotificationPreferenceDTO) []model.NotificationPreferenceUpdate {
+	updates := make([]model.NotificationPreferenceUpdate, 0, len(dtos))
+	for _, d := range dtos {
+		updates = append(updates, model.NotificationPreferenceUpdate{Kind: d.Kind, InApp: d.InApp, Email: d.Email})
+	}
+	return updates
+}
+
+type EmailSettingsDTO struct {
+	Email        string     `json:"email"`
+	Digest       bool       `json:"digest"`
+	LastDigestAt *time.Time `json:"last_digest_at,omitempty"`
+}
+
+func EmailSettingsToDTO(s model.EmailSettings) EmailSettingsDTO {
+	return EmailSettingsDTO{Email: s.Address, Digest: s.Digest, LastDigestAt: s.LastDigestAt}
+}
+
+type SaveEmailSettingsDTO struct {
+	Email  string `json:"email"`
+	Digest bool   `json:"digest"`
+}
+
+func EmailSettingsFromDTO(username string, d SaveEmailSettingsDTO) model.EmailSettings {
+	return model.EmailSettings{Username: username, Address: d.Email, Digest: d.Digest}
+}
+
+// MentionDTO — упомянутый участник доски; по нему клиент рисует чип
+// пользователя вместо @username.
+type MentionDTO struct {
+	Username  string `json:"username"`
+	Author    string `json:"author,omitempty"`
+	CommentID *int   `json:"comment_id,omitempty"`
+}
+
+func MentionsToDTO(mentions []model.Mention) []MentionDTO {
+	if len(mentions) == 0 {
+		return nil
+	}
+	dtos := make([]MentionDTO, 0, len(mentions))
+	for _, m := range mentions {
+		dtos = append(dtos, MentionDTO{Username: m.Username, Author: m.Author, CommentID: m.CommentID})
+	}
+	return dtos
+}
+
+type CommentDTO struct {
+	ID        int          `json:"id"`
+	CardID    int          `json:"card_id"`
+	Author    string       `json:"author"`
+	Text      string       `json:"text"`
+	CreatedAt time.Time    `json:"created_at"`
+	Mentions  []MentionDTO `json:"mentions"`
+}
+
+func CommentToDTO(c model.Comment) CommentDTO {
+	mentions := MentionsToDTO(c.Mentions)
+	if mentions == nil {
+		mentions = []MentionDTO{}
+	}
+	return CommentDTO{
+		ID:        c.ID,
+		CardID:    c.CardID,
+		Author:    c.Author,
+		Text:      c.Text,
+		CreatedAt: c.CreatedAt,
+		Mentions:  mentions,
+	}
+}
+
+type CreateCommentDTO struct {
+	Text string `json:"text"`
+}
+
+type BoardMemberDTO struct {
+	Username string    `json:"username"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+func BoardMembersToDTO(members []model.BoardMember) []BoardMemberDTO {
+	dtos := []BoardMemberDTO{}
+	for _, m := range members {
+		dtos = append(dtos, BoardMemberDTO{Username: m.Username, AddedAt: m.AddedAt})
+	}
+	return dtos
+}
+
+type WatchDTO struct {
+	Kind      string    `json:"kind"`
+	TargetID  int       `json:"target_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func WatchesToDTO(watches []model.Watch) []WatchDTO {
+	dtos := []WatchDTO{}
+	for _, w := range watches {
+		dtos = append(dtos, WatchDTO{Kind: w.Kind, TargetID: w.TargetID, CreatedAt: w.CreatedAt})
+	}
+	return dtos
+}
+
+// CardLinkDTO — связь с точки зрения карточки из запроса: Type уже
+// развёрнут в её сторону (blocked_by, child_of и т. п.).
+type CardLinkDTO struct {
+	ID        int       `json:"id"`
+	Type      string    `json:"type"`
+	CardID    int       `json:"card_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateCardLinkDTO struct {
+	Type   string `json:"type"`
+	CardID int    `json:"card_id"`
+}
+
+func CardLinkToDTO(cardID int, l model.CardLink) CardLinkDTO {
+	linkType, other := l.From(cardID)
+	return CardLinkDTO{ID: l.ID, Type: linkType, CardID: other, CreatedAt: l.CreatedAt}
+}
+
+func CardLinksToDTO(cardID int, links []model.CardLink) []CardLinkDTO {
+	dtos := []CardLinkDTO{}
+	for _, l := range links {
+		dtos = append(dtos, CardLinkToDTO(cardID, l))
+	}
+	return dtos
+}
+
+type CustomFieldDTO struct {
+	ID        int       `json:"id"`
+	BoardID   int       `json:"board_id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateCustomFieldDTO struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
+type UpdateCustomFieldDTO struct {
+	Name    string   `json:"name"`
+	Options []string `json:"options"`
+}
+
+func CustomFieldToDTO(f model.CustomField) CustomFieldDTO {
+	return CustomFieldDTO{ID: f.ID, BoardID: f.BoardID, Name: f.Name, Type: f.Type, Options: f.Options, CreatedAt: f.CreatedAt}
+}
+
+func CustomFieldsToDTO(fields []model.CustomField) []CustomFieldDTO {
+	dtos := []CustomFieldDTO{}
+	for _, f := range fields {
+		dtos = append(dtos, CustomFieldToDTO(f))
+	}
+	return dtos
+}
+
+// CardFieldValueDTO — значение поля: строка, число, дата YYYY-MM-DD или
+// bool; null — значение не задано.
+type CardFieldValueDTO struct {
+	FieldID int `json:"field_id"`
+	Value   any `json:"value"`
+}
+
+// SetCardFieldValueDTO — тело PUT /cards/{id}/fields/{fieldID}; null
+// очищает значение.
+type SetCardFieldValueDTO struct {
+	Value any `json:"value"`
+}
+
+func CardFieldValuesToDTO(values []model.CardFieldValue) []CardFieldValueDTO {
+	var dtos []CardFieldValueDTO
+	for _, v := range values {
+		dtos = append(dtos, CardFieldValueDTO{FieldID: v.FieldID, Value: v.Value()})
+	}
+	return dtos
+}
+
+type ViewDTO struct {
+	ID        int       `json:"id"`
+	BoardID   int       `json:"board_id"`
+	Owner     string    `json:"owner"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	Sort      string    `json:"sort"`
+	Order     string    `json:"order"`
+	GroupBy   string    `json:"group_by"`
+	Shared    bool      `json:"shared"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ViewInputDTO — тело POST /boards/{id}/views и PUT /views/{id}. Order —
+// asc (по умолчанию) или desc.
+type ViewInputDTO struct {
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	Sort    string `json:"sort"`
+	Order   string `json:"order"`
+	GroupBy string `json:"group_by"`
+	Shared  bool   `json:"shared"`
+}
+
+func ViewToDTO(v model.View) ViewDTO {
+	order := "asc"
+	if v.Desc {
+		order = "desc"
+	}
+	return ViewDTO{
+		ID:        v.ID,
+		BoardID:   v.BoardID,
+		Owner:     v.Owner,
+		Name:      v.Name,
+		Query:     v.Query,
+		Sort:      v.Sort,
+		Order:     order,
+		GroupBy:   v.Group,
+		Shared:    v.Shared,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func ViewsToDTO(views []model.View) []ViewDTO {
+	dtos := []ViewDTO{}
+	for _, v := range views {
+		dtos = append(dtos, ViewToDTO(v))
+	}
+	return dtos
+}
+
+type CardGroupDTO struct {
+	Key   string    `json:"key"`
+	Cards []CardDTO `json:"cards"`
+}
+
+// ViewCardsDTO — ответ GET /views/{id}/cards.
+type ViewCardsDTO struct {
+	View   ViewDTO        `json:"view"`
+	Groups []CardGroupDTO `json:"groups"`
+}
+
+func CardsToDTO(cards []model.Card) []CardDTO {
+	dtos := []CardDTO{}
+	for _, c := range cards {
+		dtos = append(dtos, CardToDTO(c))
+	}
+	return dtos
+}
+
+func ViewCardsToDTO(v model.View, groups []model.CardGroup) ViewCardsDTO {
+	result := ViewCardsDTO{View: ViewToDTO(v), Groups: []CardGroupDTO{}}
+	for _, g := range groups {
+		result.Groups = append(result.Groups, CardGroupDTO{Key: g.Key, Cards: CardsToDTO(g.Cards)})
+	}
+	return result
+}
+
+// CalendarDayDTO — день календаря доски; Date в формате YYYY-MM-DD.
+type CalendarDayDTO struct {
+	Date  string    `json:"date"`
+	Cards []CardDTO `json:"cards"`
+}
+
+func CalendarToDTO(days []model.CalendarDay) []CalendarDayDTO {
+	dtos := []CalendarDayDTO{}
+	for _, d := range days {
+		dtos = append(dtos, CalendarDayDTO{Date: d.Date.Format(model.FieldDateLayout), Cards: CardsToDTO(d.Cards)})
+	}
+	return dtos
+}
+
+// CalendarTokenDTO — ответ на выпуск токена ленты. Токен показывается
+// только здесь; FeedPath — путь ленты для подписки в календаре.
+type CalendarTokenDTO struct {
+	Token     string    `json:"token"`
+	FeedPath  string    `json:"feed_path"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BoardMetricsDTO — метрики потока доски. Длительности в часах, даты в
+// формате YYYY-MM-DD.
+type BoardMetricsDTO struct {
+	From           string              `json:"from"`
+	To             string              `json:"to"`
+	StartedLists   []int               `json:"started_lists"`
+	DoneLists      []int               `json:"done_lists"`
+	LeadTime       DurationStatsDTO    `json:"lead_time"`
+	CycleTime      DurationStatsDTO    `json:"cycle_time"`
+	Throughput     []WeekThroughputDTO `json:"throughput"`
+	CumulativeFlow []FlowDayDTO        `json:"cumulative_flow"`
+}
+
+type DurationStatsDTO struct {
+	Count        int     `json:"count"`
+	AverageHours float64 `json:"average_hours"`
+	MedianHours  float64 `json:"median_hours"`
+	P85Hours     float64 `json:"p85_hours"`
+}
+
+type WeekThroughputDTO struct {
+	WeekStart string `json:"week_start"`
+	Cards     int    `json:"cards"`
+}
+
+type FlowDayDTO struct {
+	Date  string         `json:"date"`
+	Lists []ListCountDTO `json:"lists"`
+}
+
+type ListCountDTO struct {
+	ListID int `json:"list_id"`
+	Cards  int `json:"cards"`
+}
+
+func MetricsToDTO(m model.BoardMetrics) BoardMetricsDTO {
+	dto := BoardMetricsDTO{
+		From:           m.From.Format(model.FieldDateLayout),
+		To:             m.To.Format(model.FieldDateLayout),
+		StartedLists:   m.StartedLists,
+		DoneLists:      m.DoneLists,
+		LeadTime:       durationStatsToDTO(m.LeadTime),
+		CycleTime:      durationStatsToDTO(m.CycleTime),
+		Throughput:     []WeekThroughputDTO{},
+		CumulativeFlow: []FlowDayDTO{},
+	}
+	for _, w := range m.Throughput {
+		dto.Throughput = append(dto.Throughput, WeekThroughputDTO{WeekStart: w.WeekStart.Format(model.FieldDateLayout), Cards: w.Cards})
+	}
+	for _, d := range m.Flow {
+		day := FlowDayDTO{Date: d.Date.Format(model.FieldDateLayout), Lists: []ListCountDTO{}}
+		for _, l := range d.Lists {
+			day.Lists = append(day.Lists, ListCountDTO{ListID: l.ListID, Cards: l.Cards})
+		}
+		dto.CumulativeFlow = append(dto.CumulativeFlow, day)
+	}
+	return dto
+}
+
+func durationStatsToDTO(s model.DurationStats) DurationStatsDTO {
+	return DurationStatsDTO{
+		Count:        s.Count,
+		AverageHours: hours(s.Average),
+		MedianHours:  hours(s.Median),
+		P85Hours:     hours(s.P85),
+	}
+}
+
+// hours переводит длительность в часы с точностью до сотых.
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+// SwimlanesDTO — режим дорожек доски: пустой, assignee, label, field
+// (с field_id) или explicit.
+type SwimlanesDTO struct {
+	Mode    string `json:"mode"`
+	FieldID *int   `json:"field_id"`
+}
+
+type LaneDTO struct {
+	ID        int       `json:"id"`
+	BoardID   int       `json:"board_id"`
+	Title     string    `json:"title"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LaneInputDTO — тело POST /boards/{id}/lanes и PUT /lanes/{id}.
+type LaneInputDTO struct {
+	Title string `json:"title"`
+}
+
+// LaneOrderDTO — все дорожки доски в новом порядке.
+type LaneOrderDTO struct {
+	LaneIDs []int `json:"lane_ids"`
+}
+
+// CardLaneDTO — тело PUT /cards/{id}/lane; null или 0 убирает карточку
+// из дорожек.
+type CardLaneDTO struct {
+	LaneID *int `json:"lane_id"`
+}
+
+func SwimlanesToDTO(s model.Swimlanes) SwimlanesDTO {
+	return SwimlanesDTO{Mode: s.Mode, FieldID: s.FieldID}
+}
+
+func LaneToDTO(l model.Lane) LaneDTO {
+	return LaneDTO{ID: l.ID, BoardID: l.BoardID, Title: l.Title, Position: l.Position, CreatedAt: l.CreatedAt}
+}
+
+func LanesToDTO(lanes []model.Lane) []LaneDTO {
+	dtos := []LaneDTO{}
+	for _, l := range lanes {
+		dtos = append(dtos, LaneToDTO(l))
+	}
+	return dtos
+}
+
+type LaneCellDTO struct {
+	ListID int       `json:"list_id"`
+	Cards  []CardDTO `json:"cards"`
+}
+
+// BoardLaneDTO — строка матрицы доски. У явной дорожки задан lane_id, у
+// остальных key; у строки карточек без дорожки оба пустые.
+type BoardLaneDTO struct {
+	Key    string        `json:"key"`
+	LaneID *int          `json:"lane_id"`
+	Title  string        `json:"title"`
+	Cells  []LaneCellDTO `json:"cells"`
+}
+
+// BoardLayoutDTO — ответ GET /boards/{id}: доска, её списки и карточки
+// в виде матрицы дорожек и списков.
+type BoardLayoutDTO struct {
+	BoardDTO
+	Swimlanes SwimlanesDTO   `json:"swimlanes"`
+	Lists     []ListDTO      `json:"lists"`
+	Lanes     []BoardLaneDTO `json:"lanes"`
+}
+
+func BoardLayoutToDTO(layout model.BoardLayout) BoardLayoutDTO {
+	dto := BoardLayoutDTO{
+		BoardDTO:  BoardToDTO(layout.Board),
+		Swimlanes: SwimlanesToDTO(layout.Swimlanes),
+		Lists:     []ListDTO{},
+		Lanes:     []BoardLaneDTO{},
+	}
+	for _, l := range layout.Lists {
+		dto.Lists = append(dto.Lists, ListToDTO(l))
+	}
+	for _, l := range layout.Lanes {
+		lane := BoardLaneDTO{Key: l.Key, LaneID: l.LaneID, Title: l.Title, Cells: []LaneCellDTO{}}
+		for _, c := range l.Cells {
+			lane.Cells = append(lane.Cells, LaneCellDTO{ListID: c.ListID, Cards: CardsToDTO(c.Cards)})
+		}
+		dto.Lanes = append(dto.Lanes, lane)
+	}
+	return dto
+}
+
+// SprintDTO — спринт доски; даты начала и конца в формате YYYY-MM-DD,
+// конец входит в спринт.
+type SprintDTO struct {
+	ID          int        `json:"id"`
+	BoardID     int        `json:"board_id"`
+	Name        string     `json:"name"`
+	Goal        string     `json:"goal"`
+	StartDate   string     `json:"start_date"`
+	EndDate     string     `json:"end_date"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// SprintInputDTO — тело POST /boards/{id}/sprints и PUT /sprints/{id}.
+type SprintInputDTO struct {
+	Name      string `json:"name"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// CompleteSprintDTO — необязательное тело POST /sprints/{id}/complete:
+// спринт, в который уйдут незавершённые карточки.
+type CompleteSprintDTO struct {
+	NextSprintID *int `json:"next_sprint_id"`
+}
+
+// CardSprintDTO — тело PUT /cards/{id}/sprint; null или 0 убирает
+// карточку из спринтов.
+type CardSprintDTO struct {
+	SprintID *int `json:"sprint_id"`
+}
+
+type SprintCompletionDTO struct {
+	Sprint     SprintDTO  `json:"sprint"`
+	Done       []int      `json:"done"`
+	RolledOver []int      `json:"rolled_over"`
+	Next       *SprintDTO `json:"next_sprint"`
+}
+
+// SprintBurndownDTO — диаграмма сгорания спринта по дням.
+type SprintBurndownDTO struct {
+	Sprint    SprintDTO        `json:"sprint"`
+	DoneLists []int            `json:"done_lists"`
+	Days      []BurndownDayDTO `json:"days"`
+}
+
+type BurndownDayDTO struct {
+	Date      string  `json:"date"`
+	Scope     int     `json:"scope"`
+	Done      int     `json:"done"`
+	Remaining int     `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+func SprintToDTO(s model.Sprint) SprintDTO {
+	return SprintDTO{
+		ID:          s.ID,
+		BoardID:     s.BoardID,
+		Name:        s.Name,
+		Goal:        s.Goal,
+		StartDate:   s.StartDate.UTC().Format(model.FieldDateLayout),
+		EndDate:     s.EndDate.UTC().Format(model.FieldDateLayout),
+		Status:      s.Status,
+		StartedAt:   s.StartedAt,
+		CompletedAt: s.CompletedAt,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+func SprintsToDTO(sprints []model.Sprint) []SprintDTO {
+	dtos := []SprintDTO{}
+	for _, s := range sprints {
+		dtos = append(dtos, SprintToDTO(s))
+	}
+	return dtos
+}
+
+func SprintCompletionToDTO(c model.SprintCompletion) SprintCompletionDTO {
+	dto := SprintCompletionDTO{Sprint: SprintToDTO(c.Sprint), Done: c.Done, RolledOver: c.RolledOver}
+	if c.Next != nil {
+		next := SprintToDTO(*c.Next)
+		dto.Next = &next
+	}
+	return dto
+}
+
+func BurndownToDTO(b model.SprintBurndown) SprintBurndownDTO {
+	dto := SprintBurndownDTO{Sprint: SprintToDTO(b.Sprint), DoneLists: b.DoneLists, Days: []BurndownDayDTO{}}
+	for _, d := range b.Days {
+		dto.Days = append(dto.Days, BurndownDayDTO{
+			Date:      d.Date.Format(model.FieldDateLayout),
+			Scope:     d.Scope,
+			Done:      d.Done,
+			Remaining: d.Remaining,
+			Ideal:     math.Round(d.Ideal*100) / 100,
+		})
 	}
+	return dto
 }
@@ -0,0 +1,24 @@
+package email
+
+import (
+	"awesomeProject2/cmd/model"
+	"time"
+)
+
+type Storage interface {
+	GetEmailSettings(username string) (model.EmailSettings, error)
+	GetDigestRecipients(before time.Time) ([]model.EmailSettings, error)
+	SetLastDigestAt(username string, at time.Time) error
+	AddDigestItem(item model.DigestItem) error
+	GetDigestItems(username string) ([]model.DigestItem, error)
+	DeleteDigestItems(username string, lastID int) error
+	EnqueueEmail(e model.Email) (model.Email, error)
+	GetPendingEmails(now time.Time, limit int) ([]model.Email, error)
+	MarkEmailSent(id int, at time.Time) error
+	MarkEmailFailed(id int, reason string, nextAttemptAt *time.Time) error
+}
+
+// Sender доставляет одно письмо почтовому серверу.
+type Sender interface {
+	Send(e model.Email) error
+}
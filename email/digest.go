@@ -0,0 +1,82 @@
+package email
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// Digest раз в Period собирает отложенные уведомления пользователя в одно
+// письмо и ставит его в очередь. Если сервер упадёт между постановкой
+// письма и очисткой пунктов, они попадут и в следующую сводку.
+type Digest struct {
+	Storage  Storage
+	Period   time.Duration
+	Interval time.Duration
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+func NewDigest(storage Storage, period, interval time.Duration, logger *zap.Logger) *Digest {
+	return &Digest{
+		Storage:  storage,
+		Period:   period,
+		Interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// RunOnce ставит в очередь сводки, время которых наступило, и возвращает
+// их число. Пользователь без новых уведомлений сводку не получает, но
+// отсчёт периода для него начинается заново.
+func (d *Digest) RunOnce() (int, error) {
+	now := d.now().UTC()
+	recipients, err := d.Storage.GetDigestRecipients(now.Add(-d.Period))
+	if err != nil {
+		return 0, err
+	}
+	queued := 0
+	for _, r := range recipients {
+		items, err := d.Storage.GetDigestItems(r.Username)
+		if err != nil {
+			return queued, err
+		}
+		if len(items) > 0 && r.Address != "" {
+			e, err := renderDigest(r.Username, items)
+			if err != nil {
+				return queued, err
+			}
+			e.Recipient = r.Address
+			e.NextAttemptAt = now
+			if _, err := d.Storage.EnqueueEmail(e); err != nil {
+				return queued, err
+			}
+			if err := d.Storage.DeleteDigestItems(r.Username, items[len(items)-1].ID); err != nil {
+				return queued, err
+			}
+			d.logger.Info("Сводка уведомлений поставлена в очередь", zap.String("username", r.Username), zap.Int("items", len(items)))
+			queued++
+		}
+		if err := d.Storage.SetLastDigestAt(r.Username, now); err != nil {
+			return queued, err
+		}
+	}
+	return queued, nil
+}
+
+// Run проверяет сводки сразу и затем каждые Interval, пока не отменён ctx.
+func (d *Digest) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.RunOnce(); err != nil {
+			d.logger.Error("Ошибка подготовки сводок уведомлений", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
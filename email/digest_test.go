@@ -0,0 +1,57 @@
+package email
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestDigest(t *testing.T) {
+	store := storage.NewStorage()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	_, err := store.SaveEmailSettings(model.EmailSettings{Username: "anna", Address: "anna@example.com", Digest: true})
+	require.NoError(t, err)
+	_, err = store.SaveEmailSettings(model.EmailSettings{Username: "boris", Address: "boris@example.com", Digest: true})
+	require.NoError(t, err)
+	mailer := NewMailer(store)
+	digest := NewDigest(store, 24*time.Hour, time.Hour, zap.NewNop())
+	digest.now = func() time.Time { return now }
+	pending := func() []model.Email {
+		t.Helper()
+		emails, err := store.GetPendingEmails(now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		return emails
+	}
+
+	require.NoError(t, mailer.Deliver(model.Notification{Username: "anna", Kind: model.NotificationAssigned, CardID: 1, CardTitle: "Релиз"}))
+	require.NoError(t, mailer.Deliver(model.Notification{Username: "anna", Kind: model.NotificationOverdue, CardID: 2, CardTitle: "Отчёт"}))
+	require.Empty(t, pending(), "digest subscribers get no immediate emails")
+
+	queued, err := digest.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, queued, "boris has nothing to report")
+	emails := pending()
+	require.Len(t, emails, 1)
+	require.Equal(t, "anna@example.com", emails[0].Recipient)
+	require.Equal(t, "Сводка уведомлений: 2", emails[0].Subject)
+	require.Contains(t, emails[0].TextBody, "- Вас назначили: «Релиз»")
+	require.Contains(t, emails[0].TextBody, "- Срок истёк: «Отчёт»")
+	require.Contains(t, emails[0].HTMLBody, "<li>Вас назначили: <b>«Релиз»</b>")
+
+	// Новое уведомление ждёт следующей сводки через сутки.
+	require.NoError(t, mailer.Deliver(model.Notification{Username: "boris", Kind: model.NotificationMentioned, CardID: 3, CardTitle: "План"}))
+	now = now.Add(12 * time.Hour)
+	queued, err = digest.RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, queued)
+	now = now.Add(12 * time.Hour)
+	queued, err = digest.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, queued)
+	emails = pending()
+	require.Len(t, emails, 2)
+	require.Equal(t, "boris@example.com", emails[1].Recipient)
+}
@@ -0,0 +1,50 @@
+package email
+
+import (
+	"awesomeProject2/cmd/model"
+	"errors"
+	"time"
+)
+
+// Mailer ставит уведомления в очередь писем или, если пользователь
+// выбрал сводку, откладывает их до неё. Пользователи без адреса писем
+// не получают.
+type Mailer struct {
+	Storage Storage
+	now     func() time.Time
+}
+
+func NewMailer(storage Storage) *Mailer {
+	return &Mailer{Storage: storage, now: time.Now}
+}
+
+func (m *Mailer) Deliver(n model.Notification) error {
+	settings, err := m.Storage.GetEmailSettings(n.Username)
+	if errors.Is(err, model.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if settings.Address == "" {
+		return nil
+	}
+	if settings.Digest {
+		return m.Storage.AddDigestItem(model.DigestItem{
+			Username:  n.Username,
+			Kind:      n.Kind,
+			Event:     n.Event,
+			BoardID:   n.BoardID,
+			CardID:    n.CardID,
+			CardTitle: n.CardTitle,
+		})
+	}
+	e, err := renderNotification(n)
+	if err != nil {
+		return err
+	}
+	e.Recipient = settings.Address
+	e.NextAttemptAt = m.now().UTC()
+	_, err = m.Storage.EnqueueEmail(e)
+	return err
+}
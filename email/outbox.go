@@ -0,0 +1,87 @@
+package email
+
+import (
+	"awesomeProject2/cmd/model"
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+const outboxBatch = 50
+
+// Outbox отправляет письма из очереди. Очередь хранится в базе, поэтому
+// переживает перезапуск; неудачная попытка повторяется через растущий
+// интервал (1, 4, 9… минут), после MaxAttempts письмо помечается
+// неотправленным.
+type Outbox struct {
+	Storage     Storage
+	Sender      Sender
+	Interval    time.Duration
+	MaxAttempts int
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+func NewOutbox(storage Storage, sender Sender, interval time.Duration, maxAttempts int, logger *zap.Logger) *Outbox {
+	return &Outbox{
+		Storage:     storage,
+		Sender:      sender,
+		Interval:    interval,
+		MaxAttempts: maxAttempts,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// RunOnce отправляет письма, чья попытка наступила, и возвращает число
+// отправленных.
+func (o *Outbox) RunOnce() (int, error) {
+	now := o.now().UTC()
+	emails, err := o.Storage.GetPendingEmails(now, outboxBatch)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for _, e := range emails {
+		log := o.logger.With(zap.Int("emailID", e.ID), zap.String("recipient", e.Recipient))
+		if err := o.Sender.Send(e); err != nil {
+			if err := o.Storage.MarkEmailFailed(e.ID, err.Error(), o.retryAt(e, now)); err != nil {
+				return sent, err
+			}
+			log.Warn("Не удалось отправить письмо", zap.Error(err), zap.Int("attempt", e.Attempts+1))
+			continue
+		}
+		if err := o.Storage.MarkEmailSent(e.ID, now); err != nil {
+			return sent, err
+		}
+		log.Info("Письмо отправлено")
+		sent++
+	}
+	return sent, nil
+}
+
+// retryAt — время следующей попытки или nil, если попытки кончились.
+func (o *Outbox) retryAt(e model.Email, now time.Time) *time.Time {
+	attempt := e.Attempts + 1
+	if attempt >= o.MaxAttempts {
+		return nil
+	}
+	next := now.Add(time.Duration(attempt*attempt) * time.Minute)
+	return &next
+}
+
+// Run отправляет очередь сразу и затем каждые Interval, пока не отменён ctx.
+func (o *Outbox) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := o.RunOnce(); err != nil {
+			o.logger.Error("Ошибка отправки очереди писем", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package email
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailerAndOutbox(t *testing.T) {
+	store := storage.NewStorage()
+	server := newFakeSMTP(t)
+	host, port := server.hostPort(t)
+	sender := NewSMTPSender(host, port, "", "", "trello@example.com")
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	newOutbox := func() *Outbox {
+		o := NewOutbox(store, sender, time.Minute, 3, zap.NewNop())
+		o.now = func() time.Time { return now }
+		return o
+	}
+
+	_, err := store.SaveEmailSettings(model.EmailSettings{Username: "anna", Address: "anna@example.com"})
+	require.NoError(t, err)
+	mailer := NewMailer(store)
+	mailer.now = func() time.Time { return now }
+	n := model.Notification{Username: "anna", Kind: model.NotificationAssigned, Event: model.EventCardAssigned, BoardID: 1, CardID: 7, CardTitle: "<Релиз>"}
+	require.NoError(t, mailer.Deliver(n))
+	require.NoError(t, mailer.Deliver(model.Notification{Username: "boris", Kind: model.NotificationAssigned}), "users without an address are skipped")
+
+	// Сервер недоступен: письмо остаётся в очереди и ждёт повтора.
+	server.setReject(true)
+	sent, err := newOutbox().RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	pending, err := store.GetPendingEmails(now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, 1, pending[0].Attempts)
+	require.NotEmpty(t, pending[0].LastError)
+	require.Equal(t, "Вас назначили на карточку «<Релиз>»", pending[0].Subject)
+	require.Contains(t, pending[0].HTMLBody, "«&lt;Релиз&gt;»", "card title is escaped in html")
+
+	// До наступления повтора ничего не отправляется, после — уходит даже
+	// из нового экземпляра (очередь в хранилище).
+	server.setReject(false)
+	sent, err = newOutbox().RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	now = now.Add(time.Minute)
+	sent, err = newOutbox().RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	received := server.received()
+	require.Len(t, received, 1)
+	require.Equal(t, []string{"anna@example.com"}, received[0].to)
+
+	sent, err = newOutbox().RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, sent, "sent emails are not resent")
+}
+
+func TestOutboxGivesUp(t *testing.T) {
+	store := storage.NewStorage()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	_, err := store.EnqueueEmail(model.Email{Recipient: "anna@example.com", Subject: "s", NextAttemptAt: now})
+	require.NoError(t, err)
+	o := NewOutbox(store, failingSender{}, time.Minute, 3, zap.NewNop())
+
+	var delays []time.Duration
+	for range 3 {
+		pending, err := store.GetPendingEmails(now.Add(time.Hour), 1)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		delays = append(delays, pending[0].NextAttemptAt.Sub(now))
+		o.now = func() time.Time { return pending[0].NextAttemptAt }
+		_, err = o.RunOnce()
+		require.NoError(t, err)
+	}
+	require.Equal(t, []time.Duration{0, time.Minute, 5 * time.Minute}, delays, "1 then 4 minutes between attempts")
+
+	pending, err := store.GetPendingEmails(now.Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Empty(t, pending, "no attempts left")
+}
+
+type failingSender struct{}
+
+func (failingSender) Send(model.Email) error { return errors.New("connection refused") }
+
+func TestRenderNotification(t *testing.T) {
+	for _, kind := range model.NotificationKinds {
+		e, err := renderNotification(model.Notification{Username: "anna", Kind: kind, CardTitle: "Релиз", BoardID: 1, CardID: 2})
+		require.NoError(t, err, kind)
+		require.Contains(t, e.Subject, "«Релиз»", kind)
+		require.True(t, strings.HasPrefix(e.TextBody, "Здравствуйте, anna!"), kind)
+		require.Contains(t, e.HTMLBody, "<b>«Релиз»</b>", kind)
+	}
+	_, err := renderNotification(model.Notification{Kind: "spam"})
+	require.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package email
+
+import (
+	"awesomeProject2/cmd/model"
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// SMTPSender отправляет письма через SMTP-сервер. Без логина письма
+// уходят без авторизации — так удобно с локальным релеем.
+type SMTPSender struct {
+	Addr string
+	From string
+	Auth smtp.Auth
+	now  func() time.Time
+}
+
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	s := &SMTPSender{
+		Addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		From: from,
+		now:  time.Now,
+	}
+	if username != "" {
+		s.Auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s
+}
+
+func (s *SMTPSender) Send(e model.Email) error {
+	msg, err := buildMessage(s.From, e, s.now())
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.Addr, s.Auth, s.From, []string{e.Recipient}, msg)
+}
+
+// buildMessage собирает письмо multipart/alternative из текстовой и
+// HTML-версии.
+func buildMessage(from string, e model.Email, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", e.TextBody},
+		{"text/html; charset=UTF-8", e.HTMLBody},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", e.Recipient)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", e.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", parts.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
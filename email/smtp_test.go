@@ -0,0 +1,180 @@
+package email
+
+import (
+	"awesomeProject2/cmd/model"
+	"bufio"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSMTP — минимальный SMTP-сервер для тестов: принимает письма и
+// складывает их в messages; с reject отвечает отказом на MAIL FROM.
+type fakeSMTP struct {
+	listener net.Listener
+	mu       sync.Mutex
+	reject   bool
+	messages []receivedMessage
+}
+
+type receivedMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTP{listener: l}
+	t.Cleanup(func() { l.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) hostPort(t *testing.T) (string, int) {
+	t.Helper()
+	host, port, err := net.SplitHostPort(s.listener.Addr().String())
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return host, p
+}
+
+func (s *fakeSMTP) setReject(reject bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reject = reject
+}
+
+func (s *fakeSMTP) received() []receivedMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]receivedMessage(nil), s.messages...)
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 localhost ESMTP")
+	var msg receivedMessage
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimRight(line, "\r\n")
+		upper := strings.ToUpper(cmd)
+		switch {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			s.mu.Lock()
+			reject := s.reject
+			s.mu.Unlock()
+			if reject {
+				reply("451 try again later")
+				continue
+			}
+			msg = receivedMessage{from: strings.Trim(cmd[len("MAIL FROM:"):], "<>")}
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			msg.to = append(msg.to, strings.Trim(cmd[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			msg.data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			reply("250 OK")
+		case upper == "RSET", upper == "NOOP":
+			reply("250 OK")
+		case upper == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	server := newFakeSMTP(t)
+	host, port := server.hostPort(t)
+	sender := NewSMTPSender(host, port, "", "", "trello@example.com")
+
+	e := model.Email{
+		Recipient: "anna@example.com",
+		Subject:   "Вас назначили на карточку «Релиз»",
+		TextBody:  "Здравствуйте, anna!",
+		HTMLBody:  "<p>Здравствуйте, <b>anna</b>!</p>",
+	}
+	require.NoError(t, sender.Send(e))
+
+	received := server.received()
+	require.Len(t, received, 1)
+	require.Equal(t, "trello@example.com", received[0].from)
+	require.Equal(t, []string{"anna@example.com"}, received[0].to)
+
+	msg, err := mail.ReadMessage(strings.NewReader(received[0].data))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, e.Subject, subject)
+	require.Equal(t, "anna@example.com", msg.Header.Get("To"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		bodies = append(bodies, part.Header.Get("Content-Type")+": "+string(body))
+	}
+	require.Equal(t, []string{
+		"text/plain; charset=UTF-8: " + e.TextBody,
+		"text/html; charset=UTF-8: " + e.HTMLBody,
+	}, bodies)
+
+	server.setReject(true)
+	require.Error(t, sender.Send(e))
+	require.Len(t, server.received(), 1)
+}
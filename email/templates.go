@@ -0,0 +1,154 @@
+package email
+
+import (
+	"awesomeProject2/cmd/model"
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// message — шаблоны письма одного вида: тема и тело в двух вариантах.
+type message struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+func newMessage(name, subject, text, html string) message {
+	return message{
+		subject: texttemplate.Must(texttemplate.New(name + ".subject").Parse(subject)),
+		text:    texttemplate.Must(texttemplate.New(name + ".txt").Funcs(templateFuncs).Parse(text)),
+		html:    htmltemplate.Must(htmltemplate.New(name + ".html").Funcs(templateFuncs).Parse(html)),
+	}
+}
+
+// render заполняет шаблоны данными; получатель письма задаёт вызывающий.
+func (m message) render(data any) (model.Email, error) {
+	var subject, text, html bytes.Buffer
+	if err := m.subject.Execute(&subject, data); err != nil {
+		return model.Email{}, err
+	}
+	if err := m.text.Execute(&text, data); err != nil {
+		return model.Email{}, err
+	}
+	if err := m.html.Execute(&html, data); err != nil {
+		return model.Email{}, err
+	}
+	return model.Email{
+		Subject:  strings.TrimSpace(subject.String()),
+		TextBody: text.String(),
+		HTMLBody: html.String(),
+	}, nil
+}
+
+const footerText = `
+--
+Настроить уведомления: /me/notification-preferences
+`
+
+const footerHTML = `<p style="color:#888;font-size:12px">Настроить уведомления: /me/notification-preferences</p>`
+
+// notificationMessages — письма о единичных уведомлениях по их виду.
+var notificationMessages = map[string]message{
+	model.NotificationAssigned: newMessage("assigned",
+		`Вас назначили на карточку «{{.CardTitle}}»`,
+		`Здравствуйте, {{.Username}}!
+
+Вас назначили исполнителем карточки «{{.CardTitle}}» (доска {{.BoardID}}, карточка {{.CardID}}).
+`+footerText,
+		`<p>Здравствуйте, {{.Username}}!</p>
+<p>Вас назначили исполнителем карточки <b>«{{.CardTitle}}»</b> (доска {{.BoardID}}, карточка {{.CardID}}).</p>
+`+footerHTML),
+	model.NotificationMentioned: newMessage("mentioned",
+		`Вас упомянули в карточке «{{.CardTitle}}»`,
+		`Здравствуйте, {{.Username}}!
+
+Вас упомянули в карточке «{{.CardTitle}}» (доска {{.BoardID}}, карточка {{.CardID}}).
+`+footerText,
+		`<p>Здравствуйте, {{.Username}}!</p>
+<p>Вас упомянули в карточке <b>«{{.CardTitle}}»</b> (доска {{.BoardID}}, карточка {{.CardID}}).</p>
+`+footerHTML),
+	model.NotificationDueSoon: newMessage("due_soon",
+		`Скоро срок карточки «{{.CardTitle}}»`,
+		`Здравствуйте, {{.Username}}!
+
+Срок карточки «{{.CardTitle}}» (доска {{.BoardID}}, карточка {{.CardID}}) скоро истекает.
+`+footerText,
+		`<p>Здравствуйте, {{.Username}}!</p>
+<p>Срок карточки <b>«{{.CardTitle}}»</b> (доска {{.BoardID}}, карточка {{.CardID}}) скоро истекает.</p>
+`+footerHTML),
+	model.NotificationOverdue: newMessage("overdue",
+		`Срок карточки «{{.CardTitle}}» истёк`,
+		`Здравствуйте, {{.Username}}!
+
+Срок карточки «{{.CardTitle}}» (доска {{.BoardID}}, карточка {{.CardID}}) истёк.
+`+footerText,
+		`<p>Здравствуйте, {{.Username}}!</p>
+<p>Срок карточки <b>«{{.CardTitle}}»</b> (доска {{.BoardID}}, карточка {{.CardID}}) истёк.</p>
+`+footerHTML),
+	model.NotificationCardChanged: newMessage("card_changed",
+		`Карточка «{{.CardTitle}}» изменилась`,
+		`Здравствуйте, {{.Username}}!
+
+В карточке «{{.CardTitle}}» (доска {{.BoardID}}, карточка {{.CardID}}) есть изменения.
+`+footerText,
+		`<p>Здравствуйте, {{.Username}}!</p>
+<p>В карточке <b>«{{.CardTitle}}»</b> (доска {{.BoardID}}, карточка {{.CardID}}) есть изменения.</p>
+`+footerHTML),
+}
+
+// digestMessage — ежедневная сводка отложенных уведомлений.
+var digestMessage = newMessage("digest",
+	`Сводка уведомлений: {{len .Items}}`,
+	`Здравствуйте, {{.Username}}!
+
+Что произошло с прошлой сводки:
+{{range .Items}}
+- {{kindTitle .Kind}}: «{{.CardTitle}}» (доска {{.BoardID}}, карточка {{.CardID}})
+{{- end}}
+`+footerText,
+	`<p>Здравствуйте, {{.Username}}!</p>
+<p>Что произошло с прошлой сводки:</p>
+<ul>
+{{- range .Items}}
+<li>{{kindTitle .Kind}}: <b>«{{.CardTitle}}»</b> (доска {{.BoardID}}, карточка {{.CardID}})</li>
+{{- end}}
+</ul>
+`+footerHTML)
+
+var kindTitles = map[string]string{
+	model.NotificationAssigned:    "Вас назначили",
+	model.NotificationMentioned:   "Вас упомянули",
+	model.NotificationDueSoon:     "Скоро срок",
+	model.NotificationOverdue:     "Срок истёк",
+	model.NotificationCardChanged: "Изменения",
+}
+
+var templateFuncs = map[string]any{"kindTitle": kindTitle}
+
+// kindTitle — вид уведомления словами, для пунктов сводки.
+func kindTitle(kind string) string {
+	if title, ok := kindTitles[kind]; ok {
+		return title
+	}
+	return kind
+}
+
+func renderNotification(n model.Notification) (model.Email, error) {
+	m, ok := notificationMessages[n.Kind]
+	if !ok {
+		return model.Email{}, fmt.Errorf("no email template for notification kind %q", n.Kind)
+	}
+	return m.render(n)
+}
+
+type digestData struct {
+	Username string
+	Items    []model.DigestItem
+}
+
+func renderDigest(username string, items []model.DigestItem) (model.Email, error) {
+	return digestMessage.render(digestData{Username: username, Items: items})
+}
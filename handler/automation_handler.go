@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type AutomationHandler struct {
+	service AutomationService
+	logger  *zap.Logger
+}
+
+func NewAutomationHandler(service AutomationService, logger *zap.Logger) *AutomationHandler {
+	return &AutomationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetRules обрабатывает GET /boards/{id}/automations.
+func (h *AutomationHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	rules, err := h.service.GetRules(boardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения правил автоматизации", zap.Int("boardID", boardID))
+		return
+	}
+	ruleDTOs := []dto.AutomationRuleDTO{}
+	for _, rule := range rules {
+		ruleDTOs = append(ruleDTOs, dto.AutomationRuleToDTO(rule))
+	}
+	writeJSON(w, h.logger, http.StatusOK, ruleDTOs)
+}
+
+// CreateRule обрабатывает POST /boards/{id}/automations.
+func (h *AutomationHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.SaveAutomationRuleDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	rule, err := h.service.CreateRule(dto.AutomationRuleFromDTO(boardID, input))
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка создания правила автоматизации", zap.Int("boardID", boardID))
+		return
+	}
+	h.logger.Info("Создано правило автоматизации", zap.Int("ruleID", rule.ID), zap.Int("boardID", boardID))
+	writeJSON(w, h.logger, http.StatusCreated, dto.AutomationRuleToDTO(rule))
+}
+
+// UpdateRule обрабатывает PUT /automations/{id}: правило заменяется целиком.
+func (h *AutomationHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	ruleID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.SaveAutomationRuleDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	rule, err := h.service.UpdateRule(ruleID, dto.AutomationRuleFromDTO(0, input))
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка изменения правила автоматизации", zap.Int("ruleID", ruleID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.AutomationRuleToDTO(rule))
+}
+
+// DeleteRule обрабатывает DELETE /automations/{id}.
+func (h *AutomationHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	ruleID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteRule(ruleID); err != nil {
+		fail(w, h.logger, err, "Ошибка удаления правила автоматизации", zap.Int("ruleID", ruleID))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetRuns обрабатывает GET /boards/{id}/automations/runs?limit=N.
+func (h *AutomationHandler) GetRuns(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	runs, err := h.service.GetRuns(boardID, limit)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения журнала автоматизации", zap.Int("boardID", boardID))
+		return
+	}
+	runDTOs := []dto.AutomationRunDTO{}
+	for _, run := range runs {
+		runDTOs = append(runDTOs, dto.AutomationRunToDTO(run))
+	}
+	writeJSON(w, h.logger, http.StatusOK, runDTOs)
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateAutomationRule(t *testing.T) {
+	rule := model.AutomationRule{
+		ID:      3,
+		BoardID: 1,
+		Name:    "close",
+		Enabled: true,
+		Trigger: model.AutomationTrigger{Type: model.EventCardMoved, ListID: 10},
+		Actions: []model.AutomationAction{{Type: model.ActionSetStatus, Status: "done"}},
+	}
+	body := `{"name":"close","trigger":{"type":"card_moved","list_id":10},"actions":[{"type":"set_status","status":"done"}]}`
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		wantInput      model.AutomationRuleInput
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name: "enabled by default",
+			id:   "1",
+			body: body,
+			wantInput: model.AutomationRuleInput{
+				BoardID: 1,
+				Name:    "close",
+				Enabled: true,
+				Trigger: rule.Trigger,
+				Actions: rule.Actions,
+			},
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "disabled",
+			id:   "1",
+			body: `{"name":"close","enabled":false,"trigger":{"type":"card_created"},"actions":[]}`,
+			wantInput: model.AutomationRuleInput{
+				BoardID: 1,
+				Name:    "close",
+				Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+				Actions: []model.AutomationAction{},
+			},
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "validation error",
+			id:             "1",
+			body:           `{"name":"close","trigger":{"type":"card_created"}}`,
+			wantInput:      model.AutomationRuleInput{BoardID: 1, Name: "close", Enabled: true, Trigger: model.AutomationTrigger{Type: model.EventCardCreated}},
+			mockError:      fmt.Errorf("%w: at least one action is required", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "board not found",
+			id:             "1",
+			body:           body,
+			wantInput:      model.AutomationRuleInput{BoardID: 1, Name: "close", Enabled: true, Trigger: rule.Trigger, Actions: rule.Actions},
+			mockError:      fmt.Errorf("board 1: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid json",
+			id:             "1",
+			body:           `{"name":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid id",
+			id:             "x",
+			body:           body,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAutomationService)
+			handler := NewAutomationHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("CreateRule", tt.wantInput).Return(rule, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/boards/"+tt.id+"/automations", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.CreateRule(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.AutomationRuleDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, 3, resp.ID)
+				require.Equal(t, rule.Trigger, resp.Trigger)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateAutomationRule(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "not found", mockError: model.ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "service error", mockError: errors.New("fail"), expectedStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAutomationService)
+			handler := NewAutomationHandler(mockService, zap.NewNop())
+			input := model.AutomationRuleInput{Name: "r", Trigger: model.AutomationTrigger{Type: model.EventDuePassed}}
+			mockService.On("UpdateRule", 3, input).Return(model.AutomationRule{ID: 3, BoardID: 1, Name: "r"}, tt.mockError)
+
+			req := httptest.NewRequest(http.MethodPut, "/automations/3", strings.NewReader(`{"name":"r","enabled":false,"trigger":{"type":"due_passed"}}`))
+			req.SetPathValue("id", "3")
+			rec := httptest.NewRecorder()
+			handler.UpdateRule(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteAutomationRule(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusNoContent},
+		{name: "not found", mockError: model.ErrNotFound, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAutomationService)
+			handler := NewAutomationHandler(mockService, zap.NewNop())
+			mockService.On("DeleteRule", 3).Return(tt.mockError)
+
+			req := httptest.NewRequest(http.MethodDelete, "/automations/3", nil)
+			req.SetPathValue("id", "3")
+			rec := httptest.NewRecorder()
+			handler.DeleteRule(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAutomationRules(t *testing.T) {
+	mockService := new(MockAutomationService)
+	handler := NewAutomationHandler(mockService, zap.NewNop())
+	mockService.On("GetRules", 1).Return([]model.AutomationRule{{ID: 3, BoardID: 1, Name: "r"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/boards/1/automations", nil)
+	req.SetPathValue("id", "1")
+	rec := httptest.NewRecorder()
+	handler.GetRules(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp []dto.AutomationRuleDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp, 1)
+	require.Equal(t, "r", resp[0].Name)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAutomationRuns(t *testing.T) {
+	runs := []model.AutomationRun{{ID: 7, RuleID: 3, BoardID: 1, CardID: 5, Event: model.EventCardMoved, Status: model.RunStatusFailed, Error: "boom"}}
+	tests := []struct {
+		name           string
+		query          string
+		wantLimit      int
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "default limit", wantLimit: 0, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "explicit limit", query: "?limit=10", wantLimit: 10, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "board not found", mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid limit", query: "?limit=0", expectedStatus: http.StatusBadRequest},
+		{name: "non-numeric limit", query: "?limit=abc", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAutomationService)
+			handler := NewAutomationHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("GetRuns", 1, tt.wantLimit).Return(runs, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/boards/1/automations/runs"+tt.query, nil)
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.GetRuns(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp []dto.AutomationRunDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Len(t, resp, 1)
+				require.Equal(t, "boom", resp[0].Error)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
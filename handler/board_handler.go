@@ -2,9 +2,13 @@ package handler
 
 import (
 	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
 	"encoding/json"
+	"errors"
 	"go.uber.org/zap"
+	"io"
 	"net/http"
+	"strconv"
 )
 
 type BoardHandler struct {
@@ -63,3 +67,93 @@ func (h *BoardHandler) HandleBoards(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
+
+// GetTemplates обрабатывает GET /boards/templates.
+func (h *BoardHandler) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	boards, err := h.service.GetTemplates()
+	if err != nil {
+		h.logger.Error("Ошибка получения шаблонов досок", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	boardDTOs := []dto.BoardDTO{}
+	for _, b := range boards {
+		boardDTOs = append(boardDTOs, dto.BoardToDTO(b))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(boardDTOs); err != nil {
+		h.logger.Error("Ошибка кодирования ответа", zap.Error(err))
+	}
+}
+
+// SetTemplate обрабатывает PUT /boards/{id}/template.
+func (h *BoardHandler) SetTemplate(w http.ResponseWriter, r *http.Request) {
+	boardID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		h.logger.Error("Некорректный id доски", zap.Error(err), zap.String("id", r.PathValue("id")))
+		http.Error(w, "invalid board id", http.StatusBadRequest)
+		return
+	}
+	var input dto.BoardTemplateDTO
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("Ошибка декодирования запроса(PUT)", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	board, err := h.service.SetTemplate(boardID, input.IsTemplate)
+	if errors.Is(err, model.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Ошибка изменения шаблона доски", zap.Error(err), zap.Int("boardID", boardID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dto.BoardToDTO(board)); err != nil {
+		h.logger.Error("Ошибка кодирования ответа", zap.Error(err), zap.Int("boardID", boardID))
+	}
+}
+
+// CopyBoard обрабатывает POST /boards/{id}/copy. Тело необязательно.
+func (h *BoardHandler) CopyBoard(w http.ResponseWriter, r *http.Request) {
+	boardID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		h.logger.Error("Некорректный id доски", zap.Error(err), zap.String("id", r.PathValue("id")))
+		http.Error(w, "invalid board id", http.StatusBadRequest)
+		return
+	}
+	var input dto.CopyBoardDTO
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error("Ошибка декодирования запроса(COPY)", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	board, err := h.service.CopyBoard(boardID, model.BoardCopyInput{
+		Title:        input.Title,
+		IncludeCards: input.IncludeCards,
+		AsTemplate:   input.AsTemplate,
+	})
+	if errors.Is(err, model.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, model.ErrInvalidInput) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Ошибка копирования доски", zap.Error(err), zap.Int("boardID", boardID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.logger.Info("Доска скопирована", zap.Int("from", boardID), zap.Int("to", board.ID))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(dto.BoardToDTO(board)); err != nil {
+		h.logger.Error("Ошибка кодирования ответа", zap.Error(err), zap.Int("boardID", board.ID))
+	}
+}
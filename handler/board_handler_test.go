@@ -2,14 +2,17 @@ package handler
 
 import (
 	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/helper"
 	"awesomeProject2/cmd/model"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -195,3 +198,130 @@ func TestHandleBoards_MethodNotAllowed(t *testing.T) {
 	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
 	require.Contains(t, rec.Body.String(), "Method not allowed")
 }
+
+func TestCopyBoard(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		wantInput      model.BoardCopyInput
+		mockResult     model.Board
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			id:             "1",
+			body:           `{"title":"Копия","include_cards":true,"as_template":true}`,
+			wantInput:      model.BoardCopyInput{Title: "Копия", IncludeCards: true, AsTemplate: true},
+			mockResult:     model.Board{ID: 2, Title: "Копия", IsTemplate: true},
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "empty body",
+			id:             "1",
+			mockResult:     model.Board{ID: 2, Title: "b (копия)"},
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "board not found",
+			id:             "1",
+			body:           `{}`,
+			mockError:      fmt.Errorf("board 1: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			id:             "1",
+			body:           `{}`,
+			mockError:      errors.New("fail"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid json",
+			id:             "1",
+			body:           `{"title":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid id",
+			id:             "x",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockBoardService)
+			handler := NewBoardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("CopyBoard", 1, tt.wantInput).Return(tt.mockResult, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/boards/"+tt.id+"/copy", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.CopyBoard(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.BoardDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, dto.BoardToDTO(tt.mockResult), resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBoardTemplates(t *testing.T) {
+	t.Run("list templates", func(t *testing.T) {
+		mockService := new(MockBoardService)
+		handler := NewBoardHandler(mockService, zap.NewNop())
+		mockService.On("GetTemplates").Return([]model.Board{{ID: 3, Title: "tpl", IsTemplate: true}}, nil)
+
+		rec := httptest.NewRecorder()
+		handler.GetTemplates(rec, httptest.NewRequest(http.MethodGet, "/boards/templates", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp []dto.BoardDTO
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Equal(t, []dto.BoardDTO{{ID: helper.GetPointer(3), Title: "tpl", IsTemplate: true}}, resp)
+		mockService.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "mark as template", id: "3", body: `{"is_template":true}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "not found", id: "3", body: `{"is_template":true}`, mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid body", id: "3", body: `nope`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid id", id: "x", body: `{"is_template":true}`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockBoardService)
+			handler := NewBoardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("SetTemplate", 3, true).Return(model.Board{ID: 3, Title: "tpl", IsTemplate: true}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/boards/"+tt.id+"/template", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.SetTemplate(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"bytes"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+// feedSuffix — расширение файла ленты: календари ждут URL на .ics.
+const feedSuffix = ".ics"
+
+type CalendarHandler struct {
+	service CalendarService
+	logger  *zap.Logger
+}
+
+func NewCalendarHandler(service CalendarService, logger *zap.Logger) *CalendarHandler {
+	return &CalendarHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetBoardCalendar обрабатывает GET /boards/{id}/calendar?from=&to=&tz=.
+func (h *CalendarHandler) GetBoardCalendar(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	days, err := h.service.BoardCalendar(boardID, query.Get("from"), query.Get("to"), query.Get("tz"))
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения календаря доски", zap.Int("boardID", boardID), zap.String("query", r.URL.RawQuery))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.CalendarToDTO(days))
+}
+
+// IssueToken обрабатывает POST /me/calendar-token: выпускает новый токен
+// ленты, прежняя ссылка перестаёт работать.
+func (h *CalendarHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	token, issued, err := h.service.IssueToken(username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка выпуска токена календаря", zap.String("username", username))
+		return
+	}
+	h.logger.Info("Выпущен токен календаря", zap.String("username", username))
+	writeJSON(w, h.logger, http.StatusCreated, dto.CalendarTokenDTO{
+		Token:     token,
+		FeedPath:  "/calendar/" + token + feedSuffix,
+		CreatedAt: issued.CreatedAt,
+	})
+}
+
+// RevokeToken обрабатывает DELETE /me/calendar-token.
+func (h *CalendarHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.RevokeToken(username); err != nil {
+		fail(w, h.logger, err, "Ошибка отзыва токена календаря", zap.String("username", username))
+		return
+	}
+	h.logger.Info("Токен календаря отозван", zap.String("username", username))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFeed обрабатывает GET /calendar/{file}, где file — <токен>.ics.
+// Заголовок X-User не нужен: календарные клиенты знают только URL, и
+// доступ даёт сам токен.
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), feedSuffix)
+	if !ok || token == "" {
+		http.NotFound(w, r)
+		return
+	}
+	// Ленту собираем в буфер, чтобы при ошибке ответить кодом, а не
+	// обрезанным календарём.
+	var buf bytes.Buffer
+	if err := h.service.Feed(token, &buf); err != nil {
+		fail(w, h.logger, err, "Ошибка формирования ленты календаря")
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := buf.WriteTo(w); err != nil {
+		h.logger.Error("Ошибка отправки ленты календаря", zap.Error(err))
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetBoardCalendar(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "bad range", mockError: fmt.Errorf("%w: to is before from", model.ErrInvalidInput), expectedStatus: http.StatusBadRequest},
+		{name: "board not found", mockError: fmt.Errorf("board 1: %w", model.ErrNotFound), expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCalendarService)
+			handler := NewCalendarHandler(mockService, zap.NewNop())
+			day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+			mockService.On("BoardCalendar", 1, "2026-03-01", "2026-03-31", "Europe/Moscow").
+				Return([]model.CalendarDay{{Date: day, Cards: []model.Card{{ID: 5, DueAt: &day}}}}, tt.mockError)
+
+			req := httptest.NewRequest(http.MethodGet, "/boards/1/calendar?from=2026-03-01&to=2026-03-31&tz=Europe/Moscow", nil)
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.GetBoardCalendar(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp []dto.CalendarDayDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Len(t, resp, 1)
+				require.Equal(t, "2026-03-01", resp[0].Date)
+				require.Equal(t, 5, *resp[0].Cards[0].ID)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIssueCalendarToken(t *testing.T) {
+	mockService := new(MockCalendarService)
+	handler := NewCalendarHandler(mockService, zap.NewNop())
+	mockService.On("IssueToken", "anna").Return("secret", model.CalendarToken{Username: "anna"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/me/calendar-token", nil)
+	req.Header.Set(userHeader, "anna")
+	rec := httptest.NewRecorder()
+	handler.IssueToken(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var resp dto.CalendarTokenDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, "secret", resp.Token)
+	require.Equal(t, "/calendar/secret.ics", resp.FeedPath)
+
+	rec = httptest.NewRecorder()
+	handler.IssueToken(rec, httptest.NewRequest(http.MethodPost, "/me/calendar-token", nil))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetCalendarFeed(t *testing.T) {
+	tests := []struct {
+		name           string
+		file           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", file: "secret.ics", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "unknown token", file: "secret.ics", mockError: fmt.Errorf("calendar token: %w", model.ErrNotFound), expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "not ics", file: "secret", expectedStatus: http.StatusNotFound},
+		{name: "empty token", file: ".ics", expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCalendarService)
+			handler := NewCalendarHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("Feed", "secret", mock.Anything).Return(tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/calendar/"+tt.file, nil)
+			req.SetPathValue("file", tt.file)
+			rec := httptest.NewRecorder()
+			handler.GetFeed(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				require.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get("Content-Type"))
+				require.Contains(t, rec.Body.String(), "BEGIN:VCALENDAR")
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
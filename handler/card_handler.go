@@ -4,8 +4,14 @@ import (
 	"awesomeProject2/cmd/dto"
 	"awesomeProject2/cmd/model"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"go.uber.org/zap"
+	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 type CardHandler struct {
@@ -24,16 +30,28 @@ func (h *CardHandler) HandleCards(w http.ResponseWriter, r *http.Request) {
 		var requestDTO dto.CardDTO
 		if r.Body != nil {
 			defer r.Body.Close()
-			if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+			// Тело необязательно: без него возвращаются карточки всех списков.
+			if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil && !errors.Is(err, io.EOF) {
 				h.logger.Error("Ошибка декодирования запроса(GET)", zap.Error(err), zap.Any("requestDTO", requestDTO))
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
 		}
-		cards, err := h.service.GetCards(requestDTO.ID)
+		filter := model.CardFilter{ListID: requestDTO.ID}
+		if v := r.URL.Query().Get("include_archived"); v != "" {
+			includeArchived, err := strconv.ParseBool(v)
+			if err != nil {
+				http.Error(w, "include_archived must be a boolean", http.StatusBadRequest)
+				return
+			}
+			filter.IncludeArchived = includeArchived
+		}
+		if !fieldQuery(w, r, &filter) {
+			return
+		}
+		cards, err := h.service.GetCards(filter)
 		if err != nil {
-			h.logger.Error("Ошибка получения карточек", zap.Error(err), zap.Any("requestDTO", requestDTO))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			fail(w, h.logger, err, "Ошибка получения карточек", zap.Any("requestDTO", requestDTO))
 			return
 		}
 		var cardDTOs []dto.CardDTO
@@ -66,13 +84,21 @@ func (h *CardHandler) HandleCards(w http.ResponseWriter, r *http.Request) {
 			ListID:      input.ListID,
 			Title:       input.Title,
 			Description: input.Description,
+			// Создатель необязателен: без заголовка карточка создаётся
+			// анонимно и ни на кого не подписывается.
+			Creator: strings.TrimSpace(r.Header.Get(userHeader)),
 		})
+		if errors.Is(err, model.ErrConflict) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		if err != nil {
 			h.logger.Error("Ошибка создание карточки", zap.Error(err), zap.Any("input", input))
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 		cardDTOs := dto.CardToDTO(card)
+		warnHeader(w, card.Warnings)
 		json.NewEncoder(w).Encode(cardDTOs)
 	} else if r.Method == http.MethodDelete {
 		var input dto.DeleteCardDTO
@@ -93,8 +119,7 @@ func (h *CardHandler) HandleCards(w http.ResponseWriter, r *http.Request) {
 		}
 		deletedCard, err := h.service.DeleteCard(input.ListID, input.CardID)
 		if err != nil {
-			h.logger.Error("Ошибка удаления карточки", zap.Error(err))
-			http.Error(w, "Error deleting card", http.StatusInternalServerError)
+			fail(w, h.logger, err, "Ошибка удаления карточки", zap.Any("input", input))
 			return
 		}
 		cardDTOs := dto.CardToDTO(deletedCard)
@@ -130,14 +155,220 @@ func (h *CardHandler) HandleCards(w http.ResponseWriter, r *http.Request) {
 		}
 		updatedCard, err := h.service.UpdateCard(updatedCard)
 		if err != nil {
-			h.logger.Error("Ошибка обновление карточки", zap.Error(err), zap.Any("updatedCard", updatedCard))
-			http.Error(w, "Error updating card", http.StatusInternalServerError)
+			fail(w, h.logger, err, "Ошибка обновление карточки", zap.Any("updatedCard", updatedCard))
 			return
 		}
 		updatedCardDTOResponse := dto.CardToDTO(updatedCard)
+		warnHeader(w, updatedCard.Warnings)
 		json.NewEncoder(w).Encode(updatedCardDTOResponse)
 	} else {
 		h.logger.Warn("Метод не поддерживается", zap.Any("method", r.Method))
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
+
+// BulkCards обрабатывает POST /cards/bulk.
+func (h *CardHandler) BulkCards(w http.ResponseWriter, r *http.Request) {
+	var input dto.BulkCardsDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	var atomic bool
+	switch input.Mode {
+	case "", dto.BulkModeAtomic:
+		atomic = true
+	case dto.BulkModeBestEffort:
+	default:
+		http.Error(w, fmt.Sprintf("unknown mode %q, expected %s or %s", input.Mode, dto.BulkModeAtomic, dto.BulkModeBestEffort), http.StatusBadRequest)
+		return
+	}
+	ops := make([]model.BulkCardOperation, 0, len(input.Operations))
+	for _, op := range input.Operations {
+		ops = append(ops, dto.BulkOperationFromDTO(op))
+	}
+
+	results, err := h.service.BulkCards(ops, atomic)
+	status := http.StatusOK
+	if err != nil {
+		failed := slices.IndexFunc(results, func(r model.BulkCardResult) bool { return r.Status == model.BulkStatusFailed })
+		if failed < 0 {
+			fail(w, h.logger, err, "Ошибка пакетной операции с карточками", zap.Int("operations", len(ops)))
+			return
+		}
+		// Результаты по операциям нужны клиенту и при ошибке, поэтому
+		// статус тот же, что у fail, но ответ пишется целиком.
+		status = errStatus(err)
+		if status == http.StatusInternalServerError {
+			h.logger.Error("Ошибка пакетной операции с карточками", zap.Error(err), zap.Int("operation", failed))
+		}
+	}
+
+	resp := dto.BulkCardsResponseDTO{Results: make([]dto.BulkCardResultDTO, 0, len(results))}
+	for i, res := range results {
+		resp.Results = append(resp.Results, dto.BulkResultToDTO(i, res))
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	writeJSON(w, h.logger, status, resp)
+}
+
+// ArchiveCard обрабатывает POST /cards/{id}/archive.
+func (h *CardHandler) ArchiveCard(w http.ResponseWriter, r *http.Request) {
+	h.changeCard(w, r, "архивации", h.service.ArchiveCard)
+}
+
+// UnarchiveCard обрабатывает POST /cards/{id}/unarchive.
+func (h *CardHandler) UnarchiveCard(w http.ResponseWriter, r *http.Request) {
+	h.changeCard(w, r, "разархивации", h.service.UnarchiveCard)
+}
+
+// RestoreCard обрабатывает POST /cards/{id}/restore: возвращает карточку из корзины.
+func (h *CardHandler) RestoreCard(w http.ResponseWriter, r *http.Request) {
+	h.changeCard(w, r, "восстановления", h.service.RestoreCard)
+}
+
+func (h *CardHandler) changeCard(w http.ResponseWriter, r *http.Request, action string, change func(id int) (model.Card, error)) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	card, err := change(cardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка "+action+" карточки", zap.Int("cardID", cardID))
+		return
+	}
+	warnHeader(w, card.Warnings)
+	writeJSON(w, h.logger, http.StatusOK, dto.CardToDTO(card))
+}
+
+// MoveCard обрабатывает POST /cards/{id}/move, в том числе на другую доску.
+func (h *CardHandler) MoveCard(w http.ResponseWriter, r *http.Request) {
+	var input dto.MoveCardDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	h.changeCard(w, r, "переноса", func(id int) (model.Card, error) {
+		return h.service.MoveCard(id, model.CardMoveInput{ListID: input.ListID, LaneID: input.LaneID})
+	})
+}
+
+// SetCardLane обрабатывает PUT /cards/{id}/lane: кладёт карточку в явную
+// дорожку доски.
+func (h *CardHandler) SetCardLane(w http.ResponseWriter, r *http.Request) {
+	var input dto.CardLaneDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	var laneID int
+	if input.LaneID != nil {
+		laneID = *input.LaneID
+	}
+	h.changeCard(w, r, "смены дорожки", func(id int) (model.Card, error) {
+		return h.service.SetCardLane(id, laneID)
+	})
+}
+
+// CopyCard обрабатывает POST /cards/{id}/copy. Тело необязательно.
+func (h *CardHandler) CopyCard(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.CopyCardDTO
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error("Ошибка декодирования запроса(COPY)", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	card, err := h.service.CopyCard(cardID, model.CardCopyInput{ListID: input.ListID, Title: input.Title})
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка копирования карточки", zap.Int("cardID", cardID))
+		return
+	}
+	warnHeader(w, card.Warnings)
+	writeJSON(w, h.logger, http.StatusCreated, dto.CardToDTO(card))
+}
+
+// SetDueDate обрабатывает PUT /cards/{id}/due.
+func (h *CardHandler) SetDueDate(w http.ResponseWriter, r *http.Request) {
+	var input dto.SetDueDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	h.changeCard(w, r, "изменения срока", func(id int) (model.Card, error) {
+		return h.service.SetDueDate(id, input.DueAt)
+	})
+}
+
+// GetTrash обрабатывает GET /boards/{id}/trash.
+func (h *CardHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	cards, err := h.service.GetTrash(boardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения корзины", zap.Int("boardID", boardID))
+		return
+	}
+	cardDTOs := []dto.CardDTO{}
+	for _, c := range cards {
+		cardDTOs = append(cardDTOs, dto.CardToDTO(c))
+	}
+	writeJSON(w, h.logger, http.StatusOK, cardDTOs)
+}
+
+// fieldQuery читает фильтр ?field_id=N&field_value=V и сортировку
+// ?sort_field=N&order=desc по пользовательскому полю.
+func fieldQuery(w http.ResponseWriter, r *http.Request, filter *model.CardFilter) bool {
+	query := r.URL.Query()
+	if v := query.Get("field_id"); v != "" {
+		fieldID, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "field_id must be an integer", http.StatusBadRequest)
+			return false
+		}
+		if !query.Has("field_value") {
+			http.Error(w, "field_value is required with field_id", http.StatusBadRequest)
+			return false
+		}
+		filter.Fields = []model.CardFieldFilter{{FieldID: fieldID, Value: query.Get("field_value")}}
+	}
+	if v := query.Get("sort_field"); v != "" {
+		fieldID, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "sort_field must be an integer", http.StatusBadRequest)
+			return false
+		}
+		desc, ok := sortOrder(w, query.Get("order"))
+		if !ok {
+			return false
+		}
+		filter.Sort = &model.CardSort{By: model.CardSortField, FieldID: fieldID, Desc: desc}
+	}
+	return true
+}
+
+// SetFieldValue обрабатывает PUT /cards/{id}/fields/{fieldID}.
+func (h *CardHandler) SetFieldValue(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	fieldID, ok := pathInt(w, r, h.logger, "fieldID")
+	if !ok {
+		return
+	}
+	var input dto.SetCardFieldValueDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	value, err := h.service.SetFieldValue(cardID, fieldID, input.Value)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка изменения поля карточки", zap.Int("cardID", cardID), zap.Int("fieldID", fieldID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.CardFieldValueDTO{FieldID: value.FieldID, Value: value.Value()})
+}
@@ -7,12 +7,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestHandleCards_GET(t *testing.T) {
@@ -56,7 +59,7 @@ func TestHandleCards_GET(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/cards", bytes.NewReader(body))
 			rec := httptest.NewRecorder()
 
-			mock.On("GetCards", tt.requestBody.ID).Return(tt.serviceResponse, tt.mockError)
+			mock.On("GetCards", model.CardFilter{ListID: tt.requestBody.ID}).Return(tt.serviceResponse, tt.mockError)
 
 			handler.HandleCards(rec, req)
 
@@ -293,6 +296,16 @@ func TestHandleCards_PUT(t *testing.T) {
 			mockError:      errors.New("fail"),
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name: "list on another board",
+			requestBody: dto.UpdateCardDTO{
+				ID:     1,
+				ListID: 7,
+				Title:  "Title",
+			},
+			mockError:      fmt.Errorf("%w: list 7 is on another board, use move", model.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -341,3 +354,537 @@ func TestHandleCard_MethodNotAllowed(t *testing.T) {
 	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
 	require.Contains(t, rec.Body.String(), "Method not allowed")
 }
+
+func TestBulkCards(t *testing.T) {
+	moved := model.Card{ID: 1, ListID: 2, Title: "card"}
+	ops := []dto.BulkCardOperationDTO{
+		{Op: model.BulkMove, CardID: 1, ListID: 2},
+		{Op: model.BulkDelete, CardID: 9},
+	}
+	modelOps := []model.BulkCardOperation{
+		{Op: model.BulkMove, CardID: 1, ListID: 2},
+		{Op: model.BulkDelete, CardID: 9},
+	}
+	notFound := fmt.Errorf("card 9: %w", model.ErrNotFound)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectCall     bool
+		atomic         bool
+		mockResult     []model.BulkCardResult
+		mockError      error
+		expectedStatus int
+		expectedBody   *dto.BulkCardsResponseDTO
+	}{
+		{
+			name:       "best effort with failed item",
+			body:       mustJSON(t, dto.BulkCardsDTO{Mode: dto.BulkModeBestEffort, Operations: ops}),
+			expectCall: true,
+			mockResult: []model.BulkCardResult{
+				{CardID: 1, Status: model.BulkStatusOK, Card: &moved},
+				{CardID: 9, Status: model.BulkStatusFailed, Err: notFound},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: &dto.BulkCardsResponseDTO{Results: []dto.BulkCardResultDTO{
+				{Index: 0, CardID: 1, Status: model.BulkStatusOK, Card: helper.GetPointer(dto.CardToDTO(moved))},
+				{Index: 1, CardID: 9, Status: model.BulkStatusFailed, Error: notFound.Error()},
+			}},
+		},
+		{
+			name:       "atomic batch aborted",
+			body:       mustJSON(t, dto.BulkCardsDTO{Operations: ops}),
+			expectCall: true,
+			atomic:     true,
+			mockResult: []model.BulkCardResult{
+				{CardID: 1, Status: model.BulkStatusRolledBack},
+				{CardID: 9, Status: model.BulkStatusFailed, Err: notFound},
+			},
+			mockError:      fmt.Errorf("operation 1: %w", notFound),
+			expectedStatus: http.StatusNotFound,
+			expectedBody: &dto.BulkCardsResponseDTO{
+				Results: []dto.BulkCardResultDTO{
+					{Index: 0, CardID: 1, Status: model.BulkStatusRolledBack},
+					{Index: 1, CardID: 9, Status: model.BulkStatusFailed, Error: notFound.Error()},
+				},
+				Error: "operation 1: card 9: not found",
+			},
+		},
+		{
+			name:           "invalid batch",
+			body:           mustJSON(t, dto.BulkCardsDTO{Mode: dto.BulkModeAtomic, Operations: ops}),
+			expectCall:     true,
+			atomic:         true,
+			mockError:      fmt.Errorf("%w: no operations", model.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "transaction error",
+			body:           mustJSON(t, dto.BulkCardsDTO{Operations: ops}),
+			expectCall:     true,
+			atomic:         true,
+			mockError:      errors.New("connection lost"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "unknown mode",
+			body:           mustJSON(t, dto.BulkCardsDTO{Mode: "yolo", Operations: ops}),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid json",
+			body:           "{",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("BulkCards", modelOps, tt.atomic).Return(tt.mockResult, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/bulk", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.BulkCards(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != nil {
+				var resp dto.BulkCardsResponseDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, *tt.expectedBody, resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestHandleCards_GETIncludeArchived(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectFilter   *model.CardFilter
+		expectedStatus int
+	}{
+		{
+			name:           "active cards by default",
+			expectFilter:   &model.CardFilter{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "include archived",
+			query:          "?include_archived=true",
+			expectFilter:   &model.CardFilter{IncludeArchived: true},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid flag",
+			query:          "?include_archived=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "custom field filter and sort",
+			query: "?field_id=3&field_value=high&sort_field=4&order=desc",
+			expectFilter: &model.CardFilter{
+				Fields: []model.CardFieldFilter{{FieldID: 3, Value: "high"}},
+				Sort:   &model.CardSort{By: model.CardSortField, FieldID: 4, Desc: true},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "field without value",
+			query:          "?field_id=3",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid order",
+			query:          "?sort_field=4&order=up",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectFilter != nil {
+				mockService.On("GetCards", *tt.expectFilter).Return([]model.Card{}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/cards"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.HandleCards(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChangeCardEndpoints(t *testing.T) {
+	archivedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	card := model.Card{ID: 5, ListID: 1, Title: "card", ArchivedAt: &archivedAt}
+	tests := []struct {
+		name           string
+		method         string
+		id             string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "archive", method: "ArchiveCard", id: "5", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "unarchive", method: "UnarchiveCard", id: "5", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "restore", method: "RestoreCard", id: "5", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "restore not in trash", method: "RestoreCard", id: "5", mockError: fmt.Errorf("deleted card 5: %w", model.ErrNotFound), expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "archive storage error", method: "ArchiveCard", id: "5", mockError: errors.New("fail"), expectCall: true, expectedStatus: http.StatusInternalServerError},
+		{name: "invalid id", method: "ArchiveCard", id: "abc", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On(tt.method, 5).Return(card, tt.mockError)
+			}
+			endpoints := map[string]http.HandlerFunc{
+				"ArchiveCard":   handler.ArchiveCard,
+				"UnarchiveCard": handler.UnarchiveCard,
+				"RestoreCard":   handler.RestoreCard,
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/"+tt.id+"/action", nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			endpoints[tt.method](rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.CardDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, dto.CardToDTO(card), resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetTrash(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		id             string
+		mockResult     []model.Card
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			id:             "1",
+			mockResult:     []model.Card{{ID: 3, Title: "gone", DeletedAt: &deletedAt}},
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty trash",
+			id:             "1",
+			mockResult:     nil,
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "service error",
+			id:             "1",
+			mockResult:     nil,
+			mockError:      errors.New("fail"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid id",
+			id:             "x",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("GetTrash", 1).Return(tt.mockResult, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/boards/"+tt.id+"/trash", nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.GetTrash(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp []dto.CardDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Len(t, resp, len(tt.mockResult))
+				for i, c := range tt.mockResult {
+					require.Equal(t, dto.CardToDTO(c), resp[i])
+				}
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveCard(t *testing.T) {
+	moved := model.Card{ID: 5, BoardID: 2, ListID: 20, Title: "card"}
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", id: "5", body: `{"list_id":20}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "invalid destination", id: "5", body: `{"list_id":20}`, mockError: fmt.Errorf("%w: target list 20 does not exist", model.ErrInvalidInput), expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "card not found", id: "5", body: `{"list_id":20}`, mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "open blockers", id: "5", body: `{"list_id":20}`, mockError: fmt.Errorf("%w: card 5 is blocked by open cards [7]", model.ErrConflict), expectCall: true, expectedStatus: http.StatusConflict},
+		{name: "invalid json", id: "5", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid id", id: "x", body: `{"list_id":20}`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("MoveCard", 5, model.CardMoveInput{ListID: 20}).Return(moved, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/"+tt.id+"/move", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.MoveCard(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.CardDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, 2, resp.BoardID)
+				require.Equal(t, 20, resp.ListID)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveCardLane(t *testing.T) {
+	mockService := new(MockCardService)
+	handler := NewCardHandler(mockService, zap.NewNop())
+	lane := 3
+	mockService.On("MoveCard", 5, model.CardMoveInput{ListID: 20, LaneID: &lane}).Return(model.Card{ID: 5, BoardID: 2, ListID: 20}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/cards/5/move", strings.NewReader(`{"list_id":20,"lane_id":3}`))
+	req.SetPathValue("id", "5")
+	rec := httptest.NewRecorder()
+	handler.MoveCard(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSetCardLane(t *testing.T) {
+	card := model.Card{ID: 5, BoardID: 2, ListID: 20, Title: "card"}
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		laneID         int
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", id: "5", body: `{"lane_id":3}`, laneID: 3, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "null clears lane", id: "5", body: `{"lane_id":null}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "lanes are not explicit", id: "5", body: `{"lane_id":3}`, laneID: 3, mockError: fmt.Errorf("%w: board 2 has no explicit lanes", model.ErrInvalidInput), expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "card not found", id: "5", body: `{"lane_id":3}`, laneID: 3, mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid json", id: "5", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid id", id: "x", body: `{"lane_id":3}`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("SetCardLane", 5, tt.laneID).Return(card, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/cards/"+tt.id+"/lane", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.SetCardLane(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveCardWIPWarning(t *testing.T) {
+	mockService := new(MockCardService)
+	handler := NewCardHandler(mockService, zap.NewNop())
+	warning := "list 20 is over its WIP limit of 3"
+	mockService.On("MoveCard", 5, model.CardMoveInput{ListID: 20}).
+		Return(model.Card{ID: 5, BoardID: 2, ListID: 20, Title: "card", Warnings: []string{warning}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/cards/5/move", strings.NewReader(`{"list_id":20}`))
+	req.SetPathValue("id", "5")
+	rec := httptest.NewRecorder()
+	handler.MoveCard(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `199 - "`+warning+`"`, rec.Header().Get("Warning"))
+	var resp dto.CardDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, []string{warning}, resp.Warnings)
+	mockService.AssertExpectations(t)
+}
+
+func TestCopyCard(t *testing.T) {
+	copied := model.Card{ID: 6, BoardID: 1, ListID: 10, Title: "copy"}
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		wantInput      model.CardCopyInput
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", id: "5", body: `{"list_id":10,"title":"copy"}`, wantInput: model.CardCopyInput{ListID: 10, Title: "copy"}, expectCall: true, expectedStatus: http.StatusCreated},
+		{name: "empty body", id: "5", expectCall: true, expectedStatus: http.StatusCreated},
+		{name: "invalid destination", id: "5", body: `{"list_id":99}`, wantInput: model.CardCopyInput{ListID: 99}, mockError: model.ErrInvalidInput, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "card not found", id: "5", body: `{}`, mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "wip limit reached", id: "5", body: `{}`, mockError: fmt.Errorf("%w: list 10 is at its WIP limit of 3", model.ErrConflict), expectCall: true, expectedStatus: http.StatusConflict},
+		{name: "service error", id: "5", body: `{}`, mockError: errors.New("fail"), expectCall: true, expectedStatus: http.StatusInternalServerError},
+		{name: "invalid id", id: "x", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("CopyCard", 5, tt.wantInput).Return(copied, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/"+tt.id+"/copy", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.CopyCard(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.CardDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, dto.CardToDTO(copied), resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetDueDate(t *testing.T) {
+	due := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		wantDue        *time.Time
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "set", id: "5", body: `{"due_at":"2026-03-01T09:00:00Z"}`, wantDue: &due, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "clear", id: "5", body: `{"due_at":null}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "card not found", id: "5", body: `{}`, mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid date", id: "5", body: `{"due_at":"tomorrow"}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid id", id: "x", body: `{}`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("SetDueDate", 5, tt.wantDue).Return(model.Card{ID: 5, BoardID: 1, ListID: 10, DueAt: tt.wantDue}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/cards/"+tt.id+"/due", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.SetDueDate(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.CardDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, tt.wantDue, resp.DueAt)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetFieldValue(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantValue      any
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "number", body: `{"value":3}`, wantValue: 3.0, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "clear", body: `{"value":null}`, expectCall: true, expectedStatus: http.StatusOK},
+		{
+			name:           "invalid value",
+			body:           `{"value":"three"}`,
+			wantValue:      "three",
+			mockError:      fmt.Errorf("%w: field expects a number", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "invalid json", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCardService)
+			handler := NewCardHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				value := model.CardFieldValue{CardID: 5, FieldID: 2}
+				if n, ok := tt.wantValue.(float64); ok {
+					value.Number = &n
+				}
+				mockService.On("SetFieldValue", 5, 2, tt.wantValue).Return(value, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/cards/5/fields/2", strings.NewReader(tt.body))
+			req.SetPathValue("id", "5")
+			req.SetPathValue("fieldID", "2")
+			rec := httptest.NewRecorder()
+			handler.SetFieldValue(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.CardFieldValueDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, dto.CardFieldValueDTO{FieldID: 2, Value: tt.wantValue}, resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
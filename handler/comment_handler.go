@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type CommentHandler struct {
+	service CommentService
+	logger  *zap.Logger
+}
+
+func NewCommentHandler(service CommentService, logger *zap.Logger) *CommentHandler {
+	return &CommentHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetComments обрабатывает GET /cards/{id}/comments.
+func (h *CommentHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	comments, err := h.service.GetComments(cardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения комментариев", zap.Int("cardID", cardID))
+		return
+	}
+	dtos := []dto.CommentDTO{}
+	for _, c := range comments {
+		dtos = append(dtos, dto.CommentToDTO(c))
+	}
+	writeJSON(w, h.logger, http.StatusOK, dtos)
+}
+
+// AddComment обрабатывает POST /cards/{id}/comments; автор — текущий
+// пользователь.
+func (h *CommentHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	author, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.CreateCommentDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	comment, err := h.service.AddComment(cardID, author, input.Text)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка добавления комментария", zap.Int("cardID", cardID), zap.String("author", author))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusCreated, dto.CommentToDTO(comment))
+}
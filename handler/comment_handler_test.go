@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAddComment(t *testing.T) {
+	commentID := 7
+	comment := model.Comment{
+		ID:       commentID,
+		CardID:   5,
+		Author:   "anna",
+		Text:     "@vera глянь",
+		Mentions: []model.Mention{{CardID: 5, CommentID: &commentID, Username: "vera", Author: "anna"}},
+	}
+	tests := []struct {
+		name           string
+		user           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", user: "anna", body: `{"text":"@vera глянь"}`, expectCall: true, expectedStatus: http.StatusCreated},
+		{
+			name:           "empty text",
+			user:           "anna",
+			body:           `{"text":"@vera глянь"}`,
+			mockError:      fmt.Errorf("%w: text is required", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "no user", body: `{"text":"hi"}`, expectedStatus: http.StatusUnauthorized},
+		{name: "invalid json", user: "anna", body: `{"text":`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCommentService)
+			handler := NewCommentHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("AddComment", 5, "anna", "@vera глянь").Return(comment, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/5/comments", strings.NewReader(tt.body))
+			req.SetPathValue("id", "5")
+			if tt.user != "" {
+				req.Header.Set("X-User", tt.user)
+			}
+			rec := httptest.NewRecorder()
+			handler.AddComment(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.CommentDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, "anna", resp.Author)
+				require.Equal(t, []dto.MentionDTO{{Username: "vera", Author: "anna", CommentID: &commentID}}, resp.Mentions)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	mockService := new(MockCommentService)
+	handler := NewCommentHandler(mockService, zap.NewNop())
+	mockService.On("GetComments", 5).Return([]model.Comment{{ID: 1, CardID: 5, Author: "anna", Text: "hi"}}, nil)
+	mockService.On("GetComments", 6).Return([]model.Comment(nil), fmt.Errorf("card 6: %w", model.ErrNotFound))
+
+	req := httptest.NewRequest(http.MethodGet, "/cards/5/comments", nil)
+	req.SetPathValue("id", "5")
+	rec := httptest.NewRecorder()
+	handler.GetComments(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp []dto.CommentDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp, 1)
+	require.Equal(t, []dto.MentionDTO{}, resp[0].Mentions)
+
+	req = httptest.NewRequest(http.MethodGet, "/cards/6/comments", nil)
+	req.SetPathValue("id", "6")
+	rec = httptest.NewRecorder()
+	handler.GetComments(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
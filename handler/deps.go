@@ -1,18 +1,129 @@
 package handler
 
-import "awesomeProject2/cmd/model"
+import (
+	"awesomeProject2/cmd/importexport"
+	"awesomeProject2/cmd/model"
+	"io"
+	"time"
+)
 
 type BoardService interface {
 	GetBoards() ([]model.Board, error)
 	CreateBoard(title string) (model.Board, error)
+	GetTemplates() ([]model.Board, error)
+	SetTemplate(id int, isTemplate bool) (model.Board, error)
+	CopyBoard(id int, input model.BoardCopyInput) (model.Board, error)
 }
 type ListService interface {
 	GetLists(boardID *int) ([]model.List, error)
 	CreateList(input model.ListInputCreate) (model.List, error)
+	SetWIPLimit(id int, limit model.ListWIPLimit) (model.List, error)
 }
 type CardService interface {
-	GetCards(boardID *int) ([]model.Card, error)
+	GetCards(filter model.CardFilter) ([]model.Card, error)
 	CreateCard(input model.CardInputCreate) (model.Card, error)
 	DeleteCard(listID int, cardID int) (model.Card, error)
 	UpdateCard(updated model.Card) (model.Card, error)
+	ArchiveCard(id int) (model.Card, error)
+	UnarchiveCard(id int) (model.Card, error)
+	RestoreCard(id int) (model.Card, error)
+	MoveCard(id int, input model.CardMoveInput) (model.Card, error)
+	CopyCard(id int, input model.CardCopyInput) (model.Card, error)
+	GetTrash(boardID int) ([]model.Card, error)
+	SetDueDate(id int, due *time.Time) (model.Card, error)
+	BulkCards(ops []model.BulkCardOperation, atomic bool) ([]model.BulkCardResult, error)
+	SetFieldValue(cardID, fieldID int, value any) (model.CardFieldValue, error)
+	SetCardLane(id int, laneID int) (model.Card, error)
+}
+type FieldService interface {
+	GetFields(boardID int) ([]model.CustomField, error)
+	CreateField(input model.CustomFieldInputCreate) (model.CustomField, error)
+	UpdateField(id int, update model.CustomFieldUpdate) (model.CustomField, error)
+	DeleteField(id int) error
+}
+type AutomationService interface {
+	GetRules(boardID int) ([]model.AutomationRule, error)
+	CreateRule(input model.AutomationRuleInput) (model.AutomationRule, error)
+	UpdateRule(id int, input model.AutomationRuleInput) (model.AutomationRule, error)
+	DeleteRule(id int) error
+	GetRuns(boardID int, limit int) ([]model.AutomationRun, error)
+}
+type RecurrenceService interface {
+	GetRecurrence(cardID int) (model.CardRecurrence, error)
+	SetRecurrence(cardID int, input model.CardRecurrenceInput) (model.CardRecurrence, error)
+	StopRecurrence(cardID int) error
+}
+type NotificationService interface {
+	GetInbox(filter model.NotificationFilter) (model.NotificationInbox, error)
+	MarkRead(id int, username string) (model.Notification, error)
+	MarkAllRead(username string) (int, error)
+	GetPreferences(username string) ([]model.NotificationPreference, error)
+	SetPreferences(username string, updates []model.NotificationPreferenceUpdate) ([]model.NotificationPreference, error)
+	GetEmailSettings(username string) (model.EmailSettings, error)
+	SetEmailSettings(settings model.EmailSettings) (model.EmailSettings, error)
+}
+type MemberService interface {
+	GetMembers(boardID int) ([]model.BoardMember, error)
+	AddMember(boardID int, username string) (model.BoardMember, error)
+	RemoveMember(boardID int, username string) error
+}
+type LinkService interface {
+	GetLinks(cardID int) ([]model.CardLink, error)
+	AddLink(cardID int, input model.CardLinkInput) (model.CardLink, error)
+	RemoveLink(cardID, linkID int) error
+}
+type WatchService interface {
+	Watch(username string, target model.WatchTarget) (model.Watch, error)
+	Unwatch(username string, target model.WatchTarget) error
+	GetWatching(username string) ([]model.Watch, error)
+	GetCardWatchers(cardID int) ([]string, error)
+}
+type CommentService interface {
+	GetComments(cardID int) ([]model.Comment, error)
+	AddComment(cardID int, author, text string) (model.Comment, error)
+}
+type ImportExportService interface {
+	Export(boardID int) (importexport.Document, error)
+	Import(data []byte) (model.Board, error)
+	ExportCardsCSV(boardID int, w io.Writer) error
+	ImportCardsCSV(listID int, r io.Reader) (importexport.CSVImportResult, error)
+}
+type ViewService interface {
+	Search(boardID int, username, q, sort string, desc bool) ([]model.Card, error)
+	GetViews(boardID int, username string) ([]model.View, error)
+	CreateView(input model.ViewInput) (model.View, error)
+	UpdateView(id int, username string, input model.ViewInput) (model.View, error)
+	DeleteView(id int, username string) error
+	ViewCards(id int, username string) (model.View, []model.CardGroup, error)
+}
+type CalendarService interface {
+	BoardCalendar(boardID int, from, to, tz string) ([]model.CalendarDay, error)
+	IssueToken(username string) (string, model.CalendarToken, error)
+	RevokeToken(username string) error
+	Feed(token string, w io.Writer) error
+}
+
+type LaneService interface {
+	Layout(boardID int) (model.BoardLayout, error)
+	SetSwimlanes(input model.Swimlanes) (model.Swimlanes, error)
+	GetLanes(boardID int) ([]model.Lane, error)
+	CreateLane(input model.LaneInputCreate) (model.Lane, error)
+	RenameLane(id int, title string) (model.Lane, error)
+	DeleteLane(id int) error
+	ReorderLanes(boardID int, ids []int) ([]model.Lane, error)
+}
+type MetricsService interface {
+	BoardMetrics(boardID int, input model.MetricsInput) (model.BoardMetrics, error)
+	SprintBurndown(sprintID int) (model.SprintBurndown, error)
+}
+type SprintService interface {
+	GetSprints(boardID int) ([]model.Sprint, error)
+	GetSprint(id int) (model.Sprint, error)
+	GetSprintCards(id int) ([]model.Card, error)
+	CreateSprint(input model.SprintInput) (model.Sprint, error)
+	UpdateSprint(id int, input model.SprintInput) (model.Sprint, error)
+	DeleteSprint(id int) error
+	StartSprint(id int) (model.Sprint, error)
+	CompleteSprint(id, nextID int) (model.SprintCompletion, error)
+	SetCardSprint(cardID, sprintID int) error
 }
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type FieldHandler struct {
+	service FieldService
+	logger  *zap.Logger
+}
+
+func NewFieldHandler(service FieldService, logger *zap.Logger) *FieldHandler {
+	return &FieldHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetFields обрабатывает GET /boards/{id}/fields.
+func (h *FieldHandler) GetFields(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	fields, err := h.service.GetFields(boardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения полей доски", zap.Int("boardID", boardID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.CustomFieldsToDTO(fields))
+}
+
+// CreateField обрабатывает POST /boards/{id}/fields.
+func (h *FieldHandler) CreateField(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.CreateCustomFieldDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	field, err := h.service.CreateField(model.CustomFieldInputCreate{BoardID: boardID, Name: input.Name, Type: input.Type, Options: input.Options})
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка создания поля доски", zap.Int("boardID", boardID), zap.Any("input", input))
+		return
+	}
+	h.logger.Info("Поле доски создано", zap.Int("boardID", boardID), zap.Int("fieldID", field.ID), zap.String("type", field.Type))
+	writeJSON(w, h.logger, http.StatusCreated, dto.CustomFieldToDTO(field))
+}
+
+// UpdateField обрабатывает PUT /fields/{id}.
+func (h *FieldHandler) UpdateField(w http.ResponseWriter, r *http.Request) {
+	fieldID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.UpdateCustomFieldDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	field, err := h.service.UpdateField(fieldID, model.CustomFieldUpdate{Name: input.Name, Options: input.Options})
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка изменения поля доски", zap.Int("fieldID", fieldID), zap.Any("input", input))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.CustomFieldToDTO(field))
+}
+
+// DeleteField обрабатывает DELETE /fields/{id}; значения на карточках
+// удаляются вместе с полем.
+func (h *FieldHandler) DeleteField(w http.ResponseWriter, r *http.Request) {
+	fieldID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteField(fieldID); err != nil {
+		fail(w, h.logger, err, "Ошибка удаления поля доски", zap.Int("fieldID", fieldID))
+		return
+	}
+	h.logger.Info("Поле доски удалено", zap.Int("fieldID", fieldID))
+	w.WriteHeader(http.StatusNoContent)
+}
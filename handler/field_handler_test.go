@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateField(t *testing.T) {
+	input := model.CustomFieldInputCreate{BoardID: 1, Name: "Priority", Type: model.FieldDropdown, Options: []string{"low", "high"}}
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", body: `{"name":"Priority","type":"dropdown","options":["low","high"]}`, expectCall: true, expectedStatus: http.StatusCreated},
+		{
+			name:           "duplicate name",
+			body:           `{"name":"Priority","type":"dropdown","options":["low","high"]}`,
+			mockError:      fmt.Errorf("%w: field already exists", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "board not found",
+			body:           `{"name":"Priority","type":"dropdown","options":["low","high"]}`,
+			mockError:      fmt.Errorf("board 1: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{name: "invalid json", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFieldService)
+			handler := NewFieldHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("CreateField", input).
+					Return(model.CustomField{ID: 4, BoardID: 1, Name: input.Name, Type: input.Type, Options: input.Options}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/boards/1/fields", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.CreateField(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.CustomFieldDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, 4, resp.ID)
+				require.Equal(t, []string{"low", "high"}, resp.Options)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetFields(t *testing.T) {
+	mockService := new(MockFieldService)
+	handler := NewFieldHandler(mockService, zap.NewNop())
+	mockService.On("GetFields", 1).Return([]model.CustomField{{ID: 1, BoardID: 1, Name: "Points", Type: model.FieldNumber}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/boards/1/fields", nil)
+	req.SetPathValue("id", "1")
+	rec := httptest.NewRecorder()
+	handler.GetFields(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp []dto.CustomFieldDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp, 1)
+	require.Equal(t, model.FieldNumber, resp[0].Type)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteField(t *testing.T) {
+	mockService := new(MockFieldService)
+	handler := NewFieldHandler(mockService, zap.NewNop())
+	mockService.On("DeleteField", 1).Return(nil)
+	mockService.On("DeleteField", 2).Return(fmt.Errorf("field 2: %w", model.ErrNotFound))
+
+	for id, status := range map[string]int{"1": http.StatusNoContent, "2": http.StatusNotFound, "x": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodDelete, "/fields/"+id, nil)
+		req.SetPathValue("id", id)
+		rec := httptest.NewRecorder()
+		handler.DeleteField(rec, req)
+		require.Equal(t, status, rec.Code, id)
+	}
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/importexport"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+const maxImportSize = 32 << 20
+
+type ImportExportHandler struct {
+	service ImportExportService
+	logger  *zap.Logger
+}
+
+func NewImportExportHandler(service ImportExportService, logger *zap.Logger) *ImportExportHandler {
+	return &ImportExportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ExportBoard обрабатывает GET /boards/{id}/export.
+func (h *ImportExportHandler) ExportBoard(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	doc, err := h.service.Export(boardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка экспорта доски", zap.Int("boardID", boardID))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="board-%d.json"`, boardID))
+	if err := json.NewEncoder(w).Encode(doc); err != nil {
+		h.logger.Error("Ошибка кодирования ответа(EXPORT)", zap.Error(err), zap.Int("boardID", boardID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ImportBoard обрабатывает POST /boards/import: принимает собственный
+// формат выгрузки или JSON-экспорт доски из Trello.
+func (h *ImportExportHandler) ImportBoard(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		h.logger.Error("Ошибка чтения запроса(IMPORT)", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	board, err := h.service.Import(data)
+	if errors.Is(err, importexport.ErrInvalidDocument) {
+		h.logger.Warn("Некорректный файл импорта", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка импорта доски")
+		return
+	}
+	resp := dto.BoardToDTO(board)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("Ошибка кодирования ответа(IMPORT)", zap.Error(err), zap.Any("dto", resp))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ExportCardsCSV обрабатывает GET /boards/{id}/cards.csv.
+func (h *ImportExportHandler) ExportCardsCSV(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="board-%d-cards.csv"`, boardID))
+	if err := h.service.ExportCardsCSV(boardID, w); err != nil {
+		// Часть файла уже могла уйти клиенту, статус тогда не поменять.
+		fail(w, h.logger, err, "Ошибка экспорта карточек в CSV", zap.Int("boardID", boardID))
+	}
+}
+
+// ImportCardsCSV обрабатывает POST /lists/{id}/cards/import. Ошибки в
+// отдельных строках возвращаются в ответе вместе с созданными карточками.
+func (h *ImportExportHandler) ImportCardsCSV(w http.ResponseWriter, r *http.Request) {
+	listID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+	result, err := h.service.ImportCardsCSV(listID, http.MaxBytesReader(w, r.Body, maxImportSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.Is(err, importexport.ErrInvalidCSV) || errors.As(err, &maxBytesErr) {
+		h.logger.Warn("Некорректный CSV-файл", zap.Error(err), zap.Int("listID", listID))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка импорта карточек из CSV", zap.Int("listID", listID))
+		return
+	}
+	resp := dto.CardImportResultDTO{
+		Created: []dto.CardDTO{},
+		Errors:  []dto.CardImportErrorDTO{},
+	}
+	for _, c := range result.Created {
+		resp.Created = append(resp.Created, dto.CardToDTO(c))
+	}
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, dto.CardImportErrorDTO{Row: e.Row, Message: e.Message})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("Ошибка кодирования ответа(CSV IMPORT)", zap.Error(err), zap.Int("listID", listID))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/importexport"
+	"awesomeProject2/cmd/model"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExportBoard(t *testing.T) {
+	doc := importexport.Document{
+		Format:  importexport.FormatName,
+		Version: importexport.FormatVersion,
+		Board:   importexport.BoardDoc{ID: 1, Title: "Board"},
+		Lists:   []importexport.ListDoc{{ID: 1, Title: "To Do"}},
+	}
+	tests := []struct {
+		name           string
+		id             string
+		mockResult     importexport.Document
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			id:             "1",
+			mockResult:     doc,
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid id",
+			id:             "abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not found",
+			id:             "1",
+			mockError:      fmt.Errorf("board 1: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			id:             "1",
+			mockError:      errors.New("fail"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockImportExportService)
+			handler := NewImportExportHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("Export", 1).Return(tt.mockResult, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/boards/"+tt.id+"/export", nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.ExportBoard(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				require.Contains(t, rec.Header().Get("Content-Disposition"), "board-1.json")
+				var resp importexport.Document
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, tt.mockResult, resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportBoard(t *testing.T) {
+	body := []byte(`{"name": "Trello board", "lists": []}`)
+	tests := []struct {
+		name           string
+		mockResult     model.Board
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			mockResult:     model.Board{ID: 7, Title: "Trello board"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid document",
+			mockError:      fmt.Errorf("%w: unknown format", importexport.ErrInvalidDocument),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			mockError:      errors.New("fail"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockImportExportService)
+			handler := NewImportExportHandler(mockService, zap.NewNop())
+			mockService.On("Import", body).Return(tt.mockResult, tt.mockError)
+
+			req := httptest.NewRequest(http.MethodPost, "/boards/import", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+			handler.ImportBoard(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.BoardDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, dto.BoardToDTO(tt.mockResult), resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExportCardsCSV(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		mockResult     func(io.Writer) error
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			mockResult: func(w io.Writer) error {
+				_, err := io.WriteString(w, "id,title\n1,card\n")
+				return err
+			},
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid id",
+			id:             "abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not found",
+			id:             "1",
+			mockError:      fmt.Errorf("board 1: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockImportExportService)
+			handler := NewImportExportHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				if tt.mockResult != nil {
+					mockService.On("ExportCardsCSV", 1, mock.Anything).Return(tt.mockResult)
+				} else {
+					mockService.On("ExportCardsCSV", 1, mock.Anything).Return(tt.mockError)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/boards/"+tt.id+"/cards.csv", nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.ExportCardsCSV(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+				require.Equal(t, "id,title\n1,card\n", rec.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportCardsCSV(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		mockResult     importexport.CSVImportResult
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+		expectedBody   dto.CardImportResultDTO
+	}{
+		{
+			name: "success with row errors",
+			id:   "3",
+			mockResult: importexport.CSVImportResult{
+				Created: []model.Card{{ID: 10, ListID: 3, Title: "ok"}},
+				Errors:  []importexport.RowError{{Row: 3, Message: "invalid input: title is required"}},
+			},
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+			expectedBody: dto.CardImportResultDTO{
+				Created: []dto.CardDTO{dto.CardToDTO(model.Card{ID: 10, ListID: 3, Title: "ok"})},
+				Errors:  []dto.CardImportErrorDTO{{Row: 3, Message: "invalid input: title is required"}},
+			},
+		},
+		{
+			name:           "invalid id",
+			id:             "x",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "list not found",
+			id:             "3",
+			mockError:      fmt.Errorf("list 3: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "bad header",
+			id:             "3",
+			mockError:      fmt.Errorf("%w: title column is required", importexport.ErrInvalidCSV),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			id:             "3",
+			mockError:      errors.New("fail"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockImportExportService)
+			handler := NewImportExportHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("ImportCardsCSV", 3, mock.Anything).Return(tt.mockResult, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/lists/"+tt.id+"/cards/import", strings.NewReader("title\nok\n\n"))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.ImportCardsCSV(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.CardImportResultDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, tt.expectedBody, resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type LaneHandler struct {
+	service LaneService
+	logger  *zap.Logger
+}
+
+func NewLaneHandler(service LaneService, logger *zap.Logger) *LaneHandler {
+	return &LaneHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetBoard обрабатывает GET /boards/{id}: доска с карточками в виде
+// матрицы дорожек и списков.
+func (h *LaneHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	layout, err := h.service.Layout(boardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения доски", zap.Int("boardID", boardID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.BoardLayoutToDTO(layout))
+}
+
+// SetSwimlanes обрабатывает PUT /boards/{id}/swimlanes.
+func (h *LaneHandler) SetSwimlanes(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.SwimlanesDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	swimlanes, err := h.service.SetSwimlanes(model.Swimlanes{BoardID: boardID, Mode: input.Mode, FieldID: input.FieldID})
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка настройки дорожек", zap.Int("boardID", boardID), zap.Any("input", input))
+		return
+	}
+	h.logger.Info("Дорожки доски настроены", zap.Int("boardID", boardID), zap.String("mode", swimlanes.Mode))
+	writeJSON(w, h.logger, http.StatusOK, dto.SwimlanesToDTO(swimlanes))
+}
+
+// GetLanes обрабатывает GET /boards/{id}/lanes: явные дорожки по порядку.
+func (h *LaneHandler) GetLanes(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	lanes, err := h.service.GetLanes(boardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения дорожек", zap.Int("boardID", boardID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.LanesToDTO(lanes))
+}
+
+// CreateLane обрабатывает POST /boards/{id}/lanes.
+func (h *LaneHandler) CreateLane(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.LaneInputDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	lane, err := h.service.CreateLane(model.LaneInputCreate{BoardID: boardID, Title: input.Title})
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка создания дорожки", zap.Int("boardID", boardID), zap.Any("input", input))
+		return
+	}
+	h.logger.Info("Дорожка создана", zap.Int("boardID", boardID), zap.Int("laneID", lane.ID))
+	writeJSON(w, h.logger, http.StatusCreated, dto.LaneToDTO(lane))
+}
+
+// ReorderLanes обрабатывает PUT /boards/{id}/lanes/order.
+func (h *LaneHandler) ReorderLanes(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.LaneOrderDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	lanes, err := h.service.ReorderLanes(boardID, input.LaneIDs)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка изменения порядка дорожек", zap.Int("boardID", boardID), zap.Ints("laneIDs", input.LaneIDs))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.LanesToDTO(lanes))
+}
+
+// RenameLane обрабатывает PUT /lanes/{id}.
+func (h *LaneHandler) RenameLane(w http.ResponseWriter, r *http.Request) {
+	laneID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.LaneInputDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	lane, err := h.service.RenameLane(laneID, input.Title)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка переименования дорожки", zap.Int("laneID", laneID), zap.Any("input", input))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.LaneToDTO(lane))
+}
+
+// DeleteLane обрабатывает DELETE /lanes/{id}; карточки дорожки остаются
+// на доске.
+func (h *LaneHandler) DeleteLane(w http.ResponseWriter, r *http.Request) {
+	laneID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteLane(laneID); err != nil {
+		fail(w, h.logger, err, "Ошибка удаления дорожки", zap.Int("laneID", laneID))
+		return
+	}
+	h.logger.Info("Дорожка удалена", zap.Int("laneID", laneID))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetBoardLayout(t *testing.T) {
+	lane := 4
+	layout := model.BoardLayout{
+		Board:     model.Board{ID: 1, Title: "b"},
+		Swimlanes: model.Swimlanes{BoardID: 1, Mode: model.LaneExplicit},
+		Lists:     []model.List{{ID: 10, BoardID: 1, Title: "todo"}},
+		Lanes: []model.BoardLane{
+			{LaneID: &lane, Title: "Urgent", Cells: []model.LaneCell{{ListID: 10, Cards: []model.Card{{ID: 7, BoardID: 1, ListID: 10}}}}},
+			{Cells: []model.LaneCell{{ListID: 10, Cards: []model.Card{}}}},
+		},
+	}
+	tests := []struct {
+		name           string
+		id             string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", id: "1", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "board not found", id: "1", mockError: fmt.Errorf("board 1: %w", model.ErrNotFound), expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "service error", id: "1", mockError: errors.New("fail"), expectCall: true, expectedStatus: http.StatusInternalServerError},
+		{name: "invalid id", id: "x", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockLaneService)
+			handler := NewLaneHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("Layout", 1).Return(layout, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/boards/"+tt.id, nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.GetBoard(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.BoardLayoutDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, "b", resp.Title)
+				require.Equal(t, model.LaneExplicit, resp.Swimlanes.Mode)
+				require.Len(t, resp.Lists, 1)
+				require.Len(t, resp.Lanes, 2)
+				require.Equal(t, &lane, resp.Lanes[0].LaneID)
+				require.Equal(t, 7, *resp.Lanes[0].Cells[0].Cards[0].ID)
+				require.Nil(t, resp.Lanes[1].LaneID)
+				require.Empty(t, resp.Lanes[1].Cells[0].Cards)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetSwimlanes(t *testing.T) {
+	field := 3
+	tests := []struct {
+		name           string
+		body           string
+		input          *model.Swimlanes
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "by field",
+			body:           `{"mode":"field","field_id":3}`,
+			input:          &model.Swimlanes{BoardID: 1, Mode: model.LaneField, FieldID: &field},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown mode",
+			body:           `{"mode":"status"}`,
+			input:          &model.Swimlanes{BoardID: 1, Mode: "status"},
+			mockError:      fmt.Errorf("%w: mode must be empty or one of assignee, label, field, explicit", model.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "invalid json", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockLaneService)
+			handler := NewLaneHandler(mockService, zap.NewNop())
+			if tt.input != nil {
+				mockService.On("SetSwimlanes", *tt.input).Return(*tt.input, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/boards/1/swimlanes", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.SetSwimlanes(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.SwimlanesDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, dto.SwimlanesDTO{Mode: model.LaneField, FieldID: &field}, resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateLane(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", body: `{"title":"Urgent"}`, expectCall: true, expectedStatus: http.StatusCreated},
+		{name: "empty title", body: `{"title":"Urgent"}`, mockError: model.ErrInvalidInput, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "board not found", body: `{"title":"Urgent"}`, mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid json", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockLaneService)
+			handler := NewLaneHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("CreateLane", model.LaneInputCreate{BoardID: 1, Title: "Urgent"}).
+					Return(model.Lane{ID: 4, BoardID: 1, Title: "Urgent"}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/boards/1/lanes", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.CreateLane(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.LaneDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, 4, resp.ID)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReorderLanes(t *testing.T) {
+	mockService := new(MockLaneService)
+	handler := NewLaneHandler(mockService, zap.NewNop())
+	mockService.On("ReorderLanes", 1, []int{5, 4}).Return([]model.Lane{{ID: 5, Position: 0}, {ID: 4, Position: 1}}, nil)
+	mockService.On("ReorderLanes", 1, []int{5}).Return([]model.Lane(nil), model.ErrInvalidInput)
+
+	req := httptest.NewRequest(http.MethodPut, "/boards/1/lanes/order", strings.NewReader(`{"lane_ids":[5,4]}`))
+	req.SetPathValue("id", "1")
+	rec := httptest.NewRecorder()
+	handler.ReorderLanes(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp []dto.LaneDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, 5, resp[0].ID)
+
+	req = httptest.NewRequest(http.MethodPut, "/boards/1/lanes/order", strings.NewReader(`{"lane_ids":[5]}`))
+	req.SetPathValue("id", "1")
+	rec = httptest.NewRecorder()
+	handler.ReorderLanes(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteLane(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", id: "4", expectCall: true, expectedStatus: http.StatusNoContent},
+		{name: "not found", id: "4", mockError: fmt.Errorf("lane 4: %w", model.ErrNotFound), expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid id", id: "x", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockLaneService)
+			handler := NewLaneHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("DeleteLane", 4).Return(tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/lanes/"+tt.id, nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.DeleteLane(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
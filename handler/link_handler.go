@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type LinkHandler struct {
+	service LinkService
+	logger  *zap.Logger
+}
+
+func NewLinkHandler(service LinkService, logger *zap.Logger) *LinkHandler {
+	return &LinkHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetLinks обрабатывает GET /cards/{id}/links.
+func (h *LinkHandler) GetLinks(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	links, err := h.service.GetLinks(cardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения связей карточки", zap.Int("cardID", cardID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.CardLinksToDTO(cardID, links))
+}
+
+// AddLink обрабатывает POST /cards/{id}/links.
+func (h *LinkHandler) AddLink(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.CreateCardLinkDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	link, err := h.service.AddLink(cardID, model.CardLinkInput{Type: input.Type, CardID: input.CardID})
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка добавления связи карточек", zap.Int("cardID", cardID), zap.Any("input", input))
+		return
+	}
+	h.logger.Info("Карточки связаны", zap.Int("source", link.SourceID), zap.String("type", link.Type), zap.Int("target", link.TargetID))
+	writeJSON(w, h.logger, http.StatusCreated, dto.CardLinkToDTO(cardID, link))
+}
+
+// RemoveLink обрабатывает DELETE /cards/{id}/links/{linkID}.
+func (h *LinkHandler) RemoveLink(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	linkID, ok := pathInt(w, r, h.logger, "linkID")
+	if !ok {
+		return
+	}
+	if err := h.service.RemoveLink(cardID, linkID); err != nil {
+		fail(w, h.logger, err, "Ошибка удаления связи карточек", zap.Int("cardID", cardID), zap.Int("linkID", linkID))
+		return
+	}
+	h.logger.Info("Связь карточек удалена", zap.Int("cardID", cardID), zap.Int("linkID", linkID))
+	w.WriteHeader(http.StatusNoContent)
+}
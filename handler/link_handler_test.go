@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAddLink(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", body: `{"type":"blocked_by","card_id":2}`, expectCall: true, expectedStatus: http.StatusCreated},
+		{
+			name:           "cycle",
+			body:           `{"type":"blocked_by","card_id":2}`,
+			mockError:      fmt.Errorf("%w: would create a cycle", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "card not found",
+			body:           `{"type":"blocked_by","card_id":2}`,
+			mockError:      fmt.Errorf("card 2: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{name: "invalid json", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockLinkService)
+			handler := NewLinkHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("AddLink", 1, model.CardLinkInput{Type: model.LinkBlockedBy, CardID: 2}).
+					Return(model.CardLink{ID: 9, SourceID: 2, TargetID: 1, Type: model.LinkBlocks}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/1/links", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.AddLink(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.CardLinkDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, dto.CardLinkDTO{ID: 9, Type: model.LinkBlockedBy, CardID: 2}, resp, "link is shown from the requested card")
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetLinks(t *testing.T) {
+	mockService := new(MockLinkService)
+	handler := NewLinkHandler(mockService, zap.NewNop())
+	mockService.On("GetLinks", 2).Return([]model.CardLink{
+		{ID: 1, SourceID: 2, TargetID: 3, Type: model.LinkBlocks},
+		{ID: 2, SourceID: 4, TargetID: 2, Type: model.LinkParentOf},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/cards/2/links", nil)
+	req.SetPathValue("id", "2")
+	rec := httptest.NewRecorder()
+	handler.GetLinks(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp []dto.CardLinkDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, []dto.CardLinkDTO{
+		{ID: 1, Type: model.LinkBlocks, CardID: 3},
+		{ID: 2, Type: model.LinkChildOf, CardID: 4},
+	}, resp)
+	mockService.AssertExpectations(t)
+}
+
+func TestRemoveLink(t *testing.T) {
+	mockService := new(MockLinkService)
+	handler := NewLinkHandler(mockService, zap.NewNop())
+	mockService.On("RemoveLink", 1, 9).Return(nil)
+	mockService.On("RemoveLink", 1, 10).Return(fmt.Errorf("card link 10 of card 1: %w", model.ErrNotFound))
+
+	for linkID, status := range map[string]int{"9": http.StatusNoContent, "10": http.StatusNotFound, "x": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodDelete, "/cards/1/links/"+linkID, nil)
+		req.SetPathValue("id", "1")
+		req.SetPathValue("linkID", linkID)
+		rec := httptest.NewRecorder()
+		handler.RemoveLink(rec, req)
+		require.Equal(t, status, rec.Code, linkID)
+	}
+	mockService.AssertExpectations(t)
+}
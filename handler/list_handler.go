@@ -61,12 +61,13 @@ func (h *ListHandler) HandleLists(w http.ResponseWriter, r *http.Request) {
 		}
 
 		list, err := h.service.CreateList(model.ListInputCreate{
-			BoardID: input.BoardID,
-			Title:   input.Title,
+			BoardID:  input.BoardID,
+			Title:    input.Title,
+			WIPLimit: input.WIPLimit,
+			WIPSoft:  input.WIPSoft,
 		})
 		if err != nil {
-			h.logger.Error("Ошибка создания листов", zap.Error(err), zap.Any("input", input))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			fail(w, h.logger, err, "Ошибка создания листов", zap.Any("input", input))
 			return
 		}
 		dto := dto.ListToDTO(list)
@@ -83,3 +84,21 @@ func (h *ListHandler) HandleLists(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// SetWIPLimit обрабатывает PUT /lists/{id}/wip-limit.
+func (h *ListHandler) SetWIPLimit(w http.ResponseWriter, r *http.Request) {
+	listID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.WIPLimitDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	list, err := h.service.SetWIPLimit(listID, model.ListWIPLimit{Limit: input.Limit, Soft: input.Soft})
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка изменения лимита списка", zap.Int("listID", listID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.ListToDTO(list))
+}
@@ -7,11 +7,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -195,3 +197,47 @@ func TestHandleLists_MethodNotAllowed(t *testing.T) {
 	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
 	require.Contains(t, rec.Body.String(), "Method not allowed")
 }
+
+func TestSetWIPLimit(t *testing.T) {
+	three := 3
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		wantLimit      *model.ListWIPLimit
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "set soft limit", id: "4", body: `{"limit":3,"soft":true}`, wantLimit: &model.ListWIPLimit{Limit: &three, Soft: true}, expectedStatus: http.StatusOK},
+		{name: "clear limit", id: "4", body: `{"limit":null}`, wantLimit: &model.ListWIPLimit{}, expectedStatus: http.StatusOK},
+		{name: "invalid limit", id: "4", body: `{"limit":0}`, wantLimit: &model.ListWIPLimit{Limit: helper.GetPointer(0)}, mockError: fmt.Errorf("%w: wip limit must be positive", model.ErrInvalidInput), expectedStatus: http.StatusBadRequest},
+		{name: "list not found", id: "4", body: `{}`, wantLimit: &model.ListWIPLimit{}, mockError: fmt.Errorf("list 4: %w", model.ErrNotFound), expectedStatus: http.StatusNotFound},
+		{name: "invalid json", id: "4", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid id", id: "x", body: `{}`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockListService)
+			handler := NewListHandler(mockService, zap.NewNop())
+			if tt.wantLimit != nil {
+				mockService.On("SetWIPLimit", 4, *tt.wantLimit).
+					Return(model.List{ID: 4, BoardID: 1, Title: "doing", WIPLimit: tt.wantLimit.Limit, WIPSoft: tt.wantLimit.Soft, CardCount: 2}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/lists/"+tt.id+"/wip-limit", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.SetWIPLimit(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.ListDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, tt.wantLimit.Limit, resp.WIPLimit)
+				require.Equal(t, tt.wantLimit.Soft, resp.WIPSoft)
+				require.Equal(t, 2, resp.CardCount)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
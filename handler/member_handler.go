@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type MemberHandler struct {
+	service MemberService
+	logger  *zap.Logger
+}
+
+func NewMemberHandler(service MemberService, logger *zap.Logger) *MemberHandler {
+	return &MemberHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetMembers обрабатывает GET /boards/{id}/members.
+func (h *MemberHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	members, err := h.service.GetMembers(boardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения участников доски", zap.Int("boardID", boardID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.BoardMembersToDTO(members))
+}
+
+// AddMember обрабатывает PUT /boards/{id}/members/{username}.
+func (h *MemberHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	username := r.PathValue("username")
+	member, err := h.service.AddMember(boardID, username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка добавления участника доски", zap.Int("boardID", boardID), zap.String("username", username))
+		return
+	}
+	h.logger.Info("Участник добавлен на доску", zap.Int("boardID", boardID), zap.String("username", username))
+	writeJSON(w, h.logger, http.StatusOK, dto.BoardMemberDTO{Username: member.Username, AddedAt: member.AddedAt})
+}
+
+// RemoveMember обрабатывает DELETE /boards/{id}/members/{username}.
+func (h *MemberHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	username := r.PathValue("username")
+	if err := h.service.RemoveMember(boardID, username); err != nil {
+		fail(w, h.logger, err, "Ошибка удаления участника доски", zap.Int("boardID", boardID), zap.String("username", username))
+		return
+	}
+	h.logger.Info("Участник удалён с доски", zap.Int("boardID", boardID), zap.String("username", username))
+	w.WriteHeader(http.StatusNoContent)
+}
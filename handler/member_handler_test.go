@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAddMember(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		username       string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", id: "3", username: "anna", expectCall: true, expectedStatus: http.StatusOK},
+		{
+			name:           "invalid username",
+			id:             "3",
+			username:       "an%20na",
+			mockError:      fmt.Errorf("%w: invalid username", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "board not found",
+			id:             "3",
+			username:       "anna",
+			mockError:      fmt.Errorf("board 3: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{name: "invalid id", id: "x", username: "anna", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMemberService)
+			handler := NewMemberHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("AddMember", 3, tt.username).Return(model.BoardMember{BoardID: 3, Username: tt.username}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/boards/"+tt.id+"/members/"+tt.username, nil)
+			req.SetPathValue("id", tt.id)
+			req.SetPathValue("username", tt.username)
+			rec := httptest.NewRecorder()
+			handler.AddMember(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.BoardMemberDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, "anna", resp.Username)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	mockService := new(MockMemberService)
+	handler := NewMemberHandler(mockService, zap.NewNop())
+	mockService.On("RemoveMember", 3, "anna").Return(nil)
+	mockService.On("RemoveMember", 3, "boris").Return(fmt.Errorf("member boris of board 3: %w", model.ErrNotFound))
+
+	for username, status := range map[string]int{"anna": http.StatusNoContent, "boris": http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodDelete, "/boards/3/members/"+username, nil)
+		req.SetPathValue("id", "3")
+		req.SetPathValue("username", username)
+		rec := httptest.NewRecorder()
+		handler.RemoveMember(rec, req)
+		require.Equal(t, status, rec.Code, username)
+	}
+	mockService.AssertExpectations(t)
+}
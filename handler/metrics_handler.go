@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type MetricsHandler struct {
+	service MetricsService
+	logger  *zap.Logger
+}
+
+func NewMetricsHandler(service MetricsService, logger *zap.Logger) *MetricsHandler {
+	return &MetricsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetBoardMetrics обрабатывает
+// GET /boards/{id}/metrics?from=&to=&tz=&started=1,2&done=3.
+func (h *MetricsHandler) GetBoardMetrics(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	input := model.MetricsInput{From: query.Get("from"), To: query.Get("to"), TZ: query.Get("tz")}
+	var err error
+	if input.StartedLists, err = listIDs(query.Get("started")); err != nil {
+		http.Error(w, "started must be a comma-separated list of list ids", http.StatusBadRequest)
+		return
+	}
+	if input.DoneLists, err = listIDs(query.Get("done")); err != nil {
+		http.Error(w, "done must be a comma-separated list of list ids", http.StatusBadRequest)
+		return
+	}
+	metrics, err := h.service.BoardMetrics(boardID, input)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка расчёта метрик доски", zap.Int("boardID", boardID), zap.String("query", r.URL.RawQuery))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.MetricsToDTO(metrics))
+}
+
+// GetSprintBurndown обрабатывает GET /sprints/{id}/burndown.
+func (h *MetricsHandler) GetSprintBurndown(w http.ResponseWriter, r *http.Request) {
+	sprintID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	burndown, err := h.service.SprintBurndown(sprintID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка расчёта диаграммы сгорания", zap.Int("sprintID", sprintID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.BurndownToDTO(burndown))
+}
+
+func listIDs(value string) ([]int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var ids []int
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
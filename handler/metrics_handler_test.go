@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetBoardMetrics(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	metrics := model.BoardMetrics{
+		From:         day,
+		To:           day.AddDate(0, 0, 6),
+		StartedLists: []int{2},
+		DoneLists:    []int{3},
+		LeadTime:     model.DurationStats{Count: 2, Average: 90 * time.Minute, Median: 90 * time.Minute, P85: 2 * time.Hour},
+		Throughput:   []model.WeekThroughput{{WeekStart: day, Cards: 2}},
+		Flow:         []model.FlowDay{{Date: day, Lists: []model.ListCount{{ListID: 2, Cards: 1}}}},
+	}
+	tests := []struct {
+		name           string
+		query          string
+		input          *model.MetricsInput
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			query:          "from=2026-03-02&to=2026-03-08&tz=UTC&started=2&done=3,%204",
+			input:          &model.MetricsInput{From: "2026-03-02", To: "2026-03-08", TZ: "UTC", StartedLists: []int{2}, DoneLists: []int{3, 4}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "defaults",
+			input:          &model.MetricsInput{},
+			expectedStatus: http.StatusOK,
+		},
+		{name: "bad list id", query: "done=x", expectedStatus: http.StatusBadRequest},
+		{
+			name:           "foreign list",
+			query:          "done=9",
+			input:          &model.MetricsInput{DoneLists: []int{9}},
+			mockError:      fmt.Errorf("%w: list 9 is not on board 1", model.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "board not found",
+			input:          &model.MetricsInput{},
+			mockError:      fmt.Errorf("board 1: %w", model.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMetricsService)
+			handler := NewMetricsHandler(mockService, zap.NewNop())
+			if tt.input != nil {
+				mockService.On("BoardMetrics", 1, *tt.input).Return(metrics, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/boards/1/metrics?"+tt.query, nil)
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.GetBoardMetrics(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.BoardMetricsDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, "2026-03-08", resp.To)
+				require.Equal(t, dto.DurationStatsDTO{Count: 2, AverageHours: 1.5, MedianHours: 1.5, P85Hours: 2}, resp.LeadTime)
+				require.Equal(t, []dto.WeekThroughputDTO{{WeekStart: "2026-03-02", Cards: 2}}, resp.Throughput)
+				require.Equal(t, []dto.ListCountDTO{{ListID: 2, Cards: 1}}, resp.CumulativeFlow[0].Lists)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetSprintBurndown(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	burndown := model.SprintBurndown{
+		Sprint:    model.Sprint{ID: 3, StartDate: day, EndDate: day.AddDate(0, 0, 3), Status: model.SprintActive},
+		DoneLists: []int{4},
+		Days:      []model.BurndownDay{{Date: day, Scope: 3, Remaining: 3, Ideal: 3}, {Date: day.AddDate(0, 0, 1), Scope: 3, Done: 1, Remaining: 2, Ideal: 4.0 / 3}},
+	}
+	mockService := new(MockMetricsService)
+	handler := NewMetricsHandler(mockService, zap.NewNop())
+	mockService.On("SprintBurndown", 3).Return(burndown, nil)
+	mockService.On("SprintBurndown", 9).Return(model.SprintBurndown{}, fmt.Errorf("sprint 9: %w", model.ErrNotFound))
+
+	req := httptest.NewRequest(http.MethodGet, "/sprints/3/burndown", nil)
+	req.SetPathValue("id", "3")
+	rec := httptest.NewRecorder()
+	handler.GetSprintBurndown(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.SprintBurndownDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, "2026-03-05", resp.Sprint.EndDate)
+	require.Equal(t, dto.BurndownDayDTO{Date: "2026-03-03", Scope: 3, Done: 1, Remaining: 2, Ideal: 1.33}, resp.Days[1])
+
+	req = httptest.NewRequest(http.MethodGet, "/sprints/9/burndown", nil)
+	req.SetPathValue("id", "9")
+	rec = httptest.NewRecorder()
+	handler.GetSprintBurndown(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
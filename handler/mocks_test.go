@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"awesomeProject2/cmd/importexport"
 	"awesomeProject2/cmd/model"
 	"github.com/stretchr/testify/mock"
+	"io"
+	"time"
 )
 
 type MockBoardService struct {
@@ -14,6 +17,15 @@ type MockListService struct {
 type MockCardService struct {
 	mock.Mock
 }
+type MockAutomationService struct {
+	mock.Mock
+}
+type MockRecurrenceService struct {
+	mock.Mock
+}
+type MockNotificationService struct {
+	mock.Mock
+}
 
 func (m *MockBoardService) CreateBoard(title string) (model.Board, error) {
 	args := m.Called(title)
@@ -23,10 +35,26 @@ func (m *MockBoardService) GetBoards() ([]model.Board, error) {
 	args := m.Called()
 	return args.Get(0).([]model.Board), args.Error(1)
 }
+func (m *MockBoardService) GetTemplates() ([]model.Board, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Board), args.Error(1)
+}
+func (m *MockBoardService) SetTemplate(id int, isTemplate bool) (model.Board, error) {
+	args := m.Called(id, isTemplate)
+	return args.Get(0).(model.Board), args.Error(1)
+}
+func (m *MockBoardService) CopyBoard(id int, input model.BoardCopyInput) (model.Board, error) {
+	args := m.Called(id, input)
+	return args.Get(0).(model.Board), args.Error(1)
+}
 func (m *MockListService) CreateList(input model.ListInputCreate) (model.List, error) {
 	args := m.Called(input)
 	return args.Get(0).(model.List), args.Error(1)
 }
+func (m *MockListService) SetWIPLimit(id int, limit model.ListWIPLimit) (model.List, error) {
+	args := m.Called(id, limit)
+	return args.Get(0).(model.List), args.Error(1)
+}
 func (m *MockListService) GetLists(BoardID *int) ([]model.List, error) {
 	args := m.Called(BoardID)
 	return args.Get(0).([]model.List), args.Error(1)
@@ -35,8 +63,36 @@ func (m *MockCardService) CreateCard(input model.CardInputCreate) (model.Card, e
 	args := m.Called(input)
 	return args.Get(0).(model.Card), args.Error(1)
 }
-func (m *MockCardService) GetCards(ListID *int) ([]model.Card, error) {
-	args := m.Called(ListID)
+func (m *MockCardService) GetCards(filter model.CardFilter) ([]model.Card, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]model.Card), args.Error(1)
+}
+func (m *MockCardService) ArchiveCard(id int) (model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) UnarchiveCard(id int) (model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) RestoreCard(id int) (model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) MoveCard(id int, input model.CardMoveInput) (model.Card, error) {
+	args := m.Called(id, input)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) CopyCard(id int, input model.CardCopyInput) (model.Card, error) {
+	args := m.Called(id, input)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) SetDueDate(id int, due *time.Time) (model.Card, error) {
+	args := m.Called(id, due)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) GetTrash(boardID int) ([]model.Card, error) {
+	args := m.Called(boardID)
 	return args.Get(0).([]model.Card), args.Error(1)
 }
 func (m *MockCardService) DeleteCard(listID, cardID int) (model.Card, error) {
@@ -47,3 +103,330 @@ func (m *MockCardService) UpdateCard(updated model.Card) (model.Card, error) {
 	args := m.Called(updated)
 	return args.Get(0).(model.Card), args.Error(1)
 }
+func (m *MockCardService) BulkCards(ops []model.BulkCardOperation, atomic bool) ([]model.BulkCardResult, error) {
+	args := m.Called(ops, atomic)
+	results, _ := args.Get(0).([]model.BulkCardResult)
+	return results, args.Error(1)
+}
+func (m *MockCardService) SetFieldValue(cardID, fieldID int, value any) (model.CardFieldValue, error) {
+	args := m.Called(cardID, fieldID, value)
+	return args.Get(0).(model.CardFieldValue), args.Error(1)
+}
+func (m *MockCardService) SetCardLane(id int, laneID int) (model.Card, error) {
+	args := m.Called(id, laneID)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+
+type MockImportExportService struct {
+	mock.Mock
+}
+
+func (m *MockImportExportService) Export(boardID int) (importexport.Document, error) {
+	args := m.Called(boardID)
+	return args.Get(0).(importexport.Document), args.Error(1)
+}
+func (m *MockImportExportService) Import(data []byte) (model.Board, error) {
+	args := m.Called(data)
+	return args.Get(0).(model.Board), args.Error(1)
+}
+func (m *MockImportExportService) ExportCardsCSV(boardID int, w io.Writer) error {
+	args := m.Called(boardID, w)
+	if fn, ok := args.Get(0).(func(io.Writer) error); ok {
+		return fn(w)
+	}
+	return args.Error(0)
+}
+func (m *MockImportExportService) ImportCardsCSV(listID int, r io.Reader) (importexport.CSVImportResult, error) {
+	args := m.Called(listID, r)
+	return args.Get(0).(importexport.CSVImportResult), args.Error(1)
+}
+
+func (m *MockAutomationService) GetRules(boardID int) ([]model.AutomationRule, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.AutomationRule), args.Error(1)
+}
+func (m *MockAutomationService) CreateRule(input model.AutomationRuleInput) (model.AutomationRule, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.AutomationRule), args.Error(1)
+}
+func (m *MockAutomationService) UpdateRule(id int, input model.AutomationRuleInput) (model.AutomationRule, error) {
+	args := m.Called(id, input)
+	return args.Get(0).(model.AutomationRule), args.Error(1)
+}
+func (m *MockAutomationService) DeleteRule(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *MockAutomationService) GetRuns(boardID int, limit int) ([]model.AutomationRun, error) {
+	args := m.Called(boardID, limit)
+	return args.Get(0).([]model.AutomationRun), args.Error(1)
+}
+func (m *MockRecurrenceService) GetRecurrence(cardID int) (model.CardRecurrence, error) {
+	args := m.Called(cardID)
+	return args.Get(0).(model.CardRecurrence), args.Error(1)
+}
+func (m *MockRecurrenceService) SetRecurrence(cardID int, input model.CardRecurrenceInput) (model.CardRecurrence, error) {
+	args := m.Called(cardID, input)
+	return args.Get(0).(model.CardRecurrence), args.Error(1)
+}
+func (m *MockRecurrenceService) StopRecurrence(cardID int) error {
+	args := m.Called(cardID)
+	return args.Error(0)
+}
+func (m *MockNotificationService) GetInbox(filter model.NotificationFilter) (model.NotificationInbox, error) {
+	args := m.Called(filter)
+	return args.Get(0).(model.NotificationInbox), args.Error(1)
+}
+func (m *MockNotificationService) MarkRead(id int, username string) (model.Notification, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(model.Notification), args.Error(1)
+}
+func (m *MockNotificationService) MarkAllRead(username string) (int, error) {
+	args := m.Called(username)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockNotificationService) GetPreferences(username string) ([]model.NotificationPreference, error) {
+	args := m.Called(username)
+	return args.Get(0).([]model.NotificationPreference), args.Error(1)
+}
+func (m *MockNotificationService) SetPreferences(username string, updates []model.NotificationPreferenceUpdate) ([]model.NotificationPreference, error) {
+	args := m.Called(username, updates)
+	return args.Get(0).([]model.NotificationPreference), args.Error(1)
+}
+func (m *MockNotificationService) GetEmailSettings(username string) (model.EmailSettings, error) {
+	args := m.Called(username)
+	return args.Get(0).(model.EmailSettings), args.Error(1)
+}
+func (m *MockNotificationService) SetEmailSettings(settings model.EmailSettings) (model.EmailSettings, error) {
+	args := m.Called(settings)
+	return args.Get(0).(model.EmailSettings), args.Error(1)
+}
+
+type MockMemberService struct {
+	mock.Mock
+}
+
+func (m *MockMemberService) GetMembers(boardID int) ([]model.BoardMember, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.BoardMember), args.Error(1)
+}
+func (m *MockMemberService) AddMember(boardID int, username string) (model.BoardMember, error) {
+	args := m.Called(boardID, username)
+	return args.Get(0).(model.BoardMember), args.Error(1)
+}
+func (m *MockMemberService) RemoveMember(boardID int, username string) error {
+	args := m.Called(boardID, username)
+	return args.Error(0)
+}
+
+type MockCommentService struct {
+	mock.Mock
+}
+
+func (m *MockCommentService) GetComments(cardID int) ([]model.Comment, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]model.Comment), args.Error(1)
+}
+func (m *MockCommentService) AddComment(cardID int, author, text string) (model.Comment, error) {
+	args := m.Called(cardID, author, text)
+	return args.Get(0).(model.Comment), args.Error(1)
+}
+
+type MockWatchService struct {
+	mock.Mock
+}
+
+func (m *MockWatchService) Watch(username string, target model.WatchTarget) (model.Watch, error) {
+	args := m.Called(username, target)
+	return args.Get(0).(model.Watch), args.Error(1)
+}
+func (m *MockWatchService) Unwatch(username string, target model.WatchTarget) error {
+	args := m.Called(username, target)
+	return args.Error(0)
+}
+func (m *MockWatchService) GetWatching(username string) ([]model.Watch, error) {
+	args := m.Called(username)
+	return args.Get(0).([]model.Watch), args.Error(1)
+}
+func (m *MockWatchService) GetCardWatchers(cardID int) ([]string, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockLinkService struct {
+	mock.Mock
+}
+
+func (m *MockLinkService) GetLinks(cardID int) ([]model.CardLink, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]model.CardLink), args.Error(1)
+}
+func (m *MockLinkService) AddLink(cardID int, input model.CardLinkInput) (model.CardLink, error) {
+	args := m.Called(cardID, input)
+	return args.Get(0).(model.CardLink), args.Error(1)
+}
+func (m *MockLinkService) RemoveLink(cardID, linkID int) error {
+	args := m.Called(cardID, linkID)
+	return args.Error(0)
+}
+
+type MockFieldService struct {
+	mock.Mock
+}
+
+func (m *MockFieldService) GetFields(boardID int) ([]model.CustomField, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.CustomField), args.Error(1)
+}
+func (m *MockFieldService) CreateField(input model.CustomFieldInputCreate) (model.CustomField, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.CustomField), args.Error(1)
+}
+func (m *MockFieldService) UpdateField(id int, update model.CustomFieldUpdate) (model.CustomField, error) {
+	args := m.Called(id, update)
+	return args.Get(0).(model.CustomField), args.Error(1)
+}
+func (m *MockFieldService) DeleteField(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockViewService struct {
+	mock.Mock
+}
+
+func (m *MockViewService) Search(boardID int, username, q, sort string, desc bool) ([]model.Card, error) {
+	args := m.Called(boardID, username, q, sort, desc)
+	return args.Get(0).([]model.Card), args.Error(1)
+}
+func (m *MockViewService) GetViews(boardID int, username string) ([]model.View, error) {
+	args := m.Called(boardID, username)
+	return args.Get(0).([]model.View), args.Error(1)
+}
+func (m *MockViewService) CreateView(input model.ViewInput) (model.View, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.View), args.Error(1)
+}
+func (m *MockViewService) UpdateView(id int, username string, input model.ViewInput) (model.View, error) {
+	args := m.Called(id, username, input)
+	return args.Get(0).(model.View), args.Error(1)
+}
+func (m *MockViewService) DeleteView(id int, username string) error {
+	args := m.Called(id, username)
+	return args.Error(0)
+}
+func (m *MockViewService) ViewCards(id int, username string) (model.View, []model.CardGroup, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(model.View), args.Get(1).([]model.CardGroup), args.Error(2)
+}
+
+type MockCalendarService struct {
+	mock.Mock
+}
+
+func (m *MockCalendarService) BoardCalendar(boardID int, from, to, tz string) ([]model.CalendarDay, error) {
+	args := m.Called(boardID, from, to, tz)
+	return args.Get(0).([]model.CalendarDay), args.Error(1)
+}
+func (m *MockCalendarService) IssueToken(username string) (string, model.CalendarToken, error) {
+	args := m.Called(username)
+	return args.String(0), args.Get(1).(model.CalendarToken), args.Error(2)
+}
+func (m *MockCalendarService) RevokeToken(username string) error {
+	args := m.Called(username)
+	return args.Error(0)
+}
+func (m *MockCalendarService) Feed(token string, w io.Writer) error {
+	args := m.Called(token, w)
+	if args.Error(0) == nil {
+		io.WriteString(w, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+	}
+	return args.Error(0)
+}
+
+type MockMetricsService struct {
+	mock.Mock
+}
+
+func (m *MockMetricsService) BoardMetrics(boardID int, input model.MetricsInput) (model.BoardMetrics, error) {
+	args := m.Called(boardID, input)
+	return args.Get(0).(model.BoardMetrics), args.Error(1)
+}
+func (m *MockMetricsService) SprintBurndown(sprintID int) (model.SprintBurndown, error) {
+	args := m.Called(sprintID)
+	return args.Get(0).(model.SprintBurndown), args.Error(1)
+}
+
+type MockLaneService struct {
+	mock.Mock
+}
+
+func (m *MockLaneService) Layout(boardID int) (model.BoardLayout, error) {
+	args := m.Called(boardID)
+	return args.Get(0).(model.BoardLayout), args.Error(1)
+}
+func (m *MockLaneService) SetSwimlanes(input model.Swimlanes) (model.Swimlanes, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.Swimlanes), args.Error(1)
+}
+func (m *MockLaneService) GetLanes(boardID int) ([]model.Lane, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.Lane), args.Error(1)
+}
+func (m *MockLaneService) CreateLane(input model.LaneInputCreate) (model.Lane, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.Lane), args.Error(1)
+}
+func (m *MockLaneService) RenameLane(id int, title string) (model.Lane, error) {
+	args := m.Called(id, title)
+	return args.Get(0).(model.Lane), args.Error(1)
+}
+func (m *MockLaneService) DeleteLane(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *MockLaneService) ReorderLanes(boardID int, ids []int) ([]model.Lane, error) {
+	args := m.Called(boardID, ids)
+	return args.Get(0).([]model.Lane), args.Error(1)
+}
+
+type MockSprintService struct {
+	mock.Mock
+}
+
+func (m *MockSprintService) GetSprints(boardID int) ([]model.Sprint, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.Sprint), args.Error(1)
+}
+func (m *MockSprintService) GetSprint(id int) (model.Sprint, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Sprint), args.Error(1)
+}
+func (m *MockSprintService) GetSprintCards(id int) ([]model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).([]model.Card), args.Error(1)
+}
+func (m *MockSprintService) CreateSprint(input model.SprintInput) (model.Sprint, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.Sprint), args.Error(1)
+}
+func (m *MockSprintService) UpdateSprint(id int, input model.SprintInput) (model.Sprint, error) {
+	args := m.Called(id, input)
+	return args.Get(0).(model.Sprint), args.Error(1)
+}
+func (m *MockSprintService) DeleteSprint(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *MockSprintService) StartSprint(id int) (model.Sprint, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Sprint), args.Error(1)
+}
+func (m *MockSprintService) CompleteSprint(id, nextID int) (model.SprintCompletion, error) {
+	args := m.Called(id, nextID)
+	return args.Get(0).(model.SprintCompletion), args.Error(1)
+}
+func (m *MockSprintService) SetCardSprint(cardID, sprintID int) error {
+	args := m.Called(cardID, sprintID)
+	return args.Error(0)
+}
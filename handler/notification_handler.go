@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type NotificationHandler struct {
+	service NotificationService
+	logger  *zap.Logger
+}
+
+func NewNotificationHandler(service NotificationService, logger *zap.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetInbox обрабатывает GET /me/notifications?unread=true&limit=N.
+func (h *NotificationHandler) GetInbox(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	filter := model.NotificationFilter{Username: username}
+	query := r.URL.Query()
+	if v := query.Get("unread"); v != "" {
+		unread, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "unread must be a boolean", http.StatusBadRequest)
+			return
+		}
+		filter.UnreadOnly = unread
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = n
+	}
+	inbox, err := h.service.GetInbox(filter)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения уведомлений", zap.String("username", username))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.NotificationInboxToDTO(inbox))
+}
+
+// MarkRead обрабатывает POST /me/notifications/{id}/read.
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	n, err := h.service.MarkRead(id, username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка отметки уведомления", zap.Int("notificationID", id), zap.String("username", username))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.NotificationToDTO(n))
+}
+
+// MarkAllRead обрабатывает POST /me/notifications/read-all.
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	marked, err := h.service.MarkAllRead(username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка отметки уведомлений", zap.String("username", username))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.MarkAllReadDTO{Marked: marked})
+}
+
+// GetPreferences обрабатывает GET /me/notification-preferences.
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	prefs, err := h.service.GetPreferences(username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения настроек уведомлений", zap.String("username", username))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.NotificationPreferencesToDTO(prefs))
+}
+
+// SetPreferences обрабатывает PUT /me/notification-preferences: меняет
+// только переданные виды и возвращает все настройки.
+func (h *NotificationHandler) SetPreferences(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	var input []dto.UpdateNotificationPreferenceDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	prefs, err := h.service.SetPreferences(username, dto.NotificationPreferenceUpdatesFromDTO(input))
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка сохранения настроек уведомлений", zap.String("username", username))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.NotificationPreferencesToDTO(prefs))
+}
+
+// GetEmailSettings обрабатывает GET /me/email-settings.
+func (h *NotificationHandler) GetEmailSettings(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	settings, err := h.service.GetEmailSettings(username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения почтовых настроек", zap.String("username", username))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.EmailSettingsToDTO(settings))
+}
+
+// SetEmailSettings обрабатывает PUT /me/email-settings: адрес и доставку
+// сразу или ежедневной сводкой.
+func (h *NotificationHandler) SetEmailSettings(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	var input dto.SaveEmailSettingsDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	settings, err := h.service.SetEmailSettings(dto.EmailSettingsFromDTO(username, input))
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка сохранения почтовых настроек", zap.String("username", username))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.EmailSettingsToDTO(settings))
+}
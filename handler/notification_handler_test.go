@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetInbox(t *testing.T) {
+	readAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	inbox := model.NotificationInbox{
+		Unread: 1,
+		Notifications: []model.Notification{
+			{ID: 2, Username: "anna", Kind: model.NotificationAssigned, Event: model.EventCardAssigned, BoardID: 1, CardID: 5, CardTitle: "c"},
+			{ID: 1, Username: "anna", Kind: model.NotificationDueSoon, Event: model.EventDueSoon, BoardID: 1, CardID: 5, CardTitle: "c", ReadAt: &readAt},
+		},
+	}
+	tests := []struct {
+		name           string
+		user           string
+		query          string
+		wantFilter     model.NotificationFilter
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "all", user: "anna", wantFilter: model.NotificationFilter{Username: "anna"}, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "unread with limit", user: "anna", query: "?unread=true&limit=10", wantFilter: model.NotificationFilter{Username: "anna", UnreadOnly: true, Limit: 10}, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "service error", user: "anna", wantFilter: model.NotificationFilter{Username: "anna"}, mockError: errors.New("fail"), expectCall: true, expectedStatus: http.StatusInternalServerError},
+		{name: "no user", expectedStatus: http.StatusUnauthorized},
+		{name: "invalid unread", user: "anna", query: "?unread=maybe", expectedStatus: http.StatusBadRequest},
+		{name: "invalid limit", user: "anna", query: "?limit=-1", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			handler := NewNotificationHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("GetInbox", tt.wantFilter).Return(inbox, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/me/notifications"+tt.query, nil)
+			req.Header.Set("X-User", tt.user)
+			rec := httptest.NewRecorder()
+			handler.GetInbox(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.NotificationInboxDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, 1, resp.Unread)
+				require.Len(t, resp.Notifications, 2)
+				require.False(t, resp.Notifications[0].Read)
+				require.True(t, resp.Notifications[1].Read)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", id: "2", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "not found", id: "2", mockError: fmt.Errorf("notification 2: %w", model.ErrNotFound), expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid id", id: "x", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			handler := NewNotificationHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				readAt := time.Now()
+				mockService.On("MarkRead", 2, "anna").Return(model.Notification{ID: 2, ReadAt: &readAt}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/me/notifications/"+tt.id+"/read", nil)
+			req.Header.Set("X-User", "anna")
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.MarkRead(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarkAllNotificationsRead(t *testing.T) {
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService, zap.NewNop())
+	mockService.On("MarkAllRead", "anna").Return(3, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/me/notifications/read-all", nil)
+	req.Header.Set("X-User", "anna")
+	rec := httptest.NewRecorder()
+	handler.MarkAllRead(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.MarkAllReadDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, 3, resp.Marked)
+	mockService.AssertExpectations(t)
+}
+
+func TestSetNotificationPreferences(t *testing.T) {
+	all := []model.NotificationPreference{{Kind: model.NotificationAssigned, InApp: true, Email: true}, {Kind: model.NotificationCardChanged, InApp: false}}
+	off, on := false, true
+	tests := []struct {
+		name           string
+		body           string
+		wantPrefs      []model.NotificationPreferenceUpdate
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `[{"kind":"card_changed","in_app":false}]`,
+			wantPrefs:      []model.NotificationPreferenceUpdate{{Kind: model.NotificationCardChanged, InApp: &off}},
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown kind",
+			body:           `[{"kind":"spam","in_app":true}]`,
+			wantPrefs:      []model.NotificationPreferenceUpdate{{Kind: "spam", InApp: &on}},
+			mockError:      fmt.Errorf("%w: unknown notification kind", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "email only",
+			body:           `[{"kind":"assigned","email":false}]`,
+			wantPrefs:      []model.NotificationPreferenceUpdate{{Kind: model.NotificationAssigned, Email: &off}},
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{name: "invalid json", body: `{"kind":`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			handler := NewNotificationHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("SetPreferences", "anna", tt.wantPrefs).Return(all, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/me/notification-preferences", strings.NewReader(tt.body))
+			req.Header.Set("X-User", "anna")
+			rec := httptest.NewRecorder()
+			handler.SetPreferences(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp []dto.NotificationPreferenceDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Len(t, resp, 2)
+				require.False(t, resp[1].InApp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetEmailSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		user           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"email":"anna@example.com","digest":true}`,
+			user:           "anna",
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid address",
+			body:           `{"email":"anna@example.com","digest":true}`,
+			user:           "anna",
+			mockError:      fmt.Errorf("%w: invalid email address", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "no user", body: `{}`, expectedStatus: http.StatusUnauthorized},
+		{name: "invalid json", body: `{"email":`, user: "anna", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			handler := NewNotificationHandler(mockService, zap.NewNop())
+			settings := model.EmailSettings{Username: "anna", Address: "anna@example.com", Digest: true}
+			if tt.expectCall {
+				mockService.On("SetEmailSettings", settings).Return(settings, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/me/email-settings", strings.NewReader(tt.body))
+			if tt.user != "" {
+				req.Header.Set("X-User", tt.user)
+			}
+			rec := httptest.NewRecorder()
+			handler.SetEmailSettings(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.EmailSettingsDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, "anna@example.com", resp.Email)
+				require.True(t, resp.Digest)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
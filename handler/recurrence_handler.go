@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type RecurrenceHandler struct {
+	service RecurrenceService
+	logger  *zap.Logger
+}
+
+func NewRecurrenceHandler(service RecurrenceService, logger *zap.Logger) *RecurrenceHandler {
+	return &RecurrenceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetRecurrence обрабатывает GET /cards/{id}/recurrence.
+func (h *RecurrenceHandler) GetRecurrence(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	rec, err := h.service.GetRecurrence(cardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения повторения карточки", zap.Int("cardID", cardID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.CardRecurrenceToDTO(rec))
+}
+
+// SetRecurrence обрабатывает PUT /cards/{id}/recurrence: включает
+// повторение или заменяет его настройки.
+func (h *RecurrenceHandler) SetRecurrence(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.SaveRecurrenceDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	rec, err := h.service.SetRecurrence(cardID, dto.CardRecurrenceFromDTO(input))
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка настройки повторения карточки", zap.Int("cardID", cardID))
+		return
+	}
+	h.logger.Info("Настроено повторение карточки", zap.Int("cardID", cardID), zap.String("frequency", rec.Frequency), zap.Time("nextRunAt", rec.NextRunAt))
+	writeJSON(w, h.logger, http.StatusOK, dto.CardRecurrenceToDTO(rec))
+}
+
+// StopRecurrence обрабатывает DELETE /cards/{id}/recurrence.
+func (h *RecurrenceHandler) StopRecurrence(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	if err := h.service.StopRecurrence(cardID); err != nil {
+		fail(w, h.logger, err, "Ошибка отключения повторения карточки", zap.Int("cardID", cardID))
+		return
+	}
+	h.logger.Info("Повторение карточки отключено", zap.Int("cardID", cardID))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetRecurrence(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	recurrence := model.CardRecurrence{CardID: 5, ListID: 10, Frequency: model.RecurrenceWeekly, Interval: 1, StartsAt: start, NextRunAt: start}
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		wantInput      model.CardRecurrenceInput
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "weekly",
+			id:             "5",
+			body:           `{"frequency":"weekly","list_id":10,"starts_at":"2026-03-02T09:00:00Z"}`,
+			wantInput:      model.CardRecurrenceInput{ListID: 10, Frequency: model.RecurrenceWeekly, StartsAt: &start},
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "cron",
+			id:             "5",
+			body:           `{"frequency":"cron","cron":"0 9 * * 1"}`,
+			wantInput:      model.CardRecurrenceInput{Frequency: model.RecurrenceCron, Cron: "0 9 * * 1"},
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "validation error",
+			id:             "5",
+			body:           `{"frequency":"yearly"}`,
+			wantInput:      model.CardRecurrenceInput{Frequency: "yearly"},
+			mockError:      fmt.Errorf("%w: unknown frequency", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "card not found",
+			id:             "5",
+			body:           `{"frequency":"daily"}`,
+			wantInput:      model.CardRecurrenceInput{Frequency: model.RecurrenceDaily},
+			mockError:      model.ErrNotFound,
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			id:             "5",
+			body:           `{"frequency":"daily"}`,
+			wantInput:      model.CardRecurrenceInput{Frequency: model.RecurrenceDaily},
+			mockError:      errors.New("fail"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{name: "invalid json", id: "5", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid id", id: "x", body: `{}`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockRecurrenceService)
+			handler := NewRecurrenceHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("SetRecurrence", 5, tt.wantInput).Return(recurrence, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/cards/"+tt.id+"/recurrence", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			handler.SetRecurrence(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.CardRecurrenceDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, model.RecurrenceWeekly, resp.Frequency)
+				require.Equal(t, start, resp.NextRunAt)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetRecurrence(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "no recurrence", mockError: fmt.Errorf("recurrence of card 5: %w", model.ErrNotFound), expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockRecurrenceService)
+			handler := NewRecurrenceHandler(mockService, zap.NewNop())
+			mockService.On("GetRecurrence", 5).Return(model.CardRecurrence{CardID: 5, Frequency: model.RecurrenceCron, Cron: "0 9 * * *"}, tt.mockError)
+
+			req := httptest.NewRequest(http.MethodGet, "/cards/5/recurrence", nil)
+			req.SetPathValue("id", "5")
+			rec := httptest.NewRecorder()
+			handler.GetRecurrence(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.CardRecurrenceDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, "0 9 * * *", resp.Cron)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStopRecurrence(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusNoContent},
+		{name: "no recurrence", mockError: model.ErrNotFound, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockRecurrenceService)
+			handler := NewRecurrenceHandler(mockService, zap.NewNop())
+			mockService.On("StopRecurrence", 5).Return(tt.mockError)
+
+			req := httptest.NewRequest(http.MethodDelete, "/cards/5/recurrence", nil)
+			req.SetPathValue("id", "5")
+			rec := httptest.NewRecorder()
+			handler.StopRecurrence(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Общие помощники для обработчиков на маршрутах вида /xxx/{id}.
+
+func pathID(w http.ResponseWriter, r *http.Request, logger *zap.Logger) (int, bool) {
+	return pathInt(w, r, logger, "id")
+}
+
+// pathInt читает числовой параметр пути name, например {linkID}.
+func pathInt(w http.ResponseWriter, r *http.Request, logger *zap.Logger, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		logger.Error("Некорректный id", zap.Error(err), zap.String(name, r.PathValue(name)))
+		http.Error(w, "invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// userHeader несёт имя текущего пользователя. Своей аутентификации у
+// приложения нет: заголовок выставляет прокси перед ним.
+const userHeader = "X-User"
+
+func currentUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	username := strings.TrimSpace(r.Header.Get(userHeader))
+	if username == "" {
+		http.Error(w, userHeader+" header is required", http.StatusUnauthorized)
+		return "", false
+	}
+	return username, true
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, logger *zap.Logger, dst any) bool {
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		logger.Error("Ошибка декодирования запроса", zap.Error(err), zap.String("path", r.URL.Path))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// fail отвечает статусом errStatus, ошибки без известного статуса
+// логирует с msg.
+func fail(w http.ResponseWriter, logger *zap.Logger, err error, msg string, fields ...zap.Field) {
+	status := errStatus(err)
+	if status == http.StatusInternalServerError {
+		logger.Error(msg, append(fields, zap.Error(err))...)
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// errStatus возвращает 404, 400, 409 и 403 для ErrNotFound, ErrInvalidInput,
+// ErrConflict и ErrForbidden и 500 для остальных ошибок.
+func errStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, model.ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// warnHeader дублирует предупреждения из тела ответа в заголовках
+// Warning, чтобы клиенту не нужно было разбирать тело. Вызывается до
+// записи статуса.
+func warnHeader(w http.ResponseWriter, warnings []string) {
+	for _, msg := range warnings {
+		w.Header().Add("Warning", "199 - "+strconv.Quote(msg))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, logger *zap.Logger, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error("Ошибка кодирования ответа", zap.Error(err))
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+type SprintHandler struct {
+	service SprintService
+	logger  *zap.Logger
+}
+
+func NewSprintHandler(service SprintService, logger *zap.Logger) *SprintHandler {
+	return &SprintHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetSprints обрабатывает GET /boards/{id}/sprints: спринты доски по дате
+// начала.
+func (h *SprintHandler) GetSprints(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	sprints, err := h.service.GetSprints(boardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения спринтов", zap.Int("boardID", boardID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.SprintsToDTO(sprints))
+}
+
+// CreateSprint обрабатывает POST /boards/{id}/sprints.
+func (h *SprintHandler) CreateSprint(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.SprintInputDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	sprint, err := h.service.CreateSprint(sprintInput(boardID, input))
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка создания спринта", zap.Int("boardID", boardID), zap.Any("input", input))
+		return
+	}
+	h.logger.Info("Спринт создан", zap.Int("boardID", boardID), zap.Int("sprintID", sprint.ID))
+	writeJSON(w, h.logger, http.StatusCreated, dto.SprintToDTO(sprint))
+}
+
+// GetSprint обрабатывает GET /sprints/{id}.
+func (h *SprintHandler) GetSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	sprint, err := h.service.GetSprint(sprintID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения спринта", zap.Int("sprintID", sprintID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.SprintToDTO(sprint))
+}
+
+// GetSprintCards обрабатывает GET /sprints/{id}/cards: карточки, которые
+// сейчас в спринте.
+func (h *SprintHandler) GetSprintCards(w http.ResponseWriter, r *http.Request) {
+	sprintID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	cards, err := h.service.GetSprintCards(sprintID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения карточек спринта", zap.Int("sprintID", sprintID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.CardsToDTO(cards))
+}
+
+// UpdateSprint обрабатывает PUT /sprints/{id}.
+func (h *SprintHandler) UpdateSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.SprintInputDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	sprint, err := h.service.UpdateSprint(sprintID, sprintInput(0, input))
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка изменения спринта", zap.Int("sprintID", sprintID), zap.Any("input", input))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.SprintToDTO(sprint))
+}
+
+// DeleteSprint обрабатывает DELETE /sprints/{id}; карточки спринта
+// остаются на доске.
+func (h *SprintHandler) DeleteSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteSprint(sprintID); err != nil {
+		fail(w, h.logger, err, "Ошибка удаления спринта", zap.Int("sprintID", sprintID))
+		return
+	}
+	h.logger.Info("Спринт удалён", zap.Int("sprintID", sprintID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// StartSprint обрабатывает POST /sprints/{id}/start.
+func (h *SprintHandler) StartSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	sprint, err := h.service.StartSprint(sprintID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка запуска спринта", zap.Int("sprintID", sprintID))
+		return
+	}
+	h.logger.Info("Спринт запущен", zap.Int("sprintID", sprintID))
+	writeJSON(w, h.logger, http.StatusOK, dto.SprintToDTO(sprint))
+}
+
+// CompleteSprint обрабатывает POST /sprints/{id}/complete. Тело
+// необязательно: без next_sprint_id незавершённые карточки уходят в
+// ближайший запланированный спринт.
+func (h *SprintHandler) CompleteSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.CompleteSprintDTO
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error("Ошибка декодирования запроса", zap.Error(err), zap.String("path", r.URL.Path))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	nextID := 0
+	if input.NextSprintID != nil {
+		nextID = *input.NextSprintID
+	}
+	completion, err := h.service.CompleteSprint(sprintID, nextID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка завершения спринта", zap.Int("sprintID", sprintID), zap.Int("nextSprintID", nextID))
+		return
+	}
+	h.logger.Info("Спринт завершён", zap.Int("sprintID", sprintID), zap.Ints("rolledOver", completion.RolledOver))
+	writeJSON(w, h.logger, http.StatusOK, dto.SprintCompletionToDTO(completion))
+}
+
+// SetCardSprint обрабатывает PUT /cards/{id}/sprint.
+func (h *SprintHandler) SetCardSprint(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	var input dto.CardSprintDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return
+	}
+	sprintID := 0
+	if input.SprintID != nil {
+		sprintID = *input.SprintID
+	}
+	if err := h.service.SetCardSprint(cardID, sprintID); err != nil {
+		fail(w, h.logger, err, "Ошибка изменения спринта карточки", zap.Int("cardID", cardID), zap.Int("sprintID", sprintID))
+		return
+	}
+	if sprintID == 0 {
+		input.SprintID = nil
+	}
+	writeJSON(w, h.logger, http.StatusOK, input)
+}
+
+func sprintInput(boardID int, input dto.SprintInputDTO) model.SprintInput {
+	return model.SprintInput{BoardID: boardID, Name: input.Name, Goal: input.Goal, StartDate: input.StartDate, EndDate: input.EndDate}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateSprint(t *testing.T) {
+	input := model.SprintInput{BoardID: 1, Name: "Sprint 1", Goal: "Login", StartDate: "2026-03-02", EndDate: "2026-03-13"}
+	body := `{"name":"Sprint 1","goal":"Login","start_date":"2026-03-02","end_date":"2026-03-13"}`
+	tests := []struct {
+		name           string
+		body           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", body: body, expectCall: true, expectedStatus: http.StatusCreated},
+		{name: "bad dates", body: body, mockError: model.ErrInvalidInput, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "board not found", body: body, mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid json", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSprintService)
+			handler := NewSprintHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("CreateSprint", input).Return(model.Sprint{
+					ID:        3,
+					BoardID:   1,
+					Name:      "Sprint 1",
+					StartDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+					Status:    model.SprintPlanned,
+				}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/boards/1/sprints", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.CreateSprint(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.SprintDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, 3, resp.ID)
+				require.Equal(t, "2026-03-02", resp.StartDate)
+				require.Equal(t, "2026-03-13", resp.EndDate)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStartSprint(t *testing.T) {
+	mockService := new(MockSprintService)
+	handler := NewSprintHandler(mockService, zap.NewNop())
+	mockService.On("StartSprint", 3).Return(model.Sprint{ID: 3, Status: model.SprintActive}, nil)
+	mockService.On("StartSprint", 4).Return(model.Sprint{}, fmt.Errorf("%w: board 1 already has an active sprint", model.ErrConflict))
+
+	for id, status := range map[string]int{"3": http.StatusOK, "4": http.StatusConflict, "x": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodPost, "/sprints/"+id+"/start", nil)
+		req.SetPathValue("id", id)
+		rec := httptest.NewRecorder()
+		handler.StartSprint(rec, req)
+		require.Equal(t, status, rec.Code, id)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestCompleteSprint(t *testing.T) {
+	next := model.Sprint{ID: 4, Status: model.SprintPlanned}
+	tests := []struct {
+		name           string
+		body           string
+		nextID         int
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "without body", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "explicit next sprint", body: `{"next_sprint_id":4}`, nextID: 4, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "not active", body: `{}`, mockError: model.ErrConflict, expectCall: true, expectedStatus: http.StatusConflict},
+		{name: "invalid json", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSprintService)
+			handler := NewSprintHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("CompleteSprint", 3, tt.nextID).Return(model.SprintCompletion{
+					Sprint:     model.Sprint{ID: 3, Status: model.SprintCompleted},
+					Done:       []int{7},
+					RolledOver: []int{8},
+					Next:       &next,
+				}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/sprints/3/complete", strings.NewReader(tt.body))
+			req.SetPathValue("id", "3")
+			rec := httptest.NewRecorder()
+			handler.CompleteSprint(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.SprintCompletionDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, []int{8}, resp.RolledOver)
+				require.Equal(t, 4, resp.Next.ID)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetCardSprint(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		sprintID       int
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "add", body: `{"sprint_id":3}`, sprintID: 3, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "remove", body: `{"sprint_id":null}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "foreign sprint", body: `{"sprint_id":9}`, sprintID: 9, mockError: model.ErrInvalidInput, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "card not found", body: `{"sprint_id":3}`, sprintID: 3, mockError: model.ErrNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSprintService)
+			handler := NewSprintHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("SetCardSprint", 1, tt.sprintID).Return(tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/cards/1/sprint", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			rec := httptest.NewRecorder()
+			handler.SetCardSprint(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteSprint(t *testing.T) {
+	mockService := new(MockSprintService)
+	handler := NewSprintHandler(mockService, zap.NewNop())
+	mockService.On("DeleteSprint", 3).Return(nil)
+	mockService.On("DeleteSprint", 4).Return(fmt.Errorf("%w: sprint 4 is active, complete it first", model.ErrConflict))
+
+	for id, status := range map[string]int{"3": http.StatusNoContent, "4": http.StatusConflict} {
+		req := httptest.NewRequest(http.MethodDelete, "/sprints/"+id, nil)
+		req.SetPathValue("id", id)
+		rec := httptest.NewRecorder()
+		handler.DeleteSprint(rec, req)
+		require.Equal(t, status, rec.Code, id)
+	}
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+type ViewHandler struct {
+	service ViewService
+	logger  *zap.Logger
+}
+
+func NewViewHandler(service ViewService, logger *zap.Logger) *ViewHandler {
+	return &ViewHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// SearchCards обрабатывает GET /boards/{id}/cards?q=...&sort=...&order=desc.
+// Пользователь необязателен: без него assignee:me ничего не находит.
+func (h *ViewHandler) SearchCards(w http.ResponseWriter, r *http.Request) {
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	desc, ok := sortOrder(w, query.Get("order"))
+	if !ok {
+		return
+	}
+	username := strings.TrimSpace(r.Header.Get(userHeader))
+	cards, err := h.service.Search(boardID, username, query.Get("q"), query.Get("sort"), desc)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка поиска карточек", zap.Int("boardID", boardID), zap.String("q", query.Get("q")))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.CardsToDTO(cards))
+}
+
+// GetViews обрабатывает GET /boards/{id}/views: свои и общие представления.
+func (h *ViewHandler) GetViews(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	views, err := h.service.GetViews(boardID, username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения представлений", zap.Int("boardID", boardID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.ViewsToDTO(views))
+}
+
+// CreateView обрабатывает POST /boards/{id}/views.
+func (h *ViewHandler) CreateView(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	boardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	input, ok := h.viewInput(w, r)
+	if !ok {
+		return
+	}
+	input.BoardID, input.Owner = boardID, username
+	view, err := h.service.CreateView(input)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка создания представления", zap.Int("boardID", boardID), zap.Any("input", input))
+		return
+	}
+	h.logger.Info("Представление создано", zap.Int("boardID", boardID), zap.Int("viewID", view.ID), zap.String("owner", username))
+	writeJSON(w, h.logger, http.StatusCreated, dto.ViewToDTO(view))
+}
+
+// UpdateView обрабатывает PUT /views/{id}; менять можно только своё.
+func (h *ViewHandler) UpdateView(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	viewID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	input, ok := h.viewInput(w, r)
+	if !ok {
+		return
+	}
+	view, err := h.service.UpdateView(viewID, username, input)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка изменения представления", zap.Int("viewID", viewID), zap.Any("input", input))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.ViewToDTO(view))
+}
+
+// DeleteView обрабатывает DELETE /views/{id}.
+func (h *ViewHandler) DeleteView(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	viewID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteView(viewID, username); err != nil {
+		fail(w, h.logger, err, "Ошибка удаления представления", zap.Int("viewID", viewID))
+		return
+	}
+	h.logger.Info("Представление удалено", zap.Int("viewID", viewID), zap.String("username", username))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ViewCards обрабатывает GET /views/{id}/cards: карточки представления
+// по группам.
+func (h *ViewHandler) ViewCards(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	viewID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	view, groups, err := h.service.ViewCards(viewID, username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения карточек представления", zap.Int("viewID", viewID))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.ViewCardsToDTO(view, groups))
+}
+
+func (h *ViewHandler) viewInput(w http.ResponseWriter, r *http.Request) (model.ViewInput, bool) {
+	var input dto.ViewInputDTO
+	if !decodeJSON(w, r, h.logger, &input) {
+		return model.ViewInput{}, false
+	}
+	desc, ok := sortOrder(w, input.Order)
+	if !ok {
+		return model.ViewInput{}, false
+	}
+	return model.ViewInput{
+		Name:   input.Name,
+		Query:  input.Query,
+		Sort:   input.Sort,
+		Desc:   desc,
+		Group:  input.GroupBy,
+		Shared: input.Shared,
+	}, true
+}
+
+// sortOrder разбирает направление сортировки: asc (или пусто) и desc.
+func sortOrder(w http.ResponseWriter, order string) (bool, bool) {
+	switch order {
+	case "", "asc":
+		return false, true
+	case "desc":
+		return true, true
+	}
+	http.Error(w, "order must be asc or desc", http.StatusBadRequest)
+	return false, false
+}
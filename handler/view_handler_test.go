@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSearchCards(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		user           string
+		expectCall     bool
+		wantQ          string
+		wantSort       string
+		wantDesc       bool
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "query with user",
+			query:          "?q=" + url.QueryEscape(`label:bug assignee:me "login fails"`) + "&sort=due_at&order=desc",
+			user:           "anna",
+			expectCall:     true,
+			wantQ:          `label:bug assignee:me "login fails"`,
+			wantSort:       "due_at",
+			wantDesc:       true,
+			expectedStatus: http.StatusOK,
+		},
+		{name: "without user", expectCall: true, expectedStatus: http.StatusOK},
+		{
+			name:           "invalid query",
+			query:          "?q=lable:bug",
+			expectCall:     true,
+			wantQ:          "lable:bug",
+			mockError:      fmt.Errorf("%w: unknown filter", model.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "invalid order", query: "?order=up", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockViewService)
+			handler := NewViewHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("Search", 1, tt.user, tt.wantQ, tt.wantSort, tt.wantDesc).
+					Return([]model.Card{{ID: 3, BoardID: 1, Title: "Login fails"}}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/boards/1/cards"+tt.query, nil)
+			req.SetPathValue("id", "1")
+			if tt.user != "" {
+				req.Header.Set(userHeader, tt.user)
+			}
+			rec := httptest.NewRecorder()
+			handler.SearchCards(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp []dto.CardDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Len(t, resp, 1)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateView(t *testing.T) {
+	input := model.ViewInput{BoardID: 1, Owner: "anna", Name: "My bugs", Query: "label:bug", Sort: "due_at", Desc: true, Group: model.GroupList, Shared: true}
+	body := `{"name":"My bugs","query":"label:bug","sort":"due_at","order":"desc","group_by":"list","shared":true}`
+	tests := []struct {
+		name           string
+		body           string
+		user           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "success", body: body, user: "anna", expectCall: true, expectedStatus: http.StatusCreated},
+		{
+			name:           "invalid view",
+			body:           body,
+			user:           "anna",
+			mockError:      fmt.Errorf("%w: board has no field", model.ErrInvalidInput),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "no user", body: body, expectedStatus: http.StatusUnauthorized},
+		{name: "invalid order", body: `{"name":"x","order":"up"}`, user: "anna", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockViewService)
+			handler := NewViewHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("CreateView", input).Return(model.View{ID: 7, BoardID: 1, Owner: "anna", Name: "My bugs", Desc: true}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/boards/1/views", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			if tt.user != "" {
+				req.Header.Set(userHeader, tt.user)
+			}
+			rec := httptest.NewRecorder()
+			handler.CreateView(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp dto.ViewDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, 7, resp.ID)
+				require.Equal(t, "desc", resp.Order)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateViewForbidden(t *testing.T) {
+	mockService := new(MockViewService)
+	handler := NewViewHandler(mockService, zap.NewNop())
+	mockService.On("UpdateView", 7, "vera", model.ViewInput{Name: "x"}).
+		Return(model.View{}, fmt.Errorf("%w: view 7 belongs to anna", model.ErrForbidden))
+
+	req := httptest.NewRequest(http.MethodPut, "/views/7", strings.NewReader(`{"name":"x"}`))
+	req.SetPathValue("id", "7")
+	req.Header.Set(userHeader, "vera")
+	rec := httptest.NewRecorder()
+	handler.UpdateView(rec, req)
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestViewCards(t *testing.T) {
+	mockService := new(MockViewService)
+	handler := NewViewHandler(mockService, zap.NewNop())
+	mockService.On("ViewCards", 7, "anna").Return(model.View{ID: 7, Group: model.GroupLabel}, []model.CardGroup{
+		{Key: "bug", Cards: []model.Card{{ID: 1}, {ID: 2}}},
+		{Cards: []model.Card{{ID: 3}}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/views/7/cards", nil)
+	req.SetPathValue("id", "7")
+	req.Header.Set(userHeader, "anna")
+	rec := httptest.NewRecorder()
+	handler.ViewCards(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.ViewCardsDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, "label", resp.View.GroupBy)
+	require.Len(t, resp.Groups, 2)
+	require.Equal(t, "bug", resp.Groups[0].Key)
+	require.Len(t, resp.Groups[0].Cards, 2)
+	require.Empty(t, resp.Groups[1].Key)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type WatchHandler struct {
+	service WatchService
+	logger  *zap.Logger
+}
+
+func NewWatchHandler(service WatchService, logger *zap.Logger) *WatchHandler {
+	return &WatchHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Watch возвращает обработчик PUT /{cards|lists|boards}/{id}/watch,
+// подписывающий текущего пользователя на объект вида kind.
+func (h *WatchHandler) Watch(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		username, ok := currentUser(w, r)
+		if !ok {
+			return
+		}
+		id, ok := pathID(w, r, h.logger)
+		if !ok {
+			return
+		}
+		watch, err := h.service.Watch(username, model.WatchTarget{Kind: kind, ID: id})
+		if err != nil {
+			fail(w, h.logger, err, "Ошибка подписки", zap.String("kind", kind), zap.Int("id", id), zap.String("username", username))
+			return
+		}
+		h.logger.Info("Пользователь подписался", zap.String("kind", kind), zap.Int("id", id), zap.String("username", username))
+		writeJSON(w, h.logger, http.StatusOK, dto.WatchDTO{Kind: watch.Kind, TargetID: watch.TargetID, CreatedAt: watch.CreatedAt})
+	}
+}
+
+// Unwatch возвращает обработчик DELETE /{cards|lists|boards}/{id}/watch.
+func (h *WatchHandler) Unwatch(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		username, ok := currentUser(w, r)
+		if !ok {
+			return
+		}
+		id, ok := pathID(w, r, h.logger)
+		if !ok {
+			return
+		}
+		if err := h.service.Unwatch(username, model.WatchTarget{Kind: kind, ID: id}); err != nil {
+			fail(w, h.logger, err, "Ошибка отписки", zap.String("kind", kind), zap.Int("id", id), zap.String("username", username))
+			return
+		}
+		h.logger.Info("Пользователь отписался", zap.String("kind", kind), zap.Int("id", id), zap.String("username", username))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetWatching обрабатывает GET /me/watching.
+func (h *WatchHandler) GetWatching(w http.ResponseWriter, r *http.Request) {
+	username, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	watches, err := h.service.GetWatching(username)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения подписок", zap.String("username", username))
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, dto.WatchesToDTO(watches))
+}
+
+// GetCardWatchers обрабатывает GET /cards/{id}/watchers: все, кто получит
+// уведомление об изменении карточки.
+func (h *WatchHandler) GetCardWatchers(w http.ResponseWriter, r *http.Request) {
+	cardID, ok := pathID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	watchers, err := h.service.GetCardWatchers(cardID)
+	if err != nil {
+		fail(w, h.logger, err, "Ошибка получения наблюдателей карточки", zap.Int("cardID", cardID))
+		return
+	}
+	if watchers == nil {
+		watchers = []string{}
+	}
+	writeJSON(w, h.logger, http.StatusOK, watchers)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"awesomeProject2/cmd/dto"
+	"awesomeProject2/cmd/model"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		kind           string
+		id             string
+		user           string
+		mockError      error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "card", kind: model.WatchCard, id: "5", user: "anna", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "board", kind: model.WatchBoard, id: "5", user: "anna", expectCall: true, expectedStatus: http.StatusOK},
+		{
+			name:           "list not found",
+			kind:           model.WatchList,
+			id:             "5",
+			user:           "anna",
+			mockError:      fmt.Errorf("list 5: %w", model.ErrNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{name: "no user", kind: model.WatchCard, id: "5", expectedStatus: http.StatusUnauthorized},
+		{name: "invalid id", kind: model.WatchCard, id: "x", user: "anna", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWatchService)
+			handler := NewWatchHandler(mockService, zap.NewNop())
+			if tt.expectCall {
+				mockService.On("Watch", tt.user, model.WatchTarget{Kind: tt.kind, ID: 5}).
+					Return(model.Watch{Username: tt.user, Kind: tt.kind, TargetID: 5}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/"+tt.kind+"s/"+tt.id+"/watch", nil)
+			req.SetPathValue("id", tt.id)
+			if tt.user != "" {
+				req.Header.Set(userHeader, tt.user)
+			}
+			rec := httptest.NewRecorder()
+			handler.Watch(tt.kind)(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp dto.WatchDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, dto.WatchDTO{Kind: tt.kind, TargetID: 5}, resp)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUnwatch(t *testing.T) {
+	mockService := new(MockWatchService)
+	handler := NewWatchHandler(mockService, zap.NewNop())
+	target := model.WatchTarget{Kind: model.WatchList, ID: 3}
+	mockService.On("Unwatch", "anna", target).Return(nil)
+	mockService.On("Unwatch", "boris", target).Return(fmt.Errorf("watch of list 3 by boris: %w", model.ErrNotFound))
+
+	for username, status := range map[string]int{"anna": http.StatusNoContent, "boris": http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodDelete, "/lists/3/watch", nil)
+		req.SetPathValue("id", "3")
+		req.Header.Set(userHeader, username)
+		rec := httptest.NewRecorder()
+		handler.Unwatch(model.WatchList)(rec, req)
+		require.Equal(t, status, rec.Code, username)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestGetCardWatchers(t *testing.T) {
+	mockService := new(MockWatchService)
+	handler := NewWatchHandler(mockService, zap.NewNop())
+	mockService.On("GetCardWatchers", 7).Return([]string(nil), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/cards/7/watchers", nil)
+	req.SetPathValue("id", "7")
+	rec := httptest.NewRecorder()
+	handler.GetCardWatchers(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `[]`, rec.Body.String())
+	mockService.AssertExpectations(t)
+}
@@ -1,5 +1,5 @@
 package helper
 
-func GetPointer(a int) *int {
+func GetPointer[T any](a T) *T {
 	return &a
 }
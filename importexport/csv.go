@@ -0,0 +1,217 @@
+package importexport
+
+import (
+	"awesomeProject2/cmd/model"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCSV = errors.New("invalid csv")
+
+// csvSeparator разделяет несколько значений в одной ячейке.
+const csvSeparator = "; "
+
+var csvExportHeader = []string{"id", "title", "description", "list", "status", "assignees", "labels", "created_at", "updated_at"}
+
+type RowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type CSVImportResult struct {
+	Created []model.Card
+	Errors  []RowError
+}
+
+// ExportCardsCSV пишет карточки доски в w по мере чтения из хранилища,
+// не собирая всю выгрузку в памяти.
+func (s Service) ExportCardsCSV(boardID int, w io.Writer) error {
+	if _, err := s.Storage.GetBoard(boardID); err != nil {
+		return err
+	}
+	lists, err := s.Storage.GetLists(&boardID)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvExportHeader); err != nil {
+		return err
+	}
+	for _, l := range lists {
+		cards, err := s.Storage.GetCards(model.CardFilter{ListID: &l.ID})
+		if err != nil {
+			return err
+		}
+		for _, c := range cards {
+			assignees, err := s.Storage.GetCardAssignees(c.ID)
+			if err != nil {
+				return err
+			}
+			labels, err := s.Storage.GetCardLabels(c.ID)
+			if err != nil {
+				return err
+			}
+			labelNames := make([]string, 0, len(labels))
+			for _, label := range labels {
+				labelNames = append(labelNames, label.Name)
+			}
+			record := []string{
+				strconv.Itoa(c.ID),
+				c.Title,
+				c.Description,
+				l.Title,
+				c.Status,
+				strings.Join(assignees, csvSeparator),
+				strings.Join(labelNames, csvSeparator),
+				c.CreatedAt.UTC().Format(time.RFC3339),
+				c.UpdatedAt.UTC().Format(time.RFC3339),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportCardsCSV создаёт карточки в списке listID. Первая строка — заголовок
+// с обязательной колонкой title и необязательными description, labels и
+// assignees; прочие колонки игнорируются. Ошибка в строке не прерывает
+// загрузку: она попадает в CSVImportResult.Errors с номером строки файла
+// (заголовок — строка 1). Строки сверх жёсткого лимита WIP списка тоже
+// становятся такими ошибками.
+func (s Service) ImportCardsCSV(listID int, r io.Reader) (CSVImportResult, error) {
+	list, err := s.Storage.GetList(listID)
+	if err != nil {
+		return CSVImportResult{}, err
+	}
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return CSVImportResult{}, fmt.Errorf("%w: header row is required", ErrInvalidCSV)
+	}
+	if err != nil {
+		return CSVImportResult{}, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+	columns, err := csvColumns(header)
+	if err != nil {
+		return CSVImportResult{}, err
+	}
+
+	labels, err := s.Storage.GetLabels(list.BoardID)
+	if err != nil {
+		return CSVImportResult{}, err
+	}
+	labelsByName := map[string]model.Label{}
+	for _, l := range labels {
+		labelsByName[strings.ToLower(l.Name)] = l
+	}
+
+	result := CSVImportResult{Created: []model.Card{}, Errors: []RowError{}}
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, csv.ErrFieldCount) {
+			result.Errors = append(result.Errors, RowError{Row: row, Message: fmt.Sprintf("expected %d fields, got %d", len(header), len(record))})
+			continue
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// После синтаксической ошибки границы строк уже не определить.
+			result.Errors = append(result.Errors, RowError{Row: row, Message: err.Error()})
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		card, err := s.Cards.CreateCard(model.CardInputCreate{
+			ListID:      listID,
+			Title:       columns.get(record, "title"),
+			Description: columns.get(record, "description"),
+		})
+		if errors.Is(err, model.ErrInvalidInput) || errors.Is(err, model.ErrConflict) {
+			result.Errors = append(result.Errors, RowError{Row: row, Message: err.Error()})
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("row %d: %w", row, err)
+		}
+		for _, name := range splitCSVValues(columns.get(record, "labels")) {
+			label, ok := labelsByName[strings.ToLower(name)]
+			if !ok {
+				label, err = s.Storage.CreateLabel(model.LabelInputCreate{BoardID: list.BoardID, Name: name})
+				if err != nil {
+					return result, fmt.Errorf("row %d: %w", row, err)
+				}
+				labelsByName[strings.ToLower(name)] = label
+			}
+			if err := s.Storage.AddCardLabel(card.ID, label.ID); err != nil {
+				return result, fmt.Errorf("row %d: %w", row, err)
+			}
+		}
+		for _, username := range splitCSVValues(columns.get(record, "assignees")) {
+			if err := s.Storage.AddCardAssignee(card.ID, username); err != nil {
+				return result, fmt.Errorf("row %d: %w", row, err)
+			}
+		}
+		result.Created = append(result.Created, card)
+	}
+	s.logger.Info("Карточки импортированы из CSV", zap.Int("list_id", listID), zap.Int("created", len(result.Created)), zap.Int("errors", len(result.Errors)))
+	return result, nil
+}
+
+type csvColumnIndex map[string]int
+
+func csvColumns(header []string) (csvColumnIndex, error) {
+	columns := csvColumnIndex{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		switch name {
+		case "title", "description", "labels", "assignees":
+		default:
+			// Остальные колонки (например, из нашей же выгрузки) пропускаем.
+			continue
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidCSV, name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("%w: title column is required", ErrInvalidCSV)
+	}
+	return columns, nil
+}
+
+func (c csvColumnIndex) get(record []string, name string) string {
+	i, ok := c[name]
+	if !ok {
+		return ""
+	}
+	return record[i]
+}
+
+func splitCSVValues(cell string) []string {
+	var values []string
+	for _, v := range strings.Split(cell, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,143 @@
+package importexport
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"bytes"
+	"encoding/csv"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestExportCardsCSV(t *testing.T) {
+	store := storage.NewStorage()
+	board := seedBoard(t, store)
+
+	var buf bytes.Buffer
+	require.NoError(t, newTestService(store).ExportCardsCSV(board.ID, &buf))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	require.Equal(t, csvExportHeader, records[0])
+	require.Len(t, records, 4, "foreign board and archived cards are not exported")
+	first := records[1]
+	require.Equal(t, []string{"first", "desc", "To Do", "anna; ivan", "bug; ux"}, []string{first[1], first[2], first[3], first[5], first[6]})
+	require.NotEmpty(t, first[7])
+	require.Equal(t, "second", records[2][1])
+	require.Equal(t, []string{"shipped", "Done"}, []string{records[3][1], records[3][3]})
+
+	err = newTestService(store).ExportCardsCSV(999, &buf)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestImportCardsCSV(t *testing.T) {
+	two := 2
+	tests := []struct {
+		name        string
+		data        string
+		wipLimit    *int
+		expectError error
+		wantTitles  []string
+		wantErrors  []RowError
+	}{
+		{
+			name:       "title only",
+			data:       "title\nfirst\nsecond\n",
+			wantTitles: []string{"first", "second"},
+			wantErrors: []RowError{},
+		},
+		{
+			name:       "row errors do not abort the upload",
+			data:       "title,description\nok,fine\n,no title\n" + strings.Repeat("x", 513) + ",too long\nextra,fields,here\nlast,\n",
+			wantTitles: []string{"ok", "last"},
+			wantErrors: []RowError{
+				{Row: 3, Message: "invalid input: title is required"},
+				{Row: 4, Message: "invalid input: title is longer than 512 characters"},
+				{Row: 5, Message: "expected 2 fields, got 3"},
+			},
+		},
+		{
+			name:       "rows over a hard WIP limit",
+			data:       "title\nfirst\nsecond\nthird\n",
+			wipLimit:   &two,
+			wantTitles: []string{"first", "second"},
+			wantErrors: []RowError{{Row: 4, Message: "conflict: list 1 is at its WIP limit of 2"}},
+		},
+		{
+			name:        "missing title column",
+			data:        "name,description\nfirst,desc\n",
+			expectError: ErrInvalidCSV,
+		},
+		{
+			name:        "empty file",
+			data:        "",
+			expectError: ErrInvalidCSV,
+		},
+		{
+			name:        "duplicate column",
+			data:        "title,Title\na,b\n",
+			expectError: ErrInvalidCSV,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewStorage()
+			board, err := store.CreateBoard("b")
+			require.NoError(t, err)
+			list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo", WIPLimit: tt.wipLimit})
+			require.NoError(t, err)
+
+			result, err := newTestService(store).ImportCardsCSV(list.ID, strings.NewReader(tt.data))
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			var titles []string
+			for _, c := range result.Created {
+				titles = append(titles, c.Title)
+			}
+			require.Equal(t, tt.wantTitles, titles)
+			require.Equal(t, tt.wantErrors, result.Errors)
+
+			stored, err := store.GetCards(model.CardFilter{ListID: &list.ID})
+			require.NoError(t, err)
+			require.Len(t, stored, len(tt.wantTitles))
+		})
+	}
+}
+
+func TestImportCardsCSVLabelsAndAssignees(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	bug, err := store.CreateLabel(model.LabelInputCreate{BoardID: board.ID, Name: "bug", Color: "red"})
+	require.NoError(t, err)
+
+	data := "\ufeffTitle,Labels,Assignees,Status\n\"Fix, login\",\"BUG; backend\",\"anna;ivan\",ignored\nOther,backend,,\n"
+	result, err := newTestService(store).ImportCardsCSV(list.ID, strings.NewReader(data))
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+	require.Len(t, result.Created, 2)
+	require.Equal(t, "Fix, login", result.Created[0].Title)
+
+	labels, err := store.GetLabels(board.ID)
+	require.NoError(t, err)
+	require.Len(t, labels, 2, "missing label is created once and reused")
+	require.Equal(t, bug, labels[0])
+	require.Equal(t, "backend", labels[1].Name)
+
+	cardLabels, err := store.GetCardLabels(result.Created[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.Label{bug, labels[1]}, cardLabels)
+	assignees, err := store.GetCardAssignees(result.Created[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna", "ivan"}, assignees)
+
+	_, err = newTestService(store).ImportCardsCSV(999, strings.NewReader(data))
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
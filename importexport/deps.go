@@ -0,0 +1,30 @@
+package importexport
+
+import "awesomeProject2/cmd/model"
+
+type Storage interface {
+	GetBoard(id int) (model.Board, error)
+	CreateBoard(title string) (model.Board, error)
+	GetLists(boardID *int) ([]model.List, error)
+	GetList(id int) (model.List, error)
+	CreateList(input model.ListInputCreate) (model.List, error)
+	GetCards(filter model.CardFilter) ([]model.Card, error)
+	CreateCard(input model.CardInputCreate) (model.Card, error)
+	GetLabels(boardID int) ([]model.Label, error)
+	CreateLabel(input model.LabelInputCreate) (model.Label, error)
+	GetCardLabels(cardID int) ([]model.Label, error)
+	AddCardLabel(cardID int, labelID int) error
+	GetCardAssignees(cardID int) ([]string, error)
+	AddCardAssignee(cardID int, username string) error
+	GetChecklists(cardID int) ([]model.Checklist, error)
+	CreateChecklist(input model.ChecklistInputCreate) (model.Checklist, error)
+	CreateChecklistItem(input model.ChecklistItemInputCreate) (model.ChecklistItem, error)
+	GetComments(cardID int) ([]model.Comment, error)
+	CreateComment(input model.CommentInputCreate) (model.Comment, error)
+}
+
+// CardCreator создаёт карточки с теми же проверками, что и API,
+// поэтому CSV-импорт идёт через сервис, а не напрямую в хранилище.
+type CardCreator interface {
+	CreateCard(input model.CardInputCreate) (model.Card, error)
+}
@@ -0,0 +1,68 @@
+package importexport
+
+import "time"
+
+const (
+	FormatName    = "testtrello"
+	FormatVersion = 1
+)
+
+// Document — собственный формат выгрузки доски. Идентификаторы в нём
+// локальны для документа и при импорте заменяются новыми.
+type Document struct {
+	Format     string     `json:"format"`
+	Version    int        `json:"version"`
+	ExportedAt time.Time  `json:"exported_at"`
+	Board      BoardDoc   `json:"board"`
+	Labels     []LabelDoc `json:"labels"`
+	Lists      []ListDoc  `json:"lists"`
+	Cards      []CardDoc  `json:"cards"`
+}
+
+type BoardDoc struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+type LabelDoc struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type ListDoc struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// CardDoc — карточка вместе с архивными: Archived отмечает, что после
+// импорта её нужно вернуть в архив.
+type CardDoc struct {
+	ID          int            `json:"id"`
+	ListID      int            `json:"list_id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Status      string         `json:"status"`
+	DueAt       *time.Time     `json:"due_at"`
+	Archived    bool           `json:"archived"`
+	Assignees   []string       `json:"assignees"`
+	LabelIDs    []int          `json:"label_ids"`
+	Checklists  []ChecklistDoc `json:"checklists"`
+	Comments    []CommentDoc   `json:"comments"`
+}
+
+type ChecklistDoc struct {
+	Title string             `json:"title"`
+	Items []ChecklistItemDoc `json:"items"`
+}
+
+type ChecklistItemDoc struct {
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+type CommentDoc struct {
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,300 @@
+package importexport
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDocument = errors.New("invalid import document")
+
+type Service struct {
+	Storage Storage
+	Cards   CardCreator
+	Tx      service.Transactor
+	logger  *zap.Logger
+	now     func() time.Time
+}
+
+func NewService(storage Storage, cards CardCreator, tx service.Transactor, logger *zap.Logger) *Service {
+	return &Service{
+		Storage: storage,
+		Cards:   cards,
+		Tx:      tx,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+func (s Service) Export(boardID int) (Document, error) {
+	board, err := s.Storage.GetBoard(boardID)
+	if err != nil {
+		return Document{}, err
+	}
+	doc := Document{
+		Format:     FormatName,
+		Version:    FormatVersion,
+		ExportedAt: s.now().UTC(),
+		Board:      BoardDoc{ID: board.ID, Title: board.Title},
+		Labels:     []LabelDoc{},
+		Lists:      []ListDoc{},
+		Cards:      []CardDoc{},
+	}
+
+	labels, err := s.Storage.GetLabels(boardID)
+	if err != nil {
+		return Document{}, err
+	}
+	for _, l := range labels {
+		doc.Labels = append(doc.Labels, LabelDoc{ID: l.ID, Name: l.Name, Color: l.Color})
+	}
+
+	lists, err := s.Storage.GetLists(&boardID)
+	if err != nil {
+		return Document{}, err
+	}
+	for _, l := range lists {
+		doc.Lists = append(doc.Lists, ListDoc{ID: l.ID, Title: l.Title})
+		cards, err := s.Storage.GetCards(model.CardFilter{ListID: &l.ID, IncludeArchived: true})
+		if err != nil {
+			return Document{}, err
+		}
+		for _, c := range cards {
+			card, err := s.exportCard(c)
+			if err != nil {
+				return Document{}, err
+			}
+			doc.Cards = append(doc.Cards, card)
+		}
+	}
+	return doc, nil
+}
+
+func (s Service) exportCard(c model.Card) (CardDoc, error) {
+	card := CardDoc{
+		ID:          c.ID,
+		ListID:      c.ListID,
+		Title:       c.Title,
+		Description: c.Description,
+		Status:      c.Status,
+		DueAt:       c.DueAt,
+		Archived:    c.ArchivedAt != nil,
+		LabelIDs:    []int{},
+		Checklists:  []ChecklistDoc{},
+		Comments:    []CommentDoc{},
+	}
+	assignees, err := s.Storage.GetCardAssignees(c.ID)
+	if err != nil {
+		return CardDoc{}, err
+	}
+	card.Assignees = append([]string{}, assignees...)
+	labels, err := s.Storage.GetCardLabels(c.ID)
+	if err != nil {
+		return CardDoc{}, err
+	}
+	for _, l := range labels {
+		card.LabelIDs = append(card.LabelIDs, l.ID)
+	}
+	checklists, err := s.Storage.GetChecklists(c.ID)
+	if err != nil {
+		return CardDoc{}, err
+	}
+	for _, cl := range checklists {
+		doc := ChecklistDoc{Title: cl.Title, Items: []ChecklistItemDoc{}}
+		for _, item := range cl.Items {
+			doc.Items = append(doc.Items, ChecklistItemDoc{Text: item.Text, Checked: item.Checked})
+		}
+		card.Checklists = append(card.Checklists, doc)
+	}
+	comments, err := s.Storage.GetComments(c.ID)
+	if err != nil {
+		return CardDoc{}, err
+	}
+	for _, cm := range comments {
+		card.Comments = append(card.Comments, CommentDoc{Author: cm.Author, Text: cm.Text, CreatedAt: cm.CreatedAt})
+	}
+	return card, nil
+}
+
+// Import принимает собственный формат или JSON-выгрузку доски из Trello
+// и создаёт новую доску, сохраняя порядок списков, карточек и чек-листов.
+// Доска создаётся в одной транзакции: при ошибке хранилища на середине
+// импорта недоделанная доска не остаётся.
+func (s Service) Import(data []byte) (model.Board, error) {
+	doc, err := Parse(data)
+	if err != nil {
+		return model.Board{}, err
+	}
+	if err := doc.validate(); err != nil {
+		return model.Board{}, err
+	}
+	var board model.Board
+	err = s.Tx.InTx(func(tx service.Tx) error {
+		board, err = importDocument(tx, doc)
+		return err
+	})
+	if err != nil {
+		return model.Board{}, err
+	}
+	s.logger.Info("Доска импортирована", zap.Int("board_id", board.ID), zap.Int("lists", len(doc.Lists)), zap.Int("cards", len(doc.Cards)))
+	return board, nil
+}
+
+func importDocument(tx service.Tx, doc Document) (model.Board, error) {
+	board, err := tx.CreateBoard(doc.Board.Title)
+	if err != nil {
+		return model.Board{}, err
+	}
+	labelIDs := map[int]int{}
+	for _, l := range doc.Labels {
+		label, err := tx.CreateLabel(model.LabelInputCreate{BoardID: board.ID, Name: l.Name, Color: l.Color})
+		if err != nil {
+			return model.Board{}, err
+		}
+		labelIDs[l.ID] = label.ID
+	}
+	listIDs := map[int]int{}
+	for _, l := range doc.Lists {
+		list, err := tx.CreateList(model.ListInputCreate{BoardID: board.ID, Title: l.Title})
+		if err != nil {
+			return model.Board{}, err
+		}
+		listIDs[l.ID] = list.ID
+	}
+	for _, c := range doc.Cards {
+		card, err := tx.CreateCard(model.CardInputCreate{
+			ListID:      listIDs[c.ListID],
+			Title:       strings.TrimSpace(c.Title),
+			Description: c.Description,
+		})
+		if err != nil {
+			return model.Board{}, err
+		}
+		if c.Status != "" {
+			if _, err := tx.SetCardStatus(card.ID, c.Status); err != nil {
+				return model.Board{}, err
+			}
+		}
+		if c.DueAt != nil {
+			if _, err := tx.SetCardDue(card.ID, c.DueAt); err != nil {
+				return model.Board{}, err
+			}
+		}
+		for _, username := range c.Assignees {
+			if err := tx.AddCardAssignee(card.ID, username); err != nil {
+				return model.Board{}, err
+			}
+		}
+		for _, id := range c.LabelIDs {
+			if err := tx.AddCardLabel(card.ID, labelIDs[id]); err != nil {
+				return model.Board{}, err
+			}
+		}
+		for _, cl := range c.Checklists {
+			checklist, err := tx.CreateChecklist(model.ChecklistInputCreate{CardID: card.ID, Title: cl.Title})
+			if err != nil {
+				return model.Board{}, err
+			}
+			for _, item := range cl.Items {
+				if _, err := tx.CreateChecklistItem(model.ChecklistItemInputCreate{
+					ChecklistID: checklist.ID,
+					Text:        item.Text,
+					Checked:     item.Checked,
+				}); err != nil {
+					return model.Board{}, err
+				}
+			}
+		}
+		for _, cm := range c.Comments {
+			input := model.CommentInputCreate{CardID: card.ID, Author: cm.Author, Text: cm.Text}
+			if !cm.CreatedAt.IsZero() {
+				input.CreatedAt = &cm.CreatedAt
+			}
+			if _, err := tx.CreateComment(input); err != nil {
+				return model.Board{}, err
+			}
+		}
+		if c.Archived {
+			if _, err := tx.ArchiveCard(card.ID); err != nil {
+				return model.Board{}, err
+			}
+		}
+	}
+	return board, nil
+}
+
+// Parse определяет формат по содержимому: у собственного формата есть
+// поле "format", выгрузка Trello узнаётся по полям "name" и "lists".
+func Parse(data []byte) (Document, error) {
+	var probe struct {
+		Format *string          `json:"format"`
+		Name   *string          `json:"name"`
+		Lists  *json.RawMessage `json:"lists"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return Document{}, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	switch {
+	case probe.Format != nil:
+		var doc Document
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return Document{}, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+		if doc.Format != FormatName || doc.Version != FormatVersion {
+			return Document{}, fmt.Errorf("%w: unsupported format %q version %d", ErrInvalidDocument, doc.Format, doc.Version)
+		}
+		return doc, nil
+	case probe.Name != nil && probe.Lists != nil:
+		var board trelloBoard
+		if err := json.Unmarshal(data, &board); err != nil {
+			return Document{}, fmt.Errorf("%w: trello: %v", ErrInvalidDocument, err)
+		}
+		return board.toDocument(), nil
+	default:
+		return Document{}, fmt.Errorf("%w: unknown format", ErrInvalidDocument)
+	}
+}
+
+// validate проверяет ссылки до создания чего-либо, чтобы битый файл
+// не оставлял после себя полуимпортированную доску.
+func (d Document) validate() error {
+	if d.Board.Title == "" {
+		return fmt.Errorf("%w: board title is required", ErrInvalidDocument)
+	}
+	labels := map[int]bool{}
+	for _, l := range d.Labels {
+		if labels[l.ID] {
+			return fmt.Errorf("%w: duplicate label id %d", ErrInvalidDocument, l.ID)
+		}
+		labels[l.ID] = true
+	}
+	lists := map[int]bool{}
+	for _, l := range d.Lists {
+		if lists[l.ID] {
+			return fmt.Errorf("%w: duplicate list id %d", ErrInvalidDocument, l.ID)
+		}
+		if l.Title == "" {
+			return fmt.Errorf("%w: list %d has no title", ErrInvalidDocument, l.ID)
+		}
+		lists[l.ID] = true
+	}
+	for _, c := range d.Cards {
+		if !lists[c.ListID] {
+			return fmt.Errorf("%w: card %d refers to unknown list %d", ErrInvalidDocument, c.ID, c.ListID)
+		}
+		if err := service.ValidateCardTitle(strings.TrimSpace(c.Title)); err != nil {
+			return fmt.Errorf("%w: card %d: %v", ErrInvalidDocument, c.ID, err)
+		}
+		for _, id := range c.LabelIDs {
+			if !labels[id] {
+				return fmt.Errorf("%w: card %d refers to unknown label %d", ErrInvalidDocument, c.ID, id)
+			}
+		}
+	}
+	return nil
+}
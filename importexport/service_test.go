@@ -0,0 +1,300 @@
+package importexport
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/storage"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var exportedAt = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestService(store *storage.Storage) *Service {
+	s := NewService(store, service.NewCardService(store, store, nil, zap.NewNop()), store, zap.NewNop())
+	s.now = func() time.Time { return exportedAt }
+	return s
+}
+
+func seedBoard(t *testing.T, s *storage.Storage) model.Board {
+	t.Helper()
+	// Посторонняя доска, чтобы идентификаторы не совпадали с порядковыми.
+	other, err := s.CreateBoard("other")
+	require.NoError(t, err)
+	otherList, err := s.CreateList(model.ListInputCreate{BoardID: other.ID, Title: "other"})
+	require.NoError(t, err)
+
+	board, err := s.CreateBoard("Roadmap")
+	require.NoError(t, err)
+	bug, err := s.CreateLabel(model.LabelInputCreate{BoardID: board.ID, Name: "bug", Color: "red"})
+	require.NoError(t, err)
+	ux, err := s.CreateLabel(model.LabelInputCreate{BoardID: board.ID, Name: "ux", Color: "blue"})
+	require.NoError(t, err)
+	todo, err := s.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "To Do"})
+	require.NoError(t, err)
+	done, err := s.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "Done"})
+	require.NoError(t, err)
+
+	_, err = s.CreateCard(model.CardInputCreate{ListID: done.ID, Title: "shipped"})
+	require.NoError(t, err)
+	_, err = s.CreateCard(model.CardInputCreate{ListID: otherList.ID, Title: "foreign"})
+	require.NoError(t, err)
+	first, err := s.CreateCard(model.CardInputCreate{ListID: todo.ID, Title: "first", Description: "desc"})
+	require.NoError(t, err)
+	_, err = s.CreateCard(model.CardInputCreate{ListID: todo.ID, Title: "second"})
+	require.NoError(t, err)
+	old, err := s.CreateCard(model.CardInputCreate{ListID: done.ID, Title: "old"})
+	require.NoError(t, err)
+	_, err = s.ArchiveCard(old.ID)
+	require.NoError(t, err)
+	_, err = s.SetCardStatus(first.ID, "in progress")
+	require.NoError(t, err)
+	due := time.Date(2024, 7, 1, 18, 0, 0, 0, time.UTC)
+	_, err = s.SetCardDue(first.ID, &due)
+	require.NoError(t, err)
+	require.NoError(t, s.AddCardAssignee(first.ID, "anna"))
+	require.NoError(t, s.AddCardAssignee(first.ID, "ivan"))
+
+	require.NoError(t, s.AddCardLabel(first.ID, ux.ID))
+	require.NoError(t, s.AddCardLabel(first.ID, bug.ID))
+	checklist, err := s.CreateChecklist(model.ChecklistInputCreate{CardID: first.ID, Title: "steps"})
+	require.NoError(t, err)
+	_, err = s.CreateChecklistItem(model.ChecklistItemInputCreate{ChecklistID: checklist.ID, Text: "one", Checked: true})
+	require.NoError(t, err)
+	_, err = s.CreateChecklistItem(model.ChecklistItemInputCreate{ChecklistID: checklist.ID, Text: "two"})
+	require.NoError(t, err)
+	at := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	_, err = s.CreateComment(model.CommentInputCreate{CardID: first.ID, Author: "anna", Text: "looks good", CreatedAt: &at})
+	require.NoError(t, err)
+	return board
+}
+
+// normalize заменяет идентификаторы порядковыми номерами, чтобы сравнивать
+// выгрузки разных экземпляров одной доски.
+func normalize(doc Document) Document {
+	doc.Board.ID = 0
+	labels := map[int]int{}
+	for i := range doc.Labels {
+		labels[doc.Labels[i].ID] = i + 1
+		doc.Labels[i].ID = i + 1
+	}
+	lists := map[int]int{}
+	for i := range doc.Lists {
+		lists[doc.Lists[i].ID] = i + 1
+		doc.Lists[i].ID = i + 1
+	}
+	for i := range doc.Cards {
+		doc.Cards[i].ID = i + 1
+		doc.Cards[i].ListID = lists[doc.Cards[i].ListID]
+		for j, id := range doc.Cards[i].LabelIDs {
+			doc.Cards[i].LabelIDs[j] = labels[id]
+		}
+		if due := doc.Cards[i].DueAt; due != nil {
+			utc := due.UTC()
+			doc.Cards[i].DueAt = &utc
+		}
+		for j := range doc.Cards[i].Comments {
+			doc.Cards[i].Comments[j].CreatedAt = doc.Cards[i].Comments[j].CreatedAt.UTC()
+		}
+	}
+	return doc
+}
+
+func TestExport(t *testing.T) {
+	store := storage.NewStorage()
+	board := seedBoard(t, store)
+	service := newTestService(store)
+
+	doc, err := service.Export(board.ID)
+	require.NoError(t, err)
+	require.Equal(t, FormatName, doc.Format)
+	require.Equal(t, exportedAt, doc.ExportedAt)
+	require.Equal(t, "Roadmap", doc.Board.Title)
+	require.Len(t, doc.Labels, 2)
+	require.Len(t, doc.Lists, 2)
+	var titles []string
+	for _, c := range doc.Cards {
+		titles = append(titles, c.Title)
+	}
+	require.Equal(t, []string{"first", "second", "shipped", "old"}, titles)
+	require.True(t, doc.Cards[3].Archived, "archived cards are exported too")
+
+	first := doc.Cards[0]
+	require.Equal(t, doc.Lists[0].ID, first.ListID)
+	require.Equal(t, "in progress", first.Status)
+	require.True(t, time.Date(2024, 7, 1, 18, 0, 0, 0, time.UTC).Equal(*first.DueAt))
+	require.Equal(t, []string{"anna", "ivan"}, first.Assignees)
+	require.False(t, first.Archived)
+	require.Equal(t, []int{doc.Labels[0].ID, doc.Labels[1].ID}, first.LabelIDs)
+	require.Equal(t, []ChecklistDoc{{Title: "steps", Items: []ChecklistItemDoc{{Text: "one", Checked: true}, {Text: "two"}}}}, first.Checklists)
+	require.Len(t, first.Comments, 1)
+	require.Equal(t, "anna", first.Comments[0].Author)
+
+	_, err = service.Export(999)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestRoundTrip(t *testing.T) {
+	source := storage.NewStorage()
+	board := seedBoard(t, source)
+	original, err := newTestService(source).Export(board.ID)
+	require.NoError(t, err)
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	target := storage.NewStorage()
+	targetService := newTestService(target)
+	imported, err := targetService.Import(data)
+	require.NoError(t, err)
+	require.Equal(t, "Roadmap", imported.Title)
+
+	again, err := targetService.Export(imported.ID)
+	require.NoError(t, err)
+	require.Equal(t, normalize(original), normalize(again))
+
+	// Повторный импорт в то же хранилище создаёт независимую копию.
+	second, err := targetService.Import(data)
+	require.NoError(t, err)
+	require.NotEqual(t, imported.ID, second.ID)
+	copyDoc, err := targetService.Export(second.ID)
+	require.NoError(t, err)
+	require.Equal(t, normalize(original), normalize(copyDoc))
+}
+
+func TestImportTrello(t *testing.T) {
+	data, err := os.ReadFile("testdata/trello_board.json")
+	require.NoError(t, err)
+
+	store := storage.NewStorage()
+	service := newTestService(store)
+	board, err := service.Import(data)
+	require.NoError(t, err)
+	require.Equal(t, "Sprint 42", board.Title)
+
+	doc, err := service.Export(board.ID)
+	require.NoError(t, err)
+	require.Equal(t, []LabelDoc{
+		{ID: doc.Labels[0].ID, Name: "bug", Color: "red"},
+		{ID: doc.Labels[1].ID, Name: "later", Color: ""},
+	}, doc.Labels)
+
+	var lists []string
+	for _, l := range doc.Lists {
+		lists = append(lists, l.Title)
+	}
+	require.Equal(t, []string{"To Do", "Doing", "Done"}, lists, "lists follow pos, archived ones are skipped")
+
+	var cards []string
+	for _, c := range doc.Cards {
+		cards = append(cards, c.Title)
+	}
+	require.Equal(t, []string{"First task", "Second task", "Shipped"}, cards, "cards follow pos, archived ones are skipped")
+
+	first := doc.Cards[0]
+	require.Equal(t, "Do **this** first", first.Description)
+	require.Equal(t, []int{doc.Labels[0].ID, doc.Labels[1].ID}, first.LabelIDs)
+	require.Equal(t, []ChecklistDoc{
+		{Title: "Steps", Items: []ChecklistItemDoc{{Text: "first", Checked: true}, {Text: "second"}}},
+		{Title: "Review", Items: []ChecklistItemDoc{{Text: "code review"}}},
+	}, first.Checklists)
+	require.Len(t, first.Comments, 2)
+	require.Equal(t, "older comment", first.Comments[0].Text)
+	require.Equal(t, "Anna K", first.Comments[0].Author)
+	require.Equal(t, "newer comment", first.Comments[1].Text)
+	require.Equal(t, "ivan", first.Comments[1].Author)
+	require.True(t, time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC).Equal(first.Comments[0].CreatedAt))
+}
+
+func TestImportInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not json", data: `{`},
+		{name: "unknown format", data: `{"foo": 1}`},
+		{name: "wrong version", data: `{"format": "testtrello", "version": 99, "board": {"title": "x"}}`},
+		{name: "foreign format name", data: `{"format": "other", "version": 1, "board": {"title": "x"}}`},
+		{name: "no board title", data: `{"format": "testtrello", "version": 1, "board": {}}`},
+		{
+			name: "card in unknown list",
+			data: `{"format": "testtrello", "version": 1, "board": {"title": "x"},
+				"lists": [{"id": 1, "title": "l"}], "cards": [{"id": 1, "list_id": 2, "title": "c"}]}`,
+		},
+		{
+			name: "card with unknown label",
+			data: `{"format": "testtrello", "version": 1, "board": {"title": "x"},
+				"lists": [{"id": 1, "title": "l"}], "cards": [{"id": 1, "list_id": 1, "title": "c", "label_ids": [5]}]}`,
+		},
+		{
+			name: "duplicate list ids",
+			data: `{"format": "testtrello", "version": 1, "board": {"title": "x"},
+				"lists": [{"id": 1, "title": "a"}, {"id": 1, "title": "b"}]}`,
+		},
+		{
+			name: "blank card title",
+			data: `{"format": "testtrello", "version": 1, "board": {"title": "x"},
+				"lists": [{"id": 1, "title": "l"}], "cards": [{"id": 1, "list_id": 1, "title": "  "}]}`,
+		},
+		{
+			name: "card title too long",
+			data: `{"format": "testtrello", "version": 1, "board": {"title": "x"},
+				"lists": [{"id": 1, "title": "l"}], "cards": [{"id": 1, "list_id": 1, "title": "` + strings.Repeat("x", 513) + `"}]}`,
+		},
+		{name: "trello without name", data: `{"name": "", "lists": []}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewStorage()
+			_, err := newTestService(store).Import([]byte(tt.data))
+			require.ErrorIs(t, err, ErrInvalidDocument)
+
+			boards, err := store.GetBoards()
+			require.NoError(t, err)
+			require.Empty(t, boards, "nothing is created for an invalid document")
+		})
+	}
+}
+
+// failingTx проводит транзакцию в хранилище, но отказывает в записи
+// комментариев, имитируя сбой хранилища на середине импорта.
+type failingTx struct {
+	*storage.Storage
+}
+
+func (s failingTx) InTx(fn func(tx service.Tx) error) error {
+	return s.Storage.InTx(func(tx service.Tx) error {
+		return fn(failingComments{tx})
+	})
+}
+
+type failingComments struct {
+	service.Tx
+}
+
+func (failingComments) CreateComment(model.CommentInputCreate) (model.Comment, error) {
+	return model.Comment{}, errors.New("db down")
+}
+
+func TestImportRollsBack(t *testing.T) {
+	source := storage.NewStorage()
+	doc, err := newTestService(source).Export(seedBoard(t, source).ID)
+	require.NoError(t, err)
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	store := storage.NewStorage()
+	s := newTestService(store)
+	s.Tx = failingTx{store}
+	_, err = s.Import(data)
+	require.ErrorContains(t, err, "db down")
+
+	boards, err := store.GetBoards()
+	require.NoError(t, err)
+	require.Empty(t, boards, "a failed import leaves no board behind")
+}
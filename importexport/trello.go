@@ -0,0 +1,160 @@
+package importexport
+
+import (
+	"sort"
+	"time"
+)
+
+// trelloBoard — подмножество JSON-выгрузки доски Trello (Menu → Print,
+// export and share → Export as JSON), которое мы умеем перенести.
+type trelloBoard struct {
+	Name       string            `json:"name"`
+	Labels     []trelloLabel     `json:"labels"`
+	Lists      []trelloList      `json:"lists"`
+	Cards      []trelloCard      `json:"cards"`
+	Checklists []trelloChecklist `json:"checklists"`
+	Actions    []trelloAction    `json:"actions"`
+}
+
+type trelloLabel struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Color *string `json:"color"`
+}
+
+type trelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type trelloCard struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Desc     string   `json:"desc"`
+	IDList   string   `json:"idList"`
+	IDLabels []string `json:"idLabels"`
+	Closed   bool     `json:"closed"`
+	Pos      float64  `json:"pos"`
+}
+
+type trelloChecklist struct {
+	ID         string            `json:"id"`
+	IDCard     string            `json:"idCard"`
+	Name       string            `json:"name"`
+	Pos        float64           `json:"pos"`
+	CheckItems []trelloCheckItem `json:"checkItems"`
+}
+
+type trelloCheckItem struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Pos   float64 `json:"pos"`
+}
+
+type trelloAction struct {
+	Type string    `json:"type"`
+	Date time.Time `json:"date"`
+	Data struct {
+		Text string `json:"text"`
+		Card struct {
+			ID string `json:"id"`
+		} `json:"card"`
+	} `json:"data"`
+	MemberCreator struct {
+		Username string `json:"username"`
+		FullName string `json:"fullName"`
+	} `json:"memberCreator"`
+}
+
+// toDocument переводит строковые идентификаторы Trello в локальные
+// номера документа. Архивные списки и карточки пропускаются.
+func (b trelloBoard) toDocument() Document {
+	doc := Document{
+		Format:  FormatName,
+		Version: FormatVersion,
+		Board:   BoardDoc{Title: b.Name},
+	}
+
+	labelIDs := map[string]int{}
+	for _, l := range b.Labels {
+		labelIDs[l.ID] = len(labelIDs) + 1
+		color := ""
+		if l.Color != nil {
+			color = *l.Color
+		}
+		doc.Labels = append(doc.Labels, LabelDoc{ID: labelIDs[l.ID], Name: l.Name, Color: color})
+	}
+
+	lists := append([]trelloList(nil), b.Lists...)
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+	listIDs := map[string]int{}
+	for _, l := range lists {
+		if l.Closed {
+			continue
+		}
+		listIDs[l.ID] = len(listIDs) + 1
+		doc.Lists = append(doc.Lists, ListDoc{ID: listIDs[l.ID], Title: l.Name})
+	}
+
+	checklists := map[string][]trelloChecklist{}
+	for _, cl := range b.Checklists {
+		checklists[cl.IDCard] = append(checklists[cl.IDCard], cl)
+	}
+	comments := map[string][]trelloAction{}
+	for _, a := range b.Actions {
+		if a.Type == "commentCard" {
+			comments[a.Data.Card.ID] = append(comments[a.Data.Card.ID], a)
+		}
+	}
+
+	cards := append([]trelloCard(nil), b.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool {
+		li, lj := listIDs[cards[i].IDList], listIDs[cards[j].IDList]
+		if li != lj {
+			return li < lj
+		}
+		return cards[i].Pos < cards[j].Pos
+	})
+	for i, c := range cards {
+		listID, ok := listIDs[c.IDList]
+		if !ok || c.Closed {
+			continue
+		}
+		card := CardDoc{
+			ID:          i + 1,
+			ListID:      listID,
+			Title:       c.Name,
+			Description: c.Desc,
+		}
+		for _, id := range c.IDLabels {
+			if labelID, ok := labelIDs[id]; ok {
+				card.LabelIDs = append(card.LabelIDs, labelID)
+			}
+		}
+		cardChecklists := checklists[c.ID]
+		sort.SliceStable(cardChecklists, func(i, j int) bool { return cardChecklists[i].Pos < cardChecklists[j].Pos })
+		for _, cl := range cardChecklists {
+			items := append([]trelloCheckItem(nil), cl.CheckItems...)
+			sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+			checklist := ChecklistDoc{Title: cl.Name}
+			for _, item := range items {
+				checklist.Items = append(checklist.Items, ChecklistItemDoc{Text: item.Name, Checked: item.State == "complete"})
+			}
+			card.Checklists = append(card.Checklists, checklist)
+		}
+		// Trello отдаёт действия от новых к старым.
+		cardComments := comments[c.ID]
+		sort.SliceStable(cardComments, func(i, j int) bool { return cardComments[i].Date.Before(cardComments[j].Date) })
+		for _, a := range cardComments {
+			author := a.MemberCreator.Username
+			if author == "" {
+				author = a.MemberCreator.FullName
+			}
+			card.Comments = append(card.Comments, CommentDoc{Author: author, Text: a.Data.Text, CreatedAt: a.Date})
+		}
+		doc.Cards = append(doc.Cards, card)
+	}
+	return doc
+}
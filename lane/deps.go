@@ -0,0 +1,34 @@
+package lane
+
+import "awesomeProject2/cmd/model"
+
+type Storage interface {
+	GetSwimlanes(boardID int) (model.Swimlanes, error)
+	SetSwimlanes(swimlanes model.Swimlanes) (model.Swimlanes, error)
+	GetLanes(boardID int) ([]model.Lane, error)
+	GetLane(id int) (model.Lane, error)
+	CreateLane(input model.LaneInputCreate) (model.Lane, error)
+	RenameLane(id int, title string) (model.Lane, error)
+	DeleteLane(id int) error
+	SetLanePositions(boardID int, ids []int) error
+	GetCardLanes(boardID int) ([]model.CardLane, error)
+}
+
+type BoardReader interface {
+	GetBoard(id int) (model.Board, error)
+	GetLists(boardID *int) ([]model.List, error)
+	GetLabels(boardID int) ([]model.Label, error)
+	GetField(id int) (model.CustomField, error)
+}
+
+// CardFinder — выборка карточек; в приложении это service.CardService,
+// который заполняет значения полей.
+type CardFinder interface {
+	GetCards(filter model.CardFilter) ([]model.Card, error)
+}
+
+// CardDetails нужен для дорожек по меткам и исполнителям.
+type CardDetails interface {
+	GetCardLabels(cardID int) ([]model.Label, error)
+	GetCardAssignees(cardID int) ([]string, error)
+}
@@ -0,0 +1,274 @@
+package lane
+
+import (
+	"awesomeProject2/cmd/model"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxLaneTitleLength = 100
+
+// Service настраивает горизонтальные дорожки доски и раскладывает её
+// карточки в матрицу дорожек и списков.
+type Service struct {
+	Storage Storage
+	Boards  BoardReader
+	Cards   CardFinder
+	Details CardDetails
+}
+
+func NewService(storage Storage, boards BoardReader, cards CardFinder, details CardDetails) *Service {
+	return &Service{
+		Storage: storage,
+		Boards:  boards,
+		Cards:   cards,
+		Details: details,
+	}
+}
+
+func (s Service) GetSwimlanes(boardID int) (model.Swimlanes, error) {
+	return s.Storage.GetSwimlanes(boardID)
+}
+
+// SetSwimlanes задаёт режим дорожек доски. Поле указывается только в
+// режиме field и должно быть на той же доске. Явные дорожки и
+// раскладка карточек по ним при смене режима сохраняются.
+func (s Service) SetSwimlanes(input model.Swimlanes) (model.Swimlanes, error) {
+	if !slices.Contains(model.LaneModes, input.Mode) {
+		return model.Swimlanes{}, fmt.Errorf("%w: mode must be empty or one of %s", model.ErrInvalidInput, strings.Join(model.LaneModes[1:], ", "))
+	}
+	if input.Mode != model.LaneField {
+		if input.FieldID != nil {
+			return model.Swimlanes{}, fmt.Errorf("%w: field_id is only allowed with mode %s", model.ErrInvalidInput, model.LaneField)
+		}
+		return s.Storage.SetSwimlanes(input)
+	}
+	if input.FieldID == nil {
+		return model.Swimlanes{}, fmt.Errorf("%w: field_id is required for mode %s", model.ErrInvalidInput, model.LaneField)
+	}
+	field, err := s.Boards.GetField(*input.FieldID)
+	if errors.Is(err, model.ErrNotFound) || err == nil && field.BoardID != input.BoardID {
+		return model.Swimlanes{}, fmt.Errorf("%w: field %d is not on board %d", model.ErrInvalidInput, *input.FieldID, input.BoardID)
+	}
+	if err != nil {
+		return model.Swimlanes{}, err
+	}
+	return s.Storage.SetSwimlanes(input)
+}
+
+func (s Service) GetLanes(boardID int) ([]model.Lane, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetLanes(boardID)
+}
+
+// CreateLane добавляет явную дорожку в конец доски.
+func (s Service) CreateLane(input model.LaneInputCreate) (model.Lane, error) {
+	title, err := laneTitle(input.Title)
+	if err != nil {
+		return model.Lane{}, err
+	}
+	input.Title = title
+	return s.Storage.CreateLane(input)
+}
+
+func (s Service) RenameLane(id int, title string) (model.Lane, error) {
+	title, err := laneTitle(title)
+	if err != nil {
+		return model.Lane{}, err
+	}
+	return s.Storage.RenameLane(id, title)
+}
+
+// DeleteLane удаляет дорожку; её карточки остаются на доске вне дорожек.
+func (s Service) DeleteLane(id int) error {
+	return s.Storage.DeleteLane(id)
+}
+
+// ReorderLanes расставляет дорожки доски в порядке ids. В ids должна
+// быть каждая дорожка доски ровно один раз.
+func (s Service) ReorderLanes(boardID int, ids []int) ([]model.Lane, error) {
+	lanes, err := s.GetLanes(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(lanes) || slices.ContainsFunc(lanes, func(l model.Lane) bool { return !slices.Contains(ids, l.ID) }) {
+		return nil, fmt.Errorf("%w: lane_ids must list every lane of board %d once", model.ErrInvalidInput, boardID)
+	}
+	if err := s.Storage.SetLanePositions(boardID, ids); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetLanes(boardID)
+}
+
+// Layout раскладывает карточки доски (без архивных) по дорожкам и
+// спискам. Дорожки по исполнителям, меткам и полю показываются, только
+// если в них есть карточки, явные — всегда. Карточки без дорожки идут
+// последней строкой, если они есть; без режима дорожек строка одна.
+func (s Service) Layout(boardID int) (model.BoardLayout, error) {
+	board, err := s.Boards.GetBoard(boardID)
+	if err != nil {
+		return model.BoardLayout{}, err
+	}
+	lists, err := s.Boards.GetLists(&boardID)
+	if err != nil {
+		return model.BoardLayout{}, err
+	}
+	swimlanes, err := s.Storage.GetSwimlanes(boardID)
+	if err != nil {
+		return model.BoardLayout{}, err
+	}
+	cards, err := s.Cards.GetCards(model.CardFilter{BoardID: &boardID})
+	if err != nil {
+		return model.BoardLayout{}, err
+	}
+	layout := model.BoardLayout{Board: board, Swimlanes: swimlanes, Lists: lists}
+	if swimlanes.Mode == model.LaneNone {
+		layout.Lanes = []model.BoardLane{row(model.BoardLane{}, lists, cards)}
+		return layout, nil
+	}
+
+	lanes, keys, err := s.lanes(swimlanes)
+	if err != nil {
+		return model.BoardLayout{}, err
+	}
+	known := len(lanes)
+	byKey := map[string][]model.Card{}
+	for _, card := range cards {
+		cardKeys, err := keys(card)
+		if err != nil {
+			return model.BoardLayout{}, err
+		}
+		if len(cardKeys) == 0 {
+			cardKeys = []string{""}
+		}
+		for _, key := range cardKeys {
+			if key != "" && !slices.ContainsFunc(lanes, func(l model.BoardLane) bool { return l.Key == key }) {
+				lanes = append(lanes, model.BoardLane{Key: key, Title: key})
+			}
+			byKey[key] = append(byKey[key], card)
+		}
+	}
+	slices.SortFunc(lanes[known:], func(a, b model.BoardLane) int { return compareKeys(a.Key, b.Key) })
+	if swimlanes.Mode != model.LaneExplicit {
+		lanes = slices.DeleteFunc(lanes, func(l model.BoardLane) bool { return len(byKey[l.Key]) == 0 })
+	}
+	if len(byKey[""]) > 0 {
+		lanes = append(lanes, model.BoardLane{})
+	}
+	for _, l := range lanes {
+		cards := byKey[l.Key]
+		if l.LaneID != nil {
+			l.Key = ""
+		}
+		layout.Lanes = append(layout.Lanes, row(l, lists, cards))
+	}
+	return layout, nil
+}
+
+// lanes возвращает известные заранее строки матрицы в порядке показа
+// (метки доски, варианты dropdown, явные дорожки) и ключи строк
+// карточки. У явных дорожек ключ — id дорожки.
+func (s Service) lanes(swimlanes model.Swimlanes) ([]model.BoardLane, func(card model.Card) ([]string, error), error) {
+	switch swimlanes.Mode {
+	case model.LaneAssignee:
+		return nil, func(card model.Card) ([]string, error) { return s.Details.GetCardAssignees(card.ID) }, nil
+	case model.LaneLabel:
+		labels, err := s.Boards.GetLabels(swimlanes.BoardID)
+		if err != nil {
+			return nil, nil, err
+		}
+		var lanes []model.BoardLane
+		for _, l := range labels {
+			lanes = append(lanes, model.BoardLane{Key: l.Name, Title: l.Name})
+		}
+		return lanes, func(card model.Card) ([]string, error) {
+			labels, err := s.Details.GetCardLabels(card.ID)
+			names := make([]string, 0, len(labels))
+			for _, l := range labels {
+				names = append(names, l.Name)
+			}
+			return names, err
+		}, nil
+	case model.LaneField:
+		field, err := s.Boards.GetField(*swimlanes.FieldID)
+		if err != nil {
+			return nil, nil, err
+		}
+		var lanes []model.BoardLane
+		for _, option := range field.Options {
+			lanes = append(lanes, model.BoardLane{Key: option, Title: option})
+		}
+		return lanes, func(card model.Card) ([]string, error) {
+			i := slices.IndexFunc(card.Fields, func(v model.CardFieldValue) bool { return v.FieldID == field.ID })
+			if i < 0 || card.Fields[i].Value() == nil {
+				return nil, nil
+			}
+			return []string{fmt.Sprint(card.Fields[i].Value())}, nil
+		}, nil
+	case model.LaneExplicit:
+		explicit, err := s.Storage.GetLanes(swimlanes.BoardID)
+		if err != nil {
+			return nil, nil, err
+		}
+		cardLanes, err := s.Storage.GetCardLanes(swimlanes.BoardID)
+		if err != nil {
+			return nil, nil, err
+		}
+		lanes := make([]model.BoardLane, 0, len(explicit))
+		for _, l := range explicit {
+			lanes = append(lanes, model.BoardLane{Key: strconv.Itoa(l.ID), LaneID: &l.ID, Title: l.Title})
+		}
+		byCard := map[int]string{}
+		for _, cl := range cardLanes {
+			byCard[cl.CardID] = strconv.Itoa(cl.LaneID)
+		}
+		return lanes, func(card model.Card) ([]string, error) {
+			if key, ok := byCard[card.ID]; ok {
+				return []string{key}, nil
+			}
+			return nil, nil
+		}, nil
+	}
+	return nil, nil, fmt.Errorf("unknown lane mode %q", swimlanes.Mode)
+}
+
+// row раскладывает карточки строки по спискам доски.
+func row(lane model.BoardLane, lists []model.List, cards []model.Card) model.BoardLane {
+	lane.Cells = make([]model.LaneCell, 0, len(lists))
+	for _, l := range lists {
+		cell := model.LaneCell{ListID: l.ID, Cards: []model.Card{}}
+		for _, card := range cards {
+			if card.ListID == l.ID {
+				cell.Cards = append(cell.Cards, card)
+			}
+		}
+		lane.Cells = append(lane.Cells, cell)
+	}
+	return lane
+}
+
+// compareKeys упорядочивает строки, которых не было среди известных:
+// числа — по значению, остальное — как строки.
+func compareKeys(a, b string) int {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	if errX == nil && errY == nil {
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(a, b)
+}
+
+func laneTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > maxLaneTitleLength {
+		return "", fmt.Errorf("%w: lane title must be 1 to %d characters", model.ErrInvalidInput, maxLaneTitleLength)
+	}
+	return title, nil
+}
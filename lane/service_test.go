@@ -0,0 +1,239 @@
+package lane
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+// fixture — доска testutil.BoardFixture с сервисами дорожек и карточек.
+type fixture struct {
+	testutil.BoardFixture
+	svc   *Service
+	cards *service.CardService
+}
+
+func newFixture(t *testing.T) fixture {
+	f := fixture{BoardFixture: testutil.NewBoardFixture(t)}
+	f.cards = service.NewCardService(f.Store, f.Store, nil, zap.NewNop())
+	f.cards.Fields = f.Store
+	f.svc = NewService(f.Store, f.Store, f.cards, f.Store)
+	return f
+}
+
+// fields добавляет на доску числовое поле Points и выпадающий список
+// Size.
+func (f fixture) fields(t *testing.T) (points, size model.CustomField) {
+	t.Helper()
+	points, err := f.Store.CreateField(model.CustomFieldInputCreate{BoardID: f.Board.ID, Name: "Points", Type: model.FieldNumber})
+	require.NoError(t, err)
+	size, err = f.Store.CreateField(model.CustomFieldInputCreate{BoardID: f.Board.ID, Name: "Size", Type: model.FieldDropdown, Options: []string{"S", "M", "L"}})
+	require.NoError(t, err)
+	return points, size
+}
+
+// laneRow — строка матрицы в виде id карточек по спискам.
+type laneRow struct {
+	Title string
+	Lane  bool
+	Cells [][]int
+}
+
+func rows(layout model.BoardLayout) []laneRow {
+	var result []laneRow
+	for _, l := range layout.Lanes {
+		row := laneRow{Title: l.Title, Lane: l.LaneID != nil}
+		for _, c := range l.Cells {
+			ids := []int{}
+			for _, card := range c.Cards {
+				ids = append(ids, card.ID)
+			}
+			row.Cells = append(row.Cells, ids)
+		}
+		result = append(result, row)
+	}
+	return result
+}
+
+func TestLayout(t *testing.T) {
+	f := newFixture(t)
+	login, report, cleanup := f.Login.ID, f.Report.ID, f.Cleanup.ID
+	bug, err := f.Store.CreateLabel(model.LabelInputCreate{BoardID: f.Board.ID, Name: "bug"})
+	require.NoError(t, err)
+	_, err = f.Store.CreateLabel(model.LabelInputCreate{BoardID: f.Board.ID, Name: "ux"})
+	require.NoError(t, err)
+	require.NoError(t, f.Store.AddCardLabel(login, bug.ID))
+	require.NoError(t, f.Store.AddCardLabel(cleanup, bug.ID))
+	require.NoError(t, f.Store.AddCardAssignee(login, "boris"))
+	require.NoError(t, f.Store.AddCardAssignee(login, "anna"))
+	require.NoError(t, f.Store.AddCardAssignee(report, "boris"))
+	points, size := f.fields(t)
+	for c, p := range map[int]float64{login: 5, report: 10} {
+		_, err := f.cards.SetFieldValue(c, points.ID, p)
+		require.NoError(t, err)
+	}
+	_, err = f.cards.SetFieldValue(login, size.ID, "M")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		swimlanes model.Swimlanes
+		want      []laneRow
+	}{
+		{
+			name: "no lanes",
+			want: []laneRow{{Cells: [][]int{{login, report}, {cleanup}}}},
+		},
+		{
+			name:      "by assignee",
+			swimlanes: model.Swimlanes{Mode: model.LaneAssignee},
+			want: []laneRow{
+				{Title: "anna", Cells: [][]int{{login}, {}}},
+				{Title: "boris", Cells: [][]int{{login, report}, {}}},
+				{Cells: [][]int{{}, {cleanup}}},
+			},
+		},
+		{
+			name:      "by label skips empty labels",
+			swimlanes: model.Swimlanes{Mode: model.LaneLabel},
+			want: []laneRow{
+				{Title: "bug", Cells: [][]int{{login}, {cleanup}}},
+				{Cells: [][]int{{report}, {}}},
+			},
+		},
+		{
+			name:      "by number field in value order",
+			swimlanes: model.Swimlanes{Mode: model.LaneField, FieldID: &points.ID},
+			want: []laneRow{
+				{Title: "5", Cells: [][]int{{login}, {}}},
+				{Title: "10", Cells: [][]int{{report}, {}}},
+				{Cells: [][]int{{}, {cleanup}}},
+			},
+		},
+		{
+			name:      "by dropdown field",
+			swimlanes: model.Swimlanes{Mode: model.LaneField, FieldID: &size.ID},
+			want: []laneRow{
+				{Title: "M", Cells: [][]int{{login}, {}}},
+				{Cells: [][]int{{report}, {cleanup}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.swimlanes.BoardID = f.Board.ID
+			_, err := f.svc.SetSwimlanes(tt.swimlanes)
+			require.NoError(t, err)
+
+			layout, err := f.svc.Layout(f.Board.ID)
+			require.NoError(t, err)
+			require.Equal(t, f.Board.ID, layout.Board.ID)
+			require.Len(t, layout.Lists, 2)
+			require.Equal(t, tt.want, rows(layout))
+		})
+	}
+
+	t.Run("missing board", func(t *testing.T) {
+		_, err := f.svc.Layout(99)
+		require.ErrorIs(t, err, model.ErrNotFound)
+	})
+}
+
+func TestExplicitLanes(t *testing.T) {
+	f := newFixture(t)
+	_, err := f.svc.SetSwimlanes(model.Swimlanes{BoardID: f.Board.ID, Mode: model.LaneExplicit})
+	require.NoError(t, err)
+	urgent, err := f.svc.CreateLane(model.LaneInputCreate{BoardID: f.Board.ID, Title: " Urgent "})
+	require.NoError(t, err)
+	require.Equal(t, "Urgent", urgent.Title)
+	later, err := f.svc.CreateLane(model.LaneInputCreate{BoardID: f.Board.ID, Title: "Later"})
+	require.NoError(t, err)
+
+	_, err = f.cards.MoveCard(f.Login.ID, model.CardMoveInput{ListID: f.Cleanup.ListID, LaneID: &later.ID})
+	require.NoError(t, err)
+	_, err = f.cards.SetCardLane(f.Report.ID, urgent.ID)
+	require.NoError(t, err)
+
+	layout, err := f.svc.Layout(f.Board.ID)
+	require.NoError(t, err)
+	require.Equal(t, []laneRow{
+		{Title: "Urgent", Lane: true, Cells: [][]int{{f.Report.ID}, {}}},
+		{Title: "Later", Lane: true, Cells: [][]int{{}, {f.Login.ID}}},
+		{Cells: [][]int{{}, {f.Cleanup.ID}}},
+	}, rows(layout))
+
+	lanes, err := f.svc.ReorderLanes(f.Board.ID, []int{later.ID, urgent.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int{later.ID, urgent.ID}, []int{lanes[0].ID, lanes[1].ID})
+	_, err = f.svc.ReorderLanes(f.Board.ID, []int{later.ID, later.ID})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+
+	// Удалённая дорожка не уносит карточки, а пустая явная остаётся видна.
+	require.NoError(t, f.svc.DeleteLane(urgent.ID))
+	_, err = f.cards.MoveCard(f.Login.ID, model.CardMoveInput{ListID: f.Login.ListID, LaneID: new(int)})
+	require.NoError(t, err)
+	layout, err = f.svc.Layout(f.Board.ID)
+	require.NoError(t, err)
+	require.Equal(t, []laneRow{
+		{Title: "Later", Lane: true, Cells: [][]int{{}, {}}},
+		{Cells: [][]int{{f.Login.ID, f.Report.ID}, {f.Cleanup.ID}}},
+	}, rows(layout))
+}
+
+func TestSetSwimlanes(t *testing.T) {
+	f := newFixture(t)
+	points, size := f.fields(t)
+	other, err := f.Store.CreateBoard("other")
+	require.NoError(t, err)
+	foreign, err := f.Store.CreateField(model.CustomFieldInputCreate{BoardID: other.ID, Name: "Team", Type: model.FieldText})
+	require.NoError(t, err)
+	missing := 99
+	tests := []struct {
+		name        string
+		input       model.Swimlanes
+		expectError error
+	}{
+		{name: "assignee", input: model.Swimlanes{BoardID: f.Board.ID, Mode: model.LaneAssignee}},
+		{name: "field", input: model.Swimlanes{BoardID: f.Board.ID, Mode: model.LaneField, FieldID: &points.ID}},
+		{name: "off", input: model.Swimlanes{BoardID: f.Board.ID}},
+		{name: "unknown mode", input: model.Swimlanes{BoardID: f.Board.ID, Mode: "status"}, expectError: model.ErrInvalidInput},
+		{name: "field without id", input: model.Swimlanes{BoardID: f.Board.ID, Mode: model.LaneField}, expectError: model.ErrInvalidInput},
+		{name: "field on another board", input: model.Swimlanes{BoardID: f.Board.ID, Mode: model.LaneField, FieldID: &foreign.ID}, expectError: model.ErrInvalidInput},
+		{name: "missing field", input: model.Swimlanes{BoardID: f.Board.ID, Mode: model.LaneField, FieldID: &missing}, expectError: model.ErrInvalidInput},
+		{name: "field id with other mode", input: model.Swimlanes{BoardID: f.Board.ID, Mode: model.LaneLabel, FieldID: &points.ID}, expectError: model.ErrInvalidInput},
+		{name: "missing board", input: model.Swimlanes{BoardID: 99, Mode: model.LaneLabel}, expectError: model.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.svc.SetSwimlanes(tt.input)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.input, got)
+		})
+	}
+
+	t.Run("deleting the field turns lanes off", func(t *testing.T) {
+		_, err := f.svc.SetSwimlanes(model.Swimlanes{BoardID: f.Board.ID, Mode: model.LaneField, FieldID: &size.ID})
+		require.NoError(t, err)
+		require.NoError(t, f.Store.DeleteField(size.ID))
+		got, err := f.svc.GetSwimlanes(f.Board.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.Swimlanes{BoardID: f.Board.ID}, got)
+	})
+}
+
+func TestCreateLaneValidation(t *testing.T) {
+	f := newFixture(t)
+	_, err := f.svc.CreateLane(model.LaneInputCreate{BoardID: f.Board.ID, Title: "  "})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+	_, err = f.svc.CreateLane(model.LaneInputCreate{BoardID: 99, Title: "Lane"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+	_, err = f.svc.RenameLane(99, "Lane")
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
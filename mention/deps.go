@@ -0,0 +1,10 @@
+package mention
+
+import "awesomeProject2/cmd/model"
+
+type Storage interface {
+	GetBoardMembers(boardID int) ([]model.BoardMember, error)
+	GetMentions(cardID int) ([]model.Mention, error)
+	CreateMention(m model.Mention) (model.Mention, error)
+	DeleteMention(id int) error
+}
@@ -0,0 +1,28 @@
+package mention
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern находит @username, перед которым нет буквы, цифры или
+// точки, чтобы не принять за упоминание адрес почты. Символы имени те же,
+// что допускает model.ValidateUsername.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@-])@([\p{L}\p{N}_][\p{L}\p{N}_.-]*)`)
+
+// Parse возвращает имена, упомянутые в тексте, без повторов и в порядке
+// появления. Точка или дефис в конце имени считаются пунктуацией.
+func Parse(text string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimRight(m[1], ".-")
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
+}
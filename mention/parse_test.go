@@ -0,0 +1,30 @@
+package mention
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "no mentions", text: "просто текст", want: nil},
+		{name: "start of text", text: "@anna посмотри", want: []string{"anna"}},
+		{name: "several in order", text: "cc @boris, @anna и @vera.", want: []string{"boris", "anna", "vera"}},
+		{name: "duplicates ignore case", text: "@anna @Anna @anna", want: []string{"anna"}},
+		{name: "email is not a mention", text: "пиши на anna@example.com", want: nil},
+		{name: "punctuation around", text: "(@anna) — @boris-", want: []string{"anna", "boris"}},
+		{name: "dots and dashes inside", text: "@anna.k-2 готово", want: []string{"anna.k-2"}},
+		{name: "cyrillic", text: "спасибо, @Анна!", want: []string{"Анна"}},
+		{name: "bare at", text: "@ и @@", want: nil},
+		{name: "newline", text: "первая строка\n@vera", want: []string{"vera"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Parse(tt.text))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package mention
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"go.uber.org/zap"
+	"slices"
+	"strings"
+)
+
+// Tracker находит упоминания участников доски в описаниях карточек и
+// комментариях, сохраняет их и сообщает о новых событием mentioned.
+// Упоминание того, кто не участник доски, остаётся просто текстом.
+//
+// Упоминания в описании сверяются при каждом его изменении: исчезнувшие
+// удаляются, а событие получают только впервые упомянутые. Автор
+// комментария об упоминании самого себя не уведомляется.
+type Tracker struct {
+	Storage Storage
+	Forward service.EventPublisher
+	logger  *zap.Logger
+}
+
+var _ service.EventPublisher = (*Tracker)(nil)
+
+func NewTracker(storage Storage, forward service.EventPublisher, logger *zap.Logger) *Tracker {
+	return &Tracker{
+		Storage: storage,
+		Forward: forward,
+		logger:  logger,
+	}
+}
+
+func (t *Tracker) Publish(event model.Event) {
+	var err error
+	switch event.Type {
+	case model.EventCardCreated, model.EventCardUpdated:
+		err = t.syncDescription(event.Card)
+	case model.EventCommentAdded:
+		if event.Comment != nil {
+			err = t.trackComment(event.Card, *event.Comment)
+		}
+	default:
+		return
+	}
+	if err != nil {
+		t.logger.Error("Не удалось обработать упоминания", zap.Error(err), zap.String("event", event.Type), zap.Int("cardID", event.Card.ID))
+	}
+}
+
+func (t *Tracker) syncDescription(card model.Card) error {
+	want, err := t.resolve(card.BoardID, card.Description)
+	if err != nil {
+		return err
+	}
+	mentions, err := t.Storage.GetMentions(card.ID)
+	if err != nil {
+		return err
+	}
+	var have []string
+	for _, m := range mentions {
+		if m.CommentID != nil {
+			continue
+		}
+		if !slices.Contains(want, m.Username) {
+			if err := t.Storage.DeleteMention(m.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		have = append(have, m.Username)
+	}
+	for _, username := range want {
+		if slices.Contains(have, username) {
+			continue
+		}
+		if _, err := t.Storage.CreateMention(model.Mention{CardID: card.ID, Username: username}); err != nil {
+			return err
+		}
+		t.forward(model.Event{Type: model.EventMentioned, Card: card, Username: username})
+	}
+	return nil
+}
+
+func (t *Tracker) trackComment(card model.Card, comment model.Comment) error {
+	usernames, err := t.resolve(card.BoardID, comment.Text)
+	if err != nil {
+		return err
+	}
+	for _, username := range usernames {
+		_, err := t.Storage.CreateMention(model.Mention{CardID: card.ID, CommentID: &comment.ID, Username: username, Author: comment.Author})
+		if err != nil {
+			return err
+		}
+		if username != comment.Author {
+			t.forward(model.Event{Type: model.EventMentioned, Card: card, Username: username})
+		}
+	}
+	return nil
+}
+
+// resolve сопоставляет упомянутые имена участникам доски без учёта
+// регистра и возвращает имена участников.
+func (t *Tracker) resolve(boardID int, text string) ([]string, error) {
+	names := Parse(text)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	members, err := t.Storage.GetBoardMembers(boardID)
+	if err != nil {
+		return nil, err
+	}
+	var usernames []string
+	for _, name := range names {
+		i := slices.IndexFunc(members, func(m model.BoardMember) bool { return strings.EqualFold(m.Username, name) })
+		if i >= 0 && !slices.Contains(usernames, members[i].Username) {
+			usernames = append(usernames, members[i].Username)
+		}
+	}
+	return usernames, nil
+}
+
+func (t *Tracker) forward(event model.Event) {
+	if t.Forward != nil {
+		t.Forward.Publish(event)
+	}
+}
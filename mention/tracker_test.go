@@ -0,0 +1,75 @@
+package mention
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+// eventRecorder запоминает адресатов событий mentioned.
+type eventRecorder struct {
+	mentioned []string
+}
+
+func (r *eventRecorder) Publish(event model.Event) {
+	if event.Type == model.EventMentioned {
+		r.mentioned = append(r.mentioned, event.Username)
+	}
+}
+
+func TestTracker(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	for _, u := range []string{"anna", "Boris", "vera"} {
+		_, err := store.AddBoardMember(board.ID, u)
+		require.NoError(t, err)
+	}
+	recorder := &eventRecorder{}
+	listeners := service.NewDispatcher(recorder)
+	listeners.Subscribe(NewTracker(store, listeners, zap.NewNop()))
+	cards := service.NewCardService(store, store, listeners, zap.NewNop())
+	cards.Mentions = store
+	comments := service.NewCommentService(store, store, listeners, zap.NewNop())
+	comments.Mentions = store
+	usernames := func(mentions []model.Mention) []string {
+		var names []string
+		for _, m := range mentions {
+			names = append(names, m.Username)
+		}
+		return names
+	}
+
+	card, err := cards.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "Релиз", Description: "@anna и @boris, а @stranger не участник"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna", "Boris"}, usernames(card.Mentions), "resolved to member names")
+	require.Equal(t, []string{"anna", "Boris"}, recorder.mentioned)
+
+	// Повторное упоминание не уведомляет, пропавшее удаляется.
+	recorder.mentioned = nil
+	card.Description = "@Boris и @vera"
+	card, err = cards.UpdateCard(card)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Boris", "vera"}, usernames(card.Mentions))
+	require.Equal(t, []string{"vera"}, recorder.mentioned)
+
+	recorder.mentioned = nil
+	comment, err := comments.AddComment(card.ID, "anna", "@anna @vera глянь")
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna", "vera"}, usernames(comment.Mentions))
+	require.Equal(t, "anna", comment.Mentions[0].Author)
+	require.Equal(t, []string{"vera"}, recorder.mentioned, "authors are not notified about themselves")
+
+	listed, err := comments.GetComments(card.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, []string{"anna", "vera"}, usernames(listed[0].Mentions))
+	got, err := cards.GetCards(model.CardFilter{ListID: &list.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Boris", "vera"}, usernames(got[0].Mentions), "comment mentions are not description mentions")
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import "awesomeProject2/cmd/model"
+
+type Storage interface {
+	AddCardMove(move model.CardMove) (model.CardMove, error)
+	GetCardMoves(boardID int) ([]model.CardMove, error)
+}
+
+type BoardReader interface {
+	GetBoard(id int) (model.Board, error)
+	GetLists(boardID *int) ([]model.List, error)
+}
+
+type CardFinder interface {
+	GetCards(filter model.CardFilter) ([]model.Card, error)
+}
+
+// SprintReader нужен для диаграммы сгорания спринта.
+type SprintReader interface {
+	GetSprint(id int) (model.Sprint, error)
+	GetSprintCards(sprintID int) ([]model.SprintCard, error)
+}
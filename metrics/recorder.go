@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"go.uber.org/zap"
+)
+
+// Recorder ведёт историю перемещений карточек между списками. Время
+// записи берётся из самой карточки — это момент изменения, а не момент
+// доставки события.
+type Recorder struct {
+	Storage Storage
+	logger  *zap.Logger
+}
+
+var _ service.EventPublisher = (*Recorder)(nil)
+
+func NewRecorder(storage Storage, logger *zap.Logger) *Recorder {
+	return &Recorder{Storage: storage, logger: logger}
+}
+
+func (r *Recorder) Publish(event model.Event) {
+	var move model.CardMove
+	switch event.Type {
+	case model.EventCardCreated:
+		move = model.CardMove{CardID: event.Card.ID, ToListID: event.Card.ListID, MovedAt: event.Card.CreatedAt}
+	case model.EventCardMoved:
+		move = model.CardMove{CardID: event.Card.ID, FromListID: event.FromListID, ToListID: event.Card.ListID, MovedAt: event.Card.UpdatedAt}
+	default:
+		return
+	}
+	if _, err := r.Storage.AddCardMove(move); err != nil {
+		r.logger.Error("Не удалось записать перемещение карточки", zap.Error(err), zap.Int("cardID", move.CardID))
+	}
+}
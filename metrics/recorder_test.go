@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	f := newFixture(t)
+	card := f.card(t, day(2), f.lists[1].ID)
+	f.clock = day(4)
+	_, err := f.cards.UpdateCard(model.Card{ID: card.ID, ListID: f.lists[3].ID, Title: "c"})
+	require.NoError(t, err)
+	_, err = f.cards.UpdateCard(model.Card{ID: card.ID, ListID: f.lists[3].ID, Title: "renamed"})
+	require.NoError(t, err)
+
+	moves, err := f.store.GetCardMoves(f.board.ID)
+	require.NoError(t, err)
+	for i := range moves {
+		moves[i].ID = 0
+	}
+	require.Equal(t, []model.CardMove{
+		{CardID: card.ID, ToListID: f.lists[0].ID, MovedAt: day(2)},
+		{CardID: card.ID, FromListID: f.lists[0].ID, ToListID: f.lists[1].ID, MovedAt: day(2).Add(24 * time.Hour)},
+		{CardID: card.ID, FromListID: f.lists[1].ID, ToListID: f.lists[3].ID, MovedAt: day(4)},
+	}, moves, "renaming is not a move")
+}
@@ -0,0 +1,384 @@
+package metrics
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+	// Параметр tz, как и у календаря, принимает любой пояс IANA.
+	_ "time/tzdata"
+)
+
+const (
+	// defaultDays — период отчёта, если from не задан: двенадцать недель.
+	defaultDays = 12 * 7
+	maxDays     = 366
+	percentile  = 0.85
+)
+
+// Service считает метрики потока доски по истории перемещений карточек.
+// Учитываются карточки, которые сейчас на доске, включая архивные.
+// Завершённой считается карточка, которая сейчас в списке «готово»;
+// время завершения — когда она туда попала.
+type Service struct {
+	Storage Storage
+	Boards  BoardReader
+	Cards   CardFinder
+	Sprints SprintReader
+	// DoneLists — названия списков «готово» по умолчанию, без учёта
+	// регистра. Если на доске таких нет, «готово» — последний список.
+	DoneLists []string
+	now       func() time.Time
+}
+
+func NewService(storage Storage, boards BoardReader, cards CardFinder) *Service {
+	return &Service{
+		Storage: storage,
+		Boards:  boards,
+		Cards:   cards,
+		now:     time.Now,
+	}
+}
+
+func (s Service) BoardMetrics(boardID int, input model.MetricsInput) (model.BoardMetrics, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return model.BoardMetrics{}, err
+	}
+	loc := time.UTC
+	if input.TZ != "" {
+		var err error
+		if loc, err = time.LoadLocation(input.TZ); err != nil {
+			return model.BoardMetrics{}, fmt.Errorf("%w: unknown time zone %q", model.ErrInvalidInput, input.TZ)
+		}
+	}
+	now := s.now()
+	start, end, err := period(input.From, input.To, midnight(now, loc))
+	if err != nil {
+		return model.BoardMetrics{}, err
+	}
+
+	lists, err := s.Boards.GetLists(&boardID)
+	if err != nil {
+		return model.BoardMetrics{}, err
+	}
+	started, done, err := s.listRoles(boardID, lists, input)
+	if err != nil {
+		return model.BoardMetrics{}, err
+	}
+	cards, err := s.Cards.GetCards(model.CardFilter{BoardID: &boardID, IncludeArchived: true})
+	if err != nil {
+		return model.BoardMetrics{}, err
+	}
+	moves, err := s.Storage.GetCardMoves(boardID)
+	if err != nil {
+		return model.BoardMetrics{}, err
+	}
+	byCard := map[int][]model.CardMove{}
+	for _, m := range moves {
+		byCard[m.CardID] = append(byCard[m.CardID], m)
+	}
+	histories := make([][]model.CardMove, len(cards))
+	for i, c := range cards {
+		histories[i] = history(c, byCard[c.ID])
+	}
+
+	result := model.BoardMetrics{
+		From:         start,
+		To:           end.AddDate(0, 0, -1),
+		StartedLists: started,
+		DoneLists:    done,
+	}
+	for week := weekStart(start); week.Before(end); week = week.AddDate(0, 0, 7) {
+		result.Throughput = append(result.Throughput, model.WeekThroughput{WeekStart: week})
+	}
+	var lead, cycle []time.Duration
+	for i, c := range cards {
+		startedAt, doneAt, ok := completion(histories[i], started, done)
+		if !ok || doneAt.Before(start) || !doneAt.Before(end) {
+			continue
+		}
+		lead = append(lead, max(doneAt.Sub(c.CreatedAt), 0))
+		cycle = append(cycle, max(doneAt.Sub(startedAt), 0))
+		for j := len(result.Throughput) - 1; j >= 0; j-- {
+			if !doneAt.Before(result.Throughput[j].WeekStart) {
+				result.Throughput[j].Cards++
+				break
+			}
+		}
+	}
+	result.LeadTime = durationStats(lead)
+	result.CycleTime = durationStats(cycle)
+	result.Flow = flow(histories, lists, start, end, now)
+	return result, nil
+}
+
+// SprintBurndown строит диаграмму сгорания спринта по дням от начала до
+// сегодняшнего дня, конца или завершения спринта. Состав на конец дня
+// берётся из истории спринта, а завершённость — из истории перемещений.
+func (s Service) SprintBurndown(sprintID int) (model.SprintBurndown, error) {
+	sprint, err := s.Sprints.GetSprint(sprintID)
+	if err != nil {
+		return model.SprintBurndown{}, err
+	}
+	lists, err := s.Boards.GetLists(&sprint.BoardID)
+	if err != nil {
+		return model.SprintBurndown{}, err
+	}
+	done := service.DoneLists(lists, s.DoneLists)
+	entries, err := s.Sprints.GetSprintCards(sprintID)
+	if err != nil {
+		return model.SprintBurndown{}, err
+	}
+	cards, err := s.Cards.GetCards(model.CardFilter{BoardID: &sprint.BoardID, IncludeArchived: true})
+	if err != nil {
+		return model.SprintBurndown{}, err
+	}
+	moves, err := s.Storage.GetCardMoves(sprint.BoardID)
+	if err != nil {
+		return model.SprintBurndown{}, err
+	}
+	byCard := map[int][]model.CardMove{}
+	for _, m := range moves {
+		byCard[m.CardID] = append(byCard[m.CardID], m)
+	}
+	histories := map[int][]model.CardMove{}
+	for _, c := range cards {
+		histories[c.ID] = history(c, byCard[c.ID])
+	}
+
+	result := model.SprintBurndown{Sprint: sprint, DoneLists: done, Days: []model.BurndownDay{}}
+	stop := s.now()
+	if sprint.CompletedAt != nil && sprint.CompletedAt.Before(stop) {
+		stop = *sprint.CompletedAt
+	}
+	start, end := sprint.StartDate.UTC(), sprint.EndDate.UTC()
+	total := int(end.Sub(start).Hours()/24) + 1
+	for day := start; !day.After(end) && !day.After(stop); day = day.AddDate(0, 0, 1) {
+		cutoff := day.AddDate(0, 0, 1)
+		if cutoff.After(stop) {
+			cutoff = stop
+		}
+		scope := map[int]bool{}
+		for _, e := range entries {
+			if !e.AddedAt.After(cutoff) && (e.RemovedAt == nil || !e.RemovedAt.Before(cutoff)) {
+				scope[e.CardID] = true
+			}
+		}
+		point := model.BurndownDay{Date: day}
+		for cardID := range scope {
+			moves, ok := histories[cardID]
+			if !ok {
+				continue
+			}
+			point.Scope++
+			listID := 0
+			for _, m := range moves {
+				if m.MovedAt.After(cutoff) {
+					break
+				}
+				listID = m.ToListID
+			}
+			if slices.Contains(done, listID) {
+				point.Done++
+			}
+		}
+		point.Remaining = point.Scope - point.Done
+		result.Days = append(result.Days, point)
+	}
+	// Идеальная линия сгорает от объёма на день запуска: спринт часто
+	// наполняют уже после даты начала.
+	if len(result.Days) > 0 && total > 1 {
+		base := result.Days[0].Scope
+		if sprint.StartedAt != nil {
+			for _, d := range result.Days {
+				if !d.Date.After(sprint.StartedAt.UTC()) {
+					base = d.Scope
+				}
+			}
+		}
+		for i := range result.Days {
+			result.Days[i].Ideal = float64(base) * float64(total-1-i) / float64(total-1)
+		}
+	}
+	return result, nil
+}
+
+// period разбирает границы отчёта и возвращает полуинтервал
+// [start, end). По умолчанию отчёт заканчивается сегодняшним днём.
+func period(from, to string, today time.Time) (time.Time, time.Time, error) {
+	end := today.AddDate(0, 0, 1)
+	if to != "" {
+		last, err := parseDay("to", to, today.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = last.AddDate(0, 0, 1)
+	}
+	start := end.AddDate(0, 0, -defaultDays)
+	if from != "" {
+		var err error
+		if start, err = parseDay("from", from, today.Location()); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: to is before from", model.ErrInvalidInput)
+	}
+	if start.AddDate(0, 0, maxDays).Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: metrics range is longer than %d days", model.ErrInvalidInput, maxDays)
+	}
+	return start, end, nil
+}
+
+func parseDay(name, value string, loc *time.Location) (time.Time, error) {
+	day, err := time.ParseInLocation(model.FieldDateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be a date %s", model.ErrInvalidInput, name, model.FieldDateLayout)
+	}
+	return day, nil
+}
+
+func midnight(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// weekStart возвращает понедельник недели дня day.
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// listRoles проверяет списки «начато» и «готово» из запроса и подставляет
+// умолчания: «готово» — списки из DoneLists, иначе последний список;
+// «начато» — все списки, кроме первого и списков «готово».
+func (s Service) listRoles(boardID int, lists []model.List, input model.MetricsInput) ([]int, []int, error) {
+	onBoard := map[int]bool{}
+	for _, l := range lists {
+		onBoard[l.ID] = true
+	}
+	for _, id := range slices.Concat(input.StartedLists, input.DoneLists) {
+		if !onBoard[id] {
+			return nil, nil, fmt.Errorf("%w: list %d is not on board %d", model.ErrInvalidInput, id, boardID)
+		}
+	}
+	for _, id := range input.StartedLists {
+		if slices.Contains(input.DoneLists, id) {
+			return nil, nil, fmt.Errorf("%w: list %d cannot be both started and done", model.ErrInvalidInput, id)
+		}
+	}
+
+	done := slices.Clone(input.DoneLists)
+	if len(done) == 0 {
+		done = service.DoneLists(lists, s.DoneLists)
+	}
+	started := slices.Clone(input.StartedLists)
+	if len(started) == 0 {
+		for i, l := range lists {
+			if i > 0 && !slices.Contains(done, l.ID) {
+				started = append(started, l.ID)
+			}
+		}
+	}
+	if started == nil {
+		started = []int{}
+	}
+	if done == nil {
+		done = []int{}
+	}
+	return started, done, nil
+}
+
+// history восстанавливает полную историю карточки. Для карточек,
+// созданных до появления истории или перенесённых в обход событий,
+// недостающие записи достраиваются по времени создания и изменения.
+func history(card model.Card, moves []model.CardMove) []model.CardMove {
+	if len(moves) == 0 || moves[0].FromListID != 0 {
+		created := model.CardMove{CardID: card.ID, ToListID: card.ListID, MovedAt: card.CreatedAt}
+		if len(moves) > 0 {
+			created.ToListID = moves[0].FromListID
+		}
+		moves = append([]model.CardMove{created}, moves...)
+	}
+	if last := moves[len(moves)-1]; last.ToListID != card.ListID {
+		moves = append(moves, model.CardMove{CardID: card.ID, FromListID: last.ToListID, ToListID: card.ListID, MovedAt: card.UpdatedAt})
+	}
+	slices.SortStableFunc(moves, func(a, b model.CardMove) int { return a.MovedAt.Compare(b.MovedAt) })
+	return moves
+}
+
+// completion возвращает, когда карточка впервые попала в «начато» (или
+// сразу в «готово») и когда в последний раз перешла в «готово».
+// ok ложно, если сейчас карточка не в «готово».
+func completion(moves []model.CardMove, started, done []int) (startedAt, doneAt time.Time, ok bool) {
+	for _, m := range moves {
+		isDone := slices.Contains(done, m.ToListID)
+		if startedAt.IsZero() && (isDone || slices.Contains(started, m.ToListID)) {
+			startedAt = m.MovedAt
+		}
+		if isDone && !ok {
+			doneAt = m.MovedAt
+		}
+		ok = isDone
+	}
+	return startedAt, doneAt, ok
+}
+
+func durationStats(durations []time.Duration) model.DurationStats {
+	if len(durations) == 0 {
+		return model.DurationStats{}
+	}
+	slices.Sort(durations)
+	n := len(durations)
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+	median := durations[n/2]
+	if n%2 == 0 {
+		median = (durations[n/2-1] + durations[n/2]) / 2
+	}
+	// Перцентиль по ближайшему рангу: не меньше 85% значений.
+	rank := int(math.Ceil(float64(n)*percentile)) - 1
+	return model.DurationStats{
+		Count:   n,
+		Average: sum / time.Duration(n),
+		Median:  median,
+		P85:     durations[max(rank, 0)],
+	}
+}
+
+// flow строит накопительную диаграмму: для каждого дня периода до
+// сегодняшнего включительно — число карточек в списках доски на конец дня.
+func flow(histories [][]model.CardMove, lists []model.List, start, end, now time.Time) []model.FlowDay {
+	index := map[int]int{}
+	for i, l := range lists {
+		index[l.ID] = i
+	}
+	days := []model.FlowDay{}
+	for day := start; day.Before(end) && !day.After(now); day = day.AddDate(0, 0, 1) {
+		cutoff := day.AddDate(0, 0, 1)
+		if cutoff.After(now) {
+			cutoff = now
+		}
+		counts := make([]model.ListCount, len(lists))
+		for i, l := range lists {
+			counts[i].ListID = l.ID
+		}
+		for _, moves := range histories {
+			listID := 0
+			for _, m := range moves {
+				if m.MovedAt.After(cutoff) {
+					break
+				}
+				listID = m.ToListID
+			}
+			if i, ok := index[listID]; ok {
+				counts[i].Cards++
+			}
+		}
+		days = append(days, model.FlowDay{Date: day, Lists: counts})
+	}
+	return days
+}
@@ -0,0 +1,241 @@
+package metrics
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type fixture struct {
+	store *storage.Storage
+	cards *service.CardService
+	svc   *Service
+	board model.Board
+	// backlog, doing, review, done — списки доски по порядку.
+	lists [4]model.List
+	clock time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	f := &fixture{store: storage.NewStorage(), clock: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	f.store.SetClock(func() time.Time { return f.clock })
+	var err error
+	f.board, err = f.store.CreateBoard("b")
+	require.NoError(t, err)
+	for i, title := range []string{"Backlog", "Doing", "Review", "Готово"} {
+		f.lists[i], err = f.store.CreateList(model.ListInputCreate{BoardID: f.board.ID, Title: title})
+		require.NoError(t, err)
+	}
+	f.cards = service.NewCardService(f.store, f.store, NewRecorder(f.store, zap.NewNop()), zap.NewNop())
+	f.svc = NewService(f.store, f.store, f.store)
+	f.svc.Sprints = f.store
+	f.svc.DoneLists = []string{"Done", "Готово"}
+	f.svc.now = func() time.Time { return f.clock }
+	return f
+}
+
+// card создаёт карточку в списке backlog в момент at и переносит её
+// в списки steps, продвигая часы на сутки перед каждым переносом.
+func (f *fixture) card(t *testing.T, at time.Time, steps ...int) model.Card {
+	t.Helper()
+	f.clock = at
+	card, err := f.cards.CreateCard(model.CardInputCreate{ListID: f.lists[0].ID, Title: "c"})
+	require.NoError(t, err)
+	for _, listID := range steps {
+		f.clock = f.clock.Add(24 * time.Hour)
+		card, err = f.cards.MoveCard(card.ID, model.CardMoveInput{ListID: listID})
+		require.NoError(t, err)
+	}
+	return card
+}
+
+func day(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+
+func TestBoardMetrics(t *testing.T) {
+	f := newFixture(t)
+	backlog, doing, review, done := f.lists[0].ID, f.lists[1].ID, f.lists[2].ID, f.lists[3].ID
+	// Создана 2-го, начата 3-го, на проверке 4-го, готова 5-го.
+	f.card(t, day(2), doing, review, done)
+	// Сразу из backlog в «готово» 10-го: цикл нулевой.
+	f.card(t, day(9), done)
+	// Возвращена из «готово» в работу — не завершена.
+	f.card(t, day(2), doing, done, doing)
+	// Завершена дважды: считается последнее попадание в «готово» 13-го.
+	f.card(t, day(9), doing, done, review, done)
+	archived := f.card(t, day(3), doing, doing, done)
+	_, err := f.store.ArchiveCard(archived.ID)
+	require.NoError(t, err)
+	deleted := f.card(t, day(3), done)
+	_, err = f.store.DeleteCard(deleted.ListID, deleted.ID)
+	require.NoError(t, err)
+	f.card(t, day(4))
+	f.clock = time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := f.svc.BoardMetrics(f.board.ID, model.MetricsInput{From: "2026-03-01", To: "2026-03-20"})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), got.From)
+	require.Equal(t, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), got.To)
+	require.Equal(t, []int{doing, review}, got.StartedLists)
+	require.Equal(t, []int{done}, got.DoneLists)
+
+	h := time.Hour
+	// Lead: 72h, 24h, 96h, 72h; cycle: 48h, 0, 72h, 48h.
+	require.Equal(t, model.DurationStats{Count: 4, Average: 66 * h, Median: 72 * h, P85: 96 * h}, got.LeadTime)
+	require.Equal(t, model.DurationStats{Count: 4, Average: 42 * h, Median: 48 * h, P85: 72 * h}, got.CycleTime)
+
+	require.Equal(t, []model.WeekThroughput{
+		{WeekStart: time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC), Cards: 0},
+		{WeekStart: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Cards: 2},
+		{WeekStart: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), Cards: 2},
+		{WeekStart: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), Cards: 0},
+	}, got.Throughput)
+
+	require.Len(t, got.Flow, 15, "flow stops today")
+	counts := func(d model.FlowDay) []int {
+		var n []int
+		for _, c := range d.Lists {
+			n = append(n, c.Cards)
+		}
+		return n
+	}
+	require.Equal(t, []int{0, 0, 0, 0}, counts(got.Flow[0]), "March 1")
+	require.Equal(t, []int{2, 0, 0, 0}, counts(got.Flow[1]), "March 2")
+	require.Equal(t, []int{1, 1, 1, 1}, counts(got.Flow[3]), "March 4")
+	require.Equal(t, []int{1, 1, 0, 4}, counts(got.Flow[14]), "March 15")
+	require.Equal(t, backlog, got.Flow[0].Lists[0].ListID)
+}
+
+func TestBoardMetricsDefaults(t *testing.T) {
+	f := newFixture(t)
+	doing, review, done := f.lists[1].ID, f.lists[2].ID, f.lists[3].ID
+	f.card(t, day(2), doing, done)
+	f.clock = time.Date(2026, 3, 5, 23, 30, 0, 0, time.UTC)
+
+	got, err := f.svc.BoardMetrics(f.board.ID, model.MetricsInput{})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC), got.From, "twelve weeks up to today")
+	require.Equal(t, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), got.To)
+	require.Equal(t, 1, got.LeadTime.Count)
+
+	got, err = f.svc.BoardMetrics(f.board.ID, model.MetricsInput{TZ: "Europe/Moscow"})
+	require.NoError(t, err)
+	require.Equal(t, "2026-03-06", got.To.Format(model.FieldDateLayout), "today in the requested zone")
+
+	got, err = f.svc.BoardMetrics(f.board.ID, model.MetricsInput{StartedLists: []int{review}, DoneLists: []int{doing}})
+	require.NoError(t, err)
+	require.Zero(t, got.LeadTime.Count, "the card left the custom done list")
+
+	f.svc.DoneLists = nil
+	got, err = f.svc.BoardMetrics(f.board.ID, model.MetricsInput{})
+	require.NoError(t, err)
+	require.Equal(t, []int{done}, got.DoneLists, "falls back to the last list")
+
+	for name, input := range map[string]model.MetricsInput{
+		"bad date":         {From: "March"},
+		"reversed range":   {From: "2026-03-02", To: "2026-03-01"},
+		"too long":         {From: "2025-01-01", To: "2026-03-01"},
+		"unknown zone":     {TZ: "Mars/Olympus"},
+		"foreign list":     {DoneLists: []int{100}},
+		"started and done": {StartedLists: []int{doing}, DoneLists: []int{doing}},
+	} {
+		_, err := f.svc.BoardMetrics(f.board.ID, input)
+		require.ErrorIs(t, err, model.ErrInvalidInput, name)
+	}
+	_, err = f.svc.BoardMetrics(100, model.MetricsInput{})
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestSprintBurndown(t *testing.T) {
+	f := newFixture(t)
+	doing, done := f.lists[1].ID, f.lists[3].ID
+	sprint, err := f.store.CreateSprint(model.Sprint{
+		BoardID:   f.board.ID,
+		Name:      "s1",
+		StartDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	// a готова 4-го, b в работе, c добавлена 4-го и готова 5-го,
+	// d убрана из спринта 3-го.
+	a := f.card(t, day(2), doing, done)
+	b := f.card(t, day(2), doing)
+	c := f.card(t, day(3))
+	d := f.card(t, day(2))
+	f.clock = day(2)
+	for _, card := range []model.Card{a, b, d} {
+		require.NoError(t, f.store.SetCardSprint(card.ID, &sprint.ID))
+	}
+	f.clock = day(3)
+	require.NoError(t, f.store.SetCardSprint(d.ID, nil))
+	// Запущен 3-го: идеальная линия идёт от объёма этого дня.
+	_, err = f.store.SetSprintStatus(sprint.ID, model.SprintActive)
+	require.NoError(t, err)
+	f.clock = day(4)
+	require.NoError(t, f.store.SetCardSprint(c.ID, &sprint.ID))
+	f.clock = day(5)
+	_, err = f.cards.MoveCard(c.ID, model.CardMoveInput{ListID: done})
+	require.NoError(t, err)
+	f.clock = time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	want := []model.BurndownDay{
+		{Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Scope: 3, Remaining: 3, Ideal: 2},
+		{Date: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), Scope: 2, Remaining: 2, Ideal: 1.5},
+		{Date: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), Scope: 3, Done: 1, Remaining: 2, Ideal: 1},
+		{Date: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), Scope: 3, Done: 2, Remaining: 1, Ideal: 0.5},
+	}
+	got, err := f.svc.SprintBurndown(sprint.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int{done}, got.DoneLists)
+	require.Equal(t, want, got.Days)
+
+	// После завершения диаграмма заканчивается днём завершения.
+	_, err = f.store.SetSprintStatus(sprint.ID, model.SprintCompleted)
+	require.NoError(t, err)
+	f.clock = day(10)
+	got, err = f.svc.SprintBurndown(sprint.ID)
+	require.NoError(t, err)
+	require.Equal(t, want, got.Days)
+
+	_, err = f.svc.SprintBurndown(99)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestHistory(t *testing.T) {
+	created := day(1)
+	card := model.Card{ID: 1, ListID: 3, CreatedAt: created, UpdatedAt: day(5)}
+	tests := []struct {
+		name  string
+		moves []model.CardMove
+		want  []model.CardMove
+	}{
+		{
+			name: "no history",
+			want: []model.CardMove{{CardID: 1, ToListID: 3, MovedAt: created}},
+		},
+		{
+			name:  "missing creation",
+			moves: []model.CardMove{{ID: 7, CardID: 1, FromListID: 2, ToListID: 3, MovedAt: day(4)}},
+			want: []model.CardMove{
+				{CardID: 1, ToListID: 2, MovedAt: created},
+				{ID: 7, CardID: 1, FromListID: 2, ToListID: 3, MovedAt: day(4)},
+			},
+		},
+		{
+			name:  "missing last move",
+			moves: []model.CardMove{{ID: 7, CardID: 1, ToListID: 2, MovedAt: created}},
+			want: []model.CardMove{
+				{ID: 7, CardID: 1, ToListID: 2, MovedAt: created},
+				{CardID: 1, FromListID: 2, ToListID: 3, MovedAt: day(5)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, history(card, tt.moves))
+		})
+	}
+}
@@ -1,3 +1,3 @@
-DROP TABLE IF EXISTS boards;
+DROP TABLE IF EXISTS cards;
 DROP TABLE IF EXISTS lists;
-DROP TABLE IF EXISTS cards;
\ No newline at end of file
+DROP TABLE IF EXISTS boards;
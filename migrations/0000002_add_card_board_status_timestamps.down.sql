@@ -0,0 +1,15 @@
+ALTER TABLE cards
+    ALTER COLUMN description DROP NOT NULL,
+    ALTER COLUMN description DROP DEFAULT,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at,
+    DROP COLUMN status,
+    DROP COLUMN board_id;
+
+ALTER TABLE lists
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at;
+
+ALTER TABLE boards
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at;
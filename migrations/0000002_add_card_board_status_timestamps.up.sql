@@ -0,0 +1,21 @@
+ALTER TABLE boards
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+ALTER TABLE lists
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+ALTER TABLE cards
+    ADD COLUMN board_id   INTEGER REFERENCES boards (id) ON DELETE CASCADE,
+    ADD COLUMN status     TEXT        NOT NULL DEFAULT '',
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE cards c SET board_id = l.board_id FROM lists l WHERE l.id = c.list_id;
+UPDATE cards SET description = '' WHERE description IS NULL;
+
+ALTER TABLE cards
+    ALTER COLUMN board_id SET NOT NULL,
+    ALTER COLUMN description SET DEFAULT '',
+    ALTER COLUMN description SET NOT NULL;
@@ -0,0 +1,5 @@
+DELETE FROM cards WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE cards
+    DROP COLUMN archived_at,
+    DROP COLUMN deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE cards
+    ADD COLUMN archived_at TIMESTAMPTZ,
+    ADD COLUMN deleted_at  TIMESTAMPTZ;
+
+CREATE INDEX cards_deleted_at_idx ON cards (deleted_at) WHERE deleted_at IS NOT NULL;
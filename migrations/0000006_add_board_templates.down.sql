@@ -0,0 +1 @@
+ALTER TABLE boards DROP COLUMN is_template;
@@ -0,0 +1,23 @@
+ALTER TABLE cards ADD COLUMN due_soon_triggered BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE notifications(
+    id         SERIAL PRIMARY KEY,
+    username   TEXT        NOT NULL,
+    kind       TEXT        NOT NULL,
+    event      TEXT        NOT NULL,
+    board_id   INTEGER     NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    card_id    INTEGER     NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    card_title TEXT        NOT NULL,
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX notifications_username_idx ON notifications (username, id);
+CREATE INDEX notifications_unread_idx ON notifications (username) WHERE read_at IS NULL;
+
+CREATE TABLE notification_preferences(
+    username TEXT    NOT NULL,
+    kind     TEXT    NOT NULL,
+    in_app   BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (username, kind)
+);
@@ -0,0 +1,37 @@
+ALTER TABLE notification_preferences ADD COLUMN email BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE email_settings(
+    username       TEXT PRIMARY KEY,
+    address        TEXT    NOT NULL,
+    digest         BOOLEAN NOT NULL DEFAULT FALSE,
+    last_digest_at TIMESTAMPTZ
+);
+
+CREATE TABLE email_digest_items(
+    id         SERIAL PRIMARY KEY,
+    username   TEXT        NOT NULL,
+    kind       TEXT        NOT NULL,
+    event      TEXT        NOT NULL,
+    board_id   INTEGER     NOT NULL,
+    card_id    INTEGER     NOT NULL,
+    card_title TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX email_digest_items_username_idx ON email_digest_items (username, id);
+
+CREATE TABLE email_outbox(
+    id              SERIAL PRIMARY KEY,
+    recipient       TEXT        NOT NULL,
+    subject         TEXT        NOT NULL,
+    text_body       TEXT        NOT NULL,
+    html_body       TEXT        NOT NULL,
+    status          TEXT        NOT NULL DEFAULT 'pending',
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    last_error      TEXT        NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX email_outbox_pending_idx ON email_outbox (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,18 @@
+CREATE TABLE board_members(
+    board_id INTEGER     NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    username TEXT        NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (board_id, username)
+);
+
+CREATE TABLE mentions(
+    id         SERIAL PRIMARY KEY,
+    card_id    INTEGER     NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    comment_id INTEGER REFERENCES comments (id) ON DELETE CASCADE,
+    username   TEXT        NOT NULL,
+    author     TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX mentions_card_id_idx ON mentions (card_id);
+CREATE INDEX mentions_username_idx ON mentions (username);
@@ -0,0 +1,3 @@
+DROP TABLE board_watchers;
+DROP TABLE list_watchers;
+DROP TABLE card_watchers;
@@ -0,0 +1,30 @@
+CREATE TABLE card_watchers(
+    card_id    INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    username   TEXT    NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (card_id, username)
+);
+
+CREATE TABLE list_watchers(
+    list_id    INTEGER NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    username   TEXT    NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, username)
+);
+
+CREATE TABLE board_watchers(
+    board_id   INTEGER NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    username   TEXT    NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (board_id, username)
+);
+
+CREATE INDEX card_watchers_username_idx ON card_watchers (username);
+CREATE INDEX list_watchers_username_idx ON list_watchers (username);
+CREATE INDEX board_watchers_username_idx ON board_watchers (username);
+
+-- Исполнители и авторы комментариев следили за карточками и раньше.
+INSERT INTO card_watchers (card_id, username)
+SELECT card_id, username FROM card_assignees
+UNION
+SELECT card_id, author FROM comments WHERE author <> '';
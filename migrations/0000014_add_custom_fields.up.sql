@@ -0,0 +1,24 @@
+CREATE TABLE custom_fields(
+    id         SERIAL PRIMARY KEY,
+    board_id   INTEGER NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    name       TEXT    NOT NULL,
+    type       TEXT    NOT NULL CHECK (type IN ('text', 'number', 'date', 'dropdown', 'checkbox')),
+    -- Варианты dropdown в виде JSON-массива строк.
+    options    TEXT    NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (board_id, name)
+);
+
+-- Значения хранятся в колонке своего типа, чтобы по ним можно было
+-- фильтровать и сортировать средствами БД.
+CREATE TABLE card_field_values(
+    card_id      INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    field_id     INTEGER NOT NULL REFERENCES custom_fields (id) ON DELETE CASCADE,
+    value_text   TEXT,
+    value_number DOUBLE PRECISION,
+    value_date   TIMESTAMPTZ,
+    value_bool   BOOLEAN,
+    PRIMARY KEY (card_id, field_id)
+);
+
+CREATE INDEX card_field_values_field_idx ON card_field_values (field_id);
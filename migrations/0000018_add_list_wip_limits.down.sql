@@ -0,0 +1,2 @@
+ALTER TABLE lists DROP COLUMN wip_soft;
+ALTER TABLE lists DROP COLUMN wip_limit;
@@ -0,0 +1,23 @@
+-- Режим дорожек доски; без записи дорожек нет. Удаление поля, по
+-- которому делятся дорожки, выключает их.
+CREATE TABLE board_swimlanes(
+    board_id INTEGER PRIMARY KEY REFERENCES boards (id) ON DELETE CASCADE,
+    mode     TEXT    NOT NULL CHECK (mode IN ('assignee', 'label', 'field', 'explicit')),
+    field_id INTEGER REFERENCES custom_fields (id) ON DELETE CASCADE
+);
+
+-- Явные дорожки и то, в какой из них лежит карточка.
+CREATE TABLE board_lanes(
+    id         SERIAL PRIMARY KEY,
+    board_id   INTEGER NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    title      TEXT    NOT NULL,
+    position   INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE card_lanes(
+    card_id INTEGER PRIMARY KEY REFERENCES cards (id) ON DELETE CASCADE,
+    lane_id INTEGER NOT NULL REFERENCES board_lanes (id) ON DELETE CASCADE
+);
+
+CREATE INDEX card_lanes_lane_idx ON card_lanes (lane_id);
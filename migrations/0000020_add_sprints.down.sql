@@ -0,0 +1,2 @@
+DROP TABLE sprint_cards;
+DROP TABLE sprints;
@@ -0,0 +1,223 @@
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+var (
+	ErrSchemaBehind = errors.New("database schema is behind")
+	ErrDirty        = errors.New("database schema is dirty")
+	ErrNoMigration  = errors.New("no migration to roll back")
+)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Current int
+	Latest  int
+	Dirty   bool
+	Pending []Migration
+}
+
+// Migrator ведёт таблицу schema_migrations в том же формате, что и
+// golang-migrate, поэтому базы, накатанные контейнером migrate/migrate,
+// подхватываются без ручных правок.
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sqlx.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Parse(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Parse читает файлы вида 0000001_name.up.sql / 0000001_name.down.sql.
+func Parse(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		base := strings.TrimSuffix(e.Name(), ".sql")
+		direction := path.Ext(base)
+		if direction != ".up" && direction != ".down" {
+			return nil, fmt.Errorf("migration %s: expected .up.sql or .down.sql suffix", e.Name())
+		}
+		base = strings.TrimSuffix(base, direction)
+		versionPart, name, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(versionPart)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version %q", e.Name(), versionPart)
+		}
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration %d: conflicting names %q and %q", version, m.Name, name)
+		}
+		if direction == ".up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+	result := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s: missing up file", m.Version, m.Name)
+		}
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+func (m *Migrator) ensureTable() error {
+	_, err := m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+	return err
+}
+
+func (m *Migrator) current() (int, bool, error) {
+	var rows []struct {
+		Version int  `db:"version"`
+		Dirty   bool `db:"dirty"`
+	}
+	if err := m.db.Select(&rows, `SELECT version, dirty FROM schema_migrations LIMIT 1`); err != nil {
+		return 0, false, err
+	}
+	if len(rows) == 0 {
+		return 0, false, nil
+	}
+	return rows[0].Version, rows[0].Dirty, nil
+}
+
+func (m *Migrator) Status() (Status, error) {
+	if err := m.ensureTable(); err != nil {
+		return Status{}, err
+	}
+	current, dirty, err := m.current()
+	if err != nil {
+		return Status{}, err
+	}
+	status := Status{Current: current, Dirty: dirty}
+	for _, mig := range m.migrations {
+		status.Latest = mig.Version
+		if mig.Version > current {
+			status.Pending = append(status.Pending, mig)
+		}
+	}
+	return status, nil
+}
+
+// Check возвращает ErrSchemaBehind, если есть ненакатанные миграции.
+func (m *Migrator) Check() error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("%w at version %d", ErrDirty, status.Current)
+	}
+	if len(status.Pending) > 0 {
+		return fmt.Errorf("%w: at version %d, latest is %d", ErrSchemaBehind, status.Current, status.Latest)
+	}
+	return nil
+}
+
+func (m *Migrator) Up() ([]Migration, error) {
+	status, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return nil, fmt.Errorf("%w at version %d", ErrDirty, status.Current)
+	}
+	var applied []Migration
+	for _, mig := range status.Pending {
+		if err := m.apply(mig.Up, mig.Version); err != nil {
+			return applied, fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+		}
+		applied = append(applied, mig)
+	}
+	return applied, nil
+}
+
+// Down откатывает одну последнюю применённую миграцию.
+func (m *Migrator) Down() (Migration, error) {
+	status, err := m.Status()
+	if err != nil {
+		return Migration{}, err
+	}
+	if status.Dirty {
+		return Migration{}, fmt.Errorf("%w at version %d", ErrDirty, status.Current)
+	}
+	if status.Current == 0 {
+		return Migration{}, ErrNoMigration
+	}
+	prev := 0
+	for i, mig := range m.migrations {
+		if mig.Version != status.Current {
+			continue
+		}
+		if mig.Down == "" {
+			return Migration{}, fmt.Errorf("migration %d_%s: missing down file", mig.Version, mig.Name)
+		}
+		if i > 0 {
+			prev = m.migrations[i-1].Version
+		}
+		if err := m.apply(mig.Down, prev); err != nil {
+			return Migration{}, fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+		}
+		return mig, nil
+	}
+	return Migration{}, fmt.Errorf("migration %d is applied but not known to this build", status.Current)
+}
+
+func (m *Migrator) apply(query string, version int) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(query); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	if version > 0 {
+		if _, err := tx.Exec(tx.Rebind(`INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)`), version, false); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       fstest.MapFS
+		want        []Migration
+		expectError bool
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"0000002_second.up.sql":   {Data: []byte("up2")},
+				"0000002_second.down.sql": {Data: []byte("down2")},
+				"0000001_first.up.sql":    {Data: []byte("up1")},
+				"0000001_first.down.sql":  {Data: []byte("down1")},
+				"README.md":               {Data: []byte("ignored")},
+			},
+			want: []Migration{
+				{Version: 1, Name: "first", Up: "up1", Down: "down1"},
+				{Version: 2, Name: "second", Up: "up2", Down: "down2"},
+			},
+		},
+		{
+			name:        "missing up file",
+			files:       fstest.MapFS{"0000001_first.down.sql": {Data: []byte("down1")}},
+			expectError: true,
+		},
+		{
+			name:        "bad version",
+			files:       fstest.MapFS{"abc_first.up.sql": {Data: []byte("up")}},
+			expectError: true,
+		},
+		{
+			name:        "bad direction",
+			files:       fstest.MapFS{"0000001_first.sql": {Data: []byte("up")}},
+			expectError: true,
+		},
+		{
+			name: "conflicting names",
+			files: fstest.MapFS{
+				"0000001_first.up.sql":   {Data: []byte("up")},
+				"0000001_other.down.sql": {Data: []byte("down")},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.files)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := Parse(FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, m := range migrations {
+		require.Equal(t, i+1, m.Version)
+		require.NotEmpty(t, m.Down, "migration %d has no down file", m.Version)
+	}
+}
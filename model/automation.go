@@ -0,0 +1,86 @@
+package model
+
+import "time"
+
+// Условия правил автоматизации.
+const (
+	ConditionInList        = "in_list"
+	ConditionHasLabel      = "has_label"
+	ConditionStatusIs      = "status_is"
+	ConditionTitleContains = "title_contains"
+)
+
+// Действия правил автоматизации.
+const (
+	ActionMoveCard    = "move_card"
+	ActionSetStatus   = "set_status"
+	ActionAddLabel    = "add_label"
+	ActionAssignUser  = "assign_user"
+	ActionPostComment = "post_comment"
+)
+
+// Результаты запуска правила в журнале.
+const (
+	RunStatusOK      = "ok"
+	RunStatusFailed  = "failed"
+	RunStatusSkipped = "skipped"
+)
+
+// AutomationTrigger описывает событие, на которое срабатывает правило.
+// Нулевые ListID и LabelID означают «любой список» и «любая метка».
+type AutomationTrigger struct {
+	Type    string `json:"type"`
+	ListID  int    `json:"list_id,omitempty"`
+	LabelID int    `json:"label_id,omitempty"`
+}
+
+type AutomationCondition struct {
+	Type    string `json:"type"`
+	ListID  int    `json:"list_id,omitempty"`
+	LabelID int    `json:"label_id,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Text    string `json:"text,omitempty"`
+}
+
+type AutomationAction struct {
+	Type     string `json:"type"`
+	ListID   int    `json:"list_id,omitempty"`
+	LabelID  int    `json:"label_id,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Username string `json:"username,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+// AutomationRule срабатывает на Trigger и, если выполнены все Conditions,
+// по порядку выполняет Actions в одной транзакции.
+type AutomationRule struct {
+	ID         int                   `json:"id"`
+	BoardID    int                   `json:"board_id"`
+	Name       string                `json:"name"`
+	Enabled    bool                  `json:"enabled"`
+	Trigger    AutomationTrigger     `json:"trigger"`
+	Conditions []AutomationCondition `json:"conditions"`
+	Actions    []AutomationAction    `json:"actions"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+type AutomationRuleInput struct {
+	BoardID    int
+	Name       string
+	Enabled    bool
+	Trigger    AutomationTrigger
+	Conditions []AutomationCondition
+	Actions    []AutomationAction
+}
+
+// AutomationRun — запись журнала выполнения правил.
+type AutomationRun struct {
+	ID        int       `db:"id" json:"id"`
+	RuleID    int       `db:"rule_id" json:"rule_id"`
+	BoardID   int       `db:"board_id" json:"board_id"`
+	CardID    int       `db:"card_id" json:"card_id"`
+	Event     string    `db:"event" json:"event"`
+	Status    string    `db:"status" json:"status"`
+	Error     string    `db:"error" json:"error"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
@@ -8,6 +8,15 @@ type Board struct {
 	Lists      []List    `db:"lists" json:"lists"`
 	NextListID int       `db:"next_list_id" json:"next_list_id"`
 	NextCardID int       `db:"next_card_id" json:"next_card_id"`
+	IsTemplate bool      `db:"is_template" json:"is_template"`
 	CreatedAt  time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
 }
+
+// BoardCopyInput — параметры копирования доски. Пустой Title означает
+// название исходной доски с пометкой «(копия)».
+type BoardCopyInput struct {
+	Title        string
+	IncludeCards bool
+	AsTemplate   bool
+}
@@ -0,0 +1,37 @@
+package model
+
+const (
+	BulkMove   = "move"
+	BulkUpdate = "update"
+	BulkDelete = "delete"
+	BulkLabel  = "label"
+	BulkAssign = "assign"
+)
+
+const (
+	BulkStatusOK         = "ok"
+	BulkStatusFailed     = "failed"
+	BulkStatusRolledBack = "rolled_back"
+	BulkStatusSkipped    = "skipped"
+)
+
+// BulkCardOperation — одна операция пакетного изменения карточек. Какие
+// поля нужны, зависит от Op: ListID для move, Title/Description для
+// update, LabelID для label, Username для assign.
+type BulkCardOperation struct {
+	Op          string
+	CardID      int
+	ListID      int
+	Title       *string
+	Description *string
+	LabelID     int
+	Username    string
+}
+
+type BulkCardResult struct {
+	CardID int
+	Status string
+	// Card — состояние карточки после операции (для delete — удалённая).
+	Card *Card
+	Err  error
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// CalendarDay — карточки со сроком в один день календаря. Date — полночь
+// этого дня в часовом поясе запроса.
+type CalendarDay struct {
+	Date  time.Time
+	Cards []Card
+}
+
+// CalendarToken — секретный токен iCalendar-ленты пользователя. Хранится
+// только хэш; сам токен показывается один раз при выпуске.
+type CalendarToken struct {
+	Username  string    `db:"username" json:"username"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
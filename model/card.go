@@ -2,18 +2,88 @@ package model
 
 import "time"
 
+// CardStatusDone — статус выполненной карточки.
+const CardStatusDone = "done"
+
 type Card struct {
-	ID          int       `db:"id" json:"id"`
-	BoardID     int       `db:"board_id" json:"board_id"`
-	ListID      int       `db:"list_id" json:"list_id"`
-	Title       string    `db:"title" json:"title"`
-	Description string    `db:"description" json:"description"`
-	Status      string    `db:"status" json:"status"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	ID          int        `db:"id" json:"id"`
+	BoardID     int        `db:"board_id" json:"board_id"`
+	ListID      int        `db:"list_id" json:"list_id"`
+	Title       string     `db:"title" json:"title"`
+	Description string     `db:"description" json:"description"`
+	Status      string     `db:"status" json:"status"`
+	DueAt       *time.Time `db:"due_at" json:"due_at"`
+	ArchivedAt  *time.Time `db:"archived_at" json:"archived_at"`
+	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+	// Mentions — упоминания в описании; хранилище их не заполняет.
+	Mentions []Mention `db:"-" json:"mentions,omitempty"`
+	// Fields — значения пользовательских полей; хранилище их не заполняет.
+	Fields []CardFieldValue `db:"-" json:"fields,omitempty"`
+	// Warnings — предупреждения к только что сделанному изменению,
+	// например о превышенном мягком лимите списка.
+	Warnings []string `db:"-" json:"warnings,omitempty"`
+}
+
+// CardFilter задаёт выборку GetCards. Карточки в корзине не попадают в
+// неё никогда, архивные — только с IncludeArchived. Условия объединяются
+// через И: карточка должна иметь все Labels (по названию, без учёта
+// регистра) и всех Assignees, статус — любой из Statuses. Срок ищется в
+// полуинтервале [DueFrom, DueTo), Text — подстрока названия или описания.
+type CardFilter struct {
+	ListID          *int
+	BoardID         *int
+	IncludeArchived bool
+	Labels          []string
+	Assignees       []string
+	Statuses        []string
+	DueFrom         *time.Time
+	DueTo           *time.Time
+	Text            string
+	Fields          []CardFieldFilter
+	Sort            *CardSort
+}
+
+// По чему можно упорядочить карточки. Без сортировки они идут по id.
+const (
+	CardSortTitle   = "title"
+	CardSortDue     = "due_at"
+	CardSortCreated = "created_at"
+	CardSortUpdated = "updated_at"
+	CardSortField   = "field"
+)
+
+var CardSorts = []string{CardSortTitle, CardSortDue, CardSortCreated, CardSortUpdated, CardSortField}
+
+// CardSort упорядочивает карточки по By; для CardSortField — по значению
+// поля FieldID, тип которого (Type) заполняет CardService. Карточки без
+// срока или значения поля идут последними, при равенстве — по id.
+type CardSort struct {
+	By      string
+	FieldID int
+	Type    string
+	Desc    bool
 }
 type CardInputCreate struct {
 	ListID      int    `db:"list_id" json:"list_id"`
 	Title       string `db:"title" json:"title"`
 	Description string `db:"description" json:"description"`
+	// Creator — кто создаёт карточку; в хранилище не попадает.
+	Creator string `db:"-" json:"-"`
+}
+
+// CardMoveInput — куда перенести карточку. Список может быть на другой
+// доске. LaneID — явная дорожка: nil оставляет прежнюю (при переносе на
+// другую доску карточка выходит из дорожек), 0 убирает из дорожек.
+type CardMoveInput struct {
+	ListID int
+	LaneID *int
+}
+
+// CardCopyInput — параметры копирования карточки. Нулевой ListID означает
+// исходный список, пустой Title — исходное название.
+type CardCopyInput struct {
+	ListID int
+	Title  string
 }
@@ -0,0 +1,26 @@
+package model
+
+type Checklist struct {
+	ID     int             `db:"id" json:"id"`
+	CardID int             `db:"card_id" json:"card_id"`
+	Title  string          `db:"title" json:"title"`
+	Items  []ChecklistItem `db:"items" json:"items"`
+}
+
+type ChecklistItem struct {
+	ID          int    `db:"id" json:"id"`
+	ChecklistID int    `db:"checklist_id" json:"checklist_id"`
+	Text        string `db:"text" json:"text"`
+	Checked     bool   `db:"checked" json:"checked"`
+}
+
+type ChecklistInputCreate struct {
+	CardID int    `db:"card_id" json:"card_id"`
+	Title  string `db:"title" json:"title"`
+}
+
+type ChecklistItemInputCreate struct {
+	ChecklistID int    `db:"checklist_id" json:"checklist_id"`
+	Text        string `db:"text" json:"text"`
+	Checked     bool   `db:"checked" json:"checked"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type Comment struct {
+	ID        int       `db:"id" json:"id"`
+	CardID    int       `db:"card_id" json:"card_id"`
+	Author    string    `db:"author" json:"author"`
+	Text      string    `db:"text" json:"text"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// Mentions — упоминания в тексте; хранилище их не заполняет.
+	Mentions []Mention `db:"-" json:"mentions,omitempty"`
+}
+
+type CommentInputCreate struct {
+	CardID int    `db:"card_id" json:"card_id"`
+	Author string `db:"author" json:"author"`
+	Text   string `db:"text" json:"text"`
+	// CreatedAt задаётся при импорте, чтобы сохранить исходную дату.
+	CreatedAt *time.Time `db:"created_at" json:"created_at,omitempty"`
+}
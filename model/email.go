@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// Состояния письма в очереди отправки.
+const (
+	EmailPending = "pending"
+	EmailSent    = "sent"
+	EmailFailed  = "failed"
+)
+
+// EmailSettings — адрес пользователя и способ доставки: сразу или
+// ежедневной сводкой.
+type EmailSettings struct {
+	Username     string     `db:"username" json:"username"`
+	Address      string     `db:"address" json:"address"`
+	Digest       bool       `db:"digest" json:"digest"`
+	LastDigestAt *time.Time `db:"last_digest_at" json:"last_digest_at"`
+}
+
+// Email — письмо в очереди отправки (outbox). Неудачные попытки
+// повторяются с NextAttemptAt, пока не кончится лимит.
+type Email struct {
+	ID            int        `db:"id" json:"id"`
+	Recipient     string     `db:"recipient" json:"recipient"`
+	Subject       string     `db:"subject" json:"subject"`
+	TextBody      string     `db:"text_body" json:"text_body"`
+	HTMLBody      string     `db:"html_body" json:"html_body"`
+	Status        string     `db:"status" json:"status"`
+	Attempts      int        `db:"attempts" json:"attempts"`
+	LastError     string     `db:"last_error" json:"last_error"`
+	NextAttemptAt time.Time  `db:"next_attempt_at" json:"next_attempt_at"`
+	SentAt        *time.Time `db:"sent_at" json:"sent_at"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+}
+
+// DigestItem — уведомление, отложенное до ежедневной сводки.
+type DigestItem struct {
+	ID        int       `db:"id" json:"id"`
+	Username  string    `db:"username" json:"username"`
+	Kind      string    `db:"kind" json:"kind"`
+	Event     string    `db:"event" json:"event"`
+	BoardID   int       `db:"board_id" json:"board_id"`
+	CardID    int       `db:"card_id" json:"card_id"`
+	CardTitle string    `db:"card_title" json:"card_title"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
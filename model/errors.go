@@ -0,0 +1,12 @@
+package model
+
+import "errors"
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrConflict — действие противоречит текущему состоянию данных.
+	ErrConflict = errors.New("conflict")
+	// ErrForbidden — у пользователя нет права на действие.
+	ErrForbidden = errors.New("forbidden")
+)
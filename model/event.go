@@ -0,0 +1,33 @@
+package model
+
+// Типы доменных событий. Часть из них служит триггерами правил
+// автоматизации, остальные нужны уведомлениям.
+const (
+	EventCardCreated  = "card_created"
+	EventCardMoved    = "card_moved"
+	EventDuePassed    = "due_passed"
+	EventLabelAdded   = "label_added"
+	EventCardAssigned = "card_assigned"
+	EventMentioned    = "mentioned"
+	EventDueSoon      = "due_soon"
+	EventCardUpdated  = "card_updated"
+	EventCommentAdded = "comment_added"
+)
+
+// Event — изменение, которое сервис уже сохранил. Card — состояние
+// карточки после изменения.
+type Event struct {
+	Type       string
+	Card       Card
+	FromListID int
+	LabelID    int
+	// Username — назначенный или упомянутый пользователь.
+	Username string
+	// Comment — добавленный комментарий у comment_added.
+	Comment *Comment
+	// Actor — кто сделал изменение, если это известно. Он не получает
+	// уведомление о собственном действии.
+	Actor string
+	// Depth больше нуля у событий, вызванных самой автоматизацией.
+	Depth int
+}
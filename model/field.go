@@ -0,0 +1,78 @@
+package model
+
+import "time"
+
+// Типы пользовательских полей.
+const (
+	FieldText     = "text"
+	FieldNumber   = "number"
+	FieldDate     = "date"
+	FieldDropdown = "dropdown"
+	FieldCheckbox = "checkbox"
+)
+
+var FieldTypes = []string{FieldText, FieldNumber, FieldDate, FieldDropdown, FieldCheckbox}
+
+// FieldDateLayout — формат значений полей типа date.
+const FieldDateLayout = "2006-01-02"
+
+// CustomField — пользовательское поле доски. Options задаются только у
+// полей типа dropdown.
+type CustomField struct {
+	ID        int       `db:"id" json:"id"`
+	BoardID   int       `db:"board_id" json:"board_id"`
+	Name      string    `db:"name" json:"name"`
+	Type      string    `db:"type" json:"type"`
+	Options   []string  `db:"-" json:"options"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type CustomFieldInputCreate struct {
+	BoardID int
+	Name    string
+	Type    string
+	Options []string
+}
+
+// CustomFieldUpdate меняет название и варианты; тип поля не меняется.
+type CustomFieldUpdate struct {
+	Name    string
+	Options []string
+}
+
+// CardFieldValue — значение поля у карточки. Заполнен ровно один из
+// указателей, по типу поля: Text — для text и dropdown, Number, Date
+// или Bool — для остальных.
+type CardFieldValue struct {
+	CardID  int        `db:"card_id" json:"card_id"`
+	FieldID int        `db:"field_id" json:"field_id"`
+	Text    *string    `db:"value_text" json:"text,omitempty"`
+	Number  *float64   `db:"value_number" json:"number,omitempty"`
+	Date    *time.Time `db:"value_date" json:"date,omitempty"`
+	Bool    *bool      `db:"value_bool" json:"bool,omitempty"`
+}
+
+// Value возвращает значение в виде для JSON: строку, число, дату в
+// FieldDateLayout или bool.
+func (v CardFieldValue) Value() any {
+	switch {
+	case v.Text != nil:
+		return *v.Text
+	case v.Number != nil:
+		return *v.Number
+	case v.Date != nil:
+		return v.Date.Format(FieldDateLayout)
+	case v.Bool != nil:
+		return *v.Bool
+	}
+	return nil
+}
+
+// CardFieldFilter оставляет карточки, у которых значение поля FieldID
+// равно Value. Value приходит строкой из запроса; CardService разбирает
+// её по типу поля в Match, по которому фильтрует хранилище.
+type CardFieldFilter struct {
+	FieldID int
+	Value   string
+	Match   CardFieldValue
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// Режимы дорожек доски. Карточка с несколькими исполнителями, метками
+// или без значения попадает в каждую свою дорожку либо в дорожку «без
+// дорожки».
+const (
+	LaneNone     = ""
+	LaneAssignee = "assignee"
+	LaneLabel    = "label"
+	LaneField    = "field"
+	LaneExplicit = "explicit"
+)
+
+var LaneModes = []string{LaneNone, LaneAssignee, LaneLabel, LaneField, LaneExplicit}
+
+// Swimlanes — как доска делит карточки на горизонтальные дорожки.
+// FieldID задан только в режиме LaneField.
+type Swimlanes struct {
+	BoardID int    `db:"board_id" json:"board_id"`
+	Mode    string `db:"mode" json:"mode"`
+	FieldID *int   `db:"field_id" json:"field_id"`
+}
+
+// Lane — явная дорожка доски для режима LaneExplicit. Дорожки идут по
+// Position, при равенстве — по id.
+type Lane struct {
+	ID        int       `db:"id" json:"id"`
+	BoardID   int       `db:"board_id" json:"board_id"`
+	Title     string    `db:"title" json:"title"`
+	Position  int       `db:"position" json:"position"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type LaneInputCreate struct {
+	BoardID int
+	Title   string
+}
+
+// CardLane — явная дорожка карточки. Карточка без записи лежит вне
+// дорожек.
+type CardLane struct {
+	CardID int `db:"card_id" json:"card_id"`
+	LaneID int `db:"lane_id" json:"lane_id"`
+}
+
+// BoardLayout — доска в виде матрицы: дорожки по строкам, списки по
+// столбцам. Без режима дорожек матрица состоит из одной строки.
+type BoardLayout struct {
+	Board     Board
+	Swimlanes Swimlanes
+	Lists     []List
+	Lanes     []BoardLane
+}
+
+// BoardLane — строка матрицы. Key — имя исполнителя, название метки или
+// значение поля; у явных дорожек вместо него задан LaneID. У строки
+// карточек без дорожки оба пустые. Cells идут в порядке Lists.
+type BoardLane struct {
+	Key    string
+	LaneID *int
+	Title  string
+	Cells  []LaneCell
+}
+
+type LaneCell struct {
+	ListID int
+	Cards  []Card
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// Типы связей между карточками. Хранятся только прямые типы, обратные
+// (blocked_by, duplicated_by, child_of) — та же связь со стороны цели.
+const (
+	LinkBlocks       = "blocks"
+	LinkBlockedBy    = "blocked_by"
+	LinkDuplicates   = "duplicates"
+	LinkDuplicatedBy = "duplicated_by"
+	LinkRelatesTo    = "relates_to"
+	LinkParentOf     = "parent_of"
+	LinkChildOf      = "child_of"
+)
+
+var LinkTypes = []string{LinkBlocks, LinkDuplicates, LinkRelatesTo, LinkParentOf}
+
+var inverseLinkTypes = map[string]string{
+	LinkBlocks:       LinkBlockedBy,
+	LinkBlockedBy:    LinkBlocks,
+	LinkDuplicates:   LinkDuplicatedBy,
+	LinkDuplicatedBy: LinkDuplicates,
+	LinkRelatesTo:    LinkRelatesTo,
+	LinkParentOf:     LinkChildOf,
+	LinkChildOf:      LinkParentOf,
+}
+
+// InverseLinkType возвращает тип связи с точки зрения другой карточки.
+func InverseLinkType(linkType string) (string, bool) {
+	inverse, ok := inverseLinkTypes[linkType]
+	return inverse, ok
+}
+
+// CardLink — направленная связь: SourceID Type TargetID, например
+// «1 blocks 2».
+type CardLink struct {
+	ID        int       `db:"id" json:"id"`
+	SourceID  int       `db:"source_id" json:"source_id"`
+	TargetID  int       `db:"target_id" json:"target_id"`
+	Type      string    `db:"type" json:"type"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// From возвращает тип связи и другую карточку с точки зрения cardID.
+func (l CardLink) From(cardID int) (string, int) {
+	if l.SourceID == cardID {
+		return l.Type, l.TargetID
+	}
+	return inverseLinkTypes[l.Type], l.SourceID
+}
+
+// CardLinkInput — связь от карточки из пути запроса к CardID; Type может
+// быть и обратным.
+type CardLinkInput struct {
+	Type   string
+	CardID int
+}
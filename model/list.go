@@ -3,14 +3,29 @@ package model
 import "time"
 
 type List struct {
-	ID        int       `db:"id" json:"id"`
-	BoardID   int       `db:"board_id" json:"board_id"`
-	Title     string    `db:"title" json:"title"`
-	Cards     []Card    `db:"cards" json:"cards"`
+	ID      int    `db:"id" json:"id"`
+	BoardID int    `db:"board_id" json:"board_id"`
+	Title   string `db:"title" json:"title"`
+	Cards   []Card `db:"cards" json:"cards"`
+	// WIPLimit — сколько карточек может быть в списке; nil — без лимита.
+	// Мягкий лимит (WIPSoft) можно превысить, получив предупреждение.
+	WIPLimit *int `db:"wip_limit" json:"wip_limit"`
+	WIPSoft  bool `db:"wip_soft" json:"wip_soft"`
+	// CardCount — карточки в списке, кроме архивных и удалённых.
+	CardCount int       `db:"card_count" json:"card_count"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
 }
 type ListInputCreate struct {
-	BoardID int    `db:"board_id" json:"board_id"`
-	Title   string `db:"title" json:"title"`
+	BoardID  int    `db:"board_id" json:"board_id"`
+	Title    string `db:"title" json:"title"`
+	WIPLimit *int   `db:"wip_limit" json:"wip_limit"`
+	WIPSoft  bool   `db:"wip_soft" json:"wip_soft"`
+}
+
+// ListWIPLimit задаёт лимит незавершённой работы списка; Limit nil
+// снимает его.
+type ListWIPLimit struct {
+	Limit *int
+	Soft  bool
 }
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// BoardMember — пользователь, которого можно упомянуть на доске.
+type BoardMember struct {
+	BoardID  int       `db:"board_id" json:"board_id"`
+	Username string    `db:"username" json:"username"`
+	AddedAt  time.Time `db:"added_at" json:"added_at"`
+}
+
+// usernamePattern — допустимое имя участника. Те же символы ищет разбор
+// упоминаний, поэтому любого участника можно упомянуть через @.
+var usernamePattern = regexp.MustCompile(`^[\p{L}\p{N}_][\p{L}\p{N}_.-]{0,63}$`)
+
+func ValidateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return fmt.Errorf("%w: invalid username %q", ErrInvalidInput, username)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Mention — упоминание участника доски в описании карточки или, если
+// задан CommentID, в комментарии. Author известен только у комментариев.
+type Mention struct {
+	ID        int       `db:"id" json:"id"`
+	CardID    int       `db:"card_id" json:"card_id"`
+	CommentID *int      `db:"comment_id" json:"comment_id"`
+	Username  string    `db:"username" json:"username"`
+	Author    string    `db:"author" json:"author"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
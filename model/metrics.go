@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// CardMove — запись истории карточки: в момент MovedAt она попала в
+// список ToListID. У только что созданной карточки FromListID равен нулю.
+type CardMove struct {
+	ID         int       `db:"id" json:"id"`
+	CardID     int       `db:"card_id" json:"card_id"`
+	FromListID int       `db:"from_list_id" json:"from_list_id"`
+	ToListID   int       `db:"to_list_id" json:"to_list_id"`
+	MovedAt    time.Time `db:"moved_at" json:"moved_at"`
+}
+
+// MetricsInput — параметры отчёта по доске. Даты YYYY-MM-DD в поясе TZ;
+// пустые списки «начато» и «готово» выбираются по умолчанию.
+type MetricsInput struct {
+	From         string
+	To           string
+	TZ           string
+	StartedLists []int
+	DoneLists    []int
+}
+
+// BoardMetrics — метрики потока доски за период с From по To
+// включительно.
+type BoardMetrics struct {
+	From         time.Time
+	To           time.Time
+	StartedLists []int
+	DoneLists    []int
+	// LeadTime — от создания карточки до попадания в «готово», CycleTime —
+	// от первого попадания в «начато».
+	LeadTime   DurationStats
+	CycleTime  DurationStats
+	Throughput []WeekThroughput
+	Flow       []FlowDay
+}
+
+type DurationStats struct {
+	Count   int
+	Average time.Duration
+	Median  time.Duration
+	P85     time.Duration
+}
+
+// WeekThroughput — сколько карточек завершено за неделю, начинающуюся в
+// понедельник WeekStart.
+type WeekThroughput struct {
+	WeekStart time.Time
+	Cards     int
+}
+
+// FlowDay — срез накопительной диаграммы потока: сколько карточек было в
+// каждом списке доски на конец дня Date.
+type FlowDay struct {
+	Date  time.Time
+	Lists []ListCount
+}
+
+type ListCount struct {
+	ListID int
+	Cards  int
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// Виды уведомлений.
+const (
+	NotificationAssigned    = "assigned"
+	NotificationMentioned   = "mentioned"
+	NotificationDueSoon     = "due_soon"
+	NotificationOverdue     = "overdue"
+	NotificationCardChanged = "card_changed"
+)
+
+// NotificationKinds — все виды уведомлений в порядке показа настроек.
+var NotificationKinds = []string{
+	NotificationAssigned,
+	NotificationMentioned,
+	NotificationDueSoon,
+	NotificationOverdue,
+	NotificationCardChanged,
+}
+
+// Notification — запись во входящих пользователя. Event — доменное
+// событие, из которого она появилась.
+type Notification struct {
+	ID        int        `db:"id" json:"id"`
+	Username  string     `db:"username" json:"username"`
+	Kind      string     `db:"kind" json:"kind"`
+	Event     string     `db:"event" json:"event"`
+	BoardID   int        `db:"board_id" json:"board_id"`
+	CardID    int        `db:"card_id" json:"card_id"`
+	CardTitle string     `db:"card_title" json:"card_title"`
+	ReadAt    *time.Time `db:"read_at" json:"read_at"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+}
+
+type NotificationFilter struct {
+	Username   string
+	UnreadOnly bool
+	Limit      int
+}
+
+// NotificationInbox — страница входящих и общее число непрочитанных.
+type NotificationInbox struct {
+	Unread        int
+	Notifications []Notification
+}
+
+// NotificationPreference — получать ли уведомления вида Kind во входящие
+// и на почту.
+type NotificationPreference struct {
+	Kind  string `db:"kind" json:"kind"`
+	InApp bool   `db:"in_app" json:"in_app"`
+	Email bool   `db:"email" json:"email"`
+}
+
+// DefaultNotificationPreference — настройка, пока пользователь её не
+// сохранил: во входящие приходит всё, на почту — всё, кроме изменений
+// карточек.
+func DefaultNotificationPreference(kind string) NotificationPreference {
+	return NotificationPreference{Kind: kind, InApp: true, Email: kind != NotificationCardChanged}
+}
+
+// NotificationPreferenceUpdate — изменение настройки одного вида; nil
+// оставляет канал как был.
+type NotificationPreferenceUpdate struct {
+	Kind  string
+	InApp *bool
+	Email *bool
+}
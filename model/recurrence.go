@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// Частота повторения карточки.
+const (
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+	RecurrenceCron    = "cron"
+)
+
+// CardRecurrence — правило повторения карточки-образца: в NextRunAt в
+// список ListID добавляется новая карточка с её названием и описанием.
+// Для daily/weekly/monthly повторы отсчитываются от StartsAt с шагом
+// Interval, для cron — по выражению Cron (UTC).
+type CardRecurrence struct {
+	CardID    int        `db:"card_id" json:"card_id"`
+	ListID    int        `db:"list_id" json:"list_id"`
+	Frequency string     `db:"frequency" json:"frequency"`
+	Interval  int        `db:"interval_count" json:"interval"`
+	Cron      string     `db:"cron" json:"cron"`
+	StartsAt  time.Time  `db:"starts_at" json:"starts_at"`
+	NextRunAt time.Time  `db:"next_run_at" json:"next_run_at"`
+	LastRunAt *time.Time `db:"last_run_at" json:"last_run_at"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+}
+
+// CardRecurrenceInput — настройки повторения. Нулевой ListID означает
+// список самой карточки, пустой StartsAt — текущий момент.
+type CardRecurrenceInput struct {
+	ListID    int
+	Frequency string
+	Interval  int
+	Cron      string
+	StartsAt  *time.Time
+}
@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// Состояния спринта: запланирован, идёт, завершён. На доске идёт не
+// больше одного спринта.
+const (
+	SprintPlanned   = "planned"
+	SprintActive    = "active"
+	SprintCompleted = "completed"
+)
+
+// Sprint — итерация доски. StartDate и EndDate — дни (полночь UTC),
+// EndDate входит в спринт.
+type Sprint struct {
+	ID          int        `db:"id" json:"id"`
+	BoardID     int        `db:"board_id" json:"board_id"`
+	Name        string     `db:"name" json:"name"`
+	Goal        string     `db:"goal" json:"goal"`
+	StartDate   time.Time  `db:"start_date" json:"start_date"`
+	EndDate     time.Time  `db:"end_date" json:"end_date"`
+	Status      string     `db:"status" json:"status"`
+	StartedAt   *time.Time `db:"started_at" json:"started_at"`
+	CompletedAt *time.Time `db:"completed_at" json:"completed_at"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+}
+
+// SprintInput — спринт из запроса; даты в формате FieldDateLayout.
+type SprintInput struct {
+	BoardID   int
+	Name      string
+	Goal      string
+	StartDate string
+	EndDate   string
+}
+
+// SprintCard — пребывание карточки в спринте с AddedAt до RemovedAt.
+// Запись без RemovedAt — текущий спринт карточки; по закрытым записям
+// строится состав спринта в прошлом.
+type SprintCard struct {
+	ID        int        `db:"id" json:"id"`
+	SprintID  int        `db:"sprint_id" json:"sprint_id"`
+	CardID    int        `db:"card_id" json:"card_id"`
+	AddedAt   time.Time  `db:"added_at" json:"added_at"`
+	RemovedAt *time.Time `db:"removed_at" json:"removed_at"`
+}
+
+// SprintCompletion — итог завершения спринта: Done остаются в нём,
+// RolledOver перенесены в Next или, если его нет, убраны из спринтов.
+type SprintCompletion struct {
+	Sprint     Sprint
+	Done       []int
+	RolledOver []int
+	Next       *Sprint
+}
+
+// BurndownDay — состояние спринта на конец дня: Scope карточек в нём,
+// из них Done в списках «готово». Ideal — равномерное сгорание объёма
+// на день запуска (без запуска — первого дня) к последнему дню спринта.
+type BurndownDay struct {
+	Date      time.Time
+	Scope     int
+	Done      int
+	Remaining int
+	Ideal     float64
+}
+
+type SprintBurndown struct {
+	Sprint    Sprint
+	DoneLists []int
+	Days      []BurndownDay
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// Группировка карточек в сохранённом представлении. Карточка с
+// несколькими метками или исполнителями попадает в каждую их группу.
+const (
+	GroupNone     = ""
+	GroupList     = "list"
+	GroupLabel    = "label"
+	GroupAssignee = "assignee"
+	GroupStatus   = "status"
+)
+
+var ViewGroups = []string{GroupNone, GroupList, GroupLabel, GroupAssignee, GroupStatus}
+
+// View — сохранённое представление доски: запрос на языке фильтров,
+// сортировка и группировка. Общее (Shared) представление видят все, но
+// менять его может только владелец.
+type View struct {
+	ID        int       `db:"id" json:"id"`
+	BoardID   int       `db:"board_id" json:"board_id"`
+	Owner     string    `db:"owner" json:"owner"`
+	Name      string    `db:"name" json:"name"`
+	Query     string    `db:"query" json:"query"`
+	Sort      string    `db:"sort" json:"sort"`
+	Desc      bool      `db:"sort_desc" json:"sort_desc"`
+	Group     string    `db:"group_by" json:"group_by"`
+	Shared    bool      `db:"shared" json:"shared"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type ViewInput struct {
+	BoardID int
+	Owner   string
+	Name    string
+	Query   string
+	Sort    string
+	Desc    bool
+	Group   string
+	Shared  bool
+}
+
+// CardGroup — карточки одной группы представления. Key — название
+// списка или метки, имя исполнителя или статус; пустой у карточек без
+// них.
+type CardGroup struct {
+	Key   string
+	Cards []Card
+}
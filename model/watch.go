@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Что можно отслеживать. Наблюдатель списка или доски следит за всеми
+// их карточками.
+const (
+	WatchCard  = "card"
+	WatchList  = "list"
+	WatchBoard = "board"
+)
+
+var WatchKinds = []string{WatchCard, WatchList, WatchBoard}
+
+type WatchTarget struct {
+	Kind string
+	ID   int
+}
+
+// Watch — подписка пользователя на карточку, список или доску.
+type Watch struct {
+	Username  string    `db:"username" json:"username"`
+	Kind      string    `db:"kind" json:"kind"`
+	TargetID  int       `db:"target_id" json:"target_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
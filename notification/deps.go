@@ -0,0 +1,26 @@
+package notification
+
+import "awesomeProject2/cmd/model"
+
+type Storage interface {
+	CreateNotification(n model.Notification) (model.Notification, error)
+	GetNotifications(filter model.NotificationFilter) ([]model.Notification, error)
+	CountUnread(username string) (int, error)
+	MarkNotificationRead(id int, username string) (model.Notification, error)
+	MarkAllNotificationsRead(username string) (int, error)
+	GetNotificationPreferences(username string) ([]model.NotificationPreference, error)
+	SetNotificationPreference(username string, pref model.NotificationPreference) error
+	GetEmailSettings(username string) (model.EmailSettings, error)
+	SaveEmailSettings(settings model.EmailSettings) (model.EmailSettings, error)
+}
+
+// AudienceReader отвечает, кому интересны изменения карточки: её
+// наблюдателям, в том числе через список и доску.
+type AudienceReader interface {
+	GetCardWatchers(cardID int) ([]string, error)
+}
+
+// Mailer доставляет уведомление на почту.
+type Mailer interface {
+	Deliver(n model.Notification) error
+}
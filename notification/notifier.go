@@ -0,0 +1,106 @@
+package notification
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"go.uber.org/zap"
+	"slices"
+)
+
+// Notifier превращает доменные события во входящие уведомления и, если
+// задан Mail, в письма. Назначение и упоминание адресованы одному
+// пользователю, остальные события — наблюдателям карточки, кроме
+// автора изменения.
+type Notifier struct {
+	Storage  Storage
+	Audience AudienceReader
+	Mail     Mailer
+	logger   *zap.Logger
+}
+
+var _ service.EventPublisher = (*Notifier)(nil)
+
+func NewNotifier(storage Storage, audience AudienceReader, logger *zap.Logger) *Notifier {
+	return &Notifier{
+		Storage:  storage,
+		Audience: audience,
+		logger:   logger,
+	}
+}
+
+// eventKinds сопоставляет событиям вид уведомления.
+var eventKinds = map[string]string{
+	model.EventCardAssigned: model.NotificationAssigned,
+	model.EventMentioned:    model.NotificationMentioned,
+	model.EventDueSoon:      model.NotificationDueSoon,
+	model.EventDuePassed:    model.NotificationOverdue,
+	model.EventCardMoved:    model.NotificationCardChanged,
+	model.EventLabelAdded:   model.NotificationCardChanged,
+	model.EventCardCreated:  model.NotificationCardChanged,
+	model.EventCardUpdated:  model.NotificationCardChanged,
+	model.EventCommentAdded: model.NotificationCardChanged,
+}
+
+func (n *Notifier) Publish(event model.Event) {
+	kind, ok := eventKinds[event.Type]
+	if !ok {
+		return
+	}
+	recipients, err := n.recipients(event)
+	if err != nil {
+		n.logger.Error("Не удалось определить получателей уведомления", zap.Error(err), zap.String("event", event.Type), zap.Int("cardID", event.Card.ID))
+		return
+	}
+	for _, username := range recipients {
+		pref, err := n.preference(username, kind)
+		if err != nil {
+			n.logger.Error("Не удалось прочитать настройки уведомлений", zap.Error(err), zap.String("username", username))
+			continue
+		}
+		notification := model.Notification{
+			Username:  username,
+			Kind:      kind,
+			Event:     event.Type,
+			BoardID:   event.Card.BoardID,
+			CardID:    event.Card.ID,
+			CardTitle: event.Card.Title,
+		}
+		if pref.InApp {
+			if _, err := n.Storage.CreateNotification(notification); err != nil {
+				n.logger.Error("Не удалось сохранить уведомление", zap.Error(err), zap.String("username", username), zap.Int("cardID", event.Card.ID))
+			}
+		}
+		if pref.Email && n.Mail != nil {
+			if err := n.Mail.Deliver(notification); err != nil {
+				n.logger.Error("Не удалось поставить письмо в очередь", zap.Error(err), zap.String("username", username), zap.Int("cardID", event.Card.ID))
+			}
+		}
+	}
+}
+
+func (n *Notifier) recipients(event model.Event) ([]string, error) {
+	switch event.Type {
+	case model.EventCardAssigned, model.EventMentioned:
+		return []string{event.Username}, nil
+	}
+	watchers, err := n.Audience.GetCardWatchers(event.Card.ID)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(watchers)
+	watchers = slices.Compact(watchers)
+	return slices.DeleteFunc(watchers, func(u string) bool { return u == event.Actor }), nil
+}
+
+func (n *Notifier) preference(username, kind string) (model.NotificationPreference, error) {
+	prefs, err := n.Storage.GetNotificationPreferences(username)
+	if err != nil {
+		return model.NotificationPreference{}, err
+	}
+	for _, p := range prefs {
+		if p.Kind == kind {
+			return p, nil
+		}
+	}
+	return model.DefaultNotificationPreference(kind), nil
+}
@@ -0,0 +1,131 @@
+package notification
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestNotifier(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	card, err := store.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "Отчёт"})
+	require.NoError(t, err)
+	watch := func(username, kind string, id int) {
+		_, err := store.Watch(username, model.WatchTarget{Kind: kind, ID: id})
+		require.NoError(t, err)
+	}
+	watch("anna", model.WatchCard, card.ID)
+	watch("boris", model.WatchBoard, board.ID)
+	watch("vera", model.WatchList, list.ID)
+	require.NoError(t, store.SetNotificationPreference("boris", model.NotificationPreference{Kind: model.NotificationCardChanged, InApp: false}))
+
+	tests := []struct {
+		name  string
+		event model.Event
+		want  map[string][]string
+	}{
+		{
+			name:  "assignment goes to the assignee only",
+			event: model.Event{Type: model.EventCardAssigned, Card: card, Username: "vera"},
+			want:  map[string][]string{"vera": {model.NotificationAssigned}},
+		},
+		{
+			name:  "mention",
+			event: model.Event{Type: model.EventMentioned, Card: card, Username: "anna"},
+			want:  map[string][]string{"anna": {model.NotificationMentioned}},
+		},
+		{
+			name:  "due soon goes to card, list and board watchers",
+			event: model.Event{Type: model.EventDueSoon, Card: card},
+			want:  map[string][]string{"anna": {model.NotificationDueSoon}, "boris": {model.NotificationDueSoon}, "vera": {model.NotificationDueSoon}},
+		},
+		{
+			name:  "overdue",
+			event: model.Event{Type: model.EventDuePassed, Card: card},
+			want:  map[string][]string{"anna": {model.NotificationOverdue}, "boris": {model.NotificationOverdue}, "vera": {model.NotificationOverdue}},
+		},
+		{
+			name:  "change respects preferences",
+			event: model.Event{Type: model.EventCardMoved, Card: card, FromListID: list.ID},
+			want:  map[string][]string{"anna": {model.NotificationCardChanged}, "vera": {model.NotificationCardChanged}},
+		},
+		{
+			name:  "actor is not notified about own change",
+			event: model.Event{Type: model.EventCardUpdated, Card: card, Actor: "anna"},
+			want:  map[string][]string{"vera": {model.NotificationCardChanged}},
+		},
+		{
+			name:  "comment",
+			event: model.Event{Type: model.EventCommentAdded, Card: card, Comment: &model.Comment{CardID: card.ID, Author: "vera"}, Actor: "vera"},
+			want:  map[string][]string{"anna": {model.NotificationCardChanged}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, u := range []string{"anna", "boris", "vera"} {
+				_, err := store.MarkAllNotificationsRead(u)
+				require.NoError(t, err)
+			}
+
+			NewNotifier(store, store, zap.NewNop()).Publish(tt.event)
+
+			got := map[string][]string{}
+			for _, u := range []string{"anna", "boris", "vera"} {
+				unread, err := store.GetNotifications(model.NotificationFilter{Username: u, UnreadOnly: true, Limit: 10})
+				require.NoError(t, err)
+				for _, n := range unread {
+					require.Equal(t, card.ID, n.CardID)
+					require.Equal(t, board.ID, n.BoardID)
+					require.Equal(t, "Отчёт", n.CardTitle)
+					require.Equal(t, tt.event.Type, n.Event)
+					got[u] = append(got[u], n.Kind)
+				}
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type mailRecorder struct {
+	delivered []model.Notification
+}
+
+func (m *mailRecorder) Deliver(n model.Notification) error {
+	m.delivered = append(m.delivered, n)
+	return nil
+}
+
+func TestNotifierMail(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	card, err := store.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "Отчёт"})
+	require.NoError(t, err)
+	_, err = store.Watch("anna", model.WatchTarget{Kind: model.WatchCard, ID: card.ID})
+	require.NoError(t, err)
+	require.NoError(t, store.SetNotificationPreference("boris", model.NotificationPreference{Kind: model.NotificationAssigned, InApp: false, Email: true}))
+	mail := &mailRecorder{}
+	notifier := NewNotifier(store, store, zap.NewNop())
+	notifier.Mail = mail
+
+	notifier.Publish(model.Event{Type: model.EventCardAssigned, Card: card, Username: "boris"})
+	notifier.Publish(model.Event{Type: model.EventCardMoved, Card: card, FromListID: list.ID})
+
+	require.Len(t, mail.delivered, 1, "card changes are not emailed by default")
+	require.Equal(t, "boris", mail.delivered[0].Username)
+	require.Equal(t, model.NotificationAssigned, mail.delivered[0].Kind)
+	unread, err := store.CountUnread("boris")
+	require.NoError(t, err)
+	require.Zero(t, unread, "in-app channel is off for boris")
+	unread, err = store.CountUnread("anna")
+	require.NoError(t, err)
+	require.Equal(t, 1, unread)
+}
@@ -0,0 +1,145 @@
+package notification
+
+import (
+	"awesomeProject2/cmd/model"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+)
+
+const (
+	defaultInboxLimit = 50
+	maxInboxLimit     = 200
+)
+
+type Service struct {
+	Storage Storage
+}
+
+func NewService(storage Storage) *Service {
+	return &Service{Storage: storage}
+}
+
+// GetInbox возвращает уведомления пользователя, новые первыми, и число
+// непрочитанных. limit вне (0, maxInboxLimit] заменяется значением по
+// умолчанию или обрезается.
+func (s Service) GetInbox(filter model.NotificationFilter) (model.NotificationInbox, error) {
+	if err := validateUsername(filter.Username); err != nil {
+		return model.NotificationInbox{}, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultInboxLimit
+	}
+	filter.Limit = min(filter.Limit, maxInboxLimit)
+	notifications, err := s.Storage.GetNotifications(filter)
+	if err != nil {
+		return model.NotificationInbox{}, err
+	}
+	unread, err := s.Storage.CountUnread(filter.Username)
+	if err != nil {
+		return model.NotificationInbox{}, err
+	}
+	return model.NotificationInbox{Unread: unread, Notifications: notifications}, nil
+}
+
+func (s Service) MarkRead(id int, username string) (model.Notification, error) {
+	return s.Storage.MarkNotificationRead(id, username)
+}
+
+// MarkAllRead возвращает число отмеченных уведомлений.
+func (s Service) MarkAllRead(username string) (int, error) {
+	if err := validateUsername(username); err != nil {
+		return 0, err
+	}
+	return s.Storage.MarkAllNotificationsRead(username)
+}
+
+// GetPreferences возвращает настройки всех видов уведомлений, подставляя
+// значения по умолчанию для несохранённых.
+func (s Service) GetPreferences(username string) ([]model.NotificationPreference, error) {
+	if err := validateUsername(username); err != nil {
+		return nil, err
+	}
+	saved, err := s.Storage.GetNotificationPreferences(username)
+	if err != nil {
+		return nil, err
+	}
+	prefs := make([]model.NotificationPreference, 0, len(model.NotificationKinds))
+	for _, kind := range model.NotificationKinds {
+		pref := model.DefaultNotificationPreference(kind)
+		if i := slices.IndexFunc(saved, func(p model.NotificationPreference) bool { return p.Kind == kind }); i >= 0 {
+			pref = saved[i]
+		}
+		prefs = append(prefs, pref)
+	}
+	return prefs, nil
+}
+
+// SetPreferences применяет переданные изменения; незаданные каналы и
+// остальные виды не меняются.
+func (s Service) SetPreferences(username string, updates []model.NotificationPreferenceUpdate) ([]model.NotificationPreference, error) {
+	if err := validateUsername(username); err != nil {
+		return nil, err
+	}
+	for _, u := range updates {
+		if !slices.Contains(model.NotificationKinds, u.Kind) {
+			return nil, fmt.Errorf("%w: unknown notification kind %q", model.ErrInvalidInput, u.Kind)
+		}
+	}
+	prefs, err := s.GetPreferences(username)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range updates {
+		pref := prefs[slices.Index(model.NotificationKinds, u.Kind)]
+		if u.InApp != nil {
+			pref.InApp = *u.InApp
+		}
+		if u.Email != nil {
+			pref.Email = *u.Email
+		}
+		if err := s.Storage.SetNotificationPreference(username, pref); err != nil {
+			return nil, err
+		}
+		prefs[slices.Index(model.NotificationKinds, u.Kind)] = pref
+	}
+	return prefs, nil
+}
+
+// GetEmailSettings возвращает почтовые настройки; пока их не сохранили,
+// адрес пуст и письма не отправляются.
+func (s Service) GetEmailSettings(username string) (model.EmailSettings, error) {
+	if err := validateUsername(username); err != nil {
+		return model.EmailSettings{}, err
+	}
+	settings, err := s.Storage.GetEmailSettings(username)
+	if errors.Is(err, model.ErrNotFound) {
+		return model.EmailSettings{Username: username}, nil
+	}
+	return settings, err
+}
+
+// SetEmailSettings сохраняет адрес и способ доставки писем. Пустой адрес
+// отключает письма.
+func (s Service) SetEmailSettings(settings model.EmailSettings) (model.EmailSettings, error) {
+	if err := validateUsername(settings.Username); err != nil {
+		return model.EmailSettings{}, err
+	}
+	settings.Address = strings.TrimSpace(settings.Address)
+	if settings.Address != "" {
+		addr, err := mail.ParseAddress(settings.Address)
+		if err != nil || addr.Address != settings.Address {
+			return model.EmailSettings{}, fmt.Errorf("%w: invalid email address %q", model.ErrInvalidInput, settings.Address)
+		}
+	}
+	return s.Storage.SaveEmailSettings(settings)
+}
+
+func validateUsername(username string) error {
+	if strings.TrimSpace(username) == "" {
+		return fmt.Errorf("%w: username is required", model.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInbox(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	card, err := store.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "c"})
+	require.NoError(t, err)
+	for range 3 {
+		_, err := store.CreateNotification(model.Notification{Username: "anna", Kind: model.NotificationAssigned, Event: model.EventCardAssigned, BoardID: board.ID, CardID: card.ID})
+		require.NoError(t, err)
+	}
+	s := NewService(store)
+
+	inbox, err := s.GetInbox(model.NotificationFilter{Username: "anna", Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, inbox.Unread)
+	require.Len(t, inbox.Notifications, 2)
+
+	_, err = s.MarkRead(inbox.Notifications[0].ID, "anna")
+	require.NoError(t, err)
+	inbox, err = s.GetInbox(model.NotificationFilter{Username: "anna", UnreadOnly: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, inbox.Unread)
+	require.Len(t, inbox.Notifications, 2)
+
+	marked, err := s.MarkAllRead("anna")
+	require.NoError(t, err)
+	require.Equal(t, 2, marked)
+	inbox, err = s.GetInbox(model.NotificationFilter{Username: "anna"})
+	require.NoError(t, err)
+	require.Zero(t, inbox.Unread)
+	require.Len(t, inbox.Notifications, 3)
+
+	_, err = s.GetInbox(model.NotificationFilter{Username: " "})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+}
+
+func TestPreferences(t *testing.T) {
+	s := NewService(storage.NewStorage())
+
+	prefs, err := s.GetPreferences("anna")
+	require.NoError(t, err)
+	require.Len(t, prefs, len(model.NotificationKinds))
+	for _, p := range prefs {
+		require.True(t, p.InApp, "everything is enabled by default")
+		require.Equal(t, p.Kind != model.NotificationCardChanged, p.Email, "card changes are not emailed by default")
+	}
+
+	off, on := false, true
+	prefs, err = s.SetPreferences("anna", []model.NotificationPreferenceUpdate{{Kind: model.NotificationCardChanged, InApp: &off}})
+	require.NoError(t, err)
+	require.Equal(t, model.NotificationKinds[0], prefs[0].Kind)
+	require.True(t, prefs[0].InApp)
+	require.Equal(t, model.NotificationCardChanged, prefs[len(prefs)-1].Kind)
+	require.False(t, prefs[len(prefs)-1].InApp)
+
+	prefs, err = s.SetPreferences("anna", []model.NotificationPreferenceUpdate{{Kind: model.NotificationCardChanged, Email: &on}})
+	require.NoError(t, err)
+	require.False(t, prefs[len(prefs)-1].InApp, "omitted channel keeps its value")
+	require.True(t, prefs[len(prefs)-1].Email)
+
+	_, err = s.SetPreferences("anna", []model.NotificationPreferenceUpdate{{Kind: model.NotificationDueSoon, InApp: &off}, {Kind: "spam"}})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+	require.ErrorContains(t, err, `unknown notification kind "spam"`)
+	prefs, err = s.GetPreferences("anna")
+	require.NoError(t, err)
+	require.True(t, prefs[2].InApp, "invalid batch changes nothing")
+}
+
+func TestEmailSettings(t *testing.T) {
+	s := NewService(storage.NewStorage())
+
+	settings, err := s.GetEmailSettings("anna")
+	require.NoError(t, err)
+	require.Equal(t, model.EmailSettings{Username: "anna"}, settings)
+
+	settings, err = s.SetEmailSettings(model.EmailSettings{Username: "anna", Address: " anna@example.com ", Digest: true})
+	require.NoError(t, err)
+	require.Equal(t, "anna@example.com", settings.Address)
+	settings, err = s.GetEmailSettings("anna")
+	require.NoError(t, err)
+	require.True(t, settings.Digest)
+
+	for _, addr := range []string{"anna", "Anna <anna@example.com>", "anna@"} {
+		_, err = s.SetEmailSettings(model.EmailSettings{Username: "anna", Address: addr})
+		require.ErrorIs(t, err, model.ErrInvalidInput, addr)
+	}
+}
@@ -0,0 +1,26 @@
+package recurrence
+
+import (
+	"awesomeProject2/cmd/model"
+	"time"
+)
+
+type Storage interface {
+	GetRecurrence(cardID int) (model.CardRecurrence, error)
+	SaveRecurrence(r model.CardRecurrence) (model.CardRecurrence, error)
+	DeleteRecurrence(cardID int) error
+	GetDueRecurrences(before time.Time) ([]model.CardRecurrence, error)
+	AdvanceRecurrence(cardID int, from, next, ranAt time.Time) (bool, error)
+	ReleaseRecurrence(r model.CardRecurrence, claimed time.Time) (bool, error)
+}
+
+type CardReader interface {
+	GetCard(id int) (model.Card, error)
+	GetList(id int) (model.List, error)
+}
+
+// CardCreator — обычно service.CardService, чтобы новые карточки проходили
+// ту же проверку и порождали те же события, что и созданные вручную.
+type CardCreator interface {
+	CreateCard(input model.CardInputCreate) (model.Card, error)
+}
@@ -0,0 +1,190 @@
+package recurrence
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronHorizon — дальше этого срока следующий запуск cron не ищется:
+// выражение вроде «31 февраля» не сработает никогда.
+const cronHorizon = 5 * 366 * 24 * time.Hour
+
+// schedule вычисляет следующий запуск строго после заданного момента.
+// Нулевое время означает, что запусков больше не будет.
+type schedule interface {
+	next(after time.Time) time.Time
+}
+
+func newSchedule(r model.CardRecurrence) (schedule, error) {
+	start := r.StartsAt.UTC()
+	switch r.Frequency {
+	case model.RecurrenceDaily:
+		return intervalSchedule{start: start, days: r.Interval}, nil
+	case model.RecurrenceWeekly:
+		return intervalSchedule{start: start, days: 7 * r.Interval}, nil
+	case model.RecurrenceMonthly:
+		return intervalSchedule{start: start, months: r.Interval}, nil
+	case model.RecurrenceCron:
+		return parseCron(r.Cron)
+	}
+	return nil, fmt.Errorf("unknown frequency %q", r.Frequency)
+}
+
+// intervalSchedule повторяется от start каждые days дней или months
+// месяцев. Если в месяце нет нужного числа, берётся его последний день.
+type intervalSchedule struct {
+	start  time.Time
+	days   int
+	months int
+}
+
+func (s intervalSchedule) next(after time.Time) time.Time {
+	if s.start.After(after) {
+		return s.start
+	}
+	if s.days > 0 {
+		step := time.Duration(s.days) * 24 * time.Hour
+		return s.start.Add((after.Sub(s.start)/step + 1) * step)
+	}
+	diff := (after.Year()-s.start.Year())*12 + int(after.Month()-s.start.Month())
+	for k := diff / s.months; ; k++ {
+		if t := addMonths(s.start, k*s.months); t.After(after) {
+			return t
+		}
+	}
+}
+
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+// cronSchedule — классическое выражение из пяти полей «минута час
+// день-месяца месяц день-недели» в UTC. Поддерживаются *, числа,
+// диапазоны a-b, списки через запятую и шаг /n; воскресенье — 0 или 7.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// Как в cron: если ограничены и день месяца, и день недели,
+	// подходит любой из них.
+	domAny, dowAny bool
+}
+
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func parseCron(expr string) (cronSchedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return cronSchedule{}, fmt.Errorf("cron expression must have %d fields, got %d", len(cronFields), len(parts))
+	}
+	var bits [5]uint64
+	for i, f := range cronFields {
+		b, err := parseCronField(parts[i], f.min, f.max)
+		if err != nil {
+			return cronSchedule{}, fmt.Errorf("cron %s: %w", f.name, err)
+		}
+		bits[i] = b
+	}
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: strings.HasPrefix(parts[2], "*"),
+		dowAny: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+func parseCronField(field string, lo, hi int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = n
+		}
+		from, to := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if from, err = cronNumber(a, lo, hi); err != nil {
+				return 0, err
+			}
+			to = from
+			if isRange {
+				if to, err = cronNumber(b, lo, hi); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				to = hi
+			}
+			if from > to {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func cronNumber(s string, lo, hi int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if n < lo || n > hi {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, lo, hi)
+	}
+	return n, nil
+}
+
+func (c cronSchedule) next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronHorizon)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case c.hour&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
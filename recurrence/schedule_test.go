@@ -0,0 +1,127 @@
+package recurrence
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse("2006-01-02 15:04", s)
+		require.NoError(t, err)
+		return v
+	}
+	tests := []struct {
+		name  string
+		rec   model.CardRecurrence
+		after string
+		want  string
+	}{
+		{
+			name:  "start in the future",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceDaily, Interval: 1, StartsAt: at("2026-03-10 09:00")},
+			after: "2026-03-01 12:00",
+			want:  "2026-03-10 09:00",
+		},
+		{
+			name:  "every day",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceDaily, Interval: 1, StartsAt: at("2026-03-01 09:00")},
+			after: "2026-03-05 10:00",
+			want:  "2026-03-06 09:00",
+		},
+		{
+			name:  "exactly at a run",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceDaily, Interval: 3, StartsAt: at("2026-03-01 09:00")},
+			after: "2026-03-04 09:00",
+			want:  "2026-03-07 09:00",
+		},
+		{
+			name:  "every two weeks",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceWeekly, Interval: 2, StartsAt: at("2026-03-02 08:30")},
+			after: "2026-03-10 00:00",
+			want:  "2026-03-16 08:30",
+		},
+		{
+			name:  "monthly on the last day",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceMonthly, Interval: 1, StartsAt: at("2026-01-31 10:00")},
+			after: "2026-02-01 00:00",
+			want:  "2026-02-28 10:00",
+		},
+		{
+			name:  "quarterly",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceMonthly, Interval: 3, StartsAt: at("2025-11-15 10:00")},
+			after: "2026-02-15 10:00",
+			want:  "2026-05-15 10:00",
+		},
+		{
+			name:  "cron weekdays at nine",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceCron, Cron: "0 9 * * 1-5"},
+			after: "2026-03-06 09:00", // пятница
+			want:  "2026-03-09 09:00",
+		},
+		{
+			name:  "cron step",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceCron, Cron: "*/15 * * * *"},
+			after: "2026-03-06 09:50",
+			want:  "2026-03-06 10:00",
+		},
+		{
+			name:  "cron sunday as seven",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceCron, Cron: "30 18 * * 7"},
+			after: "2026-03-06 09:00",
+			want:  "2026-03-08 18:30",
+		},
+		{
+			name:  "cron day of month or day of week",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceCron, Cron: "0 0 1 * 3"},
+			after: "2026-03-02 00:00",
+			want:  "2026-03-04 00:00",
+		},
+		{
+			name:  "cron leap day",
+			rec:   model.CardRecurrence{Frequency: model.RecurrenceCron, Cron: "0 12 29 2 *"},
+			after: "2026-03-01 00:00",
+			want:  "2028-02-29 12:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := newSchedule(tt.rec)
+			require.NoError(t, err)
+			require.Equal(t, at(tt.want), sched.next(at(tt.after)))
+		})
+	}
+}
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr      string
+		errorText string
+	}{
+		{expr: "0 9 * * 1,3,5"},
+		{expr: "5-55/10 0-23/2 1 1-12 *"},
+		{expr: "0 9 * *", errorText: "must have 5 fields"},
+		{expr: "60 9 * * *", errorText: "minute: value 60 out of range 0-59"},
+		{expr: "0 9 0 * *", errorText: "day of month: value 0 out of range"},
+		{expr: "0 9 * * 5-1", errorText: `day of week: invalid range "5-1"`},
+		{expr: "*/0 * * * *", errorText: `minute: invalid step "0"`},
+		{expr: "a * * * *", errorText: `minute: invalid value "a"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := parseCron(tt.expr)
+			if tt.errorText != "" {
+				require.ErrorContains(t, err, tt.errorText)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	never, err := parseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	require.True(t, never.next(time.Now()).IsZero())
+}
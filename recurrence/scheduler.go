@@ -0,0 +1,135 @@
+package recurrence
+
+import (
+	"awesomeProject2/cmd/model"
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// Scheduler создаёт очередные карточки по наступившим повторениям.
+//
+// Перед созданием карточки запуск переносится на следующий с проверкой,
+// что его не перенёс кто-то другой (AdvanceRecurrence). Поэтому после
+// перезапуска или при нескольких экземплярах сервера один запуск не
+// создаёт карточку дважды. Если карточку создать не удалось, запуск
+// возвращается (ReleaseRecurrence) и повторяется с удваивающейся паузой;
+// после maxAttempts неудач он пропускается. Пропадает запуск и тогда,
+// когда сервер упал между переносом и созданием. Пропущенные за время
+// простоя запуски не догоняются: создаётся одна карточка, следующий
+// запуск — ближайший в будущем.
+type Scheduler struct {
+	Storage  Storage
+	Cards    CardReader
+	Creator  CardCreator
+	Interval time.Duration
+	logger   *zap.Logger
+	now      func() time.Time
+	// retries — неудачные попытки по карточкам-образцам; счёт живёт до
+	// перезапуска сервера.
+	retries map[int]retry
+}
+
+// maxAttempts — сколько раз пробовать создать карточку по одному запуску.
+const maxAttempts = 5
+
+// retry — неудачные попытки запуска runAt; следующая не раньше next.
+type retry struct {
+	runAt    time.Time
+	attempts int
+	next     time.Time
+}
+
+func NewScheduler(storage Storage, cards CardReader, creator CardCreator, interval time.Duration, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		Storage:  storage,
+		Cards:    cards,
+		Creator:  creator,
+		Interval: interval,
+		logger:   logger,
+		now:      time.Now,
+		retries:  map[int]retry{},
+	}
+}
+
+// RunOnce обрабатывает наступившие повторения и возвращает число
+// созданных карточек.
+func (s *Scheduler) RunOnce() (int, error) {
+	now := s.now().UTC()
+	due, err := s.Storage.GetDueRecurrences(now)
+	if err != nil {
+		return 0, err
+	}
+	created := 0
+	for _, r := range due {
+		ok, err := s.materialize(r, now)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+func (s *Scheduler) materialize(r model.CardRecurrence, now time.Time) (bool, error) {
+	log := s.logger.With(zap.Int("cardID", r.CardID), zap.Time("runAt", r.NextRunAt))
+	rt, ok := s.retries[r.CardID]
+	if !ok || !rt.runAt.Equal(r.NextRunAt) {
+		rt = retry{runAt: r.NextRunAt}
+	}
+	if now.Before(rt.next) {
+		return false, nil
+	}
+	sched, err := newSchedule(r)
+	if err != nil {
+		log.Error("Некорректное правило повторения карточки", zap.Error(err))
+		return false, nil
+	}
+	card, err := s.Cards.GetCard(r.CardID)
+	if err != nil {
+		log.Error("Не удалось прочитать карточку-образец", zap.Error(err))
+		return false, nil
+	}
+	next := sched.next(now)
+	claimed, err := s.Storage.AdvanceRecurrence(r.CardID, r.NextRunAt, next, now)
+	if err != nil || !claimed {
+		return false, err
+	}
+	created, err := s.Creator.CreateCard(model.CardInputCreate{ListID: r.ListID, Title: card.Title, Description: card.Description})
+	if err != nil {
+		rt.attempts++
+		if rt.attempts >= maxAttempts {
+			delete(s.retries, r.CardID)
+			log.Error("Не удалось создать повторяющуюся карточку, запуск пропущен", zap.Error(err), zap.Int("attempts", rt.attempts))
+			return false, nil
+		}
+		rt.next = now.Add(s.Interval << (rt.attempts - 1))
+		s.retries[r.CardID] = rt
+		log.Warn("Не удалось создать повторяющуюся карточку, запуск будет повторён", zap.Error(err), zap.Time("retryAt", rt.next))
+		if _, err := s.Storage.ReleaseRecurrence(r, next); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	delete(s.retries, r.CardID)
+	log.Info("Создана повторяющаяся карточка", zap.Int("newCardID", created.ID), zap.Int("listID", r.ListID))
+	return true, nil
+}
+
+// Run проверяет повторения сразу и затем каждые Interval, пока не отменён ctx.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.RunOnce(); err != nil {
+			s.logger.Error("Ошибка обработки повторяющихся карточек", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
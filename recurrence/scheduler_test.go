@@ -0,0 +1,231 @@
+package recurrence
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/storage"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	store := storage.NewStorage()
+	cards := service.NewCardService(store, store, nil, zap.NewNop())
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	templates, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "templates"})
+	require.NoError(t, err)
+	todo, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	chore, err := store.CreateCard(model.CardInputCreate{ListID: templates.ID, Title: "Полить цветы", Description: "по понедельникам"})
+	require.NoError(t, err)
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	now := start.Add(-time.Hour)
+	svc := NewService(store, store)
+	svc.now = func() time.Time { return now }
+	_, err = svc.SetRecurrence(chore.ID, model.CardRecurrenceInput{ListID: todo.ID, Frequency: model.RecurrenceWeekly, StartsAt: &start})
+	require.NoError(t, err)
+
+	newScheduler := func() *Scheduler {
+		s := NewScheduler(store, store, cards, time.Minute, zap.NewNop())
+		s.now = func() time.Time { return now }
+		return s
+	}
+	todoCards := func() []model.Card {
+		t.Helper()
+		got, err := store.GetCards(model.CardFilter{ListID: &todo.ID})
+		require.NoError(t, err)
+		return got
+	}
+
+	n, err := newScheduler().RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, n, "nothing is due before the start")
+
+	now = start.Add(time.Minute)
+	n, err = newScheduler().RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	created := todoCards()
+	require.Len(t, created, 1)
+	require.Equal(t, "Полить цветы", created[0].Title)
+	require.Equal(t, "по понедельникам", created[0].Description)
+
+	// Перезапуск сервера в ту же минуту не создаёт карточку повторно.
+	n, err = newScheduler().RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Len(t, todoCards(), 1)
+
+	rec, err := store.GetRecurrence(chore.ID)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(7*24*time.Hour), rec.NextRunAt)
+	require.NotNil(t, rec.LastRunAt)
+
+	// После долгого простоя пропущенные недели не догоняются.
+	now = start.Add(30 * 24 * time.Hour)
+	n, err = newScheduler().RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Len(t, todoCards(), 2)
+	rec, err = store.GetRecurrence(chore.ID)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(35*24*time.Hour), rec.NextRunAt)
+
+	require.NoError(t, svc.StopRecurrence(chore.ID))
+	now = start.Add(60 * 24 * time.Hour)
+	n, err = newScheduler().RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, n, "stopped recurrence creates nothing")
+}
+
+// racingStorage имитирует второй экземпляр сервера, который успел
+// забрать запуск между чтением и переносом.
+type racingStorage struct {
+	*storage.Storage
+}
+
+func (s racingStorage) AdvanceRecurrence(cardID int, from, next, ranAt time.Time) (bool, error) {
+	if _, err := s.Storage.AdvanceRecurrence(cardID, from, next, ranAt); err != nil {
+		return false, err
+	}
+	return s.Storage.AdvanceRecurrence(cardID, from, next, ranAt)
+}
+
+func TestSchedulerSkipsClaimedRun(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	chore, err := store.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "chore"})
+	require.NoError(t, err)
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	_, err = store.SaveRecurrence(model.CardRecurrence{CardID: chore.ID, ListID: list.ID, Frequency: model.RecurrenceDaily, Interval: 1, StartsAt: now, NextRunAt: now})
+	require.NoError(t, err)
+
+	s := NewScheduler(racingStorage{store}, store, service.NewCardService(store, store, nil, zap.NewNop()), time.Minute, zap.NewNop())
+	s.now = func() time.Time { return now }
+	n, err := s.RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, n)
+	got, err := store.GetCards(model.CardFilter{ListID: &list.ID})
+	require.NoError(t, err)
+	require.Len(t, got, 1, "only the template card")
+}
+
+// failingCreator отказывает в создании карточки, пока fail не сброшен.
+type failingCreator struct {
+	CardCreator
+	fail bool
+}
+
+func (c *failingCreator) CreateCard(input model.CardInputCreate) (model.Card, error) {
+	if c.fail {
+		return model.Card{}, errors.New("list is full")
+	}
+	return c.CardCreator.CreateCard(input)
+}
+
+func TestSchedulerRetriesFailedRun(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	chore, err := store.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "chore"})
+	require.NoError(t, err)
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	_, err = store.SaveRecurrence(model.CardRecurrence{CardID: chore.ID, ListID: list.ID, Frequency: model.RecurrenceDaily, Interval: 1, StartsAt: start, NextRunAt: start})
+	require.NoError(t, err)
+
+	creator := &failingCreator{CardCreator: service.NewCardService(store, store, nil, zap.NewNop()), fail: true}
+	s := NewScheduler(store, store, creator, time.Minute, zap.NewNop())
+	now := start.Add(time.Minute)
+	s.now = func() time.Time { return now }
+	n, err := s.RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, n)
+	rec, err := store.GetRecurrence(chore.ID)
+	require.NoError(t, err)
+	require.Equal(t, start, rec.NextRunAt, "the failed run stays due")
+	require.Nil(t, rec.LastRunAt)
+
+	// Повтор ждёт паузу, даже если создание уже прошло бы.
+	creator.fail = false
+	n, err = s.RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, n, "the retry waits for the backoff")
+
+	now = now.Add(time.Minute)
+	n, err = s.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	got, err := store.GetCards(model.CardFilter{ListID: &list.ID})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	rec, err = store.GetRecurrence(chore.ID)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(24*time.Hour), rec.NextRunAt)
+}
+
+func TestSchedulerGivesUpOnFailingRun(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	chore, err := store.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "chore"})
+	require.NoError(t, err)
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	_, err = store.SaveRecurrence(model.CardRecurrence{CardID: chore.ID, ListID: list.ID, Frequency: model.RecurrenceDaily, Interval: 1, StartsAt: start, NextRunAt: start})
+	require.NoError(t, err)
+
+	creator := &failingCreator{CardCreator: service.NewCardService(store, store, nil, zap.NewNop()), fail: true}
+	s := NewScheduler(store, store, creator, time.Minute, zap.NewNop())
+	now := start
+	s.now = func() time.Time { return now }
+	// Паузы 1, 2, 4 и 8 минут: пятая попытка — через 15 минут.
+	for _, wait := range []time.Duration{0, 1, 2, 4, 8} {
+		now = now.Add(wait * time.Minute)
+		rec, err := store.GetRecurrence(chore.ID)
+		require.NoError(t, err)
+		require.Equal(t, start, rec.NextRunAt)
+		n, err := s.RunOnce()
+		require.NoError(t, err)
+		require.Zero(t, n)
+	}
+
+	rec, err := store.GetRecurrence(chore.ID)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(24*time.Hour), rec.NextRunAt, "the run is skipped after maxAttempts")
+	require.NotNil(t, rec.LastRunAt)
+}
+
+func TestSchedulerSkipsArchivedTemplate(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	chore, err := store.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "chore"})
+	require.NoError(t, err)
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	_, err = store.SaveRecurrence(model.CardRecurrence{CardID: chore.ID, ListID: list.ID, Frequency: model.RecurrenceDaily, Interval: 1, StartsAt: now, NextRunAt: now})
+	require.NoError(t, err)
+	_, err = store.ArchiveCard(chore.ID)
+	require.NoError(t, err)
+
+	s := NewScheduler(store, store, service.NewCardService(store, store, nil, zap.NewNop()), time.Minute, zap.NewNop())
+	s.now = func() time.Time { return now }
+	n, err := s.RunOnce()
+	require.NoError(t, err)
+	require.Zero(t, n)
+	got, err := store.GetCards(model.CardFilter{ListID: &list.ID})
+	require.NoError(t, err)
+	require.Empty(t, got, "archived template is not copied")
+}
@@ -0,0 +1,103 @@
+package recurrence
+
+import (
+	"awesomeProject2/cmd/model"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const maxInterval = 1000
+
+type Service struct {
+	Storage Storage
+	Cards   CardReader
+	now     func() time.Time
+}
+
+func NewService(storage Storage, cards CardReader) *Service {
+	return &Service{
+		Storage: storage,
+		Cards:   cards,
+		now:     time.Now,
+	}
+}
+
+func (s Service) GetRecurrence(cardID int) (model.CardRecurrence, error) {
+	return s.Storage.GetRecurrence(cardID)
+}
+
+// SetRecurrence включает повторение карточки или заменяет его настройки.
+// Следующий запуск считается заново от текущего момента.
+func (s Service) SetRecurrence(cardID int, input model.CardRecurrenceInput) (model.CardRecurrence, error) {
+	card, err := s.Cards.GetCard(cardID)
+	if err != nil {
+		return model.CardRecurrence{}, err
+	}
+	r := model.CardRecurrence{
+		CardID:    card.ID,
+		ListID:    input.ListID,
+		Frequency: input.Frequency,
+		Interval:  input.Interval,
+		Cron:      input.Cron,
+	}
+	if r.ListID == 0 {
+		r.ListID = card.ListID
+	}
+	list, err := s.Cards.GetList(r.ListID)
+	if errors.Is(err, model.ErrNotFound) {
+		return model.CardRecurrence{}, invalid("target list %d does not exist", r.ListID)
+	}
+	if err != nil {
+		return model.CardRecurrence{}, err
+	}
+	if list.BoardID != card.BoardID {
+		return model.CardRecurrence{}, invalid("list %d is on another board", list.ID)
+	}
+
+	switch r.Frequency {
+	case model.RecurrenceDaily, model.RecurrenceWeekly, model.RecurrenceMonthly:
+		if r.Cron != "" {
+			return model.CardRecurrence{}, invalid("cron is only allowed with frequency %q", model.RecurrenceCron)
+		}
+		if r.Interval == 0 {
+			r.Interval = 1
+		}
+		if r.Interval < 0 || r.Interval > maxInterval {
+			return model.CardRecurrence{}, invalid("interval must be between 1 and %d", maxInterval)
+		}
+	case model.RecurrenceCron:
+		if r.Interval != 0 {
+			return model.CardRecurrence{}, invalid("interval is not allowed with frequency %q", model.RecurrenceCron)
+		}
+		if r.Cron == "" {
+			return model.CardRecurrence{}, invalid("cron is required")
+		}
+	default:
+		return model.CardRecurrence{}, invalid("unknown frequency %q", r.Frequency)
+	}
+
+	now := s.now().UTC()
+	r.StartsAt = now.Truncate(time.Second)
+	if input.StartsAt != nil {
+		r.StartsAt = input.StartsAt.UTC().Truncate(time.Second)
+	}
+	sched, err := newSchedule(r)
+	if err != nil {
+		return model.CardRecurrence{}, invalid("%s", err)
+	}
+	r.NextRunAt = sched.next(now)
+	if r.NextRunAt.IsZero() {
+		return model.CardRecurrence{}, invalid("cron %q never fires", r.Cron)
+	}
+	return s.Storage.SaveRecurrence(r)
+}
+
+// StopRecurrence выключает повторение; уже созданные карточки остаются.
+func (s Service) StopRecurrence(cardID int) error {
+	return s.Storage.DeleteRecurrence(cardID)
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{model.ErrInvalidInput}, args...)...)
+}
@@ -0,0 +1,148 @@
+package recurrence
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSetRecurrence(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	other, err := store.CreateBoard("other")
+	require.NoError(t, err)
+	todo, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	done, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "done"})
+	require.NoError(t, err)
+	foreign, err := store.CreateList(model.ListInputCreate{BoardID: other.ID, Title: "todo"})
+	require.NoError(t, err)
+	card, err := store.CreateCard(model.CardInputCreate{ListID: todo.ID, Title: "Вынести мусор"})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 4, 10, 20, 30, 500, time.UTC) // среда
+	later := now.Add(48 * time.Hour)
+	tests := []struct {
+		name        string
+		cardID      int
+		input       model.CardRecurrenceInput
+		want        model.CardRecurrence
+		expectError error
+		errorText   string
+	}{
+		{
+			name:  "weekly from now into the card's list",
+			input: model.CardRecurrenceInput{Frequency: model.RecurrenceWeekly},
+			want: model.CardRecurrence{
+				ListID:    todo.ID,
+				Frequency: model.RecurrenceWeekly,
+				Interval:  1,
+				StartsAt:  now.Truncate(time.Second),
+				NextRunAt: now.Truncate(time.Second).Add(7 * 24 * time.Hour),
+			},
+		},
+		{
+			name:  "daily from a future start into another list",
+			input: model.CardRecurrenceInput{ListID: done.ID, Frequency: model.RecurrenceDaily, Interval: 2, StartsAt: &later},
+			want: model.CardRecurrence{
+				ListID:    done.ID,
+				Frequency: model.RecurrenceDaily,
+				Interval:  2,
+				StartsAt:  later.Truncate(time.Second),
+				NextRunAt: later.Truncate(time.Second),
+			},
+		},
+		{
+			name:  "cron",
+			input: model.CardRecurrenceInput{Frequency: model.RecurrenceCron, Cron: "0 9 * * 1"},
+			want: model.CardRecurrence{
+				ListID:    todo.ID,
+				Frequency: model.RecurrenceCron,
+				Cron:      "0 9 * * 1",
+				StartsAt:  now.Truncate(time.Second),
+				NextRunAt: time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:        "card not found",
+			cardID:      card.ID + 100,
+			input:       model.CardRecurrenceInput{Frequency: model.RecurrenceDaily},
+			expectError: model.ErrNotFound,
+		},
+		{
+			name:        "unknown list",
+			input:       model.CardRecurrenceInput{ListID: foreign.ID + 100, Frequency: model.RecurrenceDaily},
+			expectError: model.ErrInvalidInput,
+			errorText:   "does not exist",
+		},
+		{
+			name:        "list on another board",
+			input:       model.CardRecurrenceInput{ListID: foreign.ID, Frequency: model.RecurrenceDaily},
+			expectError: model.ErrInvalidInput,
+			errorText:   "on another board",
+		},
+		{
+			name:        "unknown frequency",
+			input:       model.CardRecurrenceInput{Frequency: "yearly"},
+			expectError: model.ErrInvalidInput,
+			errorText:   `unknown frequency "yearly"`,
+		},
+		{
+			name:        "negative interval",
+			input:       model.CardRecurrenceInput{Frequency: model.RecurrenceDaily, Interval: -1},
+			expectError: model.ErrInvalidInput,
+			errorText:   "interval must be between",
+		},
+		{
+			name:        "cron with interval frequency",
+			input:       model.CardRecurrenceInput{Frequency: model.RecurrenceDaily, Cron: "* * * * *"},
+			expectError: model.ErrInvalidInput,
+			errorText:   "cron is only allowed",
+		},
+		{
+			name:        "missing cron",
+			input:       model.CardRecurrenceInput{Frequency: model.RecurrenceCron},
+			expectError: model.ErrInvalidInput,
+			errorText:   "cron is required",
+		},
+		{
+			name:        "invalid cron",
+			input:       model.CardRecurrenceInput{Frequency: model.RecurrenceCron, Cron: "0 25 * * *"},
+			expectError: model.ErrInvalidInput,
+			errorText:   "cron hour",
+		},
+		{
+			name:        "cron that never fires",
+			input:       model.CardRecurrenceInput{Frequency: model.RecurrenceCron, Cron: "0 0 30 2 *"},
+			expectError: model.ErrInvalidInput,
+			errorText:   "never fires",
+		},
+	}
+
+	s := NewService(store, store)
+	s.now = func() time.Time { return now }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.cardID == 0 {
+				tt.cardID = card.ID
+			}
+			got, err := s.SetRecurrence(tt.cardID, tt.input)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+				require.ErrorContains(t, err, tt.errorText)
+				return
+			}
+			require.NoError(t, err)
+			tt.want.CardID = card.ID
+			tt.want.CreatedAt = got.CreatedAt
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	require.NoError(t, s.StopRecurrence(card.ID))
+	_, err = s.GetRecurrence(card.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
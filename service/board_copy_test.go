@@ -0,0 +1,112 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCopyBoard(t *testing.T) {
+	src := model.Board{ID: 1, Title: "Sprint"}
+	todo, done := 10, 11
+	wip := 3
+	points := 5.0
+	srcField, copiedField, copiedLane := 30, 31, 41
+	tests := []struct {
+		name        string
+		input       model.BoardCopyInput
+		setup       func(m *MockTx)
+		commitError error
+		want        model.Board
+		expectError error
+	}{
+		{
+			name:  "lists and labels only",
+			input: model.BoardCopyInput{},
+			setup: func(m *MockTx) {
+				m.On("GetBoard", 1).Return(src, nil)
+				m.On("CreateBoard", "Sprint (копия)").Return(model.Board{ID: 2, Title: "Sprint (копия)"}, nil)
+				m.On("GetLabels", 1).Return([]model.Label{{ID: 5, BoardID: 1, Name: "bug", Color: "red"}}, nil)
+				m.On("CreateLabel", model.LabelInputCreate{BoardID: 2, Name: "bug", Color: "red"}).Return(model.Label{ID: 6, BoardID: 2, Name: "bug"}, nil)
+				m.On("GetFields", 1).Return([]model.CustomField{{ID: 30, BoardID: 1, Name: "Size", Type: model.FieldDropdown, Options: []string{"S", "M"}}}, nil)
+				m.On("CreateField", model.CustomFieldInputCreate{BoardID: 2, Name: "Size", Type: model.FieldDropdown, Options: []string{"S", "M"}}).Return(model.CustomField{ID: 31, BoardID: 2, Name: "Size", Type: model.FieldDropdown}, nil)
+				m.On("GetSwimlanes", 1).Return(model.Swimlanes{BoardID: 1, Mode: model.LaneField, FieldID: &srcField}, nil)
+				m.On("SetSwimlanes", model.Swimlanes{BoardID: 2, Mode: model.LaneField, FieldID: &copiedField}).Return(model.Swimlanes{BoardID: 2, Mode: model.LaneField, FieldID: &copiedField}, nil)
+				m.On("GetLanes", 1).Return([]model.Lane{}, nil)
+				m.On("GetLists", &src.ID).Return([]model.List{{ID: todo, BoardID: 1, Title: "todo", WIPLimit: &wip, WIPSoft: true}, {ID: done, BoardID: 1, Title: "done"}}, nil)
+				m.On("CreateList", model.ListInputCreate{BoardID: 2, Title: "todo", WIPLimit: &wip, WIPSoft: true}).Return(model.List{ID: 20, BoardID: 2, Title: "todo", WIPLimit: &wip, WIPSoft: true}, nil)
+				m.On("CreateList", model.ListInputCreate{BoardID: 2, Title: "done"}).Return(model.List{ID: 21, BoardID: 2, Title: "done"}, nil)
+			},
+			want: model.Board{ID: 2, Title: "Sprint (копия)"},
+		},
+		{
+			name:  "template with cards",
+			input: model.BoardCopyInput{Title: " Шаблон ", IncludeCards: true, AsTemplate: true},
+			setup: func(m *MockTx) {
+				m.On("GetBoard", 1).Return(src, nil)
+				m.On("CreateBoard", "Шаблон").Return(model.Board{ID: 2, Title: "Шаблон"}, nil)
+				m.On("SetBoardTemplate", 2, true).Return(model.Board{ID: 2, Title: "Шаблон", IsTemplate: true}, nil)
+				m.On("GetLabels", 1).Return([]model.Label{{ID: 5, BoardID: 1, Name: "bug"}}, nil)
+				m.On("CreateLabel", model.LabelInputCreate{BoardID: 2, Name: "bug"}).Return(model.Label{ID: 6, BoardID: 2, Name: "bug"}, nil)
+				m.On("GetFields", 1).Return([]model.CustomField{{ID: 30, BoardID: 1, Name: "Points", Type: model.FieldNumber}}, nil)
+				m.On("CreateField", model.CustomFieldInputCreate{BoardID: 2, Name: "Points", Type: model.FieldNumber}).Return(model.CustomField{ID: 31, BoardID: 2, Name: "Points", Type: model.FieldNumber}, nil)
+				m.On("GetSwimlanes", 1).Return(model.Swimlanes{BoardID: 1, Mode: model.LaneExplicit}, nil)
+				m.On("SetSwimlanes", model.Swimlanes{BoardID: 2, Mode: model.LaneExplicit}).Return(model.Swimlanes{BoardID: 2, Mode: model.LaneExplicit}, nil)
+				m.On("GetLanes", 1).Return([]model.Lane{{ID: 40, BoardID: 1, Title: "backend"}}, nil)
+				m.On("CreateLane", model.LaneInputCreate{BoardID: 2, Title: "backend"}).Return(model.Lane{ID: copiedLane, BoardID: 2, Title: "backend"}, nil)
+				m.On("GetCardLanes", 1).Return([]model.CardLane{{CardID: 100, LaneID: 40}}, nil)
+				m.On("GetLists", &src.ID).Return([]model.List{{ID: todo, BoardID: 1, Title: "todo"}}, nil)
+				m.On("CreateList", model.ListInputCreate{BoardID: 2, Title: "todo"}).Return(model.List{ID: 20, BoardID: 2, Title: "todo"}, nil)
+				m.On("GetCards", model.CardFilter{ListID: &todo}).Return([]model.Card{{ID: 100, ListID: todo, Title: "card", Description: "d"}}, nil)
+				m.On("CreateCard", model.CardInputCreate{ListID: 20, Title: "card", Description: "d"}).Return(model.Card{ID: 200, ListID: 20, Title: "card"}, nil)
+				m.On("GetCardLabels", 100).Return([]model.Label{{ID: 5, Name: "bug"}}, nil)
+				m.On("AddCardLabel", 200, 6).Return(nil)
+				m.On("GetCardFieldValues", 100).Return([]model.CardFieldValue{{CardID: 100, FieldID: 30, Number: &points}, {CardID: 100, FieldID: 99, Number: &points}}, nil)
+				m.On("SetCardFieldValue", model.CardFieldValue{CardID: 200, FieldID: 31, Number: &points}).Return(model.CardFieldValue{CardID: 200, FieldID: 31, Number: &points}, nil)
+				m.On("GetChecklists", 100).Return([]model.Checklist{{ID: 7, CardID: 100, Title: "steps", Items: []model.ChecklistItem{{Text: "one", Checked: true}}}}, nil)
+				m.On("CreateChecklist", model.ChecklistInputCreate{CardID: 200, Title: "steps"}).Return(model.Checklist{ID: 8, CardID: 200, Title: "steps"}, nil)
+				m.On("CreateChecklistItem", model.ChecklistItemInputCreate{ChecklistID: 8, Text: "one", Checked: true}).Return(model.ChecklistItem{ID: 9}, nil)
+				m.On("SetCardLane", 200, &copiedLane).Return(nil)
+			},
+			want: model.Board{ID: 2, Title: "Шаблон", IsTemplate: true},
+		},
+		{
+			name:  "source board not found",
+			input: model.BoardCopyInput{},
+			setup: func(m *MockTx) {
+				m.On("GetBoard", 1).Return(model.Board{}, model.ErrNotFound)
+			},
+			expectError: model.ErrNotFound,
+		},
+		{
+			name:  "failure rolls back the copy",
+			input: model.BoardCopyInput{},
+			setup: func(m *MockTx) {
+				m.On("GetBoard", 1).Return(src, nil)
+				m.On("CreateBoard", "Sprint (копия)").Return(model.Board{ID: 2}, nil)
+				m.On("GetLabels", 1).Return([]model.Label(nil), errors.New("db down"))
+			},
+			expectError: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			tt.setup(m)
+			m.On("InTx").Return(tt.commitError)
+			svc := BoardService{Storage: m, Tx: m}
+
+			board, err := svc.CopyBoard(1, tt.input)
+			if tt.expectError != nil {
+				require.ErrorContains(t, err, tt.expectError.Error())
+				require.Equal(t, model.Board{}, board)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, board)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -3,16 +3,19 @@ package service
 import (
 	"awesomeProject2/cmd/model"
 	"go.uber.org/zap"
+	"strings"
 )
 
 type BoardService struct {
 	Storage BoardStorage
+	Tx      Transactor
 	logger  *zap.Logger
 }
 
-func NewBoardService(storage BoardStorage, logger *zap.Logger) *BoardService {
+func NewBoardService(storage BoardStorage, tx Transactor, logger *zap.Logger) *BoardService {
 	return &BoardService{
 		Storage: storage,
+		Tx:      tx,
 		logger:  logger,
 	}
 }
@@ -22,3 +25,211 @@ func (s BoardService) GetBoards() ([]model.Board, error) {
 func (s BoardService) CreateBoard(title string) (model.Board, error) {
 	return s.Storage.CreateBoard(title)
 }
+func (s BoardService) GetTemplates() ([]model.Board, error) {
+	return s.Storage.GetTemplates()
+}
+func (s BoardService) SetTemplate(id int, isTemplate bool) (model.Board, error) {
+	return s.Storage.SetBoardTemplate(id, isTemplate)
+}
+
+// CopyBoard копирует доску со списками и их лимитами WIP, метками,
+// пользовательскими полями, дорожками и, если нужно, активными
+// карточками с их метками, значениями полей, дорожками и чек-листами.
+// Всё делается в одной транзакции, так что при ошибке недоделанная копия
+// не остаётся.
+func (s BoardService) CopyBoard(id int, input model.BoardCopyInput) (model.Board, error) {
+	var board model.Board
+	err := s.Tx.InTx(func(tx Tx) error {
+		var err error
+		board, err = copyBoard(tx, id, input)
+		return err
+	})
+	if err != nil {
+		return model.Board{}, err
+	}
+	return board, nil
+}
+
+func copyBoard(tx Tx, id int, input model.BoardCopyInput) (model.Board, error) {
+	src, err := tx.GetBoard(id)
+	if err != nil {
+		return model.Board{}, err
+	}
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		title = src.Title + " (копия)"
+	}
+	board, err := tx.CreateBoard(title)
+	if err != nil {
+		return model.Board{}, err
+	}
+	if input.AsTemplate {
+		if board, err = tx.SetBoardTemplate(board.ID, true); err != nil {
+			return model.Board{}, err
+		}
+	}
+
+	labels, err := tx.GetLabels(src.ID)
+	if err != nil {
+		return model.Board{}, err
+	}
+	labelIDs := map[int]int{}
+	for _, l := range labels {
+		label, err := tx.CreateLabel(model.LabelInputCreate{BoardID: board.ID, Name: l.Name, Color: l.Color})
+		if err != nil {
+			return model.Board{}, err
+		}
+		labelIDs[l.ID] = label.ID
+	}
+
+	fields, err := tx.GetFields(src.ID)
+	if err != nil {
+		return model.Board{}, err
+	}
+	fieldMap := map[int]model.CustomField{}
+	for _, f := range fields {
+		field, err := tx.CreateField(model.CustomFieldInputCreate{BoardID: board.ID, Name: f.Name, Type: f.Type, Options: f.Options})
+		if err != nil {
+			return model.Board{}, err
+		}
+		fieldMap[f.ID] = field
+	}
+	laneIDs, err := copyLanes(tx, src.ID, board.ID, fieldMap)
+	if err != nil {
+		return model.Board{}, err
+	}
+	cardLanes := map[int]int{}
+	if input.IncludeCards {
+		lanes, err := tx.GetCardLanes(src.ID)
+		if err != nil {
+			return model.Board{}, err
+		}
+		for _, cl := range lanes {
+			cardLanes[cl.CardID] = cl.LaneID
+		}
+	}
+
+	lists, err := tx.GetLists(&src.ID)
+	if err != nil {
+		return model.Board{}, err
+	}
+	for _, l := range lists {
+		list, err := tx.CreateList(model.ListInputCreate{BoardID: board.ID, Title: l.Title, WIPLimit: l.WIPLimit, WIPSoft: l.WIPSoft})
+		if err != nil {
+			return model.Board{}, err
+		}
+		if !input.IncludeCards {
+			continue
+		}
+		cards, err := tx.GetCards(model.CardFilter{ListID: &l.ID})
+		if err != nil {
+			return model.Board{}, err
+		}
+		for _, c := range cards {
+			card, err := copyCardInto(tx, c, list.ID, labelIDs, fieldMap)
+			if err != nil {
+				return model.Board{}, err
+			}
+			laneID, ok := laneIDs[cardLanes[c.ID]]
+			if !ok {
+				continue
+			}
+			if err := tx.SetCardLane(card.ID, &laneID); err != nil {
+				return model.Board{}, err
+			}
+		}
+	}
+	return board, nil
+}
+
+// copyLanes переносит на доску boardID режим дорожек и явные дорожки
+// доски srcID и возвращает соответствие старых дорожек новым. Поле
+// дорожек берётся из уже скопированных полей fields.
+func copyLanes(tx Tx, srcID, boardID int, fields map[int]model.CustomField) (map[int]int, error) {
+	swimlanes, err := tx.GetSwimlanes(srcID)
+	if err != nil {
+		return nil, err
+	}
+	if swimlanes.Mode != model.LaneNone {
+		swimlanes.BoardID = boardID
+		if swimlanes.FieldID != nil {
+			fieldID := fields[*swimlanes.FieldID].ID
+			swimlanes.FieldID = &fieldID
+		}
+		if _, err := tx.SetSwimlanes(swimlanes); err != nil {
+			return nil, err
+		}
+	}
+	lanes, err := tx.GetLanes(srcID)
+	if err != nil {
+		return nil, err
+	}
+	laneIDs := map[int]int{}
+	for _, l := range lanes {
+		lane, err := tx.CreateLane(model.LaneInputCreate{BoardID: boardID, Title: l.Title})
+		if err != nil {
+			return nil, err
+		}
+		laneIDs[l.ID] = lane.ID
+	}
+	return laneIDs, nil
+}
+
+// copyCardInto создаёт копию карточки в списке listID. labelIDs и fields
+// переводят метки и поля исходной доски в метки и поля целевой; метки и
+// значения полей без пары не копируются.
+func copyCardInto(tx Tx, c model.Card, listID int, labelIDs map[int]int, fields map[int]model.CustomField) (model.Card, error) {
+	card, err := tx.CreateCard(model.CardInputCreate{ListID: listID, Title: c.Title, Description: c.Description})
+	if err != nil {
+		return model.Card{}, err
+	}
+	labels, err := tx.GetCardLabels(c.ID)
+	if err != nil {
+		return model.Card{}, err
+	}
+	for _, l := range labels {
+		labelID, ok := labelIDs[l.ID]
+		if !ok {
+			continue
+		}
+		if err := tx.AddCardLabel(card.ID, labelID); err != nil {
+			return model.Card{}, err
+		}
+	}
+	if len(fields) > 0 {
+		values, err := tx.GetCardFieldValues(c.ID)
+		if err != nil {
+			return model.Card{}, err
+		}
+		for _, v := range values {
+			v, ok := remapValue(v, fields)
+			if !ok {
+				continue
+			}
+			v.CardID = card.ID
+			if _, err := tx.SetCardFieldValue(v); err != nil {
+				return model.Card{}, err
+			}
+		}
+	}
+	checklists, err := tx.GetChecklists(c.ID)
+	if err != nil {
+		return model.Card{}, err
+	}
+	for _, cl := range checklists {
+		checklist, err := tx.CreateChecklist(model.ChecklistInputCreate{CardID: card.ID, Title: cl.Title})
+		if err != nil {
+			return model.Card{}, err
+		}
+		for _, item := range cl.Items {
+			if _, err := tx.CreateChecklistItem(model.ChecklistItemInputCreate{
+				ChecklistID: checklist.ID,
+				Text:        item.Text,
+				Checked:     item.Checked,
+			}); err != nil {
+				return model.Card{}, err
+			}
+		}
+	}
+	return card, nil
+}
@@ -41,7 +41,7 @@ func TestCreateBoard(t *testing.T) {
 		t.Run(tt.title, func(t *testing.T) {
 			mockStorage := new(MockBoardService)
 			logger := zap.NewNop()
-			service := NewBoardService(mockStorage, logger)
+			service := NewBoardService(mockStorage, nil, logger)
 			mockStorage.On("CreateBoard", tt.inputTitle).Return(tt.mockResult, tt.mockError)
 			result, err := service.CreateBoard(tt.inputTitle)
 			if tt.expectError {
@@ -81,7 +81,7 @@ func TestGetBoard(t *testing.T) {
 		t.Run(tt.title, func(t *testing.T) {
 			mockStorage := new(MockBoardService)
 			logger := zap.NewNop()
-			boardService := NewBoardService(mockStorage, logger)
+			boardService := NewBoardService(mockStorage, nil, logger)
 			mockStorage.On("GetBoards").Return(tt.mockResult, tt.mockError)
 			boards, err := boardService.GetBoards()
 			if tt.expectedError {
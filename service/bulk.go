@@ -0,0 +1,175 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const maxBulkOperations = 500
+
+// BulkCards выполняет пакет операций над карточками. В режиме atomic все
+// операции идут в одной транзакции: первая же ошибка откатывает пакет и
+// возвращается вместе с результатами. Иначе каждая операция выполняется в
+// своей транзакции, а ошибки остаются только в результатах по операциям.
+func (s CardService) BulkCards(ops []model.BulkCardOperation, atomic bool) ([]model.BulkCardResult, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: no operations", model.ErrInvalidInput)
+	}
+	if len(ops) > maxBulkOperations {
+		return nil, fmt.Errorf("%w: at most %d operations per request", model.ErrInvalidInput, maxBulkOperations)
+	}
+	results := make([]model.BulkCardResult, len(ops))
+	for i, op := range ops {
+		results[i] = model.BulkCardResult{CardID: op.CardID, Status: model.BulkStatusSkipped}
+	}
+	if !atomic {
+		for i, op := range ops {
+			var card model.Card
+			var events []model.Event
+			err := s.Tx.InTx(func(tx Tx) error {
+				var err error
+				card, events, err = s.applyBulkOperation(tx, op)
+				return err
+			})
+			results[i] = bulkResult(op, card, err)
+			if err == nil {
+				s.publish(events...)
+			}
+		}
+		return results, nil
+	}
+
+	failed := -1
+	var opErr error
+	var events []model.Event
+	err := s.Tx.InTx(func(tx Tx) error {
+		for i, op := range ops {
+			card, opEvents, err := s.applyBulkOperation(tx, op)
+			results[i] = bulkResult(op, card, err)
+			if err != nil {
+				failed, opErr = i, err
+				return err
+			}
+			events = append(events, opEvents...)
+		}
+		return nil
+	})
+	if err == nil {
+		s.publish(events...)
+		return results, nil
+	}
+	if failed < 0 {
+		// Операции прошли, но транзакцию не удалось зафиксировать.
+		for i := range results {
+			results[i].Status = model.BulkStatusRolledBack
+			results[i].Card = nil
+		}
+		return results, err
+	}
+	for i := 0; i < failed; i++ {
+		results[i].Status = model.BulkStatusRolledBack
+		results[i].Card = nil
+	}
+	return results, fmt.Errorf("operation %d: %w", failed, opErr)
+}
+
+func bulkResult(op model.BulkCardOperation, card model.Card, err error) model.BulkCardResult {
+	if err != nil {
+		return model.BulkCardResult{CardID: op.CardID, Status: model.BulkStatusFailed, Err: err}
+	}
+	return model.BulkCardResult{CardID: op.CardID, Status: model.BulkStatusOK, Card: &card}
+}
+
+// applyBulkOperation возвращает изменённую карточку и события, которые
+// нужно опубликовать после фиксации транзакции.
+func (s CardService) applyBulkOperation(tx Tx, op model.BulkCardOperation) (model.Card, []model.Event, error) {
+	if op.CardID <= 0 {
+		return model.Card{}, nil, fmt.Errorf("%w: card_id is required", model.ErrInvalidInput)
+	}
+	card, err := tx.GetCard(op.CardID)
+	if err != nil {
+		return model.Card{}, nil, err
+	}
+	switch op.Op {
+	case model.BulkMove:
+		list, err := tx.GetList(op.ListID)
+		if err != nil {
+			return model.Card{}, nil, err
+		}
+		if list.BoardID != card.BoardID {
+			return model.Card{}, nil, fmt.Errorf("%w: list %d is on another board", model.ErrInvalidInput, list.ID)
+		}
+		var warnings []string
+		if list.ID != card.ListID {
+			if err := s.checkBlockers(tx, card, list); err != nil {
+				return model.Card{}, nil, err
+			}
+			if warnings, err = CheckWIPLimit(tx, list); err != nil {
+				return model.Card{}, nil, err
+			}
+		}
+		fromListID := card.ListID
+		card.ListID = list.ID
+		if card, err = tx.UpdateCard(card); err != nil {
+			return model.Card{}, nil, err
+		}
+		card.Warnings = warnings
+		if card.ListID == fromListID {
+			return card, nil, nil
+		}
+		return card, []model.Event{{Type: model.EventCardMoved, Card: card, FromListID: fromListID}}, nil
+	case model.BulkUpdate:
+		if op.Title == nil && op.Description == nil {
+			return model.Card{}, nil, fmt.Errorf("%w: nothing to update", model.ErrInvalidInput)
+		}
+		if op.Title != nil {
+			card.Title = strings.TrimSpace(*op.Title)
+			if err := ValidateCardTitle(card.Title); err != nil {
+				return model.Card{}, nil, err
+			}
+		}
+		if op.Description != nil {
+			card.Description = *op.Description
+		}
+		card, err = tx.UpdateCard(card)
+		if err != nil {
+			return model.Card{}, nil, err
+		}
+		return card, []model.Event{{Type: model.EventCardUpdated, Card: card}}, nil
+	case model.BulkDelete:
+		card, err = tx.DeleteCard(card.ListID, card.ID)
+		return card, nil, err
+	case model.BulkLabel:
+		labels, err := tx.GetCardLabels(card.ID)
+		if err != nil {
+			return model.Card{}, nil, err
+		}
+		if err := tx.AddCardLabel(card.ID, op.LabelID); err != nil {
+			return model.Card{}, nil, err
+		}
+		if slices.ContainsFunc(labels, func(l model.Label) bool { return l.ID == op.LabelID }) {
+			return card, nil, nil
+		}
+		return card, []model.Event{{Type: model.EventLabelAdded, Card: card, LabelID: op.LabelID}}, nil
+	case model.BulkAssign:
+		username := strings.TrimSpace(op.Username)
+		if username == "" {
+			return model.Card{}, nil, fmt.Errorf("%w: username is required", model.ErrInvalidInput)
+		}
+		assignees, err := tx.GetCardAssignees(card.ID)
+		if err != nil {
+			return model.Card{}, nil, err
+		}
+		if err := tx.AddCardAssignee(card.ID, username); err != nil {
+			return model.Card{}, nil, err
+		}
+		if slices.Contains(assignees, username) {
+			return card, nil, nil
+		}
+		return card, []model.Event{{Type: model.EventCardAssigned, Card: card, Username: username}}, nil
+	default:
+		return model.Card{}, nil, fmt.Errorf("%w: unknown operation %q", model.ErrInvalidInput, op.Op)
+	}
+}
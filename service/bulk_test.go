@@ -0,0 +1,168 @@
+package service
+
+import (
+	"awesomeProject2/cmd/helper"
+	"awesomeProject2/cmd/model"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBulkCards(t *testing.T) {
+	card := model.Card{ID: 1, BoardID: 1, ListID: 10, Title: "card"}
+	other := model.Card{ID: 2, BoardID: 1, ListID: 10, Title: "other"}
+	notFound := fmt.Errorf("card 9: %w", model.ErrNotFound)
+
+	tests := []struct {
+		name         string
+		ops          []model.BulkCardOperation
+		atomic       bool
+		setup        func(m *MockTx)
+		commitError  error
+		txCalls      int
+		expectError  error
+		wantStatuses []string
+		wantEvents   []string
+	}{
+		{
+			name: "atomic success",
+			ops: []model.BulkCardOperation{
+				{Op: model.BulkMove, CardID: 1, ListID: 11},
+				{Op: model.BulkUpdate, CardID: 2, Title: helper.GetPointer("  renamed ")},
+				{Op: model.BulkLabel, CardID: 1, LabelID: 5},
+				{Op: model.BulkAssign, CardID: 2, Username: "anna"},
+			},
+			atomic: true,
+			setup: func(m *MockTx) {
+				m.On("GetCard", 1).Return(card, nil)
+				m.On("GetCard", 2).Return(other, nil)
+				m.On("GetList", 11).Return(model.List{ID: 11, BoardID: 1}, nil)
+				moved := card
+				moved.ListID = 11
+				m.On("UpdateCard", moved).Return(moved, nil)
+				renamed := other
+				renamed.Title = "renamed"
+				m.On("UpdateCard", renamed).Return(renamed, nil)
+				m.On("GetCardLabels", 1).Return([]model.Label{}, nil)
+				m.On("AddCardLabel", 1, 5).Return(nil)
+				m.On("GetCardAssignees", 2).Return([]string{"boris"}, nil)
+				m.On("AddCardAssignee", 2, "anna").Return(nil)
+			},
+			txCalls:      1,
+			wantStatuses: []string{model.BulkStatusOK, model.BulkStatusOK, model.BulkStatusOK, model.BulkStatusOK},
+			wantEvents:   []string{model.EventCardMoved, model.EventCardUpdated, model.EventLabelAdded, model.EventCardAssigned},
+		},
+		{
+			name: "atomic failure rolls back the batch",
+			ops: []model.BulkCardOperation{
+				{Op: model.BulkDelete, CardID: 1},
+				{Op: model.BulkDelete, CardID: 9},
+				{Op: model.BulkDelete, CardID: 2},
+			},
+			atomic: true,
+			setup: func(m *MockTx) {
+				m.On("GetCard", 1).Return(card, nil)
+				m.On("DeleteCard", 10, 1).Return(card, nil)
+				m.On("GetCard", 9).Return(model.Card{}, notFound)
+			},
+			txCalls:      1,
+			expectError:  model.ErrNotFound,
+			wantStatuses: []string{model.BulkStatusRolledBack, model.BulkStatusFailed, model.BulkStatusSkipped},
+		},
+		{
+			name: "atomic commit failure",
+			ops:  []model.BulkCardOperation{{Op: model.BulkDelete, CardID: 1}},
+			setup: func(m *MockTx) {
+				m.On("GetCard", 1).Return(card, nil)
+				m.On("DeleteCard", 10, 1).Return(card, nil)
+			},
+			atomic:       true,
+			commitError:  errors.New("connection lost"),
+			txCalls:      1,
+			expectError:  errors.New("connection lost"),
+			wantStatuses: []string{model.BulkStatusRolledBack},
+		},
+		{
+			name: "best effort keeps going",
+			ops: []model.BulkCardOperation{
+				{Op: model.BulkDelete, CardID: 9},
+				{Op: model.BulkMove, CardID: 1, ListID: 20},
+				{Op: "archive", CardID: 1},
+				{Op: model.BulkUpdate, CardID: 1},
+				{Op: model.BulkAssign, CardID: 1, Username: " "},
+				{Op: model.BulkUpdate, CardID: 2, Title: helper.GetPointer("")},
+				{Op: model.BulkUpdate, CardID: 2, Description: helper.GetPointer("new description")},
+			},
+			setup: func(m *MockTx) {
+				m.On("GetCard", 9).Return(model.Card{}, notFound)
+				m.On("GetCard", 1).Return(card, nil)
+				m.On("GetCard", 2).Return(other, nil)
+				m.On("GetList", 20).Return(model.List{ID: 20, BoardID: 2}, nil)
+				described := other
+				described.Description = "new description"
+				m.On("UpdateCard", described).Return(described, nil)
+			},
+			txCalls: 7,
+			wantStatuses: []string{
+				model.BulkStatusFailed,
+				model.BulkStatusFailed,
+				model.BulkStatusFailed,
+				model.BulkStatusFailed,
+				model.BulkStatusFailed,
+				model.BulkStatusFailed,
+				model.BulkStatusOK,
+			},
+			wantEvents: []string{model.EventCardUpdated},
+		},
+		{
+			name:        "empty batch",
+			atomic:      true,
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "too many operations",
+			ops:         make([]model.BulkCardOperation, maxBulkOperations+1),
+			expectError: model.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			if tt.txCalls > 0 {
+				m.On("InTx").Return(tt.commitError).Times(tt.txCalls)
+			}
+			events := &eventRecorder{}
+			svc := CardService{Storage: m, Tx: m, Events: events}
+
+			results, err := svc.BulkCards(tt.ops, tt.atomic)
+
+			if tt.expectError != nil {
+				require.Error(t, err)
+				if errors.Is(tt.expectError, model.ErrNotFound) || errors.Is(tt.expectError, model.ErrInvalidInput) {
+					require.ErrorIs(t, err, tt.expectError)
+				} else {
+					require.ErrorContains(t, err, tt.expectError.Error())
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			var statuses []string
+			for _, r := range results {
+				statuses = append(statuses, r.Status)
+				if r.Status == model.BulkStatusOK {
+					require.NotNil(t, r.Card)
+				} else {
+					require.Nil(t, r.Card)
+				}
+			}
+			require.Equal(t, tt.wantStatuses, statuses)
+			require.Equal(t, tt.wantEvents, events.types(), "events are published only after commit")
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxFieldTextLength = 1000
+
+// SetFieldValue задаёт значение поля карточки; nil очищает его. value —
+// значение из JSON: строка, число или bool в зависимости от типа поля.
+func (s CardService) SetFieldValue(cardID, fieldID int, value any) (model.CardFieldValue, error) {
+	if s.Fields == nil {
+		return model.CardFieldValue{}, fmt.Errorf("custom fields are not configured")
+	}
+	card, err := s.Storage.GetCard(cardID)
+	if err != nil {
+		return model.CardFieldValue{}, err
+	}
+	field, err := s.Fields.GetField(fieldID)
+	if err != nil {
+		return model.CardFieldValue{}, err
+	}
+	if field.BoardID != card.BoardID {
+		return model.CardFieldValue{}, fmt.Errorf("%w: field %d belongs to another board", model.ErrInvalidInput, field.ID)
+	}
+	if value == nil {
+		return model.CardFieldValue{CardID: cardID, FieldID: fieldID}, s.Fields.DeleteCardFieldValue(cardID, fieldID)
+	}
+	v, err := fieldValue(field, value)
+	if err != nil {
+		return model.CardFieldValue{}, err
+	}
+	v.CardID = cardID
+	return s.Fields.SetCardFieldValue(v)
+}
+
+// resolveFieldQuery разбирает фильтры и сортировку по полю из запроса по
+// типу поля.
+func (s CardService) resolveFieldQuery(filter *model.CardFilter) error {
+	if sort := filter.Sort; sort != nil && !slices.Contains(model.CardSorts, sort.By) {
+		return fmt.Errorf("%w: sort must be one of %s", model.ErrInvalidInput, strings.Join(model.CardSorts, ", "))
+	}
+	sortByField := filter.Sort != nil && filter.Sort.By == model.CardSortField
+	if len(filter.Fields) == 0 && !sortByField {
+		return nil
+	}
+	if s.Fields == nil {
+		return fmt.Errorf("%w: custom fields are not configured", model.ErrInvalidInput)
+	}
+	for i := range filter.Fields {
+		f := &filter.Fields[i]
+		field, err := s.queryField(f.FieldID)
+		if err != nil {
+			return err
+		}
+		var value any = f.Value
+		switch field.Type {
+		case model.FieldNumber:
+			if value, err = strconv.ParseFloat(f.Value, 64); err != nil {
+				return fmt.Errorf("%w: %q is not a number", model.ErrInvalidInput, f.Value)
+			}
+		case model.FieldCheckbox:
+			if value, err = strconv.ParseBool(f.Value); err != nil {
+				return fmt.Errorf("%w: %q is not a boolean", model.ErrInvalidInput, f.Value)
+			}
+		}
+		if f.Match, err = fieldValue(field, value); err != nil {
+			return err
+		}
+	}
+	if sortByField {
+		field, err := s.queryField(filter.Sort.FieldID)
+		if err != nil {
+			return err
+		}
+		filter.Sort.Type = field.Type
+	}
+	return nil
+}
+
+// queryField возвращает поле для фильтра; неизвестное поле — ошибка
+// запроса, а не 404.
+func (s CardService) queryField(id int) (model.CustomField, error) {
+	field, err := s.Fields.GetField(id)
+	if errors.Is(err, model.ErrNotFound) {
+		return model.CustomField{}, fmt.Errorf("%w: field %d does not exist", model.ErrInvalidInput, id)
+	}
+	return field, err
+}
+
+// fieldValue проверяет значение по типу поля и раскладывает его в
+// нужную колонку.
+func fieldValue(field model.CustomField, value any) (model.CardFieldValue, error) {
+	v := model.CardFieldValue{FieldID: field.ID}
+	invalid := func(expected string) (model.CardFieldValue, error) {
+		return model.CardFieldValue{}, fmt.Errorf("%w: field %q expects %s", model.ErrInvalidInput, field.Name, expected)
+	}
+	switch field.Type {
+	case model.FieldText:
+		text, ok := value.(string)
+		if !ok {
+			return invalid("a string")
+		}
+		text = strings.TrimSpace(text)
+		if utf8.RuneCountInString(text) > maxFieldTextLength {
+			return invalid(fmt.Sprintf("at most %d characters", maxFieldTextLength))
+		}
+		v.Text = &text
+	case model.FieldDropdown:
+		option, ok := value.(string)
+		if !ok || !slices.Contains(field.Options, option) {
+			return invalid("one of " + strings.Join(field.Options, ", "))
+		}
+		v.Text = &option
+	case model.FieldNumber:
+		number, ok := value.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return invalid("a number")
+		}
+		v.Number = &number
+	case model.FieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return invalid("a date " + model.FieldDateLayout)
+		}
+		date, err := time.Parse(model.FieldDateLayout, text)
+		if err != nil {
+			return invalid("a date " + model.FieldDateLayout)
+		}
+		v.Date = &date
+	case model.FieldCheckbox:
+		checked, ok := value.(bool)
+		if !ok {
+			return invalid("a boolean")
+		}
+		v.Bool = &checked
+	default:
+		return model.CardFieldValue{}, fmt.Errorf("unknown field type %q", field.Type)
+	}
+	return v, nil
+}
+
+// remapFields переносит значения полей карточки на доску boardID: значение
+// остаётся, если там есть поле с тем же названием (без учёта регистра) и
+// типом и оно принимает это значение, иначе удаляется.
+func remapFields(tx Tx, card model.Card, boardID int) error {
+	values, err := tx.GetCardFieldValues(card.ID)
+	if err != nil || len(values) == 0 {
+		return err
+	}
+	fields, err := matchFields(tx, card.BoardID, boardID)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if err := tx.DeleteCardFieldValue(card.ID, v.FieldID); err != nil {
+			return err
+		}
+		v, ok := remapValue(v, fields)
+		if !ok {
+			continue
+		}
+		if _, err := tx.SetCardFieldValue(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchFields сопоставляет полям доски fromBoardID поля доски toBoardID:
+// на той же доске — само поле, на другой — поле с тем же названием (без
+// учёта регистра) и типом.
+func matchFields(tx Tx, fromBoardID, toBoardID int) (map[int]model.CustomField, error) {
+	source, err := tx.GetFields(fromBoardID)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[int]model.CustomField{}
+	if fromBoardID == toBoardID {
+		for _, f := range source {
+			fields[f.ID] = f
+		}
+		return fields, nil
+	}
+	target, err := tx.GetFields(toBoardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range source {
+		j := slices.IndexFunc(target, func(t model.CustomField) bool {
+			return t.Type == f.Type && strings.EqualFold(t.Name, f.Name)
+		})
+		if j >= 0 {
+			fields[f.ID] = target[j]
+		}
+	}
+	return fields, nil
+}
+
+// remapValue переводит значение в сопоставленное поле. false — пары нет
+// или выпадающий список не содержит такого варианта.
+func remapValue(v model.CardFieldValue, fields map[int]model.CustomField) (model.CardFieldValue, bool) {
+	field, ok := fields[v.FieldID]
+	if !ok || field.Type == model.FieldDropdown && !slices.Contains(field.Options, *v.Text) {
+		return model.CardFieldValue{}, false
+	}
+	v.FieldID = field.ID
+	return v, true
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/require"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFieldValue(t *testing.T) {
+	text := func(s string) model.CardFieldValue { return model.CardFieldValue{FieldID: 1, Text: &s} }
+	number := func(n float64) model.CardFieldValue { return model.CardFieldValue{FieldID: 1, Number: &n} }
+	date := time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC)
+	checked := true
+	tests := []struct {
+		name        string
+		fieldType   string
+		value       any
+		want        model.CardFieldValue
+		expectError bool
+	}{
+		{name: "text", fieldType: model.FieldText, value: " acme ", want: text("acme")},
+		{name: "long text", fieldType: model.FieldText, value: strings.Repeat("x", 1001), expectError: true},
+		{name: "text from number", fieldType: model.FieldText, value: 1.0, expectError: true},
+		{name: "dropdown", fieldType: model.FieldDropdown, value: "high", want: text("high")},
+		{name: "unknown option", fieldType: model.FieldDropdown, value: "urgent", expectError: true},
+		{name: "number", fieldType: model.FieldNumber, value: 2.5, want: number(2.5)},
+		{name: "number from string", fieldType: model.FieldNumber, value: "2.5", expectError: true},
+		{name: "NaN", fieldType: model.FieldNumber, value: math.NaN(), expectError: true},
+		{name: "date", fieldType: model.FieldDate, value: "2026-05-09", want: model.CardFieldValue{FieldID: 1, Date: &date}},
+		{name: "date with time", fieldType: model.FieldDate, value: "2026-05-09T10:00:00Z", expectError: true},
+		{name: "checkbox", fieldType: model.FieldCheckbox, value: true, want: model.CardFieldValue{FieldID: 1, Bool: &checked}},
+		{name: "checkbox from string", fieldType: model.FieldCheckbox, value: "true", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := model.CustomField{ID: 1, Name: "f", Type: tt.fieldType, Options: []string{"low", "high"}}
+			got, err := fieldValue(field, tt.value)
+			if tt.expectError {
+				require.ErrorIs(t, err, model.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetFieldValue(t *testing.T) {
+	points := model.CustomField{ID: 5, BoardID: 1, Name: "points", Type: model.FieldNumber}
+	m := new(MockTx)
+	m.On("GetCard", 1).Return(model.Card{ID: 1, BoardID: 1}, nil)
+	m.On("GetCard", 2).Return(model.Card{ID: 2, BoardID: 2}, nil)
+	m.On("GetField", 5).Return(points, nil)
+	n := 3.0
+	m.On("SetCardFieldValue", model.CardFieldValue{CardID: 1, FieldID: 5, Number: &n}).Return(model.CardFieldValue{CardID: 1, FieldID: 5, Number: &n}, nil)
+	m.On("DeleteCardFieldValue", 1, 5).Return(nil)
+	svc := CardService{Storage: m, Tx: m, Fields: m}
+
+	got, err := svc.SetFieldValue(1, 5, 3.0)
+	require.NoError(t, err)
+	require.Equal(t, 3.0, *got.Number)
+	_, err = svc.SetFieldValue(1, 5, nil)
+	require.NoError(t, err, "nil clears the value")
+	_, err = svc.SetFieldValue(1, 5, "3")
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+	_, err = svc.SetFieldValue(2, 5, 3.0)
+	require.ErrorIs(t, err, model.ErrInvalidInput, "field of another board")
+	m.AssertExpectations(t)
+}
+
+func TestResolveFieldQuery(t *testing.T) {
+	m := new(MockTx)
+	m.On("GetField", 1).Return(model.CustomField{ID: 1, Type: model.FieldNumber}, nil)
+	m.On("GetField", 2).Return(model.CustomField{ID: 2, Type: model.FieldCheckbox}, nil)
+	m.On("GetField", 9).Return(model.CustomField{}, model.ErrNotFound)
+	svc := CardService{Storage: m, Tx: m, Fields: m}
+
+	filter := model.CardFilter{
+		Fields: []model.CardFieldFilter{{FieldID: 1, Value: "2.5"}, {FieldID: 2, Value: "true"}},
+		Sort:   &model.CardSort{By: model.CardSortField, FieldID: 2},
+	}
+	require.NoError(t, svc.resolveFieldQuery(&filter))
+	require.Equal(t, 2.5, *filter.Fields[0].Match.Number)
+	require.True(t, *filter.Fields[1].Match.Bool)
+	require.Equal(t, model.FieldCheckbox, filter.Sort.Type)
+
+	err := svc.resolveFieldQuery(&model.CardFilter{Fields: []model.CardFieldFilter{{FieldID: 1, Value: "many"}}})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+	err = svc.resolveFieldQuery(&model.CardFilter{Sort: &model.CardSort{By: model.CardSortField, FieldID: 9}})
+	require.ErrorIs(t, err, model.ErrInvalidInput, "unknown field is a bad query")
+	err = svc.resolveFieldQuery(&model.CardFilter{Sort: &model.CardSort{By: "priority"}})
+	require.ErrorIs(t, err, model.ErrInvalidInput, "unknown sort")
+	require.NoError(t, CardService{}.resolveFieldQuery(&model.CardFilter{Sort: &model.CardSort{By: model.CardSortDue}}), "no field query")
+}
+
+func TestRemapFields(t *testing.T) {
+	low, n := "low", 2.0
+	m := new(MockTx)
+	m.On("GetCardFieldValues", 1).Return([]model.CardFieldValue{
+		{CardID: 1, FieldID: 1, Number: &n},
+		{CardID: 1, FieldID: 2, Text: &low},
+		{CardID: 1, FieldID: 3, Text: &low},
+	}, nil)
+	m.On("GetFields", 10).Return([]model.CustomField{
+		{ID: 1, Name: "Points", Type: model.FieldNumber},
+		{ID: 2, Name: "Priority", Type: model.FieldDropdown, Options: []string{"low"}},
+		{ID: 3, Name: "Size", Type: model.FieldDropdown, Options: []string{"low"}},
+	}, nil)
+	m.On("GetFields", 20).Return([]model.CustomField{
+		{ID: 11, Name: "points", Type: model.FieldNumber},
+		{ID: 12, Name: "Priority", Type: model.FieldText},
+		{ID: 13, Name: "Size", Type: model.FieldDropdown, Options: []string{"S", "M"}},
+	}, nil)
+	for _, id := range []int{1, 2, 3} {
+		m.On("DeleteCardFieldValue", 1, id).Return(nil)
+	}
+	moved := model.CardFieldValue{CardID: 1, FieldID: 11, Number: &n}
+	m.On("SetCardFieldValue", moved).Return(moved, nil)
+
+	require.NoError(t, remapFields(m, model.Card{ID: 1, BoardID: 10}, 20))
+	m.AssertExpectations(t)
+	m.AssertNumberOfCalls(t, "SetCardFieldValue", 1)
+}
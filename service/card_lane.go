@@ -0,0 +1,47 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"errors"
+	"fmt"
+)
+
+// SetCardLane кладёт карточку в явную дорожку её доски; нулевой laneID
+// убирает карточку из дорожек.
+func (s CardService) SetCardLane(id int, laneID int) (model.Card, error) {
+	var card model.Card
+	err := s.Tx.InTx(func(tx Tx) error {
+		var err error
+		if card, err = tx.GetCard(id); err != nil {
+			return err
+		}
+		return setCardLane(tx, card, laneID)
+	})
+	if err != nil {
+		return model.Card{}, err
+	}
+	return s.withFields(s.withMentions(card)), nil
+}
+
+// setCardLane проверяет, что laneID — явная дорожка доски карточки, и
+// кладёт карточку в неё.
+func setCardLane(tx Tx, card model.Card, laneID int) error {
+	if laneID == 0 {
+		return tx.SetCardLane(card.ID, nil)
+	}
+	swimlanes, err := tx.GetSwimlanes(card.BoardID)
+	if err != nil {
+		return err
+	}
+	if swimlanes.Mode != model.LaneExplicit {
+		return fmt.Errorf("%w: board %d has no explicit lanes", model.ErrInvalidInput, card.BoardID)
+	}
+	lane, err := tx.GetLane(laneID)
+	if errors.Is(err, model.ErrNotFound) || err == nil && lane.BoardID != card.BoardID {
+		return fmt.Errorf("%w: lane %d is not on board %d", model.ErrInvalidInput, laneID, card.BoardID)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.SetCardLane(card.ID, &laneID)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSetCardLane(t *testing.T) {
+	card := model.Card{ID: 1, BoardID: 1, ListID: 10, Title: "Card"}
+	lane := 7
+	tests := []struct {
+		name        string
+		laneID      int
+		setup       func(m *MockTx)
+		expectError error
+	}{
+		{
+			name:   "explicit lane",
+			laneID: 7,
+			setup: func(m *MockTx) {
+				m.On("GetSwimlanes", 1).Return(model.Swimlanes{BoardID: 1, Mode: model.LaneExplicit}, nil)
+				m.On("GetLane", 7).Return(model.Lane{ID: 7, BoardID: 1}, nil)
+				m.On("SetCardLane", 1, &lane).Return(nil)
+			},
+		},
+		{
+			name:   "zero clears lane",
+			laneID: 0,
+			setup: func(m *MockTx) {
+				m.On("SetCardLane", 1, (*int)(nil)).Return(nil)
+			},
+		},
+		{
+			name:   "board lanes are not explicit",
+			laneID: 7,
+			setup: func(m *MockTx) {
+				m.On("GetSwimlanes", 1).Return(model.Swimlanes{BoardID: 1, Mode: model.LaneAssignee}, nil)
+			},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:   "lane on another board",
+			laneID: 7,
+			setup: func(m *MockTx) {
+				m.On("GetSwimlanes", 1).Return(model.Swimlanes{BoardID: 1, Mode: model.LaneExplicit}, nil)
+				m.On("GetLane", 7).Return(model.Lane{ID: 7, BoardID: 2}, nil)
+			},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:   "missing lane",
+			laneID: 7,
+			setup: func(m *MockTx) {
+				m.On("GetSwimlanes", 1).Return(model.Swimlanes{BoardID: 1, Mode: model.LaneExplicit}, nil)
+				m.On("GetLane", 7).Return(model.Lane{}, model.ErrNotFound)
+			},
+			expectError: model.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			m.On("InTx").Return(nil)
+			m.On("GetCard", 1).Return(card, nil)
+			tt.setup(m)
+
+			got, err := CardService{Storage: m, Tx: m}.SetCardLane(1, tt.laneID)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, card, got)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveCardLane(t *testing.T) {
+	card := model.Card{ID: 1, BoardID: 1, ListID: 10, Title: "Card"}
+	moved := model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"}
+	lane := 7
+	m := new(MockTx)
+	m.On("InTx").Return(nil)
+	m.On("GetCard", 1).Return(card, nil)
+	m.On("GetList", 11).Return(model.List{ID: 11, BoardID: 1}, nil)
+	m.On("UpdateCard", moved).Return(moved, nil)
+	m.On("GetSwimlanes", 1).Return(model.Swimlanes{BoardID: 1, Mode: model.LaneExplicit}, nil)
+	m.On("GetLane", 7).Return(model.Lane{ID: 7, BoardID: 1}, nil)
+	m.On("SetCardLane", 1, &lane).Return(nil)
+
+	got, err := CardService{Storage: m, Tx: m}.MoveCard(1, model.CardMoveInput{ListID: 11, LaneID: &lane})
+	require.NoError(t, err)
+	require.Equal(t, moved, got)
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MoveCard переносит карточку в другой список, в том числе на другую
+// доску. При переносе между досками метки заменяются одноимёнными метками
+// целевой доски (недостающие создаются), значения полей переходят в
+// одноимённые поля того же типа (остальные удаляются), board_id
+// следует за списком, а карточка выходит из дорожек и спринта старой
+// доски.
+func (s CardService) MoveCard(id int, input model.CardMoveInput) (model.Card, error) {
+	var moved model.Card
+	var fromListID int
+	var warnings []string
+	err := s.Tx.InTx(func(tx Tx) error {
+		card, err := tx.GetCard(id)
+		if err != nil {
+			return err
+		}
+		fromListID = card.ListID
+		list, err := targetList(tx, input.ListID)
+		if err != nil {
+			return err
+		}
+		if list.ID != card.ListID {
+			if err := s.checkBlockers(tx, card, list); err != nil {
+				return err
+			}
+			if warnings, err = CheckWIPLimit(tx, list); err != nil {
+				return err
+			}
+		}
+		card.ListID = list.ID
+		if list.BoardID == card.BoardID {
+			if moved, err = tx.UpdateCard(card); err != nil {
+				return err
+			}
+			return moveLane(tx, moved, input.LaneID)
+		}
+
+		if err := remapFields(tx, card, list.BoardID); err != nil {
+			return err
+		}
+		labels, err := tx.GetCardLabels(card.ID)
+		if err != nil {
+			return err
+		}
+		for _, l := range labels {
+			if err := tx.RemoveCardLabel(card.ID, l.ID); err != nil {
+				return err
+			}
+		}
+		if moved, err = tx.UpdateCard(card); err != nil {
+			return err
+		}
+		labelIDs, err := remapLabels(tx, labels, list.BoardID)
+		if err != nil {
+			return err
+		}
+		for _, l := range labels {
+			if err := tx.AddCardLabel(moved.ID, labelIDs[l.ID]); err != nil {
+				return err
+			}
+		}
+		// Дорожки и спринты старой доски на новой не действуют.
+		if err := tx.SetCardSprint(moved.ID, nil); err != nil {
+			return err
+		}
+		if input.LaneID == nil {
+			return tx.SetCardLane(moved.ID, nil)
+		}
+		return moveLane(tx, moved, input.LaneID)
+	})
+	if err != nil {
+		return model.Card{}, err
+	}
+	if moved.ListID != fromListID {
+		s.publish(model.Event{Type: model.EventCardMoved, Card: moved, FromListID: fromListID})
+	}
+	moved.Warnings = warnings
+	return moved, nil
+}
+
+// CopyCard создаёт копию карточки с метками, значениями полей и
+// чек-листами. На другой доске метки и поля сопоставляются так же, как
+// при переносе: значения полей без пары не копируются. Исполнители,
+// комментарии и состояние архива не копируются.
+func (s CardService) CopyCard(id int, input model.CardCopyInput) (model.Card, error) {
+	var copied model.Card
+	err := s.Tx.InTx(func(tx Tx) error {
+		card, err := tx.GetCard(id)
+		if err != nil {
+			return err
+		}
+		if input.ListID == 0 {
+			input.ListID = card.ListID
+		}
+		list, err := targetList(tx, input.ListID)
+		if err != nil {
+			return err
+		}
+		warnings, err := CheckWIPLimit(tx, list)
+		if err != nil {
+			return err
+		}
+		if title := strings.TrimSpace(input.Title); title != "" {
+			if err := ValidateCardTitle(title); err != nil {
+				return err
+			}
+			card.Title = title
+		}
+
+		labels, err := tx.GetCardLabels(card.ID)
+		if err != nil {
+			return err
+		}
+		labelIDs := map[int]int{}
+		if list.BoardID == card.BoardID {
+			for _, l := range labels {
+				labelIDs[l.ID] = l.ID
+			}
+		} else if labelIDs, err = remapLabels(tx, labels, list.BoardID); err != nil {
+			return err
+		}
+		fields, err := matchFields(tx, card.BoardID, list.BoardID)
+		if err != nil {
+			return err
+		}
+		copied, err = copyCardInto(tx, card, list.ID, labelIDs, fields)
+		copied.Warnings = warnings
+		return err
+	})
+	if err != nil {
+		return model.Card{}, err
+	}
+	s.publish(model.Event{Type: model.EventCardCreated, Card: copied})
+	return copied, nil
+}
+
+// moveLane меняет дорожку перенесённой карточки, если она указана.
+func moveLane(tx Tx, card model.Card, laneID *int) error {
+	if laneID == nil {
+		return nil
+	}
+	return setCardLane(tx, card, *laneID)
+}
+
+// targetList проверяет список назначения: его отсутствие — ошибка запроса,
+// а не «карточка не найдена».
+func targetList(tx Tx, listID int) (model.List, error) {
+	if listID == 0 {
+		return model.List{}, fmt.Errorf("%w: list_id is required", model.ErrInvalidInput)
+	}
+	list, err := tx.GetList(listID)
+	if errors.Is(err, model.ErrNotFound) {
+		return model.List{}, fmt.Errorf("%w: target list %d does not exist", model.ErrInvalidInput, listID)
+	}
+	return list, err
+}
+
+// remapLabels сопоставляет метки с метками доски boardID по названию без
+// учёта регистра и создаёт недостающие с тем же цветом.
+func remapLabels(tx Tx, labels []model.Label, boardID int) (map[int]int, error) {
+	labelIDs := map[int]int{}
+	if len(labels) == 0 {
+		return labelIDs, nil
+	}
+	existing, err := tx.GetLabels(boardID)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]int{}
+	for _, l := range existing {
+		byName[strings.ToLower(l.Name)] = l.ID
+	}
+	for _, l := range labels {
+		id, ok := byName[strings.ToLower(l.Name)]
+		if !ok {
+			created, err := tx.CreateLabel(model.LabelInputCreate{BoardID: boardID, Name: l.Name, Color: l.Color})
+			if err != nil {
+				return nil, err
+			}
+			id = created.ID
+			byName[strings.ToLower(l.Name)] = id
+		}
+		labelIDs[l.ID] = id
+	}
+	return labelIDs, nil
+}
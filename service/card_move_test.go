@@ -0,0 +1,278 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMoveCard(t *testing.T) {
+	card := model.Card{ID: 1, BoardID: 1, ListID: 10, Title: "Card"}
+	bug := model.Label{ID: 5, BoardID: 1, Name: "Bug", Color: "red"}
+	ux := model.Label{ID: 6, BoardID: 1, Name: "ux", Color: "blue"}
+	tests := []struct {
+		name        string
+		listID      int
+		setup       func(m *MockTx)
+		want        model.Card
+		expectError error
+	}{
+		{
+			name:   "same board",
+			listID: 11,
+			setup: func(m *MockTx) {
+				m.On("GetList", 11).Return(model.List{ID: 11, BoardID: 1}, nil)
+				m.On("UpdateCard", model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"}).Return(model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"}, nil)
+			},
+			want: model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"},
+		},
+		{
+			name:   "another board remaps labels",
+			listID: 20,
+			setup: func(m *MockTx) {
+				m.On("GetList", 20).Return(model.List{ID: 20, BoardID: 2}, nil)
+				m.On("GetCardFieldValues", 1).Return([]model.CardFieldValue(nil), nil)
+				m.On("GetCardLabels", 1).Return([]model.Label{bug, ux}, nil)
+				m.On("RemoveCardLabel", 1, 5).Return(nil)
+				m.On("RemoveCardLabel", 1, 6).Return(nil)
+				m.On("UpdateCard", model.Card{ID: 1, BoardID: 1, ListID: 20, Title: "Card"}).Return(model.Card{ID: 1, BoardID: 2, ListID: 20, Title: "Card"}, nil)
+				m.On("GetLabels", 2).Return([]model.Label{{ID: 50, BoardID: 2, Name: "bug"}}, nil)
+				m.On("CreateLabel", model.LabelInputCreate{BoardID: 2, Name: "ux", Color: "blue"}).Return(model.Label{ID: 51, BoardID: 2, Name: "ux"}, nil)
+				m.On("AddCardLabel", 1, 50).Return(nil)
+				m.On("AddCardLabel", 1, 51).Return(nil)
+				m.On("SetCardSprint", 1, (*int)(nil)).Return(nil)
+				m.On("SetCardLane", 1, (*int)(nil)).Return(nil)
+			},
+			want: model.Card{ID: 1, BoardID: 2, ListID: 20, Title: "Card"},
+		},
+		{
+			name:   "target list does not exist",
+			listID: 99,
+			setup: func(m *MockTx) {
+				m.On("GetList", 99).Return(model.List{}, model.ErrNotFound)
+			},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "list is required",
+			listID:      0,
+			setup:       func(m *MockTx) {},
+			expectError: model.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			m.On("InTx").Return(nil)
+			m.On("GetCard", 1).Return(card, nil)
+			tt.setup(m)
+			events := &eventRecorder{}
+			svc := CardService{Storage: m, Tx: m, Events: events}
+
+			moved, err := svc.MoveCard(1, model.CardMoveInput{ListID: tt.listID})
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+				require.Empty(t, events.events)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, moved)
+				require.Equal(t, []model.Event{{Type: model.EventCardMoved, Card: tt.want, FromListID: card.ListID}}, events.events)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+
+	t.Run("missing card", func(t *testing.T) {
+		m := new(MockTx)
+		m.On("InTx").Return(nil)
+		m.On("GetCard", 1).Return(model.Card{}, model.ErrNotFound)
+		_, err := CardService{Storage: m, Tx: m}.MoveCard(1, model.CardMoveInput{ListID: 10})
+		require.ErrorIs(t, err, model.ErrNotFound)
+	})
+}
+
+func TestCopyCard(t *testing.T) {
+	card := model.Card{ID: 1, BoardID: 1, ListID: 10, Title: "Card", Description: "d"}
+	bug := model.Label{ID: 5, BoardID: 1, Name: "bug"}
+	points, size := 3.0, "XL"
+	fields := []model.CustomField{
+		{ID: 7, BoardID: 1, Name: "Points", Type: model.FieldNumber},
+		{ID: 8, BoardID: 1, Name: "Size", Type: model.FieldDropdown, Options: []string{"M", "XL"}},
+	}
+	values := []model.CardFieldValue{{CardID: 1, FieldID: 7, Number: &points}, {CardID: 1, FieldID: 8, Text: &size}}
+	tests := []struct {
+		name        string
+		input       model.CardCopyInput
+		setup       func(m *MockTx)
+		want        model.Card
+		expectError error
+	}{
+		{
+			name:  "same list keeps labels and field values",
+			input: model.CardCopyInput{},
+			setup: func(m *MockTx) {
+				m.On("GetList", 10).Return(model.List{ID: 10, BoardID: 1}, nil)
+				m.On("CreateCard", model.CardInputCreate{ListID: 10, Title: "Card", Description: "d"}).Return(model.Card{ID: 2, BoardID: 1, ListID: 10, Title: "Card"}, nil)
+				m.On("GetCardLabels", 1).Return([]model.Label{bug}, nil)
+				m.On("AddCardLabel", 2, 5).Return(nil)
+				m.On("GetFields", 1).Return(fields, nil)
+				m.On("GetCardFieldValues", 1).Return(values, nil)
+				m.On("SetCardFieldValue", model.CardFieldValue{CardID: 2, FieldID: 7, Number: &points}).Return(model.CardFieldValue{}, nil)
+				m.On("SetCardFieldValue", model.CardFieldValue{CardID: 2, FieldID: 8, Text: &size}).Return(model.CardFieldValue{}, nil)
+				m.On("GetChecklists", 1).Return([]model.Checklist{}, nil)
+			},
+			want: model.Card{ID: 2, BoardID: 1, ListID: 10, Title: "Card"},
+		},
+		{
+			name:  "another board with new title",
+			input: model.CardCopyInput{ListID: 20, Title: " Copy "},
+			setup: func(m *MockTx) {
+				m.On("GetList", 20).Return(model.List{ID: 20, BoardID: 2}, nil)
+				m.On("GetCardLabels", 1).Return([]model.Label{bug}, nil)
+				m.On("GetLabels", 2).Return([]model.Label{}, nil)
+				m.On("CreateLabel", model.LabelInputCreate{BoardID: 2, Name: "bug"}).Return(model.Label{ID: 50, BoardID: 2, Name: "bug"}, nil)
+				m.On("CreateCard", model.CardInputCreate{ListID: 20, Title: "Copy", Description: "d"}).Return(model.Card{ID: 2, BoardID: 2, ListID: 20, Title: "Copy"}, nil)
+				m.On("AddCardLabel", 2, 50).Return(nil)
+				m.On("GetFields", 1).Return(fields, nil)
+				m.On("GetFields", 2).Return([]model.CustomField{
+					{ID: 70, BoardID: 2, Name: "points", Type: model.FieldNumber},
+					{ID: 80, BoardID: 2, Name: "Size", Type: model.FieldDropdown, Options: []string{"S", "M"}},
+				}, nil)
+				m.On("GetCardFieldValues", 1).Return(values, nil)
+				m.On("SetCardFieldValue", model.CardFieldValue{CardID: 2, FieldID: 70, Number: &points}).Return(model.CardFieldValue{}, nil)
+				m.On("GetChecklists", 1).Return([]model.Checklist{}, nil)
+			},
+			want: model.Card{ID: 2, BoardID: 2, ListID: 20, Title: "Copy"},
+		},
+		{
+			name:  "target list does not exist",
+			input: model.CardCopyInput{ListID: 99},
+			setup: func(m *MockTx) {
+				m.On("GetList", 99).Return(model.List{}, model.ErrNotFound)
+			},
+			expectError: model.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			m.On("InTx").Return(nil)
+			m.On("GetCard", 1).Return(card, nil)
+			tt.setup(m)
+			svc := CardService{Storage: m, Tx: m}
+
+			copied, err := svc.CopyCard(1, tt.input)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, copied)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveCardBlockers(t *testing.T) {
+	card := model.Card{ID: 1, BoardID: 1, ListID: 10, Title: "Card"}
+	blocks := func(id, source int) model.CardLink {
+		return model.CardLink{ID: id, SourceID: source, TargetID: 1, Type: model.LinkBlocks}
+	}
+	tests := []struct {
+		name        string
+		setup       func(m *MockTx)
+		expectError error
+	}{
+		{
+			name: "open blocker",
+			setup: func(m *MockTx) {
+				m.On("GetCardLinks", 1).Return([]model.CardLink{blocks(1, 2)}, nil)
+				m.On("GetCard", 2).Return(model.Card{ID: 2, ListID: 10}, nil)
+				m.On("GetList", 10).Return(model.List{ID: 10, BoardID: 1, Title: "todo"}, nil)
+			},
+			expectError: model.ErrConflict,
+		},
+		{
+			name: "closed blockers",
+			setup: func(m *MockTx) {
+				archived := time.Now()
+				m.On("GetCardLinks", 1).Return([]model.CardLink{
+					blocks(1, 2), blocks(2, 3), blocks(3, 4), blocks(4, 5),
+					{ID: 5, SourceID: 1, TargetID: 6, Type: model.LinkBlocks},
+					{ID: 6, SourceID: 7, TargetID: 1, Type: model.LinkRelatesTo},
+				}, nil)
+				m.On("GetCard", 2).Return(model.Card{ID: 2, ListID: 12}, nil)
+				m.On("GetList", 12).Return(model.List{ID: 12, BoardID: 1, Title: "Готово"}, nil)
+				m.On("GetCard", 3).Return(model.Card{ID: 3, ListID: 10, Status: model.CardStatusDone}, nil)
+				m.On("GetCard", 4).Return(model.Card{ID: 4, ListID: 10, ArchivedAt: &archived}, nil)
+				m.On("GetCard", 5).Return(model.Card{}, model.ErrNotFound)
+				m.On("UpdateCard", model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"}).Return(model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			m.On("InTx").Return(nil)
+			m.On("GetCard", 1).Return(card, nil)
+			m.On("GetList", 11).Return(model.List{ID: 11, BoardID: 1, Title: "done"}, nil)
+			tt.setup(m)
+			svc := CardService{Storage: m, Tx: m, DoneLists: []string{"Done", "Готово"}}
+
+			_, err := svc.MoveCard(1, model.CardMoveInput{ListID: 11})
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveCardWIPLimit(t *testing.T) {
+	card := model.Card{ID: 1, BoardID: 1, ListID: 10, Title: "Card"}
+	limit := func(n int, soft bool, count int) model.List {
+		return model.List{ID: 11, BoardID: 1, Title: "doing", WIPLimit: &n, WIPSoft: soft, CardCount: count}
+	}
+	tests := []struct {
+		name         string
+		list         model.List
+		expectError  error
+		wantWarnings []string
+	}{
+		{name: "no limit", list: model.List{ID: 11, BoardID: 1, Title: "doing", CardCount: 10}},
+		{name: "below hard limit", list: limit(3, false, 2)},
+		{name: "hard limit reached", list: limit(3, false, 3), expectError: model.ErrConflict},
+		{name: "below soft limit", list: limit(3, true, 2)},
+		{name: "soft limit reached", list: limit(3, true, 3), wantWarnings: []string{"list 11 is over its WIP limit of 3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			m.On("InTx").Return(nil)
+			m.On("GetCard", 1).Return(card, nil)
+			m.On("GetList", 11).Return(tt.list, nil)
+			if tt.list.WIPLimit != nil {
+				m.On("LockList", 11).Return(tt.list, nil)
+			}
+			if tt.expectError == nil {
+				m.On("UpdateCard", model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"}).Return(model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"}, nil)
+			}
+			svc := CardService{Storage: m, Tx: m}
+
+			moved, err := svc.MoveCard(1, model.CardMoveInput{ListID: 11})
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantWarnings, moved.Warnings)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -2,29 +2,353 @@ package service
 
 import (
 	"awesomeProject2/cmd/model"
+	"errors"
+	"fmt"
 	"go.uber.org/zap"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+const maxCardTitleLength = 512
+
 type CardService struct {
 	Storage CardStorage
-	logger  *zap.Logger
+	Tx      Transactor
+	Events  EventPublisher
+	// Mentions, если задан, дополняет карточки упоминаниями из описания.
+	Mentions MentionReader
+	// Fields, если задан, включает пользовательские поля: значения на
+	// карточках, фильтр и сортировку по ним.
+	Fields FieldStorage
+	// DoneLists — названия списков «готово», без учёта регистра. Карточку
+	// с открытыми блокерами нельзя перенести в такой список; пустой
+	// DoneLists отключает проверку.
+	DoneLists []string
+	logger    *zap.Logger
 }
 
-func NewCardService(storage CardStorage, logger *zap.Logger) *CardService {
+func NewCardService(storage CardStorage, tx Transactor, events EventPublisher, logger *zap.Logger) *CardService {
 	return &CardService{
 		Storage: storage,
+		Tx:      tx,
+		Events:  events,
 		logger:  logger,
 	}
 }
-func (s CardService) GetCards(CardID *int) ([]model.Card, error) {
-	return s.Storage.GetCards(CardID)
+func (s CardService) GetCards(filter model.CardFilter) ([]model.Card, error) {
+	if err := s.resolveFieldQuery(&filter); err != nil {
+		return nil, err
+	}
+	cards, err := s.Storage.GetCards(filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cards {
+		cards[i] = s.withFields(s.withMentions(cards[i]))
+	}
+	return cards, nil
 }
 func (s CardService) CreateCard(input model.CardInputCreate) (model.Card, error) {
-	return s.Storage.CreateCard(input)
+	input.Title = strings.TrimSpace(input.Title)
+	if err := ValidateCardTitle(input.Title); err != nil {
+		return model.Card{}, err
+	}
+	var card model.Card
+	err := s.Tx.InTx(func(tx Tx) error {
+		list, err := tx.GetList(input.ListID)
+		if err != nil {
+			return err
+		}
+		warnings, err := CheckWIPLimit(tx, list)
+		if err != nil {
+			return err
+		}
+		card, err = tx.CreateCard(input)
+		card.Warnings = warnings
+		return err
+	})
+	if err != nil {
+		return model.Card{}, err
+	}
+	s.publish(model.Event{Type: model.EventCardCreated, Card: card, Actor: input.Creator})
+	return s.withFields(s.withMentions(card)), nil
 }
 func (s CardService) DeleteCard(listID int, cardID int) (model.Card, error) {
 	return s.Storage.DeleteCard(listID, cardID)
 }
+
+// UpdateCard меняет карточку в пределах её доски; перенос на другую доску
+// делается через MoveCard, чтобы не потерять метки.
 func (s CardService) UpdateCard(updated model.Card) (model.Card, error) {
-	return s.Storage.UpdateCard(updated)
+	var card, current model.Card
+	var warnings []string
+	err := s.Tx.InTx(func(tx Tx) error {
+		var err error
+		current, err = tx.GetCard(updated.ID)
+		if err != nil {
+			return err
+		}
+		if updated.ListID != current.ListID {
+			list, err := targetList(tx, updated.ListID)
+			if err != nil {
+				return err
+			}
+			if list.BoardID != current.BoardID {
+				return fmt.Errorf("%w: list %d is on another board, use move", model.ErrInvalidInput, list.ID)
+			}
+			if err := s.checkBlockers(tx, current, list); err != nil {
+				return err
+			}
+			if warnings, err = CheckWIPLimit(tx, list); err != nil {
+				return err
+			}
+		}
+		card, err = tx.UpdateCard(updated)
+		return err
+	})
+	if err != nil {
+		return model.Card{}, err
+	}
+	if card.Title != current.Title || card.Description != current.Description {
+		s.publish(model.Event{Type: model.EventCardUpdated, Card: card})
+	}
+	if card.ListID != current.ListID {
+		s.publish(model.Event{Type: model.EventCardMoved, Card: card, FromListID: current.ListID})
+	}
+	card.Warnings = warnings
+	return s.withFields(s.withMentions(card)), nil
+}
+
+// SetDueDate задаёт или, при nil, снимает срок карточки.
+func (s CardService) SetDueDate(id int, due *time.Time) (model.Card, error) {
+	return s.Storage.SetCardDue(id, due)
+}
+
+// publish передаёт события подписчику, если он задан. Вызывается только
+// после фиксации изменений, чтобы подписчик не видел откатившихся данных.
+func (s CardService) publish(events ...model.Event) {
+	if s.Events == nil {
+		return
+	}
+	for _, e := range events {
+		s.Events.Publish(e)
+	}
+}
+
+// withFields дополняет карточку значениями пользовательских полей. Ошибка
+// чтения только логируется, как и в withMentions.
+func (s CardService) withFields(card model.Card) model.Card {
+	if s.Fields == nil {
+		return card
+	}
+	values, err := s.Fields.GetCardFieldValues(card.ID)
+	if err != nil {
+		s.logger.Error("Не удалось прочитать поля карточки", zap.Error(err), zap.Int("cardID", card.ID))
+		return card
+	}
+	card.Fields = values
+	return card
+}
+
+// withMentions дополняет карточку упоминаниями из описания. Ошибка
+// чтения только логируется: карточка уже сохранена.
+func (s CardService) withMentions(card model.Card) model.Card {
+	if s.Mentions == nil {
+		return card
+	}
+	mentions, err := s.Mentions.GetMentions(card.ID)
+	if err != nil {
+		s.logger.Error("Не удалось прочитать упоминания карточки", zap.Error(err), zap.Int("cardID", card.ID))
+		return card
+	}
+	card.Mentions = nil
+	for _, m := range mentions {
+		if m.CommentID == nil {
+			card.Mentions = append(card.Mentions, m)
+		}
+	}
+	return card
+}
+
+// ValidateCardTitle проверяет уже обрезанное название карточки. Её же
+// вызывает импорт досок, чтобы файл не обходил правила API.
+func ValidateCardTitle(title string) error {
+	if title == "" {
+		return fmt.Errorf("%w: title is required", model.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(title) > maxCardTitleLength {
+		return fmt.Errorf("%w: title is longer than %d characters", model.ErrInvalidInput, maxCardTitleLength)
+	}
+	return nil
+}
+
+func (s CardService) ArchiveCard(id int) (model.Card, error) {
+	return s.Storage.ArchiveCard(id)
+}
+
+// UnarchiveCard возвращает карточку из архива в её список; лимит WIP
+// списка проверяется, как при переносе.
+func (s CardService) UnarchiveCard(id int) (model.Card, error) {
+	var card model.Card
+	err := s.Tx.InTx(func(tx Tx) error {
+		current, err := tx.GetCard(id)
+		if err != nil {
+			return err
+		}
+		if card, err = tx.UnarchiveCard(id); err != nil || current.ArchivedAt == nil {
+			return err
+		}
+		card.Warnings, err = checkReturnedWIPLimit(tx, card.ListID)
+		return err
+	})
+	if err != nil {
+		return model.Card{}, err
+	}
+	return card, nil
+}
+
+// RestoreCard возвращает карточку из корзины в её список с проверкой
+// лимита WIP.
+func (s CardService) RestoreCard(id int) (model.Card, error) {
+	var card model.Card
+	err := s.Tx.InTx(func(tx Tx) error {
+		var err error
+		if card, err = tx.RestoreCard(id); err != nil {
+			return err
+		}
+		card.Warnings, err = checkReturnedWIPLimit(tx, card.ListID)
+		return err
+	})
+	if err != nil {
+		return model.Card{}, err
+	}
+	return card, nil
+}
+
+func (s CardService) GetTrash(boardID int) ([]model.Card, error) {
+	return s.Storage.GetDeletedCards(boardID)
+}
+
+// checkBlockers запрещает переносить карточку в список «готово», пока её
+// блокирует открытая карточка.
+func (s CardService) checkBlockers(tx Tx, card model.Card, list model.List) error {
+	return CheckBlockers(tx, s.DoneLists, card, list)
+}
+
+// CheckBlockers — проверка блокеров при переносе карточки в list внутри
+// транзакции; doneLists — названия списков «готово». Её же вызывает
+// движок автоматизации, чтобы правило не обходило запрет.
+func CheckBlockers(tx Tx, doneLists []string, card model.Card, list model.List) error {
+	if !isDoneList(doneLists, list.Title) {
+		return nil
+	}
+	return checkOpenBlockers(tx, doneLists, card)
+}
+
+// CheckDoneStatus запрещает ставить статус done карточке с открытыми
+// блокерами, как и перенос в список «готово». Пустой doneLists отключает
+// проверку.
+func CheckDoneStatus(tx Tx, doneLists []string, card model.Card, status string) error {
+	if status != model.CardStatusDone || len(doneLists) == 0 {
+		return nil
+	}
+	return checkOpenBlockers(tx, doneLists, card)
+}
+
+// checkOpenBlockers возвращает ErrConflict, если карточку блокирует
+// открытая карточка. Блокер закрыт, если он в архиве, в корзине, имеет
+// статус done или сам лежит в списке «готово».
+func checkOpenBlockers(tx Tx, doneLists []string, card model.Card) error {
+	links, err := tx.GetCardLinks(card.ID)
+	if err != nil {
+		return err
+	}
+	var open []int
+	for _, l := range links {
+		if l.Type != model.LinkBlocks || l.TargetID != card.ID {
+			continue
+		}
+		blocker, err := tx.GetCard(l.SourceID)
+		if errors.Is(err, model.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if blocker.ArchivedAt != nil || blocker.Status == model.CardStatusDone {
+			continue
+		}
+		blockerList, err := tx.GetList(blocker.ListID)
+		if err != nil {
+			return err
+		}
+		if !isDoneList(doneLists, blockerList.Title) {
+			open = append(open, blocker.ID)
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("%w: card %d is blocked by open cards %v", model.ErrConflict, card.ID, open)
+	}
+	return nil
+}
+
+// CheckWIPLimit проверяет лимит списка, в который добавляется карточка:
+// заполненный до жёсткого лимита список её не принимает, а превышение
+// мягкого лимита возвращается предупреждением. Список с лимитом
+// блокируется до конца транзакции и карточки пересчитываются, иначе
+// параллельные добавления увидят одно и то же число и вместе превысят
+// лимит. Её же вызывает движок автоматизации.
+func CheckWIPLimit(tx Tx, list model.List) ([]string, error) {
+	if list.WIPLimit == nil {
+		return nil, nil
+	}
+	list, err := tx.LockList(list.ID)
+	if err != nil {
+		return nil, err
+	}
+	return wipLimit(list, list.CardCount)
+}
+
+// checkReturnedWIPLimit проверяет лимит для карточки, которая в этой же
+// транзакции вернулась в список из архива или корзины и уже учтена в
+// числе его карточек.
+func checkReturnedWIPLimit(tx Tx, listID int) ([]string, error) {
+	list, err := tx.LockList(listID)
+	if err != nil {
+		return nil, err
+	}
+	return wipLimit(list, list.CardCount-1)
+}
+
+// wipLimit сравнивает число карточек в списке до добавления с лимитом.
+func wipLimit(list model.List, before int) ([]string, error) {
+	if list.WIPLimit == nil || before < *list.WIPLimit {
+		return nil, nil
+	}
+	if !list.WIPSoft {
+		return nil, fmt.Errorf("%w: list %d is at its WIP limit of %d", model.ErrConflict, list.ID, *list.WIPLimit)
+	}
+	return []string{fmt.Sprintf("list %d is over its WIP limit of %d", list.ID, *list.WIPLimit)}, nil
+}
+
+func isDoneList(doneLists []string, title string) bool {
+	return slices.ContainsFunc(doneLists, func(done string) bool { return strings.EqualFold(done, title) })
+}
+
+// DoneLists возвращает списки «готово» по умолчанию: списки с названием
+// из titles без учёта регистра, а если таких нет — последний список. По
+// ним метрики и спринты решают, завершена ли карточка.
+func DoneLists(lists []model.List, titles []string) []int {
+	done := []int{}
+	for _, l := range lists {
+		if isDoneList(titles, l.Title) {
+			done = append(done, l.ID)
+		}
+	}
+	if len(done) == 0 && len(lists) > 0 {
+		done = append(done, lists[len(lists)-1].ID)
+	}
+	return done
 }
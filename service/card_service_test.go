@@ -5,7 +5,9 @@ import (
 	"errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateCard(t *testing.T) {
@@ -38,14 +40,16 @@ func TestCreateCard(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.title, func(t *testing.T) {
-			mockStorage := new(MockCardService)
-			svc := CardService{Storage: mockStorage}
+			mockStorage := new(MockTx)
+			svc := CardService{Storage: mockStorage, Tx: mockStorage}
 
 			input := model.CardInputCreate{
 				Title:  tt.title,
 				ListID: tt.listID,
 			}
 
+			mockStorage.On("InTx").Return(nil)
+			mockStorage.On("GetList", tt.listID).Return(model.List{ID: tt.listID}, nil)
 			mockStorage.On("CreateCard", input).Return(tt.mockReturn, tt.mockError)
 
 			result, err := svc.CreateCard(input)
@@ -61,6 +65,41 @@ func TestCreateCard(t *testing.T) {
 		})
 	}
 }
+func TestCreateCardValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		inputTitle string
+		storedAs   string
+	}{
+		{name: "empty title", inputTitle: ""},
+		{name: "blank title", inputTitle: "   "},
+		{name: "too long title", inputTitle: strings.Repeat("я", maxCardTitleLength+1)},
+		{name: "title is trimmed", inputTitle: "  card  ", storedAs: "card"},
+		{name: "max length in runes", inputTitle: strings.Repeat("я", maxCardTitleLength), storedAs: strings.Repeat("я", maxCardTitleLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockTx)
+			svc := CardService{Storage: mockStorage, Tx: mockStorage}
+			if tt.storedAs != "" {
+				stored := model.CardInputCreate{ListID: 1, Title: tt.storedAs}
+				mockStorage.On("InTx").Return(nil)
+				mockStorage.On("GetList", 1).Return(model.List{ID: 1}, nil)
+				mockStorage.On("CreateCard", stored).Return(model.Card{ID: 1, ListID: 1, Title: tt.storedAs}, nil)
+			}
+
+			_, err := svc.CreateCard(model.CardInputCreate{ListID: 1, Title: tt.inputTitle})
+
+			if tt.storedAs == "" {
+				require.ErrorIs(t, err, model.ErrInvalidInput)
+			} else {
+				require.NoError(t, err)
+			}
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
 func TestGetCard(t *testing.T) {
 	tests := []struct {
 		title         string
@@ -91,10 +130,11 @@ func TestGetCard(t *testing.T) {
 		t.Run(tt.title, func(t *testing.T) {
 			mockStorage := new(MockCardService)
 			logger := zap.NewNop()
-			cardService := NewCardService(mockStorage, logger)
+			cardService := NewCardService(mockStorage, nil, nil, logger)
 			listID := tt.listID
-			mockStorage.On("GetCards", &listID).Return(tt.mockResult, tt.mockError)
-			lists, err := cardService.GetCards(&listID)
+			filter := model.CardFilter{ListID: &listID}
+			mockStorage.On("GetCards", filter).Return(tt.mockResult, tt.mockError)
+			lists, err := cardService.GetCards(filter)
 			if tt.expectedError {
 				require.Error(t, err)
 			} else {
@@ -144,50 +184,131 @@ func TestDeleteCard(t *testing.T) {
 	}
 }
 func TestUpdateCard(t *testing.T) {
+	current := model.Card{ID: 1, BoardID: 1, ListID: 10, Title: "Card"}
 	tests := []struct {
 		title         string
 		updated       model.Card
+		setup         func(m *MockTx)
 		mockReturn    model.Card
 		mockError     error
-		expectedError bool
+		expectUpdate  bool
+		expectedError error
 	}{
 		{
-			title: "success",
-			updated: model.Card{
-				ID:    1,
-				Title: "Update Card",
+			title:        "success",
+			updated:      model.Card{ID: 1, ListID: 10, Title: "Update Card"},
+			mockReturn:   model.Card{ID: 1, ListID: 10, Title: "Update Card"},
+			expectUpdate: true,
+		},
+		{
+			title:   "move within the board",
+			updated: model.Card{ID: 1, ListID: 11, Title: "Card"},
+			setup: func(m *MockTx) {
+				m.On("GetList", 11).Return(model.List{ID: 11, BoardID: 1}, nil)
 			},
-			mockReturn: model.Card{
-				ID:    1,
-				Title: "Update Card",
+			mockReturn:   model.Card{ID: 1, BoardID: 1, ListID: 11, Title: "Card"},
+			expectUpdate: true,
+		},
+		{
+			title:   "list on another board",
+			updated: model.Card{ID: 1, ListID: 20, Title: "Card"},
+			setup: func(m *MockTx) {
+				m.On("GetList", 20).Return(model.List{ID: 20, BoardID: 2}, nil)
 			},
-			mockError:     nil,
-			expectedError: false,
+			expectedError: model.ErrInvalidInput,
 		},
 		{
-			title: "error, storage returns error",
-			updated: model.Card{
-				ID:    666,
-				Title: "Doesn't matter",
+			title:   "missing list",
+			updated: model.Card{ID: 1, ListID: 99, Title: "Card"},
+			setup: func(m *MockTx) {
+				m.On("GetList", 99).Return(model.List{}, model.ErrNotFound)
 			},
-			mockReturn:    model.Card{},
+			expectedError: model.ErrInvalidInput,
+		},
+		{
+			title:         "error, storage returns error",
+			updated:       model.Card{ID: 1, ListID: 10, Title: "Doesn't matter"},
 			mockError:     errors.New("failed to update card"),
-			expectedError: true,
+			expectUpdate:  true,
+			expectedError: errors.New("failed to update card"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.title, func(t *testing.T) {
-			mockStorage := new(MockCardService)
-			service := CardService{Storage: mockStorage}
-			mockStorage.On("UpdateCard", tt.updated).Return(tt.mockReturn, tt.mockError)
+			m := new(MockTx)
+			service := CardService{Storage: m, Tx: m}
+			m.On("InTx").Return(nil)
+			m.On("GetCard", 1).Return(current, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			if tt.expectUpdate {
+				m.On("UpdateCard", tt.updated).Return(tt.mockReturn, tt.mockError)
+			}
 			result, err := service.UpdateCard(tt.updated)
-			if tt.expectedError {
-				require.Error(t, err)
+			if tt.expectedError != nil {
+				require.ErrorContains(t, err, tt.expectedError.Error())
+				if errors.Is(tt.expectedError, model.ErrInvalidInput) {
+					require.ErrorIs(t, err, model.ErrInvalidInput)
+				}
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tt.mockReturn, result)
 			}
-			mockStorage.AssertExpectations(t)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReturnCardWIPLimit(t *testing.T) {
+	archivedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	limit := func(soft bool, count int) model.List {
+		n := 2
+		return model.List{ID: 10, BoardID: 1, WIPLimit: &n, WIPSoft: soft, CardCount: count}
+	}
+	tests := []struct {
+		name         string
+		restore      bool
+		current      model.Card
+		list         model.List
+		expectError  error
+		wantWarnings []string
+	}{
+		{name: "unarchive below limit", current: model.Card{ID: 1, ListID: 10, ArchivedAt: &archivedAt}, list: limit(false, 2)},
+		{name: "unarchive into full list", current: model.Card{ID: 1, ListID: 10, ArchivedAt: &archivedAt}, list: limit(false, 3), expectError: model.ErrConflict},
+		{name: "unarchive over soft limit", current: model.Card{ID: 1, ListID: 10, ArchivedAt: &archivedAt}, list: limit(true, 3), wantWarnings: []string{"list 10 is over its WIP limit of 2"}},
+		{name: "unarchive card that is not archived", current: model.Card{ID: 1, ListID: 10}},
+		{name: "restore below limit", restore: true, list: limit(false, 2)},
+		{name: "restore into full list", restore: true, list: limit(false, 3), expectError: model.ErrConflict},
+		{name: "restore over soft limit", restore: true, list: limit(true, 3), wantWarnings: []string{"list 10 is over its WIP limit of 2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			m.On("InTx").Return(nil)
+			returned := model.Card{ID: 1, BoardID: 1, ListID: 10}
+			if tt.list.WIPLimit != nil {
+				m.On("LockList", 10).Return(tt.list, nil)
+			}
+			svc := CardService{Storage: m, Tx: m}
+
+			var got model.Card
+			var err error
+			if tt.restore {
+				m.On("RestoreCard", 1).Return(returned, nil)
+				got, err = svc.RestoreCard(1)
+			} else {
+				m.On("GetCard", 1).Return(tt.current, nil)
+				m.On("UnarchiveCard", 1).Return(returned, nil)
+				got, err = svc.UnarchiveCard(1)
+			}
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantWarnings, got.Warnings)
+			}
+			m.AssertExpectations(t)
 		})
 	}
 }
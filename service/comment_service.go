@@ -0,0 +1,94 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxCommentLength = 10000
+
+type CommentService struct {
+	Storage CommentStorage
+	Cards   CardStorage
+	Events  EventPublisher
+	// Mentions, если задан, дополняет комментарии найденными упоминаниями.
+	Mentions MentionReader
+	logger   *zap.Logger
+}
+
+func NewCommentService(storage CommentStorage, cards CardStorage, events EventPublisher, logger *zap.Logger) *CommentService {
+	return &CommentService{
+		Storage: storage,
+		Cards:   cards,
+		Events:  events,
+		logger:  logger,
+	}
+}
+
+func (s CommentService) GetComments(cardID int) ([]model.Comment, error) {
+	if _, err := s.Cards.GetCard(cardID); err != nil {
+		return nil, err
+	}
+	comments, err := s.Storage.GetComments(cardID)
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := s.mentions(cardID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comments {
+		comments[i].Mentions = commentMentions(mentions, comments[i].ID)
+	}
+	return comments, nil
+}
+
+// AddComment добавляет комментарий от имени author. Упоминания в тексте
+// разбирает подписчик события comment_added.
+func (s CommentService) AddComment(cardID int, author, text string) (model.Comment, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return model.Comment{}, fmt.Errorf("%w: text is required", model.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(text) > maxCommentLength {
+		return model.Comment{}, fmt.Errorf("%w: text is longer than %d characters", model.ErrInvalidInput, maxCommentLength)
+	}
+	card, err := s.Cards.GetCard(cardID)
+	if err != nil {
+		return model.Comment{}, err
+	}
+	comment, err := s.Storage.CreateComment(model.CommentInputCreate{CardID: card.ID, Author: author, Text: text})
+	if err != nil {
+		return model.Comment{}, err
+	}
+	if s.Events != nil {
+		s.Events.Publish(model.Event{Type: model.EventCommentAdded, Card: card, Comment: &comment, Actor: author})
+	}
+	mentions, err := s.mentions(cardID)
+	if err != nil {
+		s.logger.Error("Не удалось прочитать упоминания комментария", zap.Error(err), zap.Int("commentID", comment.ID))
+		return comment, nil
+	}
+	comment.Mentions = commentMentions(mentions, comment.ID)
+	return comment, nil
+}
+
+func (s CommentService) mentions(cardID int) ([]model.Mention, error) {
+	if s.Mentions == nil {
+		return nil, nil
+	}
+	return s.Mentions.GetMentions(cardID)
+}
+
+func commentMentions(mentions []model.Mention, commentID int) []model.Mention {
+	var result []model.Mention
+	for _, m := range mentions {
+		if m.CommentID != nil && *m.CommentID == commentID {
+			result = append(result, m)
+		}
+	}
+	return result
+}
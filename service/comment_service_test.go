@@ -0,0 +1,63 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+func TestAddComment(t *testing.T) {
+	card := model.Card{ID: 1, BoardID: 3, ListID: 10, Title: "c"}
+	tests := []struct {
+		name        string
+		text        string
+		setup       func(m *MockTx)
+		expectError error
+	}{
+		{
+			name: "success",
+			text: "  посмотри, @anna ",
+			setup: func(m *MockTx) {
+				m.On("GetCard", 1).Return(card, nil)
+				m.On("CreateComment", model.CommentInputCreate{CardID: 1, Author: "boris", Text: "посмотри, @anna"}).
+					Return(model.Comment{ID: 7, CardID: 1, Author: "boris", Text: "посмотри, @anna"}, nil)
+			},
+		},
+		{name: "empty text", text: " ", expectError: model.ErrInvalidInput},
+		{name: "too long", text: strings.Repeat("a", maxCommentLength+1), expectError: model.ErrInvalidInput},
+		{
+			name: "missing card",
+			text: "hi",
+			setup: func(m *MockTx) {
+				m.On("GetCard", 1).Return(model.Card{}, fmt.Errorf("card 1: %w", model.ErrNotFound))
+			},
+			expectError: model.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			events := &eventRecorder{}
+			s := NewCommentService(m, m, events, zap.NewNop())
+
+			comment, err := s.AddComment(1, "boris", tt.text)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+				require.Empty(t, events.events)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 7, comment.ID)
+			require.Equal(t, []string{model.EventCommentAdded}, events.types())
+			require.Equal(t, card, events.events[0].Card)
+			require.Equal(t, &comment, events.events[0].Comment)
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -1,20 +1,152 @@
 package service
 
-import "awesomeProject2/cmd/model"
+import (
+	"awesomeProject2/cmd/model"
+	"time"
+)
 
 type BoardStorage interface {
 	GetBoards() ([]model.Board, error)
+	GetBoard(id int) (model.Board, error)
 	CreateBoard(title string) (model.Board, error)
+	GetTemplates() ([]model.Board, error)
+	SetBoardTemplate(id int, isTemplate bool) (model.Board, error)
 }
 
 type ListStorage interface {
 	GetLists(boardID *int) ([]model.List, error)
+	GetList(id int) (model.List, error)
 	CreateList(input model.ListInputCreate) (model.List, error)
+	SetListWIPLimit(id int, limit model.ListWIPLimit) (model.List, error)
+}
+
+// ListLocker блокирует список до конца транзакции и возвращает его с
+// числом карточек, посчитанным уже после блокировки.
+type ListLocker interface {
+	LockList(id int) (model.List, error)
 }
 
 type CardStorage interface {
-	GetCards(boardID *int) ([]model.Card, error)
+	GetCards(filter model.CardFilter) ([]model.Card, error)
+	GetCard(id int) (model.Card, error)
 	CreateCard(input model.CardInputCreate) (model.Card, error)
 	DeleteCard(listID int, cardID int) (model.Card, error)
 	UpdateCard(updated model.Card) (model.Card, error)
+	ArchiveCard(id int) (model.Card, error)
+	UnarchiveCard(id int) (model.Card, error)
+	RestoreCard(id int) (model.Card, error)
+	GetDeletedCards(boardID int) ([]model.Card, error)
+	SetCardStatus(id int, status string) (model.Card, error)
+	SetCardDue(id int, due *time.Time) (model.Card, error)
+}
+
+type TrashStorage interface {
+	PurgeDeletedCards(before time.Time) (int, error)
+}
+
+type LabelStorage interface {
+	GetLabels(boardID int) ([]model.Label, error)
+	CreateLabel(input model.LabelInputCreate) (model.Label, error)
+	GetCardLabels(cardID int) ([]model.Label, error)
+	AddCardLabel(cardID int, labelID int) error
+	RemoveCardLabel(cardID int, labelID int) error
+}
+
+type AssigneeStorage interface {
+	GetCardAssignees(cardID int) ([]string, error)
+	AddCardAssignee(cardID int, username string) error
+}
+
+type ChecklistStorage interface {
+	GetChecklists(cardID int) ([]model.Checklist, error)
+	CreateChecklist(input model.ChecklistInputCreate) (model.Checklist, error)
+	CreateChecklistItem(input model.ChecklistItemInputCreate) (model.ChecklistItem, error)
+}
+
+type CommentStorage interface {
+	GetComments(cardID int) ([]model.Comment, error)
+	CreateComment(input model.CommentInputCreate) (model.Comment, error)
+}
+
+type MemberStorage interface {
+	GetBoardMembers(boardID int) ([]model.BoardMember, error)
+	AddBoardMember(boardID int, username string) (model.BoardMember, error)
+	RemoveBoardMember(boardID int, username string) error
+}
+
+// CardLinkStorage хранит связи между карточками.
+type CardLinkStorage interface {
+	GetCardLinks(cardID int) ([]model.CardLink, error)
+	CreateCardLink(link model.CardLink) (model.CardLink, error)
+	DeleteCardLink(id int) error
+}
+
+// FieldStorage хранит пользовательские поля досок и их значения на
+// карточках.
+type FieldStorage interface {
+	GetFields(boardID int) ([]model.CustomField, error)
+	GetField(id int) (model.CustomField, error)
+	CreateField(input model.CustomFieldInputCreate) (model.CustomField, error)
+	UpdateField(id int, update model.CustomFieldUpdate) (model.CustomField, error)
+	DeleteField(id int) error
+	GetCardFieldValues(cardID int) ([]model.CardFieldValue, error)
+	SetCardFieldValue(v model.CardFieldValue) (model.CardFieldValue, error)
+	DeleteCardFieldValue(cardID, fieldID int) error
+}
+
+// LaneStorage — дорожки доски: в явные дорожки кладут карточки при
+// переносе, а копия доски получает те же дорожки.
+type LaneStorage interface {
+	GetSwimlanes(boardID int) (model.Swimlanes, error)
+	SetSwimlanes(swimlanes model.Swimlanes) (model.Swimlanes, error)
+	GetLanes(boardID int) ([]model.Lane, error)
+	GetLane(id int) (model.Lane, error)
+	CreateLane(input model.LaneInputCreate) (model.Lane, error)
+	GetCardLanes(boardID int) ([]model.CardLane, error)
+	SetCardLane(cardID int, laneID *int) error
+}
+
+// SprintStorage — спринты доски: их запуск и завершение идут в одной
+// транзакции с переносом карточек, а карточка, ушедшая на другую доску,
+// выходит из спринта.
+type SprintStorage interface {
+	GetSprints(boardID int) ([]model.Sprint, error)
+	GetSprint(id int) (model.Sprint, error)
+	SetSprintStatus(id int, status string) (model.Sprint, error)
+	GetSprintCards(sprintID int) ([]model.SprintCard, error)
+	SetCardSprint(cardID int, sprintID *int) error
+}
+
+// MentionReader возвращает упоминания в описании карточки и её
+// комментариях.
+type MentionReader interface {
+	GetMentions(cardID int) ([]model.Mention, error)
+}
+
+// EventPublisher получает доменные события после того, как изменение
+// сохранено. Ошибки обработки остаются на стороне подписчика.
+type EventPublisher interface {
+	Publish(event model.Event)
+}
+
+// Tx — хранилища, видимые внутри транзакции.
+type Tx interface {
+	BoardStorage
+	ListStorage
+	ListLocker
+	CardStorage
+	LabelStorage
+	AssigneeStorage
+	ChecklistStorage
+	CommentStorage
+	CardLinkStorage
+	FieldStorage
+	LaneStorage
+	SprintStorage
+}
+
+// Transactor выполняет fn в одной транзакции: если fn вернула ошибку,
+// ни одно изменение, сделанное через tx, не сохраняется.
+type Transactor interface {
+	InTx(fn func(tx Tx) error) error
 }
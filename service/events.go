@@ -0,0 +1,26 @@
+package service
+
+import "awesomeProject2/cmd/model"
+
+// Dispatcher раздаёт каждое событие всем подписчикам по порядку.
+type Dispatcher struct {
+	subscribers []EventPublisher
+}
+
+var _ EventPublisher = (*Dispatcher)(nil)
+
+func NewDispatcher(subscribers ...EventPublisher) *Dispatcher {
+	return &Dispatcher{subscribers: subscribers}
+}
+
+// Subscribe добавляет подписчика. Вызывается при сборке приложения, до
+// первой публикации.
+func (d *Dispatcher) Subscribe(p EventPublisher) {
+	d.subscribers = append(d.subscribers, p)
+}
+
+func (d *Dispatcher) Publish(event model.Event) {
+	for _, p := range d.subscribers {
+		p.Publish(event)
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDispatcher(t *testing.T) {
+	first, second := &eventRecorder{}, &eventRecorder{}
+	d := NewDispatcher(first)
+	d.Subscribe(second)
+
+	d.Publish(model.Event{Type: model.EventCardCreated})
+	d.Publish(model.Event{Type: model.EventCardMoved})
+
+	require.Equal(t, []string{model.EventCardCreated, model.EventCardMoved}, first.types())
+	require.Equal(t, first.types(), second.types())
+}
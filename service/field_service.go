@@ -0,0 +1,104 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxFieldNameLength   = 100
+	maxFieldOptionLength = 100
+	maxFieldOptions      = 50
+)
+
+// FieldService управляет пользовательскими полями доски. Значения полей
+// на карточках задаются через CardService.SetFieldValue.
+type FieldService struct {
+	Storage FieldStorage
+	Boards  BoardStorage
+}
+
+func NewFieldService(storage FieldStorage, boards BoardStorage) *FieldService {
+	return &FieldService{Storage: storage, Boards: boards}
+}
+
+func (s FieldService) GetFields(boardID int) ([]model.CustomField, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetFields(boardID)
+}
+
+func (s FieldService) CreateField(input model.CustomFieldInputCreate) (model.CustomField, error) {
+	if !slices.Contains(model.FieldTypes, input.Type) {
+		return model.CustomField{}, fmt.Errorf("%w: field type must be one of %s", model.ErrInvalidInput, strings.Join(model.FieldTypes, ", "))
+	}
+	var err error
+	if input.Name, input.Options, err = s.validate(input.BoardID, 0, input.Name, input.Type, input.Options); err != nil {
+		return model.CustomField{}, err
+	}
+	return s.Storage.CreateField(input)
+}
+
+// UpdateField меняет название и варианты поля. Значения с удалёнными
+// вариантами остаются на карточках, пока их не изменят.
+func (s FieldService) UpdateField(id int, update model.CustomFieldUpdate) (model.CustomField, error) {
+	field, err := s.Storage.GetField(id)
+	if err != nil {
+		return model.CustomField{}, err
+	}
+	if update.Name, update.Options, err = s.validate(field.BoardID, field.ID, update.Name, field.Type, update.Options); err != nil {
+		return model.CustomField{}, err
+	}
+	return s.Storage.UpdateField(id, update)
+}
+
+func (s FieldService) DeleteField(id int) error {
+	return s.Storage.DeleteField(id)
+}
+
+// validate проверяет название (уникальное на доске без учёта регистра) и
+// варианты поля и возвращает их без лишних пробелов.
+func (s FieldService) validate(boardID, fieldID int, name, fieldType string, options []string) (string, []string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, fmt.Errorf("%w: field name is required", model.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(name) > maxFieldNameLength {
+		return "", nil, fmt.Errorf("%w: field name is longer than %d characters", model.ErrInvalidInput, maxFieldNameLength)
+	}
+	fields, err := s.GetFields(boardID)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, f := range fields {
+		if f.ID != fieldID && strings.EqualFold(f.Name, name) {
+			return "", nil, fmt.Errorf("%w: field %q already exists on board %d", model.ErrInvalidInput, name, boardID)
+		}
+	}
+
+	if fieldType != model.FieldDropdown {
+		if len(options) > 0 {
+			return "", nil, fmt.Errorf("%w: only dropdown fields have options", model.ErrInvalidInput)
+		}
+		return name, nil, nil
+	}
+	if len(options) == 0 || len(options) > maxFieldOptions {
+		return "", nil, fmt.Errorf("%w: dropdown needs 1 to %d options", model.ErrInvalidInput, maxFieldOptions)
+	}
+	cleaned := make([]string, 0, len(options))
+	for _, o := range options {
+		o = strings.TrimSpace(o)
+		if o == "" || utf8.RuneCountInString(o) > maxFieldOptionLength {
+			return "", nil, fmt.Errorf("%w: option must be 1 to %d characters", model.ErrInvalidInput, maxFieldOptionLength)
+		}
+		if slices.Contains(cleaned, o) {
+			return "", nil, fmt.Errorf("%w: duplicate option %q", model.ErrInvalidInput, o)
+		}
+		cleaned = append(cleaned, o)
+	}
+	return name, cleaned, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCreateField(t *testing.T) {
+	existing := []model.CustomField{{ID: 1, BoardID: 1, Name: "Points", Type: model.FieldNumber}}
+	tests := []struct {
+		name        string
+		input       model.CustomFieldInputCreate
+		want        model.CustomFieldInputCreate
+		expectError error
+	}{
+		{
+			name:  "text",
+			input: model.CustomFieldInputCreate{BoardID: 1, Name: "  Customer ", Type: model.FieldText},
+			want:  model.CustomFieldInputCreate{BoardID: 1, Name: "Customer", Type: model.FieldText},
+		},
+		{
+			name:  "dropdown options are trimmed",
+			input: model.CustomFieldInputCreate{BoardID: 1, Name: "Priority", Type: model.FieldDropdown, Options: []string{" low", "high "}},
+			want:  model.CustomFieldInputCreate{BoardID: 1, Name: "Priority", Type: model.FieldDropdown, Options: []string{"low", "high"}},
+		},
+		{
+			name:        "unknown type",
+			input:       model.CustomFieldInputCreate{BoardID: 1, Name: "x", Type: "money"},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "empty name",
+			input:       model.CustomFieldInputCreate{BoardID: 1, Name: "  ", Type: model.FieldText},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "long name",
+			input:       model.CustomFieldInputCreate{BoardID: 1, Name: strings.Repeat("x", 101), Type: model.FieldText},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "duplicate name",
+			input:       model.CustomFieldInputCreate{BoardID: 1, Name: "points", Type: model.FieldText},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "options on text field",
+			input:       model.CustomFieldInputCreate{BoardID: 1, Name: "x", Type: model.FieldText, Options: []string{"a"}},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "dropdown without options",
+			input:       model.CustomFieldInputCreate{BoardID: 1, Name: "x", Type: model.FieldDropdown},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "duplicate option",
+			input:       model.CustomFieldInputCreate{BoardID: 1, Name: "x", Type: model.FieldDropdown, Options: []string{"a", " a"}},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "empty option",
+			input:       model.CustomFieldInputCreate{BoardID: 1, Name: "x", Type: model.FieldDropdown, Options: []string{"a", ""}},
+			expectError: model.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			m.On("GetBoard", 1).Return(model.Board{ID: 1}, nil).Maybe()
+			m.On("GetFields", 1).Return(existing, nil).Maybe()
+			if tt.expectError == nil {
+				m.On("CreateField", tt.want).Return(model.CustomField{ID: 2, Name: tt.want.Name}, nil)
+			}
+			svc := NewFieldService(m, m)
+
+			_, err := svc.CreateField(tt.input)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateField(t *testing.T) {
+	field := model.CustomField{ID: 2, BoardID: 1, Name: "Priority", Type: model.FieldDropdown, Options: []string{"low"}}
+	m := new(MockTx)
+	m.On("GetField", 2).Return(field, nil)
+	m.On("GetBoard", 1).Return(model.Board{ID: 1}, nil)
+	m.On("GetFields", 1).Return([]model.CustomField{field}, nil)
+	update := model.CustomFieldUpdate{Name: "priority", Options: []string{"low", "high"}}
+	m.On("UpdateField", 2, update).Return(field, nil)
+	svc := NewFieldService(m, m)
+
+	_, err := svc.UpdateField(2, update)
+	require.NoError(t, err, "renaming to own name in another case")
+	_, err = svc.UpdateField(2, model.CustomFieldUpdate{Name: "Priority"})
+	require.ErrorIs(t, err, model.ErrInvalidInput, "dropdown keeps options")
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+)
+
+// LinkService управляет связями между карточками. Блокировки и
+// вложенность не могут образовывать циклов, а у карточки может быть
+// только одна родительская.
+type LinkService struct {
+	Storage CardLinkStorage
+	Cards   CardStorage
+	Tx      Transactor
+}
+
+func NewLinkService(storage CardLinkStorage, cards CardStorage, tx Transactor) *LinkService {
+	return &LinkService{Storage: storage, Cards: cards, Tx: tx}
+}
+
+func (s LinkService) GetLinks(cardID int) ([]model.CardLink, error) {
+	if _, err := s.Cards.GetCard(cardID); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetCardLinks(cardID)
+}
+
+// AddLink связывает карточку cardID с input.CardID. Обратный тип
+// сохраняется как прямой с переставленными карточками.
+func (s LinkService) AddLink(cardID int, input model.CardLinkInput) (model.CardLink, error) {
+	link := model.CardLink{SourceID: cardID, TargetID: input.CardID, Type: input.Type}
+	if !slices.Contains(model.LinkTypes, link.Type) {
+		inverse, ok := model.InverseLinkType(link.Type)
+		if !ok {
+			return model.CardLink{}, fmt.Errorf("%w: unknown link type %q", model.ErrInvalidInput, input.Type)
+		}
+		link = model.CardLink{SourceID: input.CardID, TargetID: cardID, Type: inverse}
+	}
+	if link.SourceID == link.TargetID {
+		return model.CardLink{}, fmt.Errorf("%w: card cannot be linked to itself", model.ErrInvalidInput)
+	}
+	var created model.CardLink
+	err := s.Tx.InTx(func(tx Tx) error {
+		for _, id := range []int{link.SourceID, link.TargetID} {
+			if _, err := tx.GetCard(id); err != nil {
+				return err
+			}
+		}
+		links, err := tx.GetCardLinks(link.TargetID)
+		if err != nil {
+			return err
+		}
+		for _, l := range links {
+			// relates_to симметрична: обратная связь уже есть.
+			if l.Type == model.LinkRelatesTo && link.Type == model.LinkRelatesTo && l.SourceID == link.TargetID && l.TargetID == link.SourceID {
+				created = l
+				return nil
+			}
+			if link.Type == model.LinkParentOf && l.Type == model.LinkParentOf && l.TargetID == link.TargetID && l.SourceID != link.SourceID {
+				return fmt.Errorf("%w: card %d already has parent %d", model.ErrInvalidInput, link.TargetID, l.SourceID)
+			}
+		}
+		if link.Type == model.LinkBlocks || link.Type == model.LinkParentOf {
+			cycle, err := reaches(tx, link.TargetID, link.SourceID, link.Type)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return fmt.Errorf("%w: card %d %s card %d would create a cycle", model.ErrInvalidInput, link.SourceID, link.Type, link.TargetID)
+			}
+		}
+		created, err = tx.CreateCardLink(link)
+		return err
+	})
+	if err != nil {
+		return model.CardLink{}, err
+	}
+	return created, nil
+}
+
+// RemoveLink удаляет связь, если карточка cardID в ней участвует.
+func (s LinkService) RemoveLink(cardID, linkID int) error {
+	links, err := s.Storage.GetCardLinks(cardID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(links, func(l model.CardLink) bool { return l.ID == linkID }) {
+		return fmt.Errorf("card link %d of card %d: %w", linkID, cardID, model.ErrNotFound)
+	}
+	return s.Storage.DeleteCardLink(linkID)
+}
+
+// reaches сообщает, можно ли дойти от from до to по связям linkType в их
+// направлении.
+func reaches(tx Tx, from, to int, linkType string) (bool, error) {
+	seen := map[int]bool{from: true}
+	queue := []int{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == to {
+			return true, nil
+		}
+		links, err := tx.GetCardLinks(id)
+		if err != nil {
+			return false, err
+		}
+		for _, l := range links {
+			if l.Type == linkType && l.SourceID == id && !seen[l.TargetID] {
+				seen[l.TargetID] = true
+				queue = append(queue, l.TargetID)
+			}
+		}
+	}
+	return false, nil
+}
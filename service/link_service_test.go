@@ -0,0 +1,115 @@
+package service
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAddLink(t *testing.T) {
+	link := func(source int, linkType string, target int) model.CardLink {
+		return model.CardLink{SourceID: source, TargetID: target, Type: linkType}
+	}
+	tests := []struct {
+		name        string
+		input       model.CardLinkInput
+		links       map[int][]model.CardLink
+		want        model.CardLink
+		expectError error
+	}{
+		{
+			name:  "blocks",
+			input: model.CardLinkInput{Type: model.LinkBlocks, CardID: 2},
+			links: map[int][]model.CardLink{2: nil},
+			want:  link(1, model.LinkBlocks, 2),
+		},
+		{
+			name:  "inverse type is stored from the other side",
+			input: model.CardLinkInput{Type: model.LinkBlockedBy, CardID: 2},
+			links: map[int][]model.CardLink{1: nil},
+			want:  link(2, model.LinkBlocks, 1),
+		},
+		{
+			name:  "child of",
+			input: model.CardLinkInput{Type: model.LinkChildOf, CardID: 2},
+			links: map[int][]model.CardLink{1: nil},
+			want:  link(2, model.LinkParentOf, 1),
+		},
+		{
+			name:  "relates to reuses the reverse link",
+			input: model.CardLinkInput{Type: model.LinkRelatesTo, CardID: 2},
+			links: map[int][]model.CardLink{2: {{ID: 7, SourceID: 2, TargetID: 1, Type: model.LinkRelatesTo}}},
+			want:  model.CardLink{ID: 7, SourceID: 2, TargetID: 1, Type: model.LinkRelatesTo},
+		},
+		{
+			name:  "blocking cycle",
+			input: model.CardLinkInput{Type: model.LinkBlocks, CardID: 2},
+			links: map[int][]model.CardLink{
+				2: {link(2, model.LinkBlocks, 3)},
+				3: {link(2, model.LinkBlocks, 3), link(3, model.LinkBlocks, 1)},
+			},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:  "parent chain cycle",
+			input: model.CardLinkInput{Type: model.LinkChildOf, CardID: 2},
+			links: map[int][]model.CardLink{
+				1: {link(1, model.LinkParentOf, 2)},
+			},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "second parent",
+			input:       model.CardLinkInput{Type: model.LinkParentOf, CardID: 2},
+			links:       map[int][]model.CardLink{2: {link(5, model.LinkParentOf, 2)}},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "unknown type",
+			input:       model.CardLinkInput{Type: "follows", CardID: 2},
+			expectError: model.ErrInvalidInput,
+		},
+		{
+			name:        "self link",
+			input:       model.CardLinkInput{Type: model.LinkRelatesTo, CardID: 1},
+			expectError: model.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockTx)
+			if tt.links != nil {
+				m.On("InTx").Return(nil)
+				m.On("GetCard", mock.Anything).Return(model.Card{}, nil)
+				for id, links := range tt.links {
+					m.On("GetCardLinks", id).Return(links, nil)
+				}
+			}
+			if tt.expectError == nil && tt.want.ID == 0 {
+				m.On("CreateCardLink", tt.want).Return(tt.want, nil)
+			}
+			svc := NewLinkService(m, m, m)
+
+			got, err := svc.AddLink(1, tt.input)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRemoveLink(t *testing.T) {
+	m := new(MockTx)
+	m.On("GetCardLinks", 1).Return([]model.CardLink{{ID: 3, SourceID: 1, TargetID: 2, Type: model.LinkBlocks}}, nil)
+	m.On("DeleteCardLink", 3).Return(nil)
+	svc := NewLinkService(m, m, m)
+
+	require.NoError(t, svc.RemoveLink(1, 3))
+	require.ErrorIs(t, svc.RemoveLink(1, 4), model.ErrNotFound, "link of another card")
+	m.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"awesomeProject2/cmd/model"
+	"fmt"
 	"go.uber.org/zap"
 )
 
@@ -20,5 +21,28 @@ func (s ListService) GetLists(ListID *int) ([]model.List, error) {
 	return s.Storage.GetLists(ListID)
 }
 func (s ListService) CreateList(input model.ListInputCreate) (model.List, error) {
+	if err := validateWIPLimit(input.WIPLimit); err != nil {
+		return model.List{}, err
+	}
 	return s.Storage.CreateList(input)
 }
+
+// SetWIPLimit задаёт или снимает лимит списка. Карточки, которые уже
+// превышают новый лимит, остаются на месте: лимит проверяется только при
+// добавлении карточек.
+func (s ListService) SetWIPLimit(id int, limit model.ListWIPLimit) (model.List, error) {
+	if err := validateWIPLimit(limit.Limit); err != nil {
+		return model.List{}, err
+	}
+	if limit.Limit == nil {
+		limit.Soft = false
+	}
+	return s.Storage.SetListWIPLimit(id, limit)
+}
+
+func validateWIPLimit(limit *int) error {
+	if limit != nil && *limit <= 0 {
+		return fmt.Errorf("%w: wip limit must be positive", model.ErrInvalidInput)
+	}
+	return nil
+}
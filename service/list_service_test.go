@@ -104,3 +104,34 @@ func TestGetList(t *testing.T) {
 		})
 	}
 }
+
+func TestSetWIPLimit(t *testing.T) {
+	three, zero := 3, 0
+	tests := []struct {
+		name   string
+		limit  model.ListWIPLimit
+		stored *model.ListWIPLimit
+	}{
+		{name: "hard limit", limit: model.ListWIPLimit{Limit: &three}, stored: &model.ListWIPLimit{Limit: &three}},
+		{name: "soft limit", limit: model.ListWIPLimit{Limit: &three, Soft: true}, stored: &model.ListWIPLimit{Limit: &three, Soft: true}},
+		{name: "cleared limit is not soft", limit: model.ListWIPLimit{Soft: true}, stored: &model.ListWIPLimit{}},
+		{name: "zero limit", limit: model.ListWIPLimit{Limit: &zero}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockListService)
+			svc := ListService{Storage: mockStorage}
+			if tt.stored != nil {
+				mockStorage.On("SetListWIPLimit", 1, *tt.stored).Return(model.List{ID: 1}, nil)
+			}
+
+			_, err := svc.SetWIPLimit(1, tt.limit)
+			if tt.stored == nil {
+				require.ErrorIs(t, err, model.ErrInvalidInput)
+			} else {
+				require.NoError(t, err)
+			}
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
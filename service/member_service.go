@@ -0,0 +1,33 @@
+package service
+
+import "awesomeProject2/cmd/model"
+
+// MemberService управляет участниками доски — теми, кого можно упомянуть
+// через @username.
+type MemberService struct {
+	Storage MemberStorage
+	Boards  BoardStorage
+}
+
+func NewMemberService(storage MemberStorage, boards BoardStorage) *MemberService {
+	return &MemberService{Storage: storage, Boards: boards}
+}
+
+func (s MemberService) GetMembers(boardID int) ([]model.BoardMember, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetBoardMembers(boardID)
+}
+
+func (s MemberService) AddMember(boardID int, username string) (model.BoardMember, error) {
+	if err := model.ValidateUsername(username); err != nil {
+		return model.BoardMember{}, err
+	}
+	return s.Storage.AddBoardMember(boardID, username)
+}
+
+// RemoveMember убирает участника; уже сделанные упоминания остаются.
+func (s MemberService) RemoveMember(boardID int, username string) error {
+	return s.Storage.RemoveBoardMember(boardID, username)
+}
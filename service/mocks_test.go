@@ -3,6 +3,7 @@ package service
 import (
 	"awesomeProject2/cmd/model"
 	"github.com/stretchr/testify/mock"
+	"time"
 )
 
 type MockBoardService struct {
@@ -23,20 +24,68 @@ func (m *MockBoardService) GetBoards() ([]model.Board, error) {
 	args := m.Called()
 	return args.Get(0).([]model.Board), args.Error(1)
 }
+func (m *MockBoardService) GetBoard(id int) (model.Board, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Board), args.Error(1)
+}
+func (m *MockBoardService) GetTemplates() ([]model.Board, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Board), args.Error(1)
+}
+func (m *MockBoardService) SetBoardTemplate(id int, isTemplate bool) (model.Board, error) {
+	args := m.Called(id, isTemplate)
+	return args.Get(0).(model.Board), args.Error(1)
+}
 func (m *MockListService) CreateList(input model.ListInputCreate) (model.List, error) {
 	args := m.Called(input)
 	return args.Get(0).(model.List), args.Error(1)
 }
+func (m *MockListService) GetList(id int) (model.List, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.List), args.Error(1)
+}
 func (m *MockListService) GetLists(BoardID *int) ([]model.List, error) {
 	args := m.Called(BoardID)
 	return args.Get(0).([]model.List), args.Error(1)
 }
+func (m *MockListService) SetListWIPLimit(id int, limit model.ListWIPLimit) (model.List, error) {
+	args := m.Called(id, limit)
+	return args.Get(0).(model.List), args.Error(1)
+}
 func (m *MockCardService) CreateCard(input model.CardInputCreate) (model.Card, error) {
 	args := m.Called(input)
 	return args.Get(0).(model.Card), args.Error(1)
 }
-func (m *MockCardService) GetCards(ListID *int) ([]model.Card, error) {
-	args := m.Called(ListID)
+func (m *MockCardService) GetCard(id int) (model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) ArchiveCard(id int) (model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) UnarchiveCard(id int) (model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) RestoreCard(id int) (model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) GetDeletedCards(boardID int) ([]model.Card, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.Card), args.Error(1)
+}
+func (m *MockCardService) SetCardStatus(id int, status string) (model.Card, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) SetCardDue(id int, due *time.Time) (model.Card, error) {
+	args := m.Called(id, due)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardService) GetCards(filter model.CardFilter) ([]model.Card, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]model.Card), args.Error(1)
 }
 func (m *MockCardService) DeleteCard(listID, cardID int) (model.Card, error) {
@@ -47,3 +96,222 @@ func (m *MockCardService) UpdateCard(updated model.Card) (model.Card, error) {
 	args := m.Called(updated)
 	return args.Get(0).(model.Card), args.Error(1)
 }
+func (m *MockCardService) GetCardLinks(cardID int) ([]model.CardLink, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]model.CardLink), args.Error(1)
+}
+func (m *MockCardService) CreateCardLink(link model.CardLink) (model.CardLink, error) {
+	args := m.Called(link)
+	return args.Get(0).(model.CardLink), args.Error(1)
+}
+func (m *MockCardService) DeleteCardLink(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *MockCardService) GetFields(boardID int) ([]model.CustomField, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.CustomField), args.Error(1)
+}
+func (m *MockCardService) GetField(id int) (model.CustomField, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.CustomField), args.Error(1)
+}
+func (m *MockCardService) CreateField(input model.CustomFieldInputCreate) (model.CustomField, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.CustomField), args.Error(1)
+}
+func (m *MockCardService) UpdateField(id int, update model.CustomFieldUpdate) (model.CustomField, error) {
+	args := m.Called(id, update)
+	return args.Get(0).(model.CustomField), args.Error(1)
+}
+func (m *MockCardService) DeleteField(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *MockCardService) GetCardFieldValues(cardID int) ([]model.CardFieldValue, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]model.CardFieldValue), args.Error(1)
+}
+func (m *MockCardService) SetCardFieldValue(v model.CardFieldValue) (model.CardFieldValue, error) {
+	args := m.Called(v)
+	return args.Get(0).(model.CardFieldValue), args.Error(1)
+}
+func (m *MockCardService) DeleteCardFieldValue(cardID, fieldID int) error {
+	args := m.Called(cardID, fieldID)
+	return args.Error(0)
+}
+
+// MockTx подменяет и транзакцию, и хранилище внутри неё.
+type MockTx struct {
+	MockCardService
+}
+
+func (m *MockTx) InTx(fn func(tx Tx) error) error {
+	args := m.Called()
+	if err := fn(m); err != nil {
+		return err
+	}
+	return args.Error(0)
+}
+func (m *MockTx) GetBoards() ([]model.Board, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Board), args.Error(1)
+}
+func (m *MockTx) GetBoard(id int) (model.Board, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Board), args.Error(1)
+}
+func (m *MockTx) CreateBoard(title string) (model.Board, error) {
+	args := m.Called(title)
+	return args.Get(0).(model.Board), args.Error(1)
+}
+func (m *MockTx) GetTemplates() ([]model.Board, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Board), args.Error(1)
+}
+func (m *MockTx) SetBoardTemplate(id int, isTemplate bool) (model.Board, error) {
+	args := m.Called(id, isTemplate)
+	return args.Get(0).(model.Board), args.Error(1)
+}
+func (m *MockTx) GetLists(boardID *int) ([]model.List, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.List), args.Error(1)
+}
+func (m *MockTx) CreateList(input model.ListInputCreate) (model.List, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.List), args.Error(1)
+}
+func (m *MockTx) GetLabels(boardID int) ([]model.Label, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.Label), args.Error(1)
+}
+func (m *MockTx) CreateLabel(input model.LabelInputCreate) (model.Label, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.Label), args.Error(1)
+}
+func (m *MockTx) GetCardLabels(cardID int) ([]model.Label, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]model.Label), args.Error(1)
+}
+func (m *MockTx) GetCardAssignees(cardID int) ([]string, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockTx) GetChecklists(cardID int) ([]model.Checklist, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]model.Checklist), args.Error(1)
+}
+func (m *MockTx) CreateChecklist(input model.ChecklistInputCreate) (model.Checklist, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.Checklist), args.Error(1)
+}
+func (m *MockTx) CreateChecklistItem(input model.ChecklistItemInputCreate) (model.ChecklistItem, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.ChecklistItem), args.Error(1)
+}
+func (m *MockTx) GetComments(cardID int) ([]model.Comment, error) {
+	args := m.Called(cardID)
+	return args.Get(0).([]model.Comment), args.Error(1)
+}
+func (m *MockTx) CreateComment(input model.CommentInputCreate) (model.Comment, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.Comment), args.Error(1)
+}
+func (m *MockTx) SetListWIPLimit(id int, limit model.ListWIPLimit) (model.List, error) {
+	args := m.Called(id, limit)
+	return args.Get(0).(model.List), args.Error(1)
+}
+func (m *MockTx) GetSwimlanes(boardID int) (model.Swimlanes, error) {
+	args := m.Called(boardID)
+	return args.Get(0).(model.Swimlanes), args.Error(1)
+}
+func (m *MockTx) SetSwimlanes(swimlanes model.Swimlanes) (model.Swimlanes, error) {
+	args := m.Called(swimlanes)
+	return args.Get(0).(model.Swimlanes), args.Error(1)
+}
+func (m *MockTx) GetLanes(boardID int) ([]model.Lane, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.Lane), args.Error(1)
+}
+func (m *MockTx) CreateLane(input model.LaneInputCreate) (model.Lane, error) {
+	args := m.Called(input)
+	return args.Get(0).(model.Lane), args.Error(1)
+}
+func (m *MockTx) GetCardLanes(boardID int) ([]model.CardLane, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.CardLane), args.Error(1)
+}
+func (m *MockTx) GetLane(id int) (model.Lane, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Lane), args.Error(1)
+}
+func (m *MockTx) SetCardLane(cardID int, laneID *int) error {
+	args := m.Called(cardID, laneID)
+	return args.Error(0)
+}
+func (m *MockTx) GetSprints(boardID int) ([]model.Sprint, error) {
+	args := m.Called(boardID)
+	return args.Get(0).([]model.Sprint), args.Error(1)
+}
+func (m *MockTx) GetSprint(id int) (model.Sprint, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Sprint), args.Error(1)
+}
+func (m *MockTx) SetSprintStatus(id int, status string) (model.Sprint, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(model.Sprint), args.Error(1)
+}
+func (m *MockTx) GetSprintCards(sprintID int) ([]model.SprintCard, error) {
+	args := m.Called(sprintID)
+	return args.Get(0).([]model.SprintCard), args.Error(1)
+}
+func (m *MockTx) SetCardSprint(cardID int, sprintID *int) error {
+	args := m.Called(cardID, sprintID)
+	return args.Error(0)
+}
+func (m *MockTx) GetList(id int) (model.List, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.List), args.Error(1)
+}
+func (m *MockTx) LockList(id int) (model.List, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.List), args.Error(1)
+}
+func (m *MockTx) AddCardLabel(cardID int, labelID int) error {
+	args := m.Called(cardID, labelID)
+	return args.Error(0)
+}
+func (m *MockTx) RemoveCardLabel(cardID int, labelID int) error {
+	args := m.Called(cardID, labelID)
+	return args.Error(0)
+}
+func (m *MockTx) AddCardAssignee(cardID int, username string) error {
+	args := m.Called(cardID, username)
+	return args.Error(0)
+}
+
+type MockTrashStorage struct {
+	mock.Mock
+}
+
+func (m *MockTrashStorage) PurgeDeletedCards(before time.Time) (int, error) {
+	args := m.Called(before)
+	return args.Int(0), args.Error(1)
+}
+
+// eventRecorder запоминает опубликованные события.
+type eventRecorder struct {
+	events []model.Event
+}
+
+func (r *eventRecorder) Publish(event model.Event) {
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) types() []string {
+	var types []string
+	for _, e := range r.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
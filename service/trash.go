@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// TrashPurger периодически окончательно удаляет карточки, пролежавшие в
+// корзине дольше Retention.
+type TrashPurger struct {
+	Storage   TrashStorage
+	Retention time.Duration
+	Interval  time.Duration
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+func NewTrashPurger(storage TrashStorage, retention, interval time.Duration, logger *zap.Logger) *TrashPurger {
+	return &TrashPurger{
+		Storage:   storage,
+		Retention: retention,
+		Interval:  interval,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+func (p *TrashPurger) PurgeOnce() (int, error) {
+	return p.Storage.PurgeDeletedCards(p.now().Add(-p.Retention))
+}
+
+// Run чистит корзину сразу и затем каждые Interval, пока не отменён ctx.
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		purged, err := p.PurgeOnce()
+		if err != nil {
+			p.logger.Error("Ошибка очистки корзины", zap.Error(err))
+		} else if purged > 0 {
+			p.logger.Info("Корзина очищена", zap.Int("purged", purged), zap.Duration("retention", p.Retention))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestTrashPurger_PurgeOnce(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		retention time.Duration
+		mockCount int
+		mockError error
+		wantCut   time.Time
+	}{
+		{
+			name:      "purges cards older than retention",
+			retention: 30 * 24 * time.Hour,
+			mockCount: 3,
+			wantCut:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "storage error",
+			retention: time.Hour,
+			mockError: errors.New("db is down"),
+			wantCut:   time.Date(2024, 3, 31, 11, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockTrashStorage)
+			mockStorage.On("PurgeDeletedCards", tt.wantCut).Return(tt.mockCount, tt.mockError)
+			purger := NewTrashPurger(mockStorage, tt.retention, time.Hour, zap.NewNop())
+			purger.now = func() time.Time { return now }
+
+			purged, err := purger.PurgeOnce()
+			if tt.mockError != nil {
+				require.ErrorIs(t, err, tt.mockError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.mockCount, purged)
+			}
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrashPurger_RunStopsOnCancel(t *testing.T) {
+	mockStorage := new(MockTrashStorage)
+	ctx, cancel := context.WithCancel(context.Background())
+	mockStorage.On("PurgeDeletedCards", mock.Anything).Return(0, nil).Run(func(mock.Arguments) { cancel() })
+	purger := NewTrashPurger(mockStorage, time.Hour, time.Hour, zap.NewNop())
+
+	done := make(chan struct{})
+	go func() {
+		purger.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after context cancellation")
+	}
+	mockStorage.AssertNumberOfCalls(t, "PurgeDeletedCards", 1)
+}
@@ -0,0 +1,22 @@
+package sprint
+
+import "awesomeProject2/cmd/model"
+
+type Storage interface {
+	GetSprints(boardID int) ([]model.Sprint, error)
+	GetSprint(id int) (model.Sprint, error)
+	CreateSprint(sprint model.Sprint) (model.Sprint, error)
+	UpdateSprint(sprint model.Sprint) (model.Sprint, error)
+	DeleteSprint(id int) error
+	GetSprintCards(sprintID int) ([]model.SprintCard, error)
+}
+
+type BoardReader interface {
+	GetBoard(id int) (model.Board, error)
+}
+
+// CardFinder — выборка карточек; в приложении это service.CardService,
+// который заполняет значения полей.
+type CardFinder interface {
+	GetCards(filter model.CardFilter) ([]model.Card, error)
+}
@@ -0,0 +1,266 @@
+package sprint
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxSprintNameLength = 100
+	maxSprintGoalLength = 1000
+)
+
+// Service ведёт спринты доски: состав, запуск и завершение с переносом
+// незавершённых карточек в следующий спринт.
+type Service struct {
+	Storage Storage
+	Boards  BoardReader
+	Cards   CardFinder
+	Tx      service.Transactor
+	// DoneLists — названия списков «готово», как у метрик: карточка в
+	// таком списке считается завершённой.
+	DoneLists []string
+}
+
+func NewService(storage Storage, boards BoardReader, cards CardFinder, tx service.Transactor) *Service {
+	return &Service{
+		Storage: storage,
+		Boards:  boards,
+		Cards:   cards,
+		Tx:      tx,
+	}
+}
+
+func (s Service) GetSprints(boardID int) ([]model.Sprint, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetSprints(boardID)
+}
+
+func (s Service) GetSprint(id int) (model.Sprint, error) {
+	return s.Storage.GetSprint(id)
+}
+
+// GetSprintCards возвращает карточки, которые сейчас в спринте, включая
+// архивные.
+func (s Service) GetSprintCards(id int) ([]model.Card, error) {
+	sprint, err := s.Storage.GetSprint(id)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.Storage.GetSprintCards(id)
+	if err != nil {
+		return nil, err
+	}
+	current := map[int]bool{}
+	for _, e := range entries {
+		if e.RemovedAt == nil {
+			current[e.CardID] = true
+		}
+	}
+	cards, err := s.Cards.GetCards(model.CardFilter{BoardID: &sprint.BoardID, IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(cards, func(c model.Card) bool { return !current[c.ID] }), nil
+}
+
+func (s Service) CreateSprint(input model.SprintInput) (model.Sprint, error) {
+	sprint, err := parseSprint(input)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	return s.Storage.CreateSprint(sprint)
+}
+
+// UpdateSprint меняет название, цель и даты спринта. Завершённый спринт
+// не меняется: по нему уже посчитан итог.
+func (s Service) UpdateSprint(id int, input model.SprintInput) (model.Sprint, error) {
+	sprint, err := parseSprint(input)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	stored, err := s.Storage.GetSprint(id)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	if stored.Status == model.SprintCompleted {
+		return model.Sprint{}, fmt.Errorf("%w: sprint %d is completed", model.ErrConflict, id)
+	}
+	sprint.ID = id
+	return s.Storage.UpdateSprint(sprint)
+}
+
+// DeleteSprint удаляет запланированный или завершённый спринт; его
+// карточки остаются на доске вне спринтов.
+func (s Service) DeleteSprint(id int) error {
+	sprint, err := s.Storage.GetSprint(id)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == model.SprintActive {
+		return fmt.Errorf("%w: sprint %d is active, complete it first", model.ErrConflict, id)
+	}
+	return s.Storage.DeleteSprint(id)
+}
+
+// StartSprint запускает запланированный спринт. На доске идёт не больше
+// одного спринта.
+func (s Service) StartSprint(id int) (model.Sprint, error) {
+	var started model.Sprint
+	err := s.Tx.InTx(func(tx service.Tx) error {
+		sprint, err := tx.GetSprint(id)
+		if err != nil {
+			return err
+		}
+		if sprint.Status != model.SprintPlanned {
+			return fmt.Errorf("%w: sprint %d is %s, only planned sprints can be started", model.ErrConflict, id, sprint.Status)
+		}
+		started, err = tx.SetSprintStatus(id, model.SprintActive)
+		return err
+	})
+	return started, err
+}
+
+// CompleteSprint завершает идущий спринт. Карточки в списках «готово»
+// и архивные остаются в нём, остальные переходят в спринт nextID, а при
+// нулевом nextID — в ближайший запланированный спринт доски; если его
+// нет, карточки выходят из спринтов.
+func (s Service) CompleteSprint(id, nextID int) (model.SprintCompletion, error) {
+	result := model.SprintCompletion{Done: []int{}, RolledOver: []int{}}
+	err := s.Tx.InTx(func(tx service.Tx) error {
+		sprint, err := tx.GetSprint(id)
+		if err != nil {
+			return err
+		}
+		if sprint.Status != model.SprintActive {
+			return fmt.Errorf("%w: sprint %d is %s, only active sprints can be completed", model.ErrConflict, id, sprint.Status)
+		}
+		if result.Next, err = nextSprint(tx, sprint, nextID); err != nil {
+			return err
+		}
+		lists, err := tx.GetLists(&sprint.BoardID)
+		if err != nil {
+			return err
+		}
+		done := service.DoneLists(lists, s.DoneLists)
+		entries, err := tx.GetSprintCards(id)
+		if err != nil {
+			return err
+		}
+		// Статус меняется до переноса карточек, чтобы они ушли из спринта
+		// не раньше его завершения и последний день диаграммы сгорания
+		// показал их как невыполненные.
+		if result.Sprint, err = tx.SetSprintStatus(id, model.SprintCompleted); err != nil {
+			return err
+		}
+		var to *int
+		if result.Next != nil {
+			to = &result.Next.ID
+		}
+		for _, e := range entries {
+			if e.RemovedAt != nil {
+				continue
+			}
+			card, err := tx.GetCard(e.CardID)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(done, card.ListID) {
+				result.Done = append(result.Done, card.ID)
+				continue
+			}
+			if card.ArchivedAt != nil {
+				continue
+			}
+			if err := tx.SetCardSprint(card.ID, to); err != nil {
+				return err
+			}
+			result.RolledOver = append(result.RolledOver, card.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return model.SprintCompletion{}, err
+	}
+	return result, nil
+}
+
+// SetCardSprint кладёт карточку в спринт её доски; нулевой sprintID
+// убирает её из спринтов. В завершённый спринт карточку не добавить.
+func (s Service) SetCardSprint(cardID, sprintID int) error {
+	return s.Tx.InTx(func(tx service.Tx) error {
+		card, err := tx.GetCard(cardID)
+		if err != nil {
+			return err
+		}
+		if sprintID == 0 {
+			return tx.SetCardSprint(card.ID, nil)
+		}
+		sprint, err := tx.GetSprint(sprintID)
+		if errors.Is(err, model.ErrNotFound) || err == nil && sprint.BoardID != card.BoardID {
+			return fmt.Errorf("%w: sprint %d is not on board %d", model.ErrInvalidInput, sprintID, card.BoardID)
+		}
+		if err != nil {
+			return err
+		}
+		if sprint.Status == model.SprintCompleted {
+			return fmt.Errorf("%w: sprint %d is completed", model.ErrInvalidInput, sprintID)
+		}
+		return tx.SetCardSprint(card.ID, &sprint.ID)
+	})
+}
+
+// nextSprint выбирает спринт, в который уйдут незавершённые карточки:
+// указанный явно или первый запланированный на доске.
+func nextSprint(tx service.Tx, sprint model.Sprint, nextID int) (*model.Sprint, error) {
+	if nextID != 0 {
+		next, err := tx.GetSprint(nextID)
+		if errors.Is(err, model.ErrNotFound) || err == nil && (next.BoardID != sprint.BoardID || next.Status != model.SprintPlanned) {
+			return nil, fmt.Errorf("%w: next sprint %d must be a planned sprint of board %d", model.ErrInvalidInput, nextID, sprint.BoardID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &next, nil
+	}
+	sprints, err := tx.GetSprints(sprint.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(sprints, func(s model.Sprint) bool { return s.Status == model.SprintPlanned })
+	if i < 0 {
+		return nil, nil
+	}
+	return &sprints[i], nil
+}
+
+func parseSprint(input model.SprintInput) (model.Sprint, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxSprintNameLength {
+		return model.Sprint{}, fmt.Errorf("%w: sprint name must be 1 to %d characters", model.ErrInvalidInput, maxSprintNameLength)
+	}
+	goal := strings.TrimSpace(input.Goal)
+	if utf8.RuneCountInString(goal) > maxSprintGoalLength {
+		return model.Sprint{}, fmt.Errorf("%w: sprint goal must be at most %d characters", model.ErrInvalidInput, maxSprintGoalLength)
+	}
+	start, err := time.Parse(model.FieldDateLayout, input.StartDate)
+	if err != nil {
+		return model.Sprint{}, fmt.Errorf("%w: start_date must be YYYY-MM-DD", model.ErrInvalidInput)
+	}
+	end, err := time.Parse(model.FieldDateLayout, input.EndDate)
+	if err != nil {
+		return model.Sprint{}, fmt.Errorf("%w: end_date must be YYYY-MM-DD", model.ErrInvalidInput)
+	}
+	if end.Before(start) {
+		return model.Sprint{}, fmt.Errorf("%w: end_date must not be before start_date", model.ErrInvalidInput)
+	}
+	return model.Sprint{BoardID: input.BoardID, Name: name, Goal: goal, StartDate: start, EndDate: end}, nil
+}
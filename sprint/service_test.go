@@ -0,0 +1,193 @@
+package sprint
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+// fixture — доска testutil.BoardFixture с двумя запланированными
+// спринтами; done — завершающий список.
+type fixture struct {
+	testutil.BoardFixture
+	svc           *Service
+	first, second model.Sprint
+}
+
+func newFixture(t *testing.T) fixture {
+	f := fixture{BoardFixture: testutil.NewBoardFixture(t)}
+	f.svc = NewService(f.Store, f.Store, service.NewCardService(f.Store, f.Store, nil, zap.NewNop()), f.Store)
+	f.svc.DoneLists = []string{"Done"}
+	var err error
+	f.second, err = f.svc.CreateSprint(model.SprintInput{BoardID: f.Board.ID, Name: "Sprint 2", StartDate: "2026-03-16", EndDate: "2026-03-27"})
+	require.NoError(t, err)
+	f.first, err = f.svc.CreateSprint(model.SprintInput{BoardID: f.Board.ID, Name: "Sprint 1", Goal: "Login", StartDate: "2026-03-02", EndDate: "2026-03-13"})
+	require.NoError(t, err)
+	return f
+}
+
+func cardIDs(cards []model.Card) []int {
+	ids := []int{}
+	for _, c := range cards {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func TestCreateSprint(t *testing.T) {
+	f := newFixture(t)
+	tests := []struct {
+		name    string
+		input   model.SprintInput
+		wantErr error
+	}{
+		{name: "valid", input: model.SprintInput{BoardID: f.Board.ID, Name: " Sprint 3 ", StartDate: "2026-03-30", EndDate: "2026-03-30"}},
+		{name: "empty name", input: model.SprintInput{BoardID: f.Board.ID, Name: " ", StartDate: "2026-03-30", EndDate: "2026-04-10"}, wantErr: model.ErrInvalidInput},
+		{name: "bad date", input: model.SprintInput{BoardID: f.Board.ID, Name: "s", StartDate: "30.03.2026", EndDate: "2026-04-10"}, wantErr: model.ErrInvalidInput},
+		{name: "end before start", input: model.SprintInput{BoardID: f.Board.ID, Name: "s", StartDate: "2026-04-10", EndDate: "2026-03-30"}, wantErr: model.ErrInvalidInput},
+		{name: "unknown board", input: model.SprintInput{BoardID: 99, Name: "s", StartDate: "2026-03-30", EndDate: "2026-04-10"}, wantErr: model.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.svc.CreateSprint(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "Sprint 3", got.Name)
+			require.Equal(t, model.SprintPlanned, got.Status)
+		})
+	}
+
+	sprints, err := f.svc.GetSprints(f.Board.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Sprint 1", "Sprint 2", "Sprint 3"}, []string{sprints[0].Name, sprints[1].Name, sprints[2].Name})
+}
+
+func TestStartSprint(t *testing.T) {
+	f := newFixture(t)
+	started, err := f.svc.StartSprint(f.first.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.SprintActive, started.Status)
+	require.NotNil(t, started.StartedAt)
+
+	_, err = f.svc.StartSprint(f.first.ID)
+	require.ErrorIs(t, err, model.ErrConflict)
+	_, err = f.svc.StartSprint(f.second.ID)
+	require.ErrorIs(t, err, model.ErrConflict)
+	require.ErrorIs(t, f.svc.DeleteSprint(f.first.ID), model.ErrConflict)
+	_, err = f.svc.StartSprint(99)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestSetCardSprint(t *testing.T) {
+	f := newFixture(t)
+	other, err := f.Store.CreateBoard("other")
+	require.NoError(t, err)
+	foreign, err := f.svc.CreateSprint(model.SprintInput{BoardID: other.ID, Name: "s", StartDate: "2026-03-02", EndDate: "2026-03-13"})
+	require.NoError(t, err)
+
+	require.NoError(t, f.svc.SetCardSprint(f.Login.ID, f.first.ID))
+	require.NoError(t, f.svc.SetCardSprint(f.Report.ID, f.first.ID))
+	require.NoError(t, f.svc.SetCardSprint(f.Report.ID, f.second.ID))
+	require.ErrorIs(t, f.svc.SetCardSprint(f.Login.ID, foreign.ID), model.ErrInvalidInput)
+	require.ErrorIs(t, f.svc.SetCardSprint(f.Login.ID, 99), model.ErrInvalidInput)
+	require.ErrorIs(t, f.svc.SetCardSprint(99, f.first.ID), model.ErrNotFound)
+
+	cards, err := f.svc.GetSprintCards(f.first.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int{f.Login.ID}, cardIDs(cards))
+	cards, err = f.svc.GetSprintCards(f.second.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int{f.Report.ID}, cardIDs(cards))
+
+	require.NoError(t, f.svc.SetCardSprint(f.Login.ID, 0))
+	cards, err = f.svc.GetSprintCards(f.first.ID)
+	require.NoError(t, err)
+	require.Empty(t, cards)
+}
+
+func TestCompleteSprint(t *testing.T) {
+	tests := []struct {
+		name         string
+		next         func(f fixture) int
+		wantErr      error
+		wantNext     func(f fixture) *int
+		wantInSecond func(f fixture) []int
+	}{
+		{
+			name:         "first planned sprint by default",
+			next:         func(f fixture) int { return 0 },
+			wantNext:     func(f fixture) *int { return &f.second.ID },
+			wantInSecond: func(f fixture) []int { return []int{f.Report.ID} },
+		},
+		{
+			name: "no planned sprints",
+			next: func(f fixture) int {
+				require.NoError(t, f.svc.DeleteSprint(f.second.ID))
+				return 0
+			},
+			wantNext: func(f fixture) *int { return nil },
+		},
+		{
+			name:    "next sprint must be planned",
+			next:    func(f fixture) int { return f.first.ID },
+			wantErr: model.ErrInvalidInput,
+		},
+		{
+			name:    "unknown next sprint",
+			next:    func(f fixture) int { return 99 },
+			wantErr: model.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			for _, c := range []model.Card{f.Login, f.Report, f.Cleanup} {
+				require.NoError(t, f.svc.SetCardSprint(c.ID, f.first.ID))
+			}
+			_, err := f.Store.ArchiveCard(f.Login.ID)
+			require.NoError(t, err)
+			_, err = f.svc.StartSprint(f.first.ID)
+			require.NoError(t, err)
+
+			got, err := f.svc.CompleteSprint(f.first.ID, tt.next(f))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				sprint, err := f.svc.GetSprint(f.first.ID)
+				require.NoError(t, err)
+				require.Equal(t, model.SprintActive, sprint.Status)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, model.SprintCompleted, got.Sprint.Status)
+			require.NotNil(t, got.Sprint.CompletedAt)
+			require.Equal(t, []int{f.Cleanup.ID}, got.Done)
+			require.Equal(t, []int{f.Report.ID}, got.RolledOver)
+			if want := tt.wantNext(f); want == nil {
+				require.Nil(t, got.Next)
+			} else {
+				require.Equal(t, *want, got.Next.ID)
+			}
+
+			// Архивная и завершённая карточки остаются в спринте.
+			cards, err := f.svc.GetSprintCards(f.first.ID)
+			require.NoError(t, err)
+			require.Equal(t, []int{f.Login.ID, f.Cleanup.ID}, cardIDs(cards))
+			if tt.wantInSecond != nil {
+				cards, err = f.svc.GetSprintCards(f.second.ID)
+				require.NoError(t, err)
+				require.Equal(t, tt.wantInSecond(f), cardIDs(cards))
+			}
+
+			_, err = f.svc.CompleteSprint(f.first.ID, 0)
+			require.ErrorIs(t, err, model.ErrConflict)
+			_, err = f.svc.UpdateSprint(f.first.ID, model.SprintInput{Name: "s", StartDate: "2026-03-02", EndDate: "2026-03-13"})
+			require.ErrorIs(t, err, model.ErrConflict)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS cards;
+DROP TABLE IF EXISTS lists;
+DROP TABLE IF EXISTS boards;
@@ -0,0 +1,25 @@
+CREATE TABLE boards(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    title      TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE lists(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    title      TEXT      NOT NULL,
+    board_id   INTEGER   NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE cards(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    title       TEXT      NOT NULL,
+    description TEXT      NOT NULL DEFAULT '',
+    status      TEXT      NOT NULL DEFAULT '',
+    board_id    INTEGER   NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    list_id     INTEGER   NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX lists_board_id_idx ON lists (board_id);
+CREATE INDEX cards_list_id_idx ON cards (list_id);
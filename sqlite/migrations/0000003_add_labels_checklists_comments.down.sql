@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS checklist_items;
+DROP TABLE IF EXISTS checklists;
+DROP TABLE IF EXISTS card_labels;
+DROP TABLE IF EXISTS labels;
@@ -0,0 +1,37 @@
+CREATE TABLE labels(
+    id       INTEGER PRIMARY KEY AUTOINCREMENT,
+    board_id INTEGER NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    name     TEXT    NOT NULL DEFAULT '',
+    color    TEXT    NOT NULL DEFAULT ''
+);
+
+CREATE TABLE card_labels(
+    card_id  INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    label_id INTEGER NOT NULL REFERENCES labels (id) ON DELETE CASCADE,
+    PRIMARY KEY (card_id, label_id)
+);
+
+CREATE TABLE checklists(
+    id      INTEGER PRIMARY KEY AUTOINCREMENT,
+    card_id INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    title   TEXT    NOT NULL
+);
+
+CREATE TABLE checklist_items(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    checklist_id INTEGER NOT NULL REFERENCES checklists (id) ON DELETE CASCADE,
+    text         TEXT    NOT NULL,
+    checked      BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE comments(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    card_id    INTEGER   NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    author     TEXT      NOT NULL DEFAULT '',
+    text       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX labels_board_id_idx ON labels (board_id);
+CREATE INDEX checklists_card_id_idx ON checklists (card_id);
+CREATE INDEX comments_card_id_idx ON comments (card_id);
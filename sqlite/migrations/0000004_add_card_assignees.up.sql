@@ -0,0 +1,7 @@
+CREATE TABLE card_assignees(
+    card_id  INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    username TEXT    NOT NULL,
+    PRIMARY KEY (card_id, username)
+);
+
+CREATE INDEX card_assignees_username_idx ON card_assignees (username);
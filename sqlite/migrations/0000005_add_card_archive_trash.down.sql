@@ -0,0 +1,4 @@
+DELETE FROM cards WHERE deleted_at IS NOT NULL;
+DROP INDEX cards_deleted_at_idx;
+ALTER TABLE cards DROP COLUMN archived_at;
+ALTER TABLE cards DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+ALTER TABLE cards ADD COLUMN archived_at TIMESTAMP;
+ALTER TABLE cards ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX cards_deleted_at_idx ON cards (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE boards ADD COLUMN is_template BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+DROP TABLE automation_runs;
+DROP TABLE automation_rules;
+
+DROP INDEX cards_due_at_idx;
+ALTER TABLE cards DROP COLUMN due_triggered;
+ALTER TABLE cards DROP COLUMN due_at;
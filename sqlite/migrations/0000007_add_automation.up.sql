@@ -0,0 +1,31 @@
+ALTER TABLE cards ADD COLUMN due_at TIMESTAMP;
+ALTER TABLE cards ADD COLUMN due_triggered BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX cards_due_at_idx ON cards (due_at) WHERE due_at IS NOT NULL AND NOT due_triggered;
+
+CREATE TABLE automation_rules(
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    board_id         INTEGER   NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    name             TEXT      NOT NULL,
+    enabled          BOOLEAN   NOT NULL DEFAULT TRUE,
+    trigger_type     TEXT      NOT NULL,
+    trigger_list_id  INTEGER   NOT NULL DEFAULT 0,
+    trigger_label_id INTEGER   NOT NULL DEFAULT 0,
+    conditions       TEXT      NOT NULL DEFAULT '[]',
+    actions          TEXT      NOT NULL DEFAULT '[]',
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE automation_runs(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    rule_id    INTEGER   NOT NULL REFERENCES automation_rules (id) ON DELETE CASCADE,
+    board_id   INTEGER   NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    card_id    INTEGER   NOT NULL,
+    event      TEXT      NOT NULL,
+    status     TEXT      NOT NULL,
+    error      TEXT      NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX automation_rules_board_id_idx ON automation_rules (board_id);
+CREATE INDEX automation_runs_board_id_idx ON automation_runs (board_id, id);
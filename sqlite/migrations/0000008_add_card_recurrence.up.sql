@@ -0,0 +1,13 @@
+CREATE TABLE card_recurrences(
+    card_id        INTEGER PRIMARY KEY REFERENCES cards (id) ON DELETE CASCADE,
+    list_id        INTEGER   NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    frequency      TEXT      NOT NULL,
+    interval_count INTEGER   NOT NULL DEFAULT 1,
+    cron           TEXT      NOT NULL DEFAULT '',
+    starts_at      TIMESTAMP NOT NULL,
+    next_run_at    TIMESTAMP NOT NULL,
+    last_run_at    TIMESTAMP,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX card_recurrences_next_run_at_idx ON card_recurrences (next_run_at);
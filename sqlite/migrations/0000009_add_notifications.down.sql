@@ -0,0 +1,4 @@
+DROP TABLE notification_preferences;
+DROP TABLE notifications;
+
+ALTER TABLE cards DROP COLUMN due_soon_triggered;
@@ -0,0 +1,5 @@
+DROP TABLE email_outbox;
+DROP TABLE email_digest_items;
+DROP TABLE email_settings;
+
+ALTER TABLE notification_preferences DROP COLUMN email;
@@ -0,0 +1,37 @@
+ALTER TABLE notification_preferences ADD COLUMN email BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE email_settings(
+    username       TEXT PRIMARY KEY,
+    address        TEXT    NOT NULL,
+    digest         BOOLEAN NOT NULL DEFAULT FALSE,
+    last_digest_at TIMESTAMP
+);
+
+CREATE TABLE email_digest_items(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    username   TEXT      NOT NULL,
+    kind       TEXT      NOT NULL,
+    event      TEXT      NOT NULL,
+    board_id   INTEGER   NOT NULL,
+    card_id    INTEGER   NOT NULL,
+    card_title TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX email_digest_items_username_idx ON email_digest_items (username, id);
+
+CREATE TABLE email_outbox(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    recipient       TEXT      NOT NULL,
+    subject         TEXT      NOT NULL,
+    text_body       TEXT      NOT NULL,
+    html_body       TEXT      NOT NULL,
+    status          TEXT      NOT NULL DEFAULT 'pending',
+    attempts        INTEGER   NOT NULL DEFAULT 0,
+    last_error      TEXT      NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at         TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX email_outbox_pending_idx ON email_outbox (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+DROP TABLE mentions;
+DROP TABLE board_members;
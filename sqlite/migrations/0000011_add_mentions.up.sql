@@ -0,0 +1,18 @@
+CREATE TABLE board_members(
+    board_id INTEGER   NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    username TEXT      NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (board_id, username)
+);
+
+CREATE TABLE mentions(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    card_id    INTEGER   NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    comment_id INTEGER REFERENCES comments (id) ON DELETE CASCADE,
+    username   TEXT      NOT NULL,
+    author     TEXT      NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX mentions_card_id_idx ON mentions (card_id);
+CREATE INDEX mentions_username_idx ON mentions (username);
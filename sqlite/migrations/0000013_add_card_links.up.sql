@@ -0,0 +1,11 @@
+CREATE TABLE card_links(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_id  INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    target_id  INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    type       TEXT    NOT NULL CHECK (type IN ('blocks', 'duplicates', 'relates_to', 'parent_of')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (source_id, target_id, type),
+    CHECK (source_id <> target_id)
+);
+
+CREATE INDEX card_links_target_idx ON card_links (target_id);
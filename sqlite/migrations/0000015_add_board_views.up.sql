@@ -0,0 +1,16 @@
+-- Сохранённые представления доски: запрос на языке фильтров, сортировка
+-- и группировка. shared делает представление видимым всем.
+CREATE TABLE board_views(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    board_id   INTEGER NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    owner      TEXT    NOT NULL,
+    name       TEXT    NOT NULL,
+    query      TEXT    NOT NULL DEFAULT '',
+    sort       TEXT    NOT NULL DEFAULT '',
+    sort_desc  BOOLEAN NOT NULL DEFAULT FALSE,
+    group_by   TEXT    NOT NULL DEFAULT '',
+    shared     BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (board_id, owner, name)
+);
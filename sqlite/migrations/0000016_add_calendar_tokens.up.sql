@@ -0,0 +1,7 @@
+-- Токены iCalendar-лент: у пользователя не больше одного, хранится
+-- SHA-256 токена.
+CREATE TABLE calendar_tokens(
+    username   TEXT PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1 @@
+DROP TABLE card_moves;
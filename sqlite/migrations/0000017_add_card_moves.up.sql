@@ -0,0 +1,11 @@
+-- История перемещений карточек между списками для метрик доски.
+-- Ссылки на списки не внешние ключи: история переживает удаление списка.
+CREATE TABLE card_moves(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    card_id      INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    from_list_id INTEGER,
+    to_list_id   INTEGER NOT NULL,
+    moved_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX card_moves_card_idx ON card_moves (card_id, moved_at);
@@ -0,0 +1,4 @@
+-- Лимит незавершённой работы: NULL — без лимита, wip_soft — лимит можно
+-- превысить с предупреждением.
+ALTER TABLE lists ADD COLUMN wip_limit INTEGER CHECK (wip_limit > 0);
+ALTER TABLE lists ADD COLUMN wip_soft BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+DROP TABLE card_lanes;
+DROP TABLE board_lanes;
+DROP TABLE board_swimlanes;
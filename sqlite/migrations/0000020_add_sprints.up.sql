@@ -0,0 +1,30 @@
+-- Спринты доски. Даты — дни (полночь UTC), end_date входит в спринт.
+CREATE TABLE sprints(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    board_id     INTEGER NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    name         TEXT    NOT NULL,
+    goal         TEXT    NOT NULL DEFAULT '',
+    start_date   TIMESTAMP NOT NULL,
+    end_date     TIMESTAMP NOT NULL,
+    status       TEXT    NOT NULL DEFAULT 'planned' CHECK (status IN ('planned', 'active', 'completed')),
+    started_at   TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date >= start_date)
+);
+
+-- На доске идёт не больше одного спринта.
+CREATE UNIQUE INDEX sprints_active_idx ON sprints (board_id) WHERE status = 'active';
+
+-- История состава спринтов: текущий спринт карточки — запись без
+-- removed_at, закрытые записи нужны для диаграммы сгорания.
+CREATE TABLE sprint_cards(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    sprint_id  INTEGER NOT NULL REFERENCES sprints (id) ON DELETE CASCADE,
+    card_id    INTEGER NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    added_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    removed_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX sprint_cards_current_idx ON sprint_cards (card_id) WHERE removed_at IS NULL;
+CREATE INDEX sprint_cards_sprint_idx ON sprint_cards (sprint_id);
@@ -0,0 +1,38 @@
+// Package sqlite открывает SQLite-базу для однофайловых установок.
+// Запросы общие с Postgres (пакет db), отличается только набор миграций.
+package sqlite
+
+import (
+	"embed"
+	"github.com/jmoiron/sqlx"
+	"io/fs"
+	_ "modernc.org/sqlite"
+)
+
+const DriverName = "sqlite"
+
+// Версии миграций совпадают с набором для Postgres: 0000002_init
+// создаёт схему, равную Postgres-схеме версии 2.
+//
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+var Migrations, _ = fs.Sub(embedded, "migrations")
+
+func init() {
+	sqlx.BindDriver(DriverName, sqlx.QUESTION)
+}
+
+func Open(path string) (*sqlx.DB, error) {
+	db, err := sqlx.Open(DriverName, "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite сериализует запись, одно соединение избавляет от SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	storage "awesomeProject2/cmd/db"
+	"awesomeProject2/cmd/migrations"
+	"awesomeProject2/cmd/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLite_Conformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Store {
+		db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		migrator, err := migrations.NewMigrator(db, Migrations)
+		require.NoError(t, err)
+		_, err = migrator.Up()
+		require.NoError(t, err)
+
+		return storage.NewStores(db)
+	})
+}
+
+func TestSQLite_Migrations(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	migrator, err := migrations.NewMigrator(db, Migrations)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, migrator.Check(), migrations.ErrSchemaBehind)
+	applied, err := migrator.Up()
+	require.NoError(t, err)
+	require.NotEmpty(t, applied)
+	require.NoError(t, migrator.Check())
+
+	for range applied {
+		_, err := migrator.Down()
+		require.NoError(t, err)
+	}
+	status, err := migrator.Status()
+	require.NoError(t, err)
+	require.Zero(t, status.Current)
+	_, err = migrator.Down()
+	require.ErrorIs(t, err, migrations.ErrNoMigration)
+}
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+)
+
+func (s *Storage) GetCardAssignees(cardID int) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.assignees[cardID]), nil
+}
+
+// AddCardAssignee идемпотентен, как и AddCardLabel.
+func (s *Storage) AddCardAssignee(cardID int, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[cardID]; !ok {
+		return fmt.Errorf("card %d: %w", cardID, model.ErrNotFound)
+	}
+	if slices.Contains(s.assignees[cardID], username) {
+		return nil
+	}
+	s.assignees[cardID] = append(s.assignees[cardID], username)
+	slices.Sort(s.assignees[cardID])
+	return nil
+}
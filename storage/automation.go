@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+)
+
+func (s *Storage) GetRules(boardID int) ([]model.AutomationRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var rules []model.AutomationRule
+	for _, r := range sortedByID(s.rules, func(r model.AutomationRule) int { return r.ID }) {
+		if r.BoardID == boardID {
+			rules = append(rules, cloneRule(r))
+		}
+	}
+	return rules, nil
+}
+
+func (s *Storage) GetRule(id int) (model.AutomationRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rule, ok := s.rules[id]
+	if !ok {
+		return model.AutomationRule{}, fmt.Errorf("automation rule %d: %w", id, model.ErrNotFound)
+	}
+	return cloneRule(rule), nil
+}
+
+func (s *Storage) CreateRule(input model.AutomationRuleInput) (model.AutomationRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[input.BoardID]; !ok {
+		return model.AutomationRule{}, fmt.Errorf("board %d: %w", input.BoardID, model.ErrNotFound)
+	}
+	s.ruleID++
+	rule := ruleFromInput(s.ruleID, input)
+	rule.CreatedAt = s.now()
+	s.rules[rule.ID] = rule
+	return cloneRule(rule), nil
+}
+
+func (s *Storage) UpdateRule(id int, input model.AutomationRuleInput) (model.AutomationRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.rules[id]
+	if !ok {
+		return model.AutomationRule{}, fmt.Errorf("automation rule %d: %w", id, model.ErrNotFound)
+	}
+	input.BoardID = current.BoardID
+	rule := ruleFromInput(id, input)
+	rule.CreatedAt = current.CreatedAt
+	s.rules[id] = rule
+	return cloneRule(rule), nil
+}
+
+func (s *Storage) DeleteRule(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.rules[id]; !ok {
+		return fmt.Errorf("automation rule %d: %w", id, model.ErrNotFound)
+	}
+	delete(s.rules, id)
+	for runID, run := range s.runs {
+		if run.RuleID == id {
+			delete(s.runs, runID)
+		}
+	}
+	return nil
+}
+
+func (s *Storage) CreateRun(run model.AutomationRun) (model.AutomationRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runID++
+	run.ID = s.runID
+	run.CreatedAt = s.now()
+	s.runs[run.ID] = run
+	return run, nil
+}
+
+func (s *Storage) GetRuns(boardID int, limit int) ([]model.AutomationRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var runs []model.AutomationRun
+	for _, r := range s.runs {
+		if r.BoardID == boardID {
+			runs = append(runs, r)
+		}
+	}
+	slices.SortFunc(runs, func(a, b model.AutomationRun) int { return b.ID - a.ID })
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+func ruleFromInput(id int, input model.AutomationRuleInput) model.AutomationRule {
+	return model.AutomationRule{
+		ID:         id,
+		BoardID:    input.BoardID,
+		Name:       input.Name,
+		Enabled:    input.Enabled,
+		Trigger:    input.Trigger,
+		Conditions: append([]model.AutomationCondition{}, input.Conditions...),
+		Actions:    append([]model.AutomationAction{}, input.Actions...),
+	}
+}
+
+// cloneRule не даёт вызывающему изменить правило в хранилище через срезы.
+func cloneRule(r model.AutomationRule) model.AutomationRule {
+	r.Conditions = append([]model.AutomationCondition{}, r.Conditions...)
+	r.Actions = append([]model.AutomationAction{}, r.Actions...)
+	return r
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"time"
+)
+
+type calendarToken struct {
+	hash      string
+	createdAt time.Time
+}
+
+func (s *Storage) SetCalendarToken(username, hash string) (model.CalendarToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token := calendarToken{hash: hash, createdAt: s.now()}
+	s.calendarTokens[username] = token
+	return model.CalendarToken{Username: username, CreatedAt: token.createdAt}, nil
+}
+
+func (s *Storage) GetCalendarTokenUser(hash string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for username, token := range s.calendarTokens {
+		if token.hash == hash {
+			return username, nil
+		}
+	}
+	return "", fmt.Errorf("calendar token: %w", model.ErrNotFound)
+}
+
+func (s *Storage) DeleteCalendarToken(username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.calendarTokens[username]; !ok {
+		return fmt.Errorf("calendar token of %s: %w", username, model.ErrNotFound)
+	}
+	delete(s.calendarTokens, username)
+	return nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// matchCard повторяет условия GetCards из SQL-хранилища.
+func (s *Storage) matchCard(c model.Card, filter model.CardFilter) bool {
+	switch {
+	case c.DeletedAt != nil,
+		!filter.IncludeArchived && c.ArchivedAt != nil,
+		filter.ListID != nil && c.ListID != *filter.ListID,
+		filter.BoardID != nil && c.BoardID != *filter.BoardID,
+		len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, c.Status),
+		filter.DueFrom != nil && (c.DueAt == nil || c.DueAt.Before(*filter.DueFrom)),
+		filter.DueTo != nil && (c.DueAt == nil || !c.DueAt.Before(*filter.DueTo)):
+		return false
+	}
+	if filter.Text != "" {
+		text := strings.ToLower(filter.Text)
+		if !strings.Contains(strings.ToLower(c.Title), text) && !strings.Contains(strings.ToLower(c.Description), text) {
+			return false
+		}
+	}
+	for _, label := range filter.Labels {
+		if !slices.ContainsFunc(s.cardLabels[c.ID], func(id int) bool { return strings.EqualFold(s.labels[id].Name, label) }) {
+			return false
+		}
+	}
+	for _, username := range filter.Assignees {
+		if !slices.Contains(s.assignees[c.ID], username) {
+			return false
+		}
+	}
+	for _, f := range filter.Fields {
+		if !s.matchField(c.ID, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortCards упорядочивает карточки как ORDER BY из SQL-хранилища: пустые
+// сроки — в конце, при равенстве — по id (карточки уже идут по id).
+func (s *Storage) sortCards(cards []model.Card, sort model.CardSort) error {
+	var compare func(a, b model.Card) int
+	switch sort.By {
+	case model.CardSortTitle:
+		compare = func(a, b model.Card) int { return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)) }
+	case model.CardSortCreated:
+		compare = func(a, b model.Card) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case model.CardSortUpdated:
+		compare = func(a, b model.Card) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+	case model.CardSortDue:
+		slices.SortStableFunc(cards, func(a, b model.Card) int {
+			if c := cmp.Compare(dueRank(a.DueAt), dueRank(b.DueAt)); c != 0 || a.DueAt == nil {
+				return c
+			}
+			if sort.Desc {
+				return b.DueAt.Compare(*a.DueAt)
+			}
+			return a.DueAt.Compare(*b.DueAt)
+		})
+		return nil
+	case model.CardSortField:
+		if !slices.Contains(model.FieldTypes, sort.Type) {
+			return fmt.Errorf("%w: unknown field type %q", model.ErrInvalidInput, sort.Type)
+		}
+		s.sortByField(cards, sort)
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown sort %q", model.ErrInvalidInput, sort.By)
+	}
+	slices.SortStableFunc(cards, func(a, b model.Card) int {
+		if sort.Desc {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+	return nil
+}
+
+func dueRank(due *time.Time) int {
+	if due == nil {
+		return 1
+	}
+	return 0
+}
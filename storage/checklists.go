@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+)
+
+func (s *Storage) GetChecklists(cardID int) ([]model.Checklist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var result []model.Checklist
+	items := sortedByID(s.items, func(i model.ChecklistItem) int { return i.ID })
+	for _, c := range sortedByID(s.checklists, func(c model.Checklist) int { return c.ID }) {
+		if c.CardID != cardID {
+			continue
+		}
+		for _, item := range items {
+			if item.ChecklistID == c.ID {
+				c.Items = append(c.Items, item)
+			}
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateChecklist(input model.ChecklistInputCreate) (model.Checklist, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[input.CardID]; !ok {
+		return model.Checklist{}, fmt.Errorf("card %d: %w", input.CardID, model.ErrNotFound)
+	}
+	s.checkID++
+	checklist := model.Checklist{
+		ID:     s.checkID,
+		CardID: input.CardID,
+		Title:  input.Title,
+	}
+	s.checklists[checklist.ID] = checklist
+	return checklist, nil
+}
+
+func (s *Storage) CreateChecklistItem(input model.ChecklistItemInputCreate) (model.ChecklistItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.checklists[input.ChecklistID]; !ok {
+		return model.ChecklistItem{}, fmt.Errorf("checklist %d: %w", input.ChecklistID, model.ErrNotFound)
+	}
+	s.itemID++
+	item := model.ChecklistItem{
+		ID:          s.itemID,
+		ChecklistID: input.ChecklistID,
+		Text:        input.Text,
+		Checked:     input.Checked,
+	}
+	s.items[item.ID] = item
+	return item, nil
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+)
+
+func (s *Storage) GetComments(cardID int) ([]model.Comment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	comments := sortedByID(s.comments, func(c model.Comment) int { return c.ID })
+	return slices.DeleteFunc(comments, func(c model.Comment) bool { return c.CardID != cardID }), nil
+}
+
+func (s *Storage) CreateComment(input model.CommentInputCreate) (model.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[input.CardID]; !ok {
+		return model.Comment{}, fmt.Errorf("card %d: %w", input.CardID, model.ErrNotFound)
+	}
+	s.commentID++
+	comment := model.Comment{
+		ID:        s.commentID,
+		CardID:    input.CardID,
+		Author:    input.Author,
+		Text:      input.Text,
+		CreatedAt: s.now(),
+	}
+	if input.CreatedAt != nil {
+		comment.CreatedAt = *input.CreatedAt
+	}
+	s.comments[comment.ID] = comment
+	return comment, nil
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"slices"
+	"time"
+)
+
+func (s *Storage) SetCardStatus(id int, status string) (model.Card, error) {
+	return s.updateLiveCard(id, func(c *model.Card, _ time.Time) {
+		c.Status = status
+	})
+}
+
+func (s *Storage) SetCardDue(id int, due *time.Time) (model.Card, error) {
+	return s.updateLiveCard(id, func(c *model.Card, _ time.Time) {
+		c.DueAt = nil
+		if due != nil {
+			utc := due.UTC()
+			c.DueAt = &utc
+		}
+		// Вызывается под блокировкой updateLiveCard.
+		delete(s.dueTriggered, id)
+		delete(s.dueSoonTriggered, id)
+	})
+}
+
+func (s *Storage) GetDueCards(before time.Time) ([]model.Card, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dueCards(before, s.dueTriggered), nil
+}
+
+func (s *Storage) MarkDueTriggered(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[id]; ok {
+		s.dueTriggered[id] = true
+	}
+	return nil
+}
+
+func (s *Storage) GetDueSoonCards(before time.Time) ([]model.Card, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dueCards(before, s.dueSoonTriggered), nil
+}
+
+func (s *Storage) MarkDueSoonTriggered(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[id]; ok {
+		s.dueSoonTriggered[id] = true
+	}
+	return nil
+}
+
+// dueCards отбирает активные карточки со сроком не позже before, не
+// отмеченные в triggered. Вызывающий держит блокировку.
+func (s *Storage) dueCards(before time.Time, triggered map[int]bool) []model.Card {
+	var cards []model.Card
+	for _, c := range s.cards {
+		if c.DueAt == nil || c.DueAt.After(before) || triggered[c.ID] || c.DeletedAt != nil || c.ArchivedAt != nil {
+			continue
+		}
+		cards = append(cards, c)
+	}
+	slices.SortFunc(cards, func(a, b model.Card) int {
+		if c := a.DueAt.Compare(*b.DueAt); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	})
+	return cards
+}
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (s *Storage) GetEmailSettings(username string) (model.EmailSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	settings, ok := s.emailSettings[username]
+	if !ok {
+		return model.EmailSettings{}, fmt.Errorf("email settings of %s: %w", username, model.ErrNotFound)
+	}
+	return settings, nil
+}
+
+func (s *Storage) SaveEmailSettings(settings model.EmailSettings) (model.EmailSettings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	settings.LastDigestAt = s.emailSettings[settings.Username].LastDigestAt
+	s.emailSettings[settings.Username] = settings
+	return settings, nil
+}
+
+func (s *Storage) GetDigestRecipients(before time.Time) ([]model.EmailSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var recipients []model.EmailSettings
+	for _, settings := range s.emailSettings {
+		if settings.Digest && (settings.LastDigestAt == nil || !settings.LastDigestAt.After(before)) {
+			recipients = append(recipients, settings)
+		}
+	}
+	slices.SortFunc(recipients, func(a, b model.EmailSettings) int { return strings.Compare(a.Username, b.Username) })
+	return recipients, nil
+}
+
+func (s *Storage) SetLastDigestAt(username string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if settings, ok := s.emailSettings[username]; ok {
+		at = at.UTC()
+		settings.LastDigestAt = &at
+		s.emailSettings[username] = settings
+	}
+	return nil
+}
+
+func (s *Storage) AddDigestItem(item model.DigestItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.digestItemID++
+	item.ID = s.digestItemID
+	item.CreatedAt = s.now()
+	s.digestItems[item.ID] = item
+	return nil
+}
+
+func (s *Storage) GetDigestItems(username string) ([]model.DigestItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var items []model.DigestItem
+	for _, item := range sortedByID(s.digestItems, func(i model.DigestItem) int { return i.ID }) {
+		if item.Username == username {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (s *Storage) DeleteDigestItems(username string, lastID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, item := range s.digestItems {
+		if item.Username == username && id <= lastID {
+			delete(s.digestItems, id)
+		}
+	}
+	return nil
+}
+
+func (s *Storage) EnqueueEmail(e model.Email) (model.Email, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emailID++
+	e.ID = s.emailID
+	e.Status = model.EmailPending
+	e.Attempts = 0
+	e.LastError = ""
+	e.NextAttemptAt = e.NextAttemptAt.UTC()
+	e.SentAt = nil
+	e.CreatedAt = s.now()
+	s.outbox[e.ID] = e
+	return e, nil
+}
+
+func (s *Storage) GetPendingEmails(now time.Time, limit int) ([]model.Email, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var emails []model.Email
+	for _, e := range s.outbox {
+		if e.Status == model.EmailPending && !e.NextAttemptAt.After(now) {
+			emails = append(emails, e)
+		}
+	}
+	slices.SortFunc(emails, func(a, b model.Email) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	})
+	return emails[:min(len(emails), limit)], nil
+}
+
+func (s *Storage) MarkEmailSent(id int, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.outbox[id]
+	if !ok {
+		return fmt.Errorf("email %d: %w", id, model.ErrNotFound)
+	}
+	at = at.UTC()
+	e.Status = model.EmailSent
+	e.Attempts++
+	e.LastError = ""
+	e.SentAt = &at
+	s.outbox[id] = e
+	return nil
+}
+
+func (s *Storage) MarkEmailFailed(id int, reason string, nextAttemptAt *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.outbox[id]
+	if !ok {
+		return fmt.Errorf("email %d: %w", id, model.ErrNotFound)
+	}
+	e.Attempts++
+	e.LastError = reason
+	if nextAttemptAt != nil {
+		e.NextAttemptAt = nextAttemptAt.UTC()
+	} else {
+		e.Status = model.EmailFailed
+	}
+	s.outbox[id] = e
+	return nil
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type fieldValueKey struct {
+	cardID  int
+	fieldID int
+}
+
+func (s *Storage) GetFields(boardID int) ([]model.CustomField, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fields := sortedByID(s.fields, func(f model.CustomField) int { return f.ID })
+	return slices.DeleteFunc(fields, func(f model.CustomField) bool { return f.BoardID != boardID }), nil
+}
+
+func (s *Storage) GetField(id int) (model.CustomField, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	field, ok := s.fields[id]
+	if !ok {
+		return model.CustomField{}, fmt.Errorf("field %d: %w", id, model.ErrNotFound)
+	}
+	return field, nil
+}
+
+func (s *Storage) CreateField(input model.CustomFieldInputCreate) (model.CustomField, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[input.BoardID]; !ok {
+		return model.CustomField{}, fmt.Errorf("board %d: %w", input.BoardID, model.ErrNotFound)
+	}
+	s.fieldID++
+	field := model.CustomField{
+		ID:        s.fieldID,
+		BoardID:   input.BoardID,
+		Name:      input.Name,
+		Type:      input.Type,
+		Options:   cloneOptions(input.Options),
+		CreatedAt: s.now(),
+	}
+	s.fields[field.ID] = field
+	return field, nil
+}
+
+func (s *Storage) UpdateField(id int, update model.CustomFieldUpdate) (model.CustomField, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	field, ok := s.fields[id]
+	if !ok {
+		return model.CustomField{}, fmt.Errorf("field %d: %w", id, model.ErrNotFound)
+	}
+	field.Name = update.Name
+	field.Options = cloneOptions(update.Options)
+	s.fields[id] = field
+	return field, nil
+}
+
+func (s *Storage) DeleteField(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.fields[id]; !ok {
+		return fmt.Errorf("field %d: %w", id, model.ErrNotFound)
+	}
+	delete(s.fields, id)
+	for key := range s.fieldValues {
+		if key.fieldID == id {
+			delete(s.fieldValues, key)
+		}
+	}
+	for boardID, swimlanes := range s.swimlanes {
+		if swimlanes.FieldID != nil && *swimlanes.FieldID == id {
+			delete(s.swimlanes, boardID)
+		}
+	}
+	return nil
+}
+
+func (s *Storage) GetCardFieldValues(cardID int) ([]model.CardFieldValue, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var values []model.CardFieldValue
+	for key, v := range s.fieldValues {
+		if key.cardID == cardID {
+			values = append(values, v)
+		}
+	}
+	slices.SortFunc(values, func(a, b model.CardFieldValue) int { return a.FieldID - b.FieldID })
+	return values, nil
+}
+
+func (s *Storage) SetCardFieldValue(v model.CardFieldValue) (model.CardFieldValue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[v.CardID]; !ok {
+		return model.CardFieldValue{}, fmt.Errorf("card %d: %w", v.CardID, model.ErrNotFound)
+	}
+	if _, ok := s.fields[v.FieldID]; !ok {
+		return model.CardFieldValue{}, fmt.Errorf("field %d: %w", v.FieldID, model.ErrNotFound)
+	}
+	if v.Date != nil {
+		date := v.Date.UTC()
+		v.Date = &date
+	}
+	s.fieldValues[fieldValueKey{v.CardID, v.FieldID}] = v
+	return v, nil
+}
+
+func (s *Storage) DeleteCardFieldValue(cardID, fieldID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.fieldValues, fieldValueKey{cardID, fieldID})
+	return nil
+}
+
+// matchField повторяет условие фильтра по полю из SQL-хранилища.
+func (s *Storage) matchField(cardID int, f model.CardFieldFilter) bool {
+	v, ok := s.fieldValues[fieldValueKey{cardID, f.FieldID}]
+	if !ok {
+		return false
+	}
+	m := f.Match
+	switch {
+	case m.Number != nil:
+		return v.Number != nil && *v.Number == *m.Number
+	case m.Date != nil:
+		return v.Date != nil && v.Date.Equal(*m.Date)
+	case m.Bool != nil:
+		return v.Bool != nil && *v.Bool == *m.Bool
+	case m.Text != nil:
+		return v.Text != nil && *v.Text == *m.Text
+	}
+	return false
+}
+
+// sortByField упорядочивает карточки по значению поля; без значения — в
+// конце, при равенстве — по id.
+func (s *Storage) sortByField(cards []model.Card, sort model.CardSort) {
+	compare := func(a, b model.CardFieldValue) int {
+		switch sort.Type {
+		case model.FieldNumber:
+			return cmp.Compare(*a.Number, *b.Number)
+		case model.FieldDate:
+			return a.Date.Compare(*b.Date)
+		case model.FieldCheckbox:
+			return cmp.Compare(boolRank(*a.Bool), boolRank(*b.Bool))
+		}
+		return strings.Compare(*a.Text, *b.Text)
+	}
+	slices.SortStableFunc(cards, func(a, b model.Card) int {
+		va, okA := s.fieldValues[fieldValueKey{a.ID, sort.FieldID}]
+		vb, okB := s.fieldValues[fieldValueKey{b.ID, sort.FieldID}]
+		switch {
+		case !okA && !okB:
+			return 0
+		case !okA:
+			return 1
+		case !okB:
+			return -1
+		}
+		if c := compare(va, vb); c != 0 {
+			if sort.Desc {
+				return -c
+			}
+			return c
+		}
+		return 0
+	})
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func cloneOptions(options []string) []string {
+	if options == nil {
+		return []string{}
+	}
+	return slices.Clone(options)
+}
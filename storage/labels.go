@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+)
+
+func (s *Storage) GetLabels(boardID int) ([]model.Label, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	labels := sortedByID(s.labels, func(l model.Label) int { return l.ID })
+	return slices.DeleteFunc(labels, func(l model.Label) bool { return l.BoardID != boardID }), nil
+}
+
+func (s *Storage) CreateLabel(input model.LabelInputCreate) (model.Label, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[input.BoardID]; !ok {
+		return model.Label{}, fmt.Errorf("board %d: %w", input.BoardID, model.ErrNotFound)
+	}
+	s.labelID++
+	label := model.Label{
+		ID:      s.labelID,
+		BoardID: input.BoardID,
+		Name:    input.Name,
+		Color:   input.Color,
+	}
+	s.labels[label.ID] = label
+	return label, nil
+}
+
+func (s *Storage) GetCardLabels(cardID int) ([]model.Label, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var labels []model.Label
+	for _, id := range s.cardLabels[cardID] {
+		labels = append(labels, s.labels[id])
+	}
+	return labels, nil
+}
+
+// AddCardLabel идемпотентен: повторное добавление метки не считается ошибкой.
+func (s *Storage) AddCardLabel(cardID int, labelID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	card, ok := s.cards[cardID]
+	if !ok {
+		return fmt.Errorf("card %d: %w", cardID, model.ErrNotFound)
+	}
+	label, ok := s.labels[labelID]
+	if !ok || label.BoardID != card.BoardID {
+		return fmt.Errorf("label %d on board %d: %w", labelID, card.BoardID, model.ErrNotFound)
+	}
+	if slices.Contains(s.cardLabels[cardID], labelID) {
+		return nil
+	}
+	s.cardLabels[cardID] = append(s.cardLabels[cardID], labelID)
+	slices.Sort(s.cardLabels[cardID])
+	return nil
+}
+
+func (s *Storage) RemoveCardLabel(cardID int, labelID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cardLabels[cardID] = slices.DeleteFunc(s.cardLabels[cardID], func(id int) bool { return id == labelID })
+	return nil
+}
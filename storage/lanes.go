@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+func (s *Storage) GetSwimlanes(boardID int) (model.Swimlanes, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.boards[boardID]; !ok {
+		return model.Swimlanes{}, fmt.Errorf("board %d: %w", boardID, model.ErrNotFound)
+	}
+	swimlanes, ok := s.swimlanes[boardID]
+	if !ok {
+		return model.Swimlanes{BoardID: boardID}, nil
+	}
+	swimlanes.FieldID = clonePtr(swimlanes.FieldID)
+	return swimlanes, nil
+}
+
+func (s *Storage) SetSwimlanes(swimlanes model.Swimlanes) (model.Swimlanes, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[swimlanes.BoardID]; !ok {
+		return model.Swimlanes{}, fmt.Errorf("board %d: %w", swimlanes.BoardID, model.ErrNotFound)
+	}
+	if swimlanes.Mode == model.LaneNone {
+		delete(s.swimlanes, swimlanes.BoardID)
+		return model.Swimlanes{BoardID: swimlanes.BoardID}, nil
+	}
+	swimlanes.FieldID = clonePtr(swimlanes.FieldID)
+	s.swimlanes[swimlanes.BoardID] = swimlanes
+	swimlanes.FieldID = clonePtr(swimlanes.FieldID)
+	return swimlanes, nil
+}
+
+func (s *Storage) GetLanes(boardID int) ([]model.Lane, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.boardLanes(boardID), nil
+}
+
+func (s *Storage) GetLane(id int) (model.Lane, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	lane, ok := s.lanes[id]
+	if !ok {
+		return model.Lane{}, fmt.Errorf("lane %d: %w", id, model.ErrNotFound)
+	}
+	return lane, nil
+}
+
+func (s *Storage) CreateLane(input model.LaneInputCreate) (model.Lane, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[input.BoardID]; !ok {
+		return model.Lane{}, fmt.Errorf("board %d: %w", input.BoardID, model.ErrNotFound)
+	}
+	position := 0
+	for _, l := range s.boardLanes(input.BoardID) {
+		position = max(position, l.Position+1)
+	}
+	s.laneID++
+	lane := model.Lane{ID: s.laneID, BoardID: input.BoardID, Title: input.Title, Position: position, CreatedAt: s.now()}
+	s.lanes[lane.ID] = lane
+	return lane, nil
+}
+
+func (s *Storage) RenameLane(id int, title string) (model.Lane, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lane, ok := s.lanes[id]
+	if !ok {
+		return model.Lane{}, fmt.Errorf("lane %d: %w", id, model.ErrNotFound)
+	}
+	lane.Title = title
+	s.lanes[id] = lane
+	return lane, nil
+}
+
+func (s *Storage) DeleteLane(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.lanes[id]; !ok {
+		return fmt.Errorf("lane %d: %w", id, model.ErrNotFound)
+	}
+	delete(s.lanes, id)
+	for cardID, laneID := range s.cardLanes {
+		if laneID == id {
+			delete(s.cardLanes, cardID)
+		}
+	}
+	return nil
+}
+
+func (s *Storage) SetLanePositions(boardID int, ids []int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, id := range ids {
+		if lane, ok := s.lanes[id]; ok && lane.BoardID == boardID {
+			lane.Position = i
+			s.lanes[id] = lane
+		}
+	}
+	return nil
+}
+
+func (s *Storage) GetCardLanes(boardID int) ([]model.CardLane, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	lanes := []model.CardLane{}
+	for cardID, laneID := range s.cardLanes {
+		if card, ok := s.cards[cardID]; ok && card.BoardID == boardID && card.DeletedAt == nil {
+			lanes = append(lanes, model.CardLane{CardID: cardID, LaneID: laneID})
+		}
+	}
+	slices.SortFunc(lanes, func(a, b model.CardLane) int { return a.CardID - b.CardID })
+	return lanes, nil
+}
+
+func (s *Storage) SetCardLane(cardID int, laneID *int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if laneID == nil {
+		delete(s.cardLanes, cardID)
+		return nil
+	}
+	if card, ok := s.cards[cardID]; !ok || card.DeletedAt != nil {
+		return fmt.Errorf("card %d: %w", cardID, model.ErrNotFound)
+	}
+	if _, ok := s.lanes[*laneID]; !ok {
+		return fmt.Errorf("lane %d: %w", *laneID, model.ErrNotFound)
+	}
+	s.cardLanes[cardID] = *laneID
+	return nil
+}
+
+// boardLanes возвращает дорожки доски по порядку. Вызывающий держит
+// блокировку.
+func (s *Storage) boardLanes(boardID int) []model.Lane {
+	lanes := []model.Lane{}
+	for _, l := range s.lanes {
+		if l.BoardID == boardID {
+			lanes = append(lanes, l)
+		}
+	}
+	slices.SortFunc(lanes, func(a, b model.Lane) int {
+		return cmp.Or(a.Position-b.Position, a.ID-b.ID)
+	})
+	return lanes
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+)
+
+func (s *Storage) GetCardLinks(cardID int) ([]model.CardLink, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	links := sortedByID(s.links, func(l model.CardLink) int { return l.ID })
+	return slices.DeleteFunc(links, func(l model.CardLink) bool { return l.SourceID != cardID && l.TargetID != cardID }), nil
+}
+
+func (s *Storage) CreateCardLink(link model.CardLink) (model.CardLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range []int{link.SourceID, link.TargetID} {
+		if _, ok := s.cards[id]; !ok {
+			return model.CardLink{}, fmt.Errorf("card %d: %w", id, model.ErrNotFound)
+		}
+	}
+	for _, l := range s.links {
+		if l.SourceID == link.SourceID && l.TargetID == link.TargetID && l.Type == link.Type {
+			return l, nil
+		}
+	}
+	s.linkID++
+	link.ID = s.linkID
+	link.CreatedAt = s.now()
+	s.links[link.ID] = link
+	return link, nil
+}
+
+func (s *Storage) DeleteCardLink(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.links[id]; !ok {
+		return fmt.Errorf("card link %d: %w", id, model.ErrNotFound)
+	}
+	delete(s.links, id)
+	return nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func (s *Storage) GetMemberBoards(username string) ([]model.Board, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	boards := sortedByID(s.boards, func(b model.Board) int { return b.ID })
+	return slices.DeleteFunc(boards, func(b model.Board) bool {
+		return !slices.ContainsFunc(s.members[b.ID], func(m model.BoardMember) bool { return m.Username == username })
+	}), nil
+}
+
+func (s *Storage) GetBoardMembers(boardID int) ([]model.BoardMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.members[boardID]), nil
+}
+
+func (s *Storage) AddBoardMember(boardID int, username string) (model.BoardMember, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[boardID]; !ok {
+		return model.BoardMember{}, fmt.Errorf("board %d: %w", boardID, model.ErrNotFound)
+	}
+	members := s.members[boardID]
+	if i := slices.IndexFunc(members, func(m model.BoardMember) bool { return m.Username == username }); i >= 0 {
+		return members[i], nil
+	}
+	member := model.BoardMember{BoardID: boardID, Username: username, AddedAt: s.now()}
+	members = append(members, member)
+	slices.SortFunc(members, func(a, b model.BoardMember) int { return strings.Compare(a.Username, b.Username) })
+	s.members[boardID] = members
+	return member, nil
+}
+
+func (s *Storage) RemoveBoardMember(boardID int, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members := s.members[boardID]
+	i := slices.IndexFunc(members, func(m model.BoardMember) bool { return m.Username == username })
+	if i < 0 {
+		return fmt.Errorf("member %s of board %d: %w", username, boardID, model.ErrNotFound)
+	}
+	s.members[boardID] = slices.Delete(slices.Clone(members), i, i+1)
+	return nil
+}
+
+func (s *Storage) CreateMention(m model.Mention) (model.Mention, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[m.CardID]; !ok {
+		return model.Mention{}, fmt.Errorf("card %d: %w", m.CardID, model.ErrNotFound)
+	}
+	if m.CommentID != nil {
+		if _, ok := s.comments[*m.CommentID]; !ok {
+			return model.Mention{}, fmt.Errorf("comment %d: %w", *m.CommentID, model.ErrNotFound)
+		}
+	}
+	s.mentionID++
+	m.ID = s.mentionID
+	m.CreatedAt = s.now()
+	s.mentions[m.ID] = m
+	return m, nil
+}
+
+func (s *Storage) GetMentions(cardID int) ([]model.Mention, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	mentions := sortedByID(s.mentions, func(m model.Mention) int { return m.ID })
+	return slices.DeleteFunc(mentions, func(m model.Mention) bool { return m.CardID != cardID }), nil
+}
+
+func (s *Storage) DeleteMention(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.mentions, id)
+	return nil
+}
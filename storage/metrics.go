@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+)
+
+func (s *Storage) AddCardMove(move model.CardMove) (model.CardMove, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[move.CardID]; !ok {
+		return model.CardMove{}, fmt.Errorf("card %d: %w", move.CardID, model.ErrNotFound)
+	}
+	s.moveID++
+	move.ID = s.moveID
+	s.cardMoves[move.ID] = move
+	return move, nil
+}
+
+func (s *Storage) GetCardMoves(boardID int) ([]model.CardMove, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	moves := []model.CardMove{}
+	for _, m := range s.cardMoves {
+		if card, ok := s.cards[m.CardID]; ok && card.BoardID == boardID && card.DeletedAt == nil {
+			moves = append(moves, m)
+		}
+	}
+	slices.SortFunc(moves, func(a, b model.CardMove) int {
+		if a.CardID != b.CardID {
+			return a.CardID - b.CardID
+		}
+		return a.ID - b.ID
+	})
+	return moves, nil
+}
@@ -23,10 +23,26 @@ func (m *MockBoardStorage) GetBoards() ([]model.Board, error) {
 	args := m.Called()
 	return args.Get(0).([]model.Board), args.Error(1)
 }
+func (m *MockBoardStorage) GetBoard(id int) (model.Board, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Board), args.Error(1)
+}
+func (m *MockBoardStorage) GetTemplates() ([]model.Board, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Board), args.Error(1)
+}
+func (m *MockBoardStorage) SetBoardTemplate(id int, isTemplate bool) (model.Board, error) {
+	args := m.Called(id, isTemplate)
+	return args.Get(0).(model.Board), args.Error(1)
+}
 func (m *MockListStorage) CreateList(input model.ListInputCreate) (model.List, error) {
 	args := m.Called(input)
 	return args.Get(0).(model.List), args.Error(1)
 }
+func (m *MockListStorage) GetList(id int) (model.List, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.List), args.Error(1)
+}
 func (m *MockListStorage) GetLists(BoardID *int) ([]model.List, error) {
 	args := m.Called(BoardID)
 	return args.Get(0).([]model.List), args.Error(1)
@@ -35,8 +51,12 @@ func (m *MockCardStorage) CreateCard(input model.CardInputCreate) (model.Card, e
 	args := m.Called(input)
 	return args.Get(0).(model.Card), args.Error(1)
 }
-func (m *MockCardStorage) GetCards(ListID *int) ([]model.Card, error) {
-	args := m.Called(ListID)
+func (m *MockCardStorage) GetCard(id int) (model.Card, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Card), args.Error(1)
+}
+func (m *MockCardStorage) GetCards(filter model.CardFilter) ([]model.Card, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]model.Card), args.Error(1)
 }
 func (m *MockCardStorage) DeleteCard(listID, cardID int) (model.Card, error) {
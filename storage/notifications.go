@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// preferenceKey — настройка одного вида уведомлений одного пользователя.
+type preferenceKey struct {
+	username string
+	kind     string
+}
+
+func (s *Storage) CreateNotification(n model.Notification) (model.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[n.CardID]; !ok {
+		return model.Notification{}, fmt.Errorf("card %d: %w", n.CardID, model.ErrNotFound)
+	}
+	s.notificationID++
+	n.ID = s.notificationID
+	n.ReadAt = nil
+	n.CreatedAt = s.now()
+	s.notifications[n.ID] = n
+	return n, nil
+}
+
+func (s *Storage) GetNotifications(filter model.NotificationFilter) ([]model.Notification, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var notifications []model.Notification
+	for _, n := range s.notifications {
+		if n.Username == filter.Username && (!filter.UnreadOnly || n.ReadAt == nil) {
+			notifications = append(notifications, n)
+		}
+	}
+	slices.SortFunc(notifications, func(a, b model.Notification) int { return b.ID - a.ID })
+	return notifications[:min(len(notifications), filter.Limit)], nil
+}
+
+func (s *Storage) CountUnread(username string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 0
+	for _, n := range s.notifications {
+		if n.Username == username && n.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *Storage) MarkNotificationRead(id int, username string) (model.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.notifications[id]
+	if !ok || n.Username != username {
+		return model.Notification{}, fmt.Errorf("notification %d: %w", id, model.ErrNotFound)
+	}
+	if n.ReadAt == nil {
+		now := s.now()
+		n.ReadAt = &now
+		s.notifications[id] = n
+	}
+	return n, nil
+}
+
+func (s *Storage) MarkAllNotificationsRead(username string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	marked := 0
+	for id, n := range s.notifications {
+		if n.Username == username && n.ReadAt == nil {
+			n.ReadAt = &now
+			s.notifications[id] = n
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (s *Storage) GetNotificationPreferences(username string) ([]model.NotificationPreference, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var prefs []model.NotificationPreference
+	for key, pref := range s.preferences {
+		if key.username == username {
+			prefs = append(prefs, pref)
+		}
+	}
+	slices.SortFunc(prefs, func(a, b model.NotificationPreference) int { return strings.Compare(a.Kind, b.Kind) })
+	return prefs, nil
+}
+
+func (s *Storage) SetNotificationPreference(username string, pref model.NotificationPreference) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.preferences[preferenceKey{username, pref.Kind}] = pref
+	return nil
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"time"
+)
+
+func (s *Storage) GetRecurrence(cardID int) (model.CardRecurrence, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r, ok := s.recurrences[cardID]
+	if !ok || s.cards[cardID].DeletedAt != nil {
+		return model.CardRecurrence{}, fmt.Errorf("recurrence of card %d: %w", cardID, model.ErrNotFound)
+	}
+	return r, nil
+}
+
+func (s *Storage) SaveRecurrence(r model.CardRecurrence) (model.CardRecurrence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cards[r.CardID]; !ok {
+		return model.CardRecurrence{}, fmt.Errorf("card %d: %w", r.CardID, model.ErrNotFound)
+	}
+	if _, ok := s.lists[r.ListID]; !ok {
+		return model.CardRecurrence{}, fmt.Errorf("list %d: %w", r.ListID, model.ErrNotFound)
+	}
+	r.StartsAt = r.StartsAt.UTC()
+	r.NextRunAt = r.NextRunAt.UTC()
+	r.CreatedAt = s.now()
+	if current, ok := s.recurrences[r.CardID]; ok {
+		r.CreatedAt = current.CreatedAt
+		r.LastRunAt = current.LastRunAt
+	} else {
+		r.LastRunAt = nil
+	}
+	s.recurrences[r.CardID] = r
+	return r, nil
+}
+
+func (s *Storage) DeleteRecurrence(cardID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.recurrences[cardID]; !ok {
+		return fmt.Errorf("recurrence of card %d: %w", cardID, model.ErrNotFound)
+	}
+	delete(s.recurrences, cardID)
+	return nil
+}
+
+func (s *Storage) GetDueRecurrences(before time.Time) ([]model.CardRecurrence, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var rs []model.CardRecurrence
+	for _, r := range s.recurrences {
+		card := s.cards[r.CardID]
+		if r.NextRunAt.After(before) || card.ArchivedAt != nil || card.DeletedAt != nil {
+			continue
+		}
+		rs = append(rs, r)
+	}
+	slices.SortFunc(rs, func(a, b model.CardRecurrence) int {
+		if c := a.NextRunAt.Compare(b.NextRunAt); c != 0 {
+			return c
+		}
+		return a.CardID - b.CardID
+	})
+	return rs, nil
+}
+
+func (s *Storage) AdvanceRecurrence(cardID int, from, next, ranAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.recurrences[cardID]
+	if !ok || !r.NextRunAt.Equal(from) {
+		return false, nil
+	}
+	ranAt = ranAt.UTC()
+	r.NextRunAt = next.UTC()
+	r.LastRunAt = &ranAt
+	s.recurrences[cardID] = r
+	return true, nil
+}
+
+func (s *Storage) ReleaseRecurrence(r model.CardRecurrence, claimed time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.recurrences[r.CardID]
+	if !ok || !current.NextRunAt.Equal(claimed) {
+		return false, nil
+	}
+	current.NextRunAt = r.NextRunAt.UTC()
+	current.LastRunAt = nil
+	if r.LastRunAt != nil {
+		lastRunAt := r.LastRunAt.UTC()
+		current.LastRunAt = &lastRunAt
+	}
+	s.recurrences[r.CardID] = current
+	return true, nil
+}
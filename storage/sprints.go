@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+func (s *Storage) GetSprints(boardID int) ([]model.Sprint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sprints := []model.Sprint{}
+	for _, sprint := range s.sprints {
+		if sprint.BoardID == boardID {
+			sprints = append(sprints, sprint)
+		}
+	}
+	slices.SortFunc(sprints, func(a, b model.Sprint) int {
+		return cmp.Or(a.StartDate.Compare(b.StartDate), a.ID-b.ID)
+	})
+	return sprints, nil
+}
+
+func (s *Storage) GetSprint(id int) (model.Sprint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sprint, ok := s.sprints[id]
+	if !ok {
+		return model.Sprint{}, fmt.Errorf("sprint %d: %w", id, model.ErrNotFound)
+	}
+	return sprint, nil
+}
+
+func (s *Storage) CreateSprint(sprint model.Sprint) (model.Sprint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[sprint.BoardID]; !ok {
+		return model.Sprint{}, fmt.Errorf("board %d: %w", sprint.BoardID, model.ErrNotFound)
+	}
+	s.sprintID++
+	sprint.ID = s.sprintID
+	sprint.StartDate = sprint.StartDate.UTC()
+	sprint.EndDate = sprint.EndDate.UTC()
+	sprint.Status = model.SprintPlanned
+	sprint.StartedAt, sprint.CompletedAt = nil, nil
+	sprint.CreatedAt = s.now()
+	s.sprints[sprint.ID] = sprint
+	return sprint, nil
+}
+
+func (s *Storage) UpdateSprint(sprint model.Sprint) (model.Sprint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.sprints[sprint.ID]
+	if !ok {
+		return model.Sprint{}, fmt.Errorf("sprint %d: %w", sprint.ID, model.ErrNotFound)
+	}
+	stored.Name = sprint.Name
+	stored.Goal = sprint.Goal
+	stored.StartDate = sprint.StartDate.UTC()
+	stored.EndDate = sprint.EndDate.UTC()
+	s.sprints[sprint.ID] = stored
+	return stored, nil
+}
+
+func (s *Storage) DeleteSprint(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sprints[id]; !ok {
+		return fmt.Errorf("sprint %d: %w", id, model.ErrNotFound)
+	}
+	delete(s.sprints, id)
+	for scID, sc := range s.sprintCards {
+		if sc.SprintID == id {
+			delete(s.sprintCards, scID)
+		}
+	}
+	return nil
+}
+
+// SetSprintStatus повторяет частичный уникальный индекс SQL-схемы: на
+// доске идёт не больше одного спринта.
+func (s *Storage) SetSprintStatus(id int, status string) (model.Sprint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sprint, ok := s.sprints[id]
+	if !ok {
+		return model.Sprint{}, fmt.Errorf("sprint %d: %w", id, model.ErrNotFound)
+	}
+	if status == model.SprintActive {
+		for _, other := range s.sprints {
+			if other.ID != id && other.BoardID == sprint.BoardID && other.Status == model.SprintActive {
+				return model.Sprint{}, fmt.Errorf("%w: board %d already has an active sprint", model.ErrConflict, sprint.BoardID)
+			}
+		}
+	}
+	now := s.now()
+	switch status {
+	case model.SprintActive:
+		sprint.StartedAt = &now
+	case model.SprintCompleted:
+		sprint.CompletedAt = &now
+	}
+	sprint.Status = status
+	s.sprints[id] = sprint
+	return sprint, nil
+}
+
+func (s *Storage) GetSprintCards(sprintID int) ([]model.SprintCard, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cards := []model.SprintCard{}
+	for _, sc := range s.sprintCards {
+		if card, ok := s.cards[sc.CardID]; sc.SprintID == sprintID && ok && card.DeletedAt == nil {
+			cards = append(cards, sc)
+		}
+	}
+	slices.SortFunc(cards, func(a, b model.SprintCard) int { return a.ID - b.ID })
+	return cards, nil
+}
+
+func (s *Storage) SetCardSprint(cardID int, sprintID *int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for id, sc := range s.sprintCards {
+		if sc.CardID != cardID || sc.RemovedAt != nil {
+			continue
+		}
+		if sprintID != nil && sc.SprintID == *sprintID {
+			return nil
+		}
+		sc.RemovedAt = &now
+		s.sprintCards[id] = sc
+	}
+	if sprintID == nil {
+		return nil
+	}
+	if card, ok := s.cards[cardID]; !ok || card.DeletedAt != nil {
+		return fmt.Errorf("card %d: %w", cardID, model.ErrNotFound)
+	}
+	if _, ok := s.sprints[*sprintID]; !ok {
+		return fmt.Errorf("sprint %d: %w", *sprintID, model.ErrNotFound)
+	}
+	s.sprintCardID++
+	s.sprintCards[s.sprintCardID] = model.SprintCard{ID: s.sprintCardID, SprintID: *sprintID, CardID: cardID, AddedAt: now}
+	return nil
+}
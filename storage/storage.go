@@ -2,143 +2,418 @@ package storage
 
 import (
 	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
 	"fmt"
 	"maps"
 	"slices"
+	"sync"
+	"time"
 )
 
+// Storage — хранилище в памяти, взаимозаменяемое с Postgres-хранилищами
+// из пакета db: реализует интерфейсы хранилищ из пакета service.
 type Storage struct {
-	Boards  map[int]model.Board
-	boardID int
-	listID  int
-	cardID  int
+	mu         sync.RWMutex
+	boards     map[int]model.Board
+	lists      map[int]model.List
+	cards      map[int]model.Card
+	labels     map[int]model.Label
+	cardLabels map[int][]int
+	assignees  map[int][]string
+	checklists map[int]model.Checklist
+	items      map[int]model.ChecklistItem
+	comments   map[int]model.Comment
+	// dueTriggered — карточки, чей текущий срок уже обработан.
+	dueTriggered map[int]bool
+	// dueSoonTriggered — карточки, о приближении срока которых уже
+	// предупредили.
+	dueSoonTriggered map[int]bool
+	rules            map[int]model.AutomationRule
+	runs             map[int]model.AutomationRun
+	recurrences      map[int]model.CardRecurrence
+	notifications    map[int]model.Notification
+	preferences      map[preferenceKey]model.NotificationPreference
+	emailSettings    map[string]model.EmailSettings
+	digestItems      map[int]model.DigestItem
+	outbox           map[int]model.Email
+	members          map[int][]model.BoardMember
+	mentions         map[int]model.Mention
+	watches          map[watchKey]model.Watch
+	links            map[int]model.CardLink
+	fields           map[int]model.CustomField
+	fieldValues      map[fieldValueKey]model.CardFieldValue
+	views            map[int]model.View
+	// calendarTokens — хэш токена iCalendar-ленты по пользователю.
+	calendarTokens map[string]calendarToken
+	cardMoves      map[int]model.CardMove
+	swimlanes      map[int]model.Swimlanes
+	lanes          map[int]model.Lane
+	// cardLanes — явная дорожка по карточке.
+	cardLanes      map[int]int
+	sprints        map[int]model.Sprint
+	sprintCards    map[int]model.SprintCard
+	boardID        int
+	listID         int
+	cardID         int
+	labelID        int
+	checkID        int
+	itemID         int
+	commentID      int
+	ruleID         int
+	runID          int
+	notificationID int
+	digestItemID   int
+	emailID        int
+	mentionID      int
+	linkID         int
+	fieldID        int
+	viewID         int
+	moveID         int
+	laneID         int
+	sprintID       int
+	sprintCardID   int
+	now            func() time.Time
 }
 
-func (s *Storage) GetBoards() []model.Board {
-	return slices.Collect(maps.Values(s.Boards))
+var (
+	_ service.BoardStorage     = (*Storage)(nil)
+	_ service.ListStorage      = (*Storage)(nil)
+	_ service.CardStorage      = (*Storage)(nil)
+	_ service.LabelStorage     = (*Storage)(nil)
+	_ service.AssigneeStorage  = (*Storage)(nil)
+	_ service.ChecklistStorage = (*Storage)(nil)
+	_ service.CommentStorage   = (*Storage)(nil)
+	_ service.CardLinkStorage  = (*Storage)(nil)
+	_ service.Transactor       = (*Storage)(nil)
+)
+
+func NewStorage() *Storage {
+	return &Storage{
+		boards:           map[int]model.Board{},
+		lists:            map[int]model.List{},
+		cards:            map[int]model.Card{},
+		labels:           map[int]model.Label{},
+		cardLabels:       map[int][]int{},
+		assignees:        map[int][]string{},
+		checklists:       map[int]model.Checklist{},
+		items:            map[int]model.ChecklistItem{},
+		comments:         map[int]model.Comment{},
+		dueTriggered:     map[int]bool{},
+		dueSoonTriggered: map[int]bool{},
+		rules:            map[int]model.AutomationRule{},
+		runs:             map[int]model.AutomationRun{},
+		recurrences:      map[int]model.CardRecurrence{},
+		notifications:    map[int]model.Notification{},
+		preferences:      map[preferenceKey]model.NotificationPreference{},
+		emailSettings:    map[string]model.EmailSettings{},
+		digestItems:      map[int]model.DigestItem{},
+		outbox:           map[int]model.Email{},
+		members:          map[int][]model.BoardMember{},
+		mentions:         map[int]model.Mention{},
+		watches:          map[watchKey]model.Watch{},
+		links:            map[int]model.CardLink{},
+		fields:           map[int]model.CustomField{},
+		fieldValues:      map[fieldValueKey]model.CardFieldValue{},
+		views:            map[int]model.View{},
+		calendarTokens:   map[string]calendarToken{},
+		cardMoves:        map[int]model.CardMove{},
+		swimlanes:        map[int]model.Swimlanes{},
+		lanes:            map[int]model.Lane{},
+		cardLanes:        map[int]int{},
+		sprints:          map[int]model.Sprint{},
+		sprintCards:      map[int]model.SprintCard{},
+		now:              time.Now,
+	}
 }
-func (s *Storage) CreateBoard(title string) model.Board {
-	board := model.Board{
-		Title: title,
-		ID:    s.boardID,
+
+// SetClock подменяет часы, по которым проставляются метки времени.
+// Нужен тестам других пакетов, которые проверяют расчёты по времени.
+func (s *Storage) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+func (s *Storage) GetBoards() ([]model.Board, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sortedByID(s.boards, func(b model.Board) int { return b.ID }), nil
+}
+
+func (s *Storage) GetBoard(id int) (model.Board, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	board, ok := s.boards[id]
+	if !ok {
+		return model.Board{}, fmt.Errorf("board %d: %w", id, model.ErrNotFound)
 	}
-	s.Boards[s.boardID] = board
+	return board, nil
+}
+
+func (s *Storage) CreateBoard(title string) (model.Board, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.boardID++
-	return board
+	now := s.now()
+	board := model.Board{
+		ID:        s.boardID,
+		Title:     title,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.boards[board.ID] = board
+	return board, nil
 }
-func (s *Storage) GetLists(listID *int) []model.List {
-	if listID == nil {
-		var result []model.List
-		for _, b := range s.Boards {
-			result = append(result, b.Lists...)
-		}
-		return result
 
+func (s *Storage) GetTemplates() ([]model.Board, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	boards := sortedByID(s.boards, func(b model.Board) int { return b.ID })
+	return slices.DeleteFunc(boards, func(b model.Board) bool { return !b.IsTemplate }), nil
+}
+
+func (s *Storage) SetBoardTemplate(id int, isTemplate bool) (model.Board, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	board, ok := s.boards[id]
+	if !ok {
+		return model.Board{}, fmt.Errorf("board %d: %w", id, model.ErrNotFound)
 	}
-	for _, b := range s.Boards {
-		for _, l := range b.Lists {
-			if l.ID == *listID {
-				return []model.List{l}
-			}
+	board.IsTemplate = isTemplate
+	board.UpdatedAt = s.now()
+	s.boards[id] = board
+	return board, nil
+}
+
+func (s *Storage) GetLists(boardID *int) ([]model.List, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	lists := sortedByID(s.lists, func(l model.List) int { return l.ID })
+	if boardID != nil {
+		lists = slices.DeleteFunc(lists, func(l model.List) bool { return l.BoardID != *boardID })
+	}
+	for i := range lists {
+		lists[i].CardCount = s.listCardCount(lists[i].ID)
+	}
+	return lists, nil
+}
+
+// LockList ничего не блокирует отдельно: InTx и так держит всё хранилище
+// на запись до конца транзакции.
+func (s *Storage) LockList(id int) (model.List, error) {
+	return s.GetList(id)
+}
+
+func (s *Storage) GetList(id int) (model.List, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list, ok := s.lists[id]
+	if !ok {
+		return model.List{}, fmt.Errorf("list %d: %w", id, model.ErrNotFound)
+	}
+	list.CardCount = s.listCardCount(id)
+	return list, nil
+}
+
+// listCardCount считает карточки списка, кроме архивных и удалённых.
+// Вызывающий держит блокировку.
+func (s *Storage) listCardCount(listID int) int {
+	n := 0
+	for _, c := range s.cards {
+		if c.ListID == listID && c.ArchivedAt == nil && c.DeletedAt == nil {
+			n++
 		}
 	}
-	return []model.List{}
+	return n
 }
-func (s *Storage) CreateList(title string, boardID int) model.List {
-	newList := model.List{
-		ID:      s.listID,
-		Title:   title,
-		BoardID: boardID,
+
+func (s *Storage) CreateList(input model.ListInputCreate) (model.List, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[input.BoardID]; !ok {
+		return model.List{}, fmt.Errorf("board %d: %w", input.BoardID, model.ErrNotFound)
 	}
 	s.listID++
-	board, ok := s.Boards[boardID]
+	now := s.now()
+	list := model.List{
+		ID:        s.listID,
+		BoardID:   input.BoardID,
+		Title:     input.Title,
+		WIPLimit:  clonePtr(input.WIPLimit),
+		WIPSoft:   input.WIPSoft,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.lists[list.ID] = list
+	return list, nil
+}
+
+func (s *Storage) SetListWIPLimit(id int, limit model.ListWIPLimit) (model.List, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list, ok := s.lists[id]
 	if !ok {
-		return model.List{}
-	}
-	board.Lists = append(board.Lists, newList)
-	s.Boards[boardID] = board
-	return newList
-}
-func (s *Storage) GetCards(cardID *int) []model.Card {
-	if cardID == nil {
-		var result []model.Card
-		for _, b := range s.Boards {
-			for _, l := range b.Lists {
-				result = append(result, l.Cards...)
-			}
-		}
-		return result
-	}
-	for _, b := range s.Boards {
-		for _, l := range b.Lists {
-			for _, c := range l.Cards {
-				if c.ID == *cardID {
-					return []model.Card{c}
-				}
-			}
+		return model.List{}, fmt.Errorf("list %d: %w", id, model.ErrNotFound)
+	}
+	list.WIPLimit = clonePtr(limit.Limit)
+	list.WIPSoft = limit.Soft
+	list.UpdatedAt = s.now()
+	s.lists[id] = list
+	list.CardCount = s.listCardCount(id)
+	return list, nil
+}
+
+func (s *Storage) GetCards(filter model.CardFilter) ([]model.Card, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cards := sortedByID(s.cards, func(c model.Card) int { return c.ID })
+	cards = slices.DeleteFunc(cards, func(c model.Card) bool { return !s.matchCard(c, filter) })
+	if filter.Sort != nil {
+		if err := s.sortCards(cards, *filter.Sort); err != nil {
+			return nil, err
 		}
 	}
-	return []model.Card{}
+	return cards, nil
+}
+
+func (s *Storage) GetCard(id int) (model.Card, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	card, ok := s.cards[id]
+	if !ok || card.DeletedAt != nil {
+		return model.Card{}, fmt.Errorf("card %d: %w", id, model.ErrNotFound)
+	}
+	return card, nil
 }
 
-func (s *Storage) CreateCard(title string, boardID int, listID int) model.Card {
-	newCard := model.Card{
-		Title:   title,
-		ID:      s.cardID,
-		BoardID: boardID,
-		ListID:  listID,
+func (s *Storage) CreateCard(input model.CardInputCreate) (model.Card, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list, ok := s.lists[input.ListID]
+	if !ok {
+		return model.Card{}, fmt.Errorf("list %d: %w", input.ListID, model.ErrNotFound)
 	}
-	newCard.ID = s.cardID
 	s.cardID++
-	for i := range s.Boards {
-		if s.Boards[i].ID == boardID {
-			for j := range s.Boards[i].Lists {
-				if s.Boards[i].Lists[j].ID == listID {
-					s.Boards[i].Lists[j].Cards = append(s.Boards[i].Lists[j].Cards, newCard)
-					return newCard
-				}
-			}
+	now := s.now()
+	card := model.Card{
+		ID:          s.cardID,
+		BoardID:     list.BoardID,
+		ListID:      list.ID,
+		Title:       input.Title,
+		Description: input.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.cards[card.ID] = card
+	return card, nil
+}
+
+func (s *Storage) DeleteCard(listID int, cardID int) (model.Card, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	card, ok := s.cards[cardID]
+	if !ok || card.ListID != listID || card.DeletedAt != nil {
+		return model.Card{}, fmt.Errorf("card %d in list %d: %w", cardID, listID, model.ErrNotFound)
+	}
+	now := s.now()
+	card.DeletedAt = &now
+	s.cards[cardID] = card
+	return card, nil
+}
+
+// deleteCardChildren повторяет ON DELETE CASCADE из SQL-схемы.
+func (s *Storage) deleteCardChildren(cardID int) {
+	delete(s.cardLanes, cardID)
+	for id, sc := range s.sprintCards {
+		if sc.CardID == cardID {
+			delete(s.sprintCards, id)
+		}
+	}
+	delete(s.cardLabels, cardID)
+	delete(s.dueTriggered, cardID)
+	delete(s.dueSoonTriggered, cardID)
+	delete(s.recurrences, cardID)
+	for id, n := range s.notifications {
+		if n.CardID == cardID {
+			delete(s.notifications, id)
 		}
 	}
-	return model.Card{}
-}
-func (s *Storage) DeleteCard(boardID int, listID int, cardID int) (model.Card, error) {
-	for i := range s.Boards {
-		if s.Boards[i].ID == boardID {
-			for j := range s.Boards[i].Lists {
-				if s.Boards[i].Lists[j].ID == listID {
-					cards := s.Boards[i].Lists[j].Cards
-					for k, c := range cards {
-						if c.ID == cardID {
-							s.Boards[i].Lists[j].Cards = append(cards[:k], cards[k+1:]...)
-							return c, nil
-						}
-					}
-					return model.Card{}, fmt.Errorf("card %d not found in list %d", cardID, listID)
-				}
+	delete(s.assignees, cardID)
+	for id, c := range s.checklists {
+		if c.CardID != cardID {
+			continue
+		}
+		for itemID, item := range s.items {
+			if item.ChecklistID == id {
+				delete(s.items, itemID)
 			}
-			return model.Card{}, fmt.Errorf("list %d not found in board %d", listID, boardID)
+		}
+		delete(s.checklists, id)
+	}
+	for id, c := range s.comments {
+		if c.CardID == cardID {
+			delete(s.comments, id)
+		}
+	}
+	for id, m := range s.mentions {
+		if m.CardID == cardID {
+			delete(s.mentions, id)
+		}
+	}
+	for key := range s.watches {
+		if key.kind == model.WatchCard && key.targetID == cardID {
+			delete(s.watches, key)
+		}
+	}
+	for id, l := range s.links {
+		if l.SourceID == cardID || l.TargetID == cardID {
+			delete(s.links, id)
+		}
+	}
+	for key := range s.fieldValues {
+		if key.cardID == cardID {
+			delete(s.fieldValues, key)
+		}
+	}
+	for id, m := range s.cardMoves {
+		if m.CardID == cardID {
+			delete(s.cardMoves, id)
 		}
 	}
-	return model.Card{}, fmt.Errorf("board %d not found", boardID)
 }
+
 func (s *Storage) UpdateCard(updated model.Card) (model.Card, error) {
-	for i := range s.Boards {
-		if s.Boards[i].ID == updated.BoardID {
-			for j := range s.Boards[i].Lists {
-				if s.Boards[i].Lists[j].ID == updated.ListID {
-					for c := range s.Boards[i].Lists[j].Cards {
-						if s.Boards[i].Lists[j].Cards[c].ID == updated.ID {
-							s.Boards[i].Lists[j].Cards[c].Title = updated.Title
-							s.Boards[i].Lists[j].Cards[c].Description = updated.Description
-							return s.Boards[i].Lists[j].Cards[c], nil
-						}
-					}
-					return model.Card{}, fmt.Errorf("card %d not found in list %d", updated.ID, updated.ListID)
-				}
-			}
-			return model.Card{}, fmt.Errorf("list %d not found in board %d", updated.ListID, updated.BoardID)
-		}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	card, ok := s.cards[updated.ID]
+	if !ok || card.DeletedAt != nil {
+		return model.Card{}, fmt.Errorf("card %d: %w", updated.ID, model.ErrNotFound)
+	}
+	list, ok := s.lists[updated.ListID]
+	if !ok {
+		return model.Card{}, fmt.Errorf("list %d: %w", updated.ListID, model.ErrNotFound)
+	}
+	card.Title = updated.Title
+	card.Description = updated.Description
+	card.ListID = list.ID
+	card.BoardID = list.BoardID
+	card.UpdatedAt = s.now()
+	s.cards[card.ID] = card
+	return card, nil
+}
+
+func sortedByID[T any](m map[int]T, id func(T) int) []T {
+	result := slices.Collect(maps.Values(m))
+	slices.SortFunc(result, func(a, b T) int { return id(a) - id(b) })
+	return result
+}
+
+// clonePtr не даёт вызывающему изменить значение в хранилище через
+// указатель.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
 	}
-	return model.Card{}, fmt.Errorf("board %d not found", updated.BoardID)
+	v := *p
+	return &v
 }
@@ -2,15 +2,44 @@ package storage
 
 import (
 	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage/storagetest"
+	"errors"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 )
 
-func TestStorage_CreateBoard(t *testing.T) {
-	type fields struct {
-		Boards  map[int]model.Board
-		boardID int
+var fixedNow = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type fields struct {
+	boards  map[int]model.Board
+	lists   map[int]model.List
+	cards   map[int]model.Card
+	boardID int
+	listID  int
+	cardID  int
+}
+
+func (f fields) storage() *Storage {
+	s := NewStorage()
+	s.now = func() time.Time { return fixedNow }
+	if f.boards != nil {
+		s.boards = f.boards
+	}
+	if f.lists != nil {
+		s.lists = f.lists
 	}
+	if f.cards != nil {
+		s.cards = f.cards
+	}
+	s.boardID = f.boardID
+	s.listID = f.listID
+	s.cardID = f.cardID
+	return s
+}
+
+func TestStorage_CreateBoard(t *testing.T) {
 	type args struct {
 		title string
 	}
@@ -22,572 +51,423 @@ func TestStorage_CreateBoard(t *testing.T) {
 	}{
 		{
 			name:   "success",
-			fields: fields{Boards: map[int]model.Board{}, boardID: 1},
+			fields: fields{},
 			args:   args{title: "test"},
 			want: model.Board{
-				ID:    1,
-				Title: "test",
+				ID:        1,
+				Title:     "test",
+				CreatedAt: fixedNow,
+				UpdatedAt: fixedNow,
 			},
 		},
 		{
-			name:   "error create board",
-			fields: fields{Boards: map[int]model.Board{}, boardID: 333},
-			args:   args{title: "bad test"},
+			name:   "continues sequence",
+			fields: fields{boardID: 332},
+			args:   args{title: "next"},
 			want: model.Board{
-				ID:    333,
-				Title: "bad test",
+				ID:        333,
+				Title:     "next",
+				CreatedAt: fixedNow,
+				UpdatedAt: fixedNow,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{
-				Boards:  tt.fields.Boards,
-				boardID: tt.fields.boardID,
+			s := tt.fields.storage()
+			got, err := s.CreateBoard(tt.args.title)
+			if err != nil {
+				t.Fatalf("CreateBoard() error = %v", err)
 			}
-			if got := s.CreateBoard(tt.args.title); !reflect.DeepEqual(got, tt.want) {
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CreateBoard() = %v, want %v", got, tt.want)
 			}
+			if !reflect.DeepEqual(s.boards[got.ID], tt.want) {
+				t.Errorf("board not stored: %v", s.boards)
+			}
 		})
 	}
 }
 
-func TestStorage_CreateCard(t *testing.T) {
-	type fields struct {
-		Boards  map[int]model.Board
-		boardID int
-		listID  int
-		cardID  int
-	}
-	type args struct {
-		title   string
-		boardID int
-		listID  int
-	}
+func TestStorage_GetBoards(t *testing.T) {
 	tests := []struct {
-		name       string
-		fields     fields
-		args       args
-		wantID     int
-		wantTitle  string
-		wantInList bool
+		name   string
+		fields fields
+		want   []model.Board
 	}{
 		{
-			name: "success",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID:    1,
-						Title: "Board 1",
-						Lists: []model.List{
-							{
-								ID:    1,
-								Title: "List 1",
-								Cards: []model.Card{},
-							},
-						},
-					},
-				},
-				boardID: 1,
-				listID:  1,
-				cardID:  1,
-			},
-			args: args{
-				title:   "Test Card",
-				boardID: 1,
-				listID:  1,
+			name: "sorted by id",
+			fields: fields{boards: map[int]model.Board{
+				2: {ID: 2, Title: "Board 2"},
+				1: {ID: 1, Title: "Board 1"},
+				3: {ID: 3, Title: "Board 3"},
+			}},
+			want: []model.Board{
+				{ID: 1, Title: "Board 1"},
+				{ID: 2, Title: "Board 2"},
+				{ID: 3, Title: "Board 3"},
 			},
-			wantID:     1,
-			wantTitle:  "Test Card",
-			wantInList: true,
+		},
+		{
+			name:   "empty",
+			fields: fields{},
+			want:   nil,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{
-				Boards:  tt.fields.Boards,
-				boardID: tt.fields.boardID,
-				listID:  tt.fields.listID,
-				cardID:  tt.fields.cardID,
+			got, err := tt.fields.storage().GetBoards()
+			if err != nil {
+				t.Fatalf("GetBoards() error = %v", err)
 			}
-
-			got := s.CreateCard(tt.args.title, tt.args.boardID, tt.args.listID)
-
-			if got.ID != tt.wantID || got.Title != tt.wantTitle {
-				t.Errorf("CreateCard() = %+v, want ID=%d, Title=%q", got, tt.wantID, tt.wantTitle)
-			}
-
-			if tt.wantInList {
-				board := s.Boards[tt.args.boardID]
-				found := false
-				for _, list := range board.Lists {
-					if list.ID == tt.args.listID {
-						for _, card := range list.Cards {
-							if card.ID == got.ID && card.Title == got.Title {
-								found = true
-								break
-							}
-						}
-					}
-				}
-				if !found {
-					t.Errorf("Created card not found in list: %+v", board.Lists)
-				}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBoards() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
 func TestStorage_CreateList(t *testing.T) {
-	type fields struct {
-		Boards  map[int]model.Board
-		boardID int
-		listID  int
-	}
-	type args struct {
-		title   string
-		boardID int
-	}
 	tests := []struct {
-		name        string
-		fields      fields
-		args        args
-		wantID      int
-		wantTitle   string
-		wantInBoard bool
+		name    string
+		fields  fields
+		input   model.ListInputCreate
+		want    model.List
+		wantErr error
 	}{
 		{
 			name: "success",
 			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID:    1,
-						Title: "Test Board",
-						Lists: []model.List{},
-					},
-				},
-				boardID: 1,
-				listID:  1,
+				boards: map[int]model.Board{1: {ID: 1, Title: "Test Board"}},
+				listID: 4,
 			},
-			args: args{
-				title:   "test",
-				boardID: 1,
+			input: model.ListInputCreate{BoardID: 1, Title: "test"},
+			want: model.List{
+				ID:        5,
+				BoardID:   1,
+				Title:     "test",
+				CreatedAt: fixedNow,
+				UpdatedAt: fixedNow,
 			},
-			wantID:      1,
-			wantTitle:   "test",
-			wantInBoard: true,
+		},
+		{
+			name:    "board not found",
+			fields:  fields{},
+			input:   model.ListInputCreate{BoardID: 42, Title: "test"},
+			wantErr: model.ErrNotFound,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{
-				Boards:  tt.fields.Boards,
-				boardID: tt.fields.boardID,
-				listID:  tt.fields.listID,
+			s := tt.fields.storage()
+			got, err := s.CreateList(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateList() error = %v, want %v", err, tt.wantErr)
 			}
-
-			got := s.CreateList(tt.args.title, tt.args.boardID)
-
-			if got.ID != tt.wantID || got.Title != tt.wantTitle {
-				t.Errorf("CreateList() = %+v, want ID=%d, Title=%q", got, tt.wantID, tt.wantTitle)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateList() = %+v, want %+v", got, tt.want)
 			}
-
-			if tt.wantInBoard {
-				board := s.Boards[tt.args.boardID]
-				found := false
-				for _, list := range board.Lists {
-					if list.ID == got.ID && list.Title == got.Title {
-						found = true
-						break
-					}
-				}
-				if !found {
-					t.Errorf("Created list not found in board's list slice: got %+v", board.Lists)
-				}
+			if tt.wantErr == nil && !reflect.DeepEqual(s.lists[got.ID], got) {
+				t.Errorf("created list not stored: %+v", s.lists)
 			}
 		})
 	}
 }
 
-func TestStorage_GetCards(t *testing.T) {
-	type fields struct {
-		Boards  map[int]model.Board
-		boardID int
-		listID  int
-		cardID  int
-	}
-	type args struct {
-		cardID *int
+func TestStorage_GetLists(t *testing.T) {
+	lists := map[int]model.List{
+		1: {ID: 1, BoardID: 1, Title: "List 1"},
+		2: {ID: 2, BoardID: 2, Title: "List 2"},
+		3: {ID: 3, BoardID: 1, Title: "List 3"},
 	}
+	boardID := 1
+	missing := 999
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   []model.Card
+		name    string
+		boardID *int
+		want    []model.List
 	}{
 		{
-			name: "success",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID: 1,
-						Lists: []model.List{
-							{
-								ID: 1,
-								Cards: []model.Card{
-									{ID: 1, Title: "Test Card"},
-									{ID: 2, Title: "Test Card"},
-								},
-							},
-						},
-					},
-				},
-				boardID: 1,
-				listID:  1,
-			},
-			args: args{cardID: nil},
-			want: []model.Card{
-				{ID: 1, Title: "Test Card"},
-				{ID: 2, Title: "Test Card"},
-			},
+			name:    "all lists",
+			boardID: nil,
+			want:    []model.List{lists[1], lists[2], lists[3]},
 		},
 		{
-			name: "error",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID: 1,
-						Lists: []model.List{
-							{
-								ID: 1,
-								Cards: []model.Card{
-									{ID: 1, Title: "Test Card"},
-									{ID: 2, Title: "Test Card"},
-								},
-							},
-						},
-					},
-				},
-				boardID: 1,
-				listID:  1,
-			},
-			args: func() args {
-				id := 999
-				return args{cardID: &id}
-			}(),
-			want: []model.Card{},
+			name:    "by board",
+			boardID: &boardID,
+			want:    []model.List{lists[1], lists[3]},
+		},
+		{
+			name:    "unknown board",
+			boardID: &missing,
+			want:    []model.List{},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{
-				Boards:  tt.fields.Boards,
-				boardID: tt.fields.boardID,
-				listID:  tt.fields.listID,
-				cardID:  tt.fields.cardID,
+			s := fields{lists: lists}.storage()
+			got, err := s.GetLists(tt.boardID)
+			if err != nil {
+				t.Fatalf("GetLists() error = %v", err)
 			}
-			if got := s.GetCards(tt.args.cardID); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetCards() = %v, want %v", got, tt.want)
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("GetLists() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestStorage_GetLists(t *testing.T) {
-	type fields struct {
-		Boards  map[int]model.Board
-		boardID int
-		listID  int
-		cardID  int
-	}
-	type args struct {
-		listID *int
-	}
+func TestStorage_CreateCard(t *testing.T) {
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   []model.List
+		name    string
+		fields  fields
+		input   model.CardInputCreate
+		want    model.Card
+		wantErr error
 	}{
 		{
 			name: "success",
 			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID: 1,
-						Lists: []model.List{
-							{ID: 1, Title: "Test List"},
-							{ID: 2, Title: "Test List"},
-						},
-					},
-				},
-				boardID: 1,
+				boards: map[int]model.Board{7: {ID: 7}},
+				lists:  map[int]model.List{3: {ID: 3, BoardID: 7}},
 			},
-			args: args{listID: nil},
-			want: []model.List{
-				{ID: 1, Title: "Test List"},
-				{ID: 2, Title: "Test List"},
+			input: model.CardInputCreate{ListID: 3, Title: "Test Card", Description: "desc"},
+			want: model.Card{
+				ID:          1,
+				BoardID:     7,
+				ListID:      3,
+				Title:       "Test Card",
+				Description: "desc",
+				CreatedAt:   fixedNow,
+				UpdatedAt:   fixedNow,
 			},
 		},
 		{
-			name: "error",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID: 1,
-						Lists: []model.List{
-							{ID: 1, Title: "Test List"},
-							{ID: 2, Title: "Test List"},
-						},
-					},
-				},
-				boardID: 1,
-			},
-			args: func() args {
-				id := 999
-				return args{listID: &id}
-			}(),
-			want: []model.List{},
+			name:    "list not found",
+			fields:  fields{},
+			input:   model.CardInputCreate{ListID: 3, Title: "Test Card"},
+			wantErr: model.ErrNotFound,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{
-				Boards:  tt.fields.Boards,
-				boardID: tt.fields.boardID,
-				listID:  tt.fields.listID,
-				cardID:  tt.fields.cardID,
+			s := tt.fields.storage()
+			got, err := s.CreateCard(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateCard() error = %v, want %v", err, tt.wantErr)
 			}
-			if got := s.GetLists(tt.args.listID); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetLists() = %v, want %v", got, tt.want)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateCard() = %+v, want %+v", got, tt.want)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(s.cards[got.ID], got) {
+				t.Errorf("created card not stored: %+v", s.cards)
 			}
 		})
 	}
 }
 
-func TestStorage_GetBoards(t *testing.T) {
-	type fields struct {
-		Boards  map[int]model.Board
-		boardID int
-		listID  int
-		cardID  int
+func TestStorage_GetCards(t *testing.T) {
+	cards := map[int]model.Card{
+		1: {ID: 1, ListID: 1, Title: "Card 1"},
+		2: {ID: 2, ListID: 2, Title: "Card 2"},
+		3: {ID: 3, ListID: 1, Title: "Card 3"},
 	}
+	listID := 1
+	missing := 999
 	tests := []struct {
 		name   string
-		fields fields
-		want   []model.Board
+		listID *int
+		want   []model.Card
 	}{
 		{
-			name: "success",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {ID: 1, Title: "Test Board"},
-				},
-			},
-			want: []model.Board{
-				{ID: 1, Title: "Test Board"},
-			},
+			name:   "all cards",
+			listID: nil,
+			want:   []model.Card{cards[1], cards[2], cards[3]},
 		},
 		{
-			name: "error",
-			fields: fields{
-				Boards: map[int]model.Board{
-					555: {ID: 555, Title: "Test Board"},
-				},
-			},
-			want: []model.Board{
-				{ID: 555, Title: "Test Board"},
-			},
+			name:   "by list",
+			listID: &listID,
+			want:   []model.Card{cards[1], cards[3]},
+		},
+		{
+			name:   "unknown list",
+			listID: &missing,
+			want:   []model.Card{},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{
-				Boards:  tt.fields.Boards,
-				boardID: tt.fields.boardID,
-				listID:  tt.fields.listID,
-				cardID:  tt.fields.cardID,
+			s := fields{cards: cards}.storage()
+			got, err := s.GetCards(model.CardFilter{ListID: tt.listID})
+			if err != nil {
+				t.Fatalf("GetCards() error = %v", err)
 			}
-			if got := s.GetBoards(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetBoards() = %v, want %v", got, tt.want)
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("GetCards() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
 func TestStorage_DeleteCard(t *testing.T) {
-	type fields struct {
-		Boards  map[int]model.Board
-		boardID int
-		listID  int
-		cardID  int
-	}
 	type args struct {
-		boardID int
-		listID  int
-		cardID  int
+		listID int
+		cardID int
 	}
 	tests := []struct {
 		name    string
-		fields  fields
 		args    args
 		want    model.Card
-		wantErr bool
+		wantErr error
 	}{
 		{
-			name: "successfully deletes card",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID: 1,
-						Lists: []model.List{
-							{
-								ID: 1,
-								Cards: []model.Card{
-									{ID: 1, Title: "Test Card"},
-									{ID: 2, Title: "Second Card"},
-								},
-							},
-						},
-					},
-				},
-			},
-			args: args{
-				boardID: 1,
-				listID:  1,
-				cardID:  1,
-			},
-			want:    model.Card{ID: 1, Title: "Test Card"},
-			wantErr: false,
+			name: "success",
+			args: args{listID: 1, cardID: 2},
+			want: model.Card{ID: 2, ListID: 1, Title: "Card 2", DeletedAt: &fixedNow},
+		},
+		{
+			name:    "card not found",
+			args:    args{listID: 1, cardID: 99},
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name:    "card already in trash",
+			args:    args{listID: 1, cardID: 3},
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name:    "card in another list",
+			args:    args{listID: 5, cardID: 2},
+			wantErr: model.ErrNotFound,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{
-				Boards:  tt.fields.Boards,
-				boardID: tt.fields.boardID,
-				listID:  tt.fields.listID,
-				cardID:  tt.fields.cardID,
-			}
-			got, err := s.DeleteCard(tt.args.boardID, tt.args.listID, tt.args.cardID)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("DeleteCard() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			s := fields{cards: map[int]model.Card{
+				1: {ID: 1, ListID: 1, Title: "Card 1"},
+				2: {ID: 2, ListID: 1, Title: "Card 2"},
+				3: {ID: 3, ListID: 1, Title: "Card 3", DeletedAt: &fixedNow},
+			}}.storage()
+			got, err := s.DeleteCard(tt.args.listID, tt.args.cardID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteCard() error = %v, want %v", err, tt.wantErr)
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("DeleteCard() got = %v, want %v", got, tt.want)
+				t.Errorf("DeleteCard() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr == nil && s.cards[tt.args.cardID].DeletedAt == nil {
+				t.Errorf("card %d was not moved to trash", tt.args.cardID)
+			}
+			if s.cards[1].DeletedAt != nil {
+				t.Errorf("unrelated card was deleted: %v", s.cards[1])
 			}
 		})
 	}
 }
 
 func TestStorage_UpdateCard(t *testing.T) {
-	type fields struct {
-		Boards  map[int]model.Board
-		boardID int
-		listID  int
-		cardID  int
-	}
-	type args struct {
-		updated model.Card
-	}
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name    string
-		fields  fields
-		args    args
+		updated model.Card
 		want    model.Card
-		wantErr bool
+		wantErr error
 	}{
 		{
-			name: "successfully updates card",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID: 1,
-						Lists: []model.List{
-							{
-								ID: 1,
-								Cards: []model.Card{
-									{ID: 1, Title: "Old Title", Description: "Old Desc", ListID: 1, BoardID: 1},
-								},
-							},
-						},
-					},
-				},
+			name:    "success",
+			updated: model.Card{ID: 1, ListID: 1, Title: "New", Description: "New desc"},
+			want: model.Card{
+				ID:          1,
+				BoardID:     1,
+				ListID:      1,
+				Title:       "New",
+				Description: "New desc",
+				CreatedAt:   created,
+				UpdatedAt:   fixedNow,
 			},
-			args: args{
-				updated: model.Card{ID: 1, Title: "New Title", Description: "New Desc", ListID: 1, BoardID: 1},
-			},
-			want:    model.Card{ID: 1, Title: "New Title", Description: "New Desc", ListID: 1, BoardID: 1},
-			wantErr: false,
 		},
 		{
-			name: "card not found",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID: 1,
-						Lists: []model.List{
-							{
-								ID: 1,
-								Cards: []model.Card{
-									{ID: 2, Title: "Other Card", ListID: 1, BoardID: 1},
-								},
-							},
-						},
-					},
-				},
-			},
-			args: args{
-				updated: model.Card{ID: 999, Title: "Doesn't Exist", ListID: 1, BoardID: 1},
+			name:    "move to another list",
+			updated: model.Card{ID: 1, ListID: 2, Title: "Old"},
+			want: model.Card{
+				ID:        1,
+				BoardID:   1,
+				ListID:    2,
+				Title:     "Old",
+				CreatedAt: created,
+				UpdatedAt: fixedNow,
 			},
-			want:    model.Card{},
-			wantErr: true,
 		},
 		{
-			name: "list not found",
-			fields: fields{
-				Boards: map[int]model.Board{
-					1: {
-						ID:    1,
-						Lists: []model.List{},
-					},
-				},
-			},
-			args: args{
-				updated: model.Card{ID: 1, Title: "Any", ListID: 999, BoardID: 1},
-			},
-			want:    model.Card{},
-			wantErr: true,
+			name:    "card not found",
+			updated: model.Card{ID: 42, ListID: 1, Title: "New"},
+			wantErr: model.ErrNotFound,
 		},
 		{
-			name: "board not found",
-			fields: fields{
-				Boards: map[int]model.Board{},
-			},
-			args: args{
-				updated: model.Card{ID: 1, Title: "Any", ListID: 1, BoardID: 999},
-			},
-			want:    model.Card{},
-			wantErr: true,
+			name:    "list not found",
+			updated: model.Card{ID: 1, ListID: 42, Title: "New"},
+			wantErr: model.ErrNotFound,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{
-				Boards:  tt.fields.Boards,
-				boardID: tt.fields.boardID,
-				listID:  tt.fields.listID,
-				cardID:  tt.fields.cardID,
-			}
-			got, err := s.UpdateCard(tt.args.updated)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("UpdateCard() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			s := fields{
+				lists: map[int]model.List{
+					1: {ID: 1, BoardID: 1},
+					2: {ID: 2, BoardID: 1},
+				},
+				cards: map[int]model.Card{
+					1: {ID: 1, BoardID: 1, ListID: 1, Title: "Old", Description: "Old desc", CreatedAt: created, UpdatedAt: created},
+				},
+			}.storage()
+			got, err := s.UpdateCard(tt.updated)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateCard() error = %v, want %v", err, tt.wantErr)
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("UpdateCard() got = %v, want %v", got, tt.want)
+				t.Errorf("UpdateCard() = %+v, want %+v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestStorage_Concurrent(t *testing.T) {
+	s := NewStorage()
+	board, _ := s.CreateBoard("board")
+	list, _ := s.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "list"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := s.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "card"}); err != nil {
+				t.Errorf("CreateCard() error = %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := s.GetCards(model.CardFilter{ListID: &list.ID}); err != nil {
+				t.Errorf("GetCards() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	cards, _ := s.GetCards(model.CardFilter{})
+	if len(cards) != 50 {
+		t.Fatalf("expected 50 cards, got %d", len(cards))
+	}
+	for i, c := range cards {
+		if c.ID != i+1 {
+			t.Errorf("card ids are not unique and sequential: %v", cards)
+			break
+		}
+	}
+}
+
+func TestStorage_Conformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Store {
+		return NewStorage()
+	})
+}
@@ -0,0 +1,1992 @@
+// Package storagetest содержит общий набор проверок для всех реализаций
+// хранилища, чтобы поведение бэкендов не расходилось.
+package storagetest
+
+import (
+	"awesomeProject2/cmd/automation"
+	"awesomeProject2/cmd/calendar"
+	"awesomeProject2/cmd/email"
+	"awesomeProject2/cmd/lane"
+	"awesomeProject2/cmd/mention"
+	"awesomeProject2/cmd/metrics"
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/notification"
+	"awesomeProject2/cmd/recurrence"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/sprint"
+	"awesomeProject2/cmd/view"
+	"awesomeProject2/cmd/watch"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+type Store interface {
+	service.BoardStorage
+	service.ListStorage
+	service.CardStorage
+	service.LabelStorage
+	service.AssigneeStorage
+	service.ChecklistStorage
+	service.CommentStorage
+	service.Transactor
+	service.TrashStorage
+	automation.RuleStorage
+	automation.DueStorage
+	recurrence.Storage
+	notification.Storage
+	email.Storage
+	service.MemberStorage
+	mention.Storage
+	watch.Storage
+	service.CardLinkStorage
+	service.FieldStorage
+	view.Storage
+	calendar.TokenStorage
+	calendar.BoardReader
+	metrics.Storage
+	lane.Storage
+	service.LaneStorage
+	sprint.Storage
+	service.SprintStorage
+}
+
+// Run прогоняет набор проверок. newStore должен возвращать пустое
+// хранилище для каждого вызова.
+func Run(t *testing.T, newStore func(t *testing.T) Store) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s Store)
+	}{
+		{"boards create and read", testBoards},
+		{"board by id", testGetBoard},
+		{"board templates", testBoardTemplates},
+		{"lists create and read", testLists},
+		{"list by id", testGetList},
+		{"list for missing board", testListMissingBoard},
+		{"list wip limit and card count", testListWIPLimit},
+		{"hard wip limit under concurrent adds", testWIPLimitConcurrent},
+		{"cards create and read", testCards},
+		{"card for missing list", testCardMissingList},
+		{"card by id", testGetCard},
+		{"card update", testCardUpdate},
+		{"card update not found", testCardUpdateNotFound},
+		{"card delete", testCardDelete},
+		{"card delete not found", testCardDeleteNotFound},
+		{"labels", testLabels},
+		{"card labels", testCardLabels},
+		{"card assignees", testCardAssignees},
+		{"checklists", testChecklists},
+		{"comments", testComments},
+		{"card archive", testCardArchive},
+		{"card trash and restore", testCardTrash},
+		{"trash purge keeps recent cards", testPurgeRetention},
+		{"card purge cascades", testCardPurgeCascade},
+		{"card status", testCardStatus},
+		{"card due dates", testCardDue},
+		{"automation rules", testAutomationRules},
+		{"automation runs", testAutomationRuns},
+		{"card recurrences", testRecurrences},
+		{"card due soon", testCardDueSoon},
+		{"notifications", testNotifications},
+		{"notification preferences", testNotificationPreferences},
+		{"email settings", testEmailSettings},
+		{"email digest items", testDigestItems},
+		{"email outbox", testEmailOutbox},
+		{"board members", testBoardMembers},
+		{"mentions", testMentions},
+		{"watchers", testWatchers},
+		{"card links", testCardLinks},
+		{"custom fields", testCustomFields},
+		{"cards by custom field", testCardsByField},
+		{"card query", testCardQuery},
+		{"views", testViews},
+		{"calendar tokens", testCalendarTokens},
+		{"member boards", testMemberBoards},
+		{"card moves", testCardMoves},
+		{"swimlanes", testSwimlanes},
+		{"sprints", testSprints},
+		{"one active sprint under concurrent starts", testSprintStartConcurrent},
+		{"transaction commit", testTxCommit},
+		{"transaction rollback", testTxRollback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+func testBoards(t *testing.T, s Store) {
+	boards, err := s.GetBoards()
+	require.NoError(t, err)
+	require.Empty(t, boards)
+
+	var created []model.Board
+	for _, title := range []string{"first", "second", "third"} {
+		b, err := s.CreateBoard(title)
+		require.NoError(t, err)
+		require.NotZero(t, b.ID)
+		require.Equal(t, title, b.Title)
+		require.False(t, b.CreatedAt.IsZero())
+		created = append(created, b)
+	}
+
+	boards, err = s.GetBoards()
+	require.NoError(t, err)
+	require.Len(t, boards, 3)
+	for i := range created {
+		require.Equal(t, created[i].ID, boards[i].ID)
+		require.Equal(t, created[i].Title, boards[i].Title)
+	}
+}
+
+func testBoardTemplates(t *testing.T, s Store) {
+	b1 := mustBoard(t, s, "b1")
+	b2 := mustBoard(t, s, "b2")
+	require.False(t, b1.IsTemplate)
+
+	templates, err := s.GetTemplates()
+	require.NoError(t, err)
+	require.Empty(t, templates)
+
+	marked, err := s.SetBoardTemplate(b2.ID, true)
+	require.NoError(t, err)
+	require.True(t, marked.IsTemplate)
+	require.Equal(t, "b2", marked.Title)
+
+	templates, err = s.GetTemplates()
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Equal(t, b2.ID, templates[0].ID)
+
+	boards, err := s.GetBoards()
+	require.NoError(t, err)
+	require.Len(t, boards, 2, "templates are still listed as boards")
+
+	unmarked, err := s.SetBoardTemplate(b2.ID, false)
+	require.NoError(t, err)
+	require.False(t, unmarked.IsTemplate)
+	templates, err = s.GetTemplates()
+	require.NoError(t, err)
+	require.Empty(t, templates)
+
+	_, err = s.SetBoardTemplate(b1.ID+1000, true)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testLists(t *testing.T, s Store) {
+	b1 := mustBoard(t, s, "b1")
+	b2 := mustBoard(t, s, "b2")
+	l1 := mustList(t, s, b1.ID, "todo")
+	l2 := mustList(t, s, b2.ID, "other")
+	l3 := mustList(t, s, b1.ID, "done")
+	require.Equal(t, b1.ID, l1.BoardID)
+	require.Equal(t, "todo", l1.Title)
+
+	all, err := s.GetLists(nil)
+	require.NoError(t, err)
+	require.Equal(t, []int{l1.ID, l2.ID, l3.ID}, listIDs(all))
+
+	byBoard, err := s.GetLists(&b1.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int{l1.ID, l3.ID}, listIDs(byBoard))
+
+	missing := b2.ID + 1000
+	none, err := s.GetLists(&missing)
+	require.NoError(t, err)
+	require.Empty(t, none)
+}
+
+func testGetList(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	created := mustList(t, s, b.ID, "todo")
+
+	got, err := s.GetList(created.ID)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, got.ID)
+	require.Equal(t, b.ID, got.BoardID)
+	require.Equal(t, "todo", got.Title)
+
+	_, err = s.GetList(created.ID + 1000)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testListMissingBoard(t *testing.T, s Store) {
+	_, err := s.CreateList(model.ListInputCreate{BoardID: 4242, Title: "orphan"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	lists, err := s.GetLists(nil)
+	require.NoError(t, err)
+	require.Empty(t, lists)
+}
+
+func testCards(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	todo := mustList(t, s, b.ID, "todo")
+	done := mustList(t, s, b.ID, "done")
+
+	c1 := mustCard(t, s, todo.ID, "c1")
+	c2 := mustCard(t, s, done.ID, "c2")
+	c3 := mustCard(t, s, todo.ID, "c3")
+	require.Equal(t, b.ID, c1.BoardID, "card inherits board from its list")
+	require.Equal(t, todo.ID, c1.ListID)
+	require.Equal(t, "c1", c1.Title)
+	require.Equal(t, "c1 description", c1.Description)
+	require.False(t, c1.CreatedAt.IsZero())
+
+	all, err := s.GetCards(model.CardFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []int{c1.ID, c2.ID, c3.ID}, cardIDs(all))
+
+	byList, err := s.GetCards(model.CardFilter{ListID: &todo.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int{c1.ID, c3.ID}, cardIDs(byList))
+	require.Equal(t, b.ID, byList[0].BoardID)
+}
+
+func testCardMissingList(t *testing.T, s Store) {
+	_, err := s.CreateCard(model.CardInputCreate{ListID: 4242, Title: "orphan"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testCardUpdate(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	todo := mustList(t, s, b.ID, "todo")
+	done := mustList(t, s, b.ID, "done")
+	c := mustCard(t, s, todo.ID, "c")
+
+	updated, err := s.UpdateCard(model.Card{ID: c.ID, ListID: done.ID, Title: "renamed", Description: "new"})
+	require.NoError(t, err)
+	require.Equal(t, c.ID, updated.ID)
+	require.Equal(t, "renamed", updated.Title)
+	require.Equal(t, "new", updated.Description)
+	require.Equal(t, done.ID, updated.ListID)
+	require.Equal(t, b.ID, updated.BoardID)
+
+	inDone, err := s.GetCards(model.CardFilter{ListID: &done.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int{c.ID}, cardIDs(inDone))
+	inTodo, err := s.GetCards(model.CardFilter{ListID: &todo.ID})
+	require.NoError(t, err)
+	require.Empty(t, inTodo)
+
+	other := mustBoard(t, s, "other")
+	backlog := mustList(t, s, other.ID, "backlog")
+	moved, err := s.UpdateCard(model.Card{ID: c.ID, ListID: backlog.ID, Title: "renamed"})
+	require.NoError(t, err)
+	require.Equal(t, other.ID, moved.BoardID, "board follows the list")
+	got, err := s.GetCard(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, other.ID, got.BoardID)
+}
+
+func testCardUpdateNotFound(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+
+	_, err := s.UpdateCard(model.Card{ID: c.ID + 1000, ListID: l.ID, Title: "x"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	_, err = s.UpdateCard(model.Card{ID: c.ID, ListID: l.ID + 1000, Title: "x"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	cards, err := s.GetCards(model.CardFilter{})
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	require.Equal(t, "c", cards[0].Title, "failed update must not change the card")
+	require.Equal(t, l.ID, cards[0].ListID)
+}
+
+func testCardDelete(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	keep := mustCard(t, s, l.ID, "keep")
+	gone := mustCard(t, s, l.ID, "gone")
+
+	deleted, err := s.DeleteCard(l.ID, gone.ID)
+	require.NoError(t, err)
+	require.Equal(t, gone.ID, deleted.ID)
+	require.Equal(t, "gone", deleted.Title)
+	require.Equal(t, l.ID, deleted.ListID)
+	require.Equal(t, b.ID, deleted.BoardID)
+	require.NotNil(t, deleted.DeletedAt)
+
+	cards, err := s.GetCards(model.CardFilter{ListID: &l.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int{keep.ID}, cardIDs(cards))
+	cards, err = s.GetCards(model.CardFilter{ListID: &l.ID, IncludeArchived: true})
+	require.NoError(t, err)
+	require.Equal(t, []int{keep.ID}, cardIDs(cards), "trashed cards are not archived ones")
+	_, err = s.GetCard(gone.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	_, err = s.DeleteCard(l.ID, gone.ID)
+	require.ErrorIs(t, err, model.ErrNotFound, "second delete of the same card")
+}
+
+func testCardDeleteNotFound(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l1 := mustList(t, s, b.ID, "l1")
+	l2 := mustList(t, s, b.ID, "l2")
+	c := mustCard(t, s, l1.ID, "c")
+
+	_, err := s.DeleteCard(l2.ID, c.ID)
+	require.ErrorIs(t, err, model.ErrNotFound, "card belongs to another list")
+
+	cards, err := s.GetCards(model.CardFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []int{c.ID}, cardIDs(cards))
+}
+
+func testGetBoard(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	got, err := s.GetBoard(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, b.ID, got.ID)
+	require.Equal(t, "b", got.Title)
+
+	_, err = s.GetBoard(b.ID + 1000)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testLabels(t *testing.T, s Store) {
+	b1 := mustBoard(t, s, "b1")
+	b2 := mustBoard(t, s, "b2")
+	bug := mustLabel(t, s, b1.ID, "bug")
+	mustLabel(t, s, b2.ID, "other")
+	feature := mustLabel(t, s, b1.ID, "feature")
+	require.Equal(t, b1.ID, bug.BoardID)
+	require.Equal(t, "bug", bug.Name)
+	require.Equal(t, "bug-color", bug.Color)
+
+	labels, err := s.GetLabels(b1.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.Label{bug, feature}, labels)
+
+	_, err = s.CreateLabel(model.LabelInputCreate{BoardID: b2.ID + 1000, Name: "orphan"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testCardLabels(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	other := mustBoard(t, s, "other")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+	feature := mustLabel(t, s, b.ID, "feature")
+	bug := mustLabel(t, s, b.ID, "bug")
+	foreign := mustLabel(t, s, other.ID, "foreign")
+
+	require.NoError(t, s.AddCardLabel(c.ID, bug.ID))
+	require.NoError(t, s.AddCardLabel(c.ID, feature.ID))
+	require.NoError(t, s.AddCardLabel(c.ID, bug.ID), "adding a label twice is a no-op")
+
+	labels, err := s.GetCardLabels(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.Label{feature, bug}, labels)
+
+	require.ErrorIs(t, s.AddCardLabel(c.ID, foreign.ID), model.ErrNotFound, "label from another board")
+	require.ErrorIs(t, s.AddCardLabel(c.ID+1000, bug.ID), model.ErrNotFound)
+	require.ErrorIs(t, s.AddCardLabel(c.ID, foreign.ID+1000), model.ErrNotFound)
+
+	require.NoError(t, s.RemoveCardLabel(c.ID, bug.ID))
+	require.NoError(t, s.RemoveCardLabel(c.ID, bug.ID), "removing a missing label is a no-op")
+	labels, err = s.GetCardLabels(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.Label{feature}, labels)
+}
+
+func testCardAssignees(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+
+	none, err := s.GetCardAssignees(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	require.NoError(t, s.AddCardAssignee(c.ID, "ivan"))
+	require.NoError(t, s.AddCardAssignee(c.ID, "anna"))
+	require.NoError(t, s.AddCardAssignee(c.ID, "ivan"), "assigning twice is a no-op")
+
+	assignees, err := s.GetCardAssignees(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna", "ivan"}, assignees)
+
+	require.ErrorIs(t, s.AddCardAssignee(c.ID+1000, "anna"), model.ErrNotFound)
+}
+
+func testChecklists(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+	other := mustCard(t, s, l.ID, "other")
+
+	first, err := s.CreateChecklist(model.ChecklistInputCreate{CardID: c.ID, Title: "first"})
+	require.NoError(t, err)
+	require.Equal(t, c.ID, first.CardID)
+	second, err := s.CreateChecklist(model.ChecklistInputCreate{CardID: c.ID, Title: "second"})
+	require.NoError(t, err)
+	_, err = s.CreateChecklist(model.ChecklistInputCreate{CardID: other.ID, Title: "foreign"})
+	require.NoError(t, err)
+
+	done, err := s.CreateChecklistItem(model.ChecklistItemInputCreate{ChecklistID: first.ID, Text: "done", Checked: true})
+	require.NoError(t, err)
+	require.True(t, done.Checked)
+	open, err := s.CreateChecklistItem(model.ChecklistItemInputCreate{ChecklistID: first.ID, Text: "open"})
+	require.NoError(t, err)
+
+	checklists, err := s.GetChecklists(c.ID)
+	require.NoError(t, err)
+	require.Len(t, checklists, 2)
+	require.Equal(t, first.ID, checklists[0].ID)
+	require.Equal(t, "first", checklists[0].Title)
+	require.Equal(t, []model.ChecklistItem{done, open}, checklists[0].Items)
+	require.Equal(t, second.ID, checklists[1].ID)
+	require.Empty(t, checklists[1].Items)
+
+	_, err = s.CreateChecklist(model.ChecklistInputCreate{CardID: other.ID + 1000, Title: "orphan"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+	_, err = s.CreateChecklistItem(model.ChecklistItemInputCreate{ChecklistID: second.ID + 1000, Text: "orphan"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testComments(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+
+	now, err := s.CreateComment(model.CommentInputCreate{CardID: c.ID, Author: "anna", Text: "hello"})
+	require.NoError(t, err)
+	require.Equal(t, c.ID, now.CardID)
+	require.Equal(t, "anna", now.Author)
+	require.False(t, now.CreatedAt.IsZero())
+
+	at := time.Date(2020, 5, 6, 7, 8, 9, 0, time.UTC)
+	old, err := s.CreateComment(model.CommentInputCreate{CardID: c.ID, Author: "ivan", Text: "imported", CreatedAt: &at})
+	require.NoError(t, err)
+	require.True(t, at.Equal(old.CreatedAt), "imported comment keeps its date, got %v", old.CreatedAt)
+
+	comments, err := s.GetComments(c.ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	require.Equal(t, "hello", comments[0].Text)
+	require.Equal(t, "imported", comments[1].Text)
+	require.True(t, at.Equal(comments[1].CreatedAt))
+
+	_, err = s.CreateComment(model.CommentInputCreate{CardID: c.ID + 1000, Text: "orphan"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testCardArchive(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	active := mustCard(t, s, l.ID, "active")
+	old := mustCard(t, s, l.ID, "old")
+
+	archived, err := s.ArchiveCard(old.ID)
+	require.NoError(t, err)
+	require.NotNil(t, archived.ArchivedAt)
+	again, err := s.ArchiveCard(old.ID)
+	require.NoError(t, err)
+	require.True(t, archived.ArchivedAt.Equal(*again.ArchivedAt), "archiving twice keeps the first date")
+
+	cards, err := s.GetCards(model.CardFilter{ListID: &l.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int{active.ID}, cardIDs(cards))
+	cards, err = s.GetCards(model.CardFilter{IncludeArchived: true})
+	require.NoError(t, err)
+	require.Equal(t, []int{active.ID, old.ID}, cardIDs(cards))
+	got, err := s.GetCard(old.ID)
+	require.NoError(t, err, "archived cards stay readable")
+	require.NotNil(t, got.ArchivedAt)
+
+	restored, err := s.UnarchiveCard(old.ID)
+	require.NoError(t, err)
+	require.Nil(t, restored.ArchivedAt)
+	cards, err = s.GetCards(model.CardFilter{ListID: &l.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int{active.ID, old.ID}, cardIDs(cards))
+
+	_, err = s.ArchiveCard(old.ID + 1000)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testCardTrash(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	other := mustBoard(t, s, "other")
+	l := mustList(t, s, b.ID, "todo")
+	foreignList := mustList(t, s, other.ID, "todo")
+	first := mustCard(t, s, l.ID, "first")
+	second := mustCard(t, s, l.ID, "second")
+	foreign := mustCard(t, s, foreignList.ID, "foreign")
+	require.NoError(t, s.AddCardAssignee(first.ID, "anna"))
+
+	for _, c := range []model.Card{first, second, foreign} {
+		_, err := s.DeleteCard(c.ListID, c.ID)
+		require.NoError(t, err)
+	}
+
+	trash, err := s.GetDeletedCards(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int{second.ID, first.ID}, cardIDs(trash), "newest first, only this board")
+
+	_, err = s.UpdateCard(model.Card{ID: first.ID, ListID: l.ID, Title: "x"})
+	require.ErrorIs(t, err, model.ErrNotFound, "trashed cards are read-only")
+	_, err = s.ArchiveCard(first.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	restored, err := s.RestoreCard(first.ID)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+	require.Equal(t, "first", restored.Title)
+	cards, err := s.GetCards(model.CardFilter{ListID: &l.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int{first.ID}, cardIDs(cards))
+	assignees, err := s.GetCardAssignees(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna"}, assignees, "restore brings back related data")
+
+	_, err = s.RestoreCard(first.ID)
+	require.ErrorIs(t, err, model.ErrNotFound, "card is not in the trash anymore")
+	_, err = s.RestoreCard(first.ID + 1000)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testPurgeRetention(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	live := mustCard(t, s, l.ID, "live")
+	trashed := mustCard(t, s, l.ID, "trashed")
+	_, err := s.DeleteCard(l.ID, trashed.ID)
+	require.NoError(t, err)
+
+	purged, err := s.PurgeDeletedCards(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, purged, "card was trashed less than an hour ago")
+	trash, err := s.GetDeletedCards(b.ID)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+
+	purged, err = s.PurgeDeletedCards(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	trash, err = s.GetDeletedCards(b.ID)
+	require.NoError(t, err)
+	require.Empty(t, trash)
+	_, err = s.RestoreCard(trashed.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	cards, err := s.GetCards(model.CardFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []int{live.ID}, cardIDs(cards), "live cards are never purged")
+}
+
+func testCardStatus(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+	require.Empty(t, c.Status)
+
+	updated, err := s.SetCardStatus(c.ID, "done")
+	require.NoError(t, err)
+	require.Equal(t, "done", updated.Status)
+	require.Equal(t, "c", updated.Title)
+	got, err := s.GetCard(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, "done", got.Status)
+
+	_, err = s.SetCardStatus(c.ID+1000, "done")
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testCardDue(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	soon := mustCard(t, s, l.ID, "soon")
+	later := mustCard(t, s, l.ID, "later")
+	archived := mustCard(t, s, l.ID, "archived")
+	mustCard(t, s, l.ID, "no due")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	updated, err := s.SetCardDue(soon.ID, helperTime(now.Add(-time.Hour)))
+	require.NoError(t, err)
+	require.NotNil(t, updated.DueAt)
+	require.True(t, now.Add(-time.Hour).Equal(*updated.DueAt))
+	_, err = s.SetCardDue(later.ID, helperTime(now.Add(time.Hour)))
+	require.NoError(t, err)
+	_, err = s.SetCardDue(archived.ID, helperTime(now.Add(-time.Hour)))
+	require.NoError(t, err)
+	_, err = s.ArchiveCard(archived.ID)
+	require.NoError(t, err)
+
+	due, err := s.GetDueCards(now)
+	require.NoError(t, err)
+	require.Equal(t, []int{soon.ID}, cardIDs(due), "archived and future cards are not due")
+
+	require.NoError(t, s.MarkDueTriggered(soon.ID))
+	due, err = s.GetDueCards(now)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	_, err = s.SetCardDue(soon.ID, helperTime(now.Add(-time.Minute)))
+	require.NoError(t, err)
+	due, err = s.GetDueCards(now)
+	require.NoError(t, err)
+	require.Equal(t, []int{soon.ID}, cardIDs(due), "a new due date can fire again")
+
+	cleared, err := s.SetCardDue(soon.ID, nil)
+	require.NoError(t, err)
+	require.Nil(t, cleared.DueAt)
+	due, err = s.GetDueCards(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []int{later.ID}, cardIDs(due))
+
+	_, err = s.SetCardDue(soon.ID+1000, nil)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testAutomationRules(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	other := mustBoard(t, s, "other")
+	l := mustList(t, s, b.ID, "done")
+
+	none, err := s.GetRules(b.ID)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	input := model.AutomationRuleInput{
+		BoardID: b.ID,
+		Name:    "done",
+		Enabled: true,
+		Trigger: model.AutomationTrigger{Type: model.EventCardMoved, ListID: l.ID},
+		Conditions: []model.AutomationCondition{
+			{Type: model.ConditionTitleContains, Text: "bug"},
+		},
+		Actions: []model.AutomationAction{
+			{Type: model.ActionSetStatus, Status: "done"},
+			{Type: model.ActionPostComment, Text: "closed"},
+		},
+	}
+	rule, err := s.CreateRule(input)
+	require.NoError(t, err)
+	require.NotZero(t, rule.ID)
+	require.Equal(t, b.ID, rule.BoardID)
+	require.Equal(t, input.Trigger, rule.Trigger)
+	require.Equal(t, input.Conditions, rule.Conditions)
+	require.Equal(t, input.Actions, rule.Actions)
+	require.True(t, rule.Enabled)
+	require.False(t, rule.CreatedAt.IsZero())
+
+	second, err := s.CreateRule(model.AutomationRuleInput{
+		BoardID: b.ID,
+		Name:    "created",
+		Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+		Actions: []model.AutomationAction{{Type: model.ActionAssignUser, Username: "anna"}},
+	})
+	require.NoError(t, err)
+	require.False(t, second.Enabled)
+	require.Empty(t, second.Conditions)
+
+	rules, err := s.GetRules(b.ID)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, rule, rules[0])
+	foreign, err := s.GetRules(other.ID)
+	require.NoError(t, err)
+	require.Empty(t, foreign)
+
+	input.Name = "renamed"
+	input.Enabled = false
+	input.Conditions = nil
+	updated, err := s.UpdateRule(rule.ID, input)
+	require.NoError(t, err)
+	require.Equal(t, "renamed", updated.Name)
+	require.False(t, updated.Enabled)
+	require.Empty(t, updated.Conditions)
+	got, err := s.GetRule(rule.ID)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+
+	require.NoError(t, s.DeleteRule(second.ID))
+	rules, err = s.GetRules(b.ID)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	_, err = s.GetRule(second.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+	require.ErrorIs(t, s.DeleteRule(second.ID), model.ErrNotFound)
+	_, err = s.UpdateRule(second.ID, input)
+	require.ErrorIs(t, err, model.ErrNotFound)
+	input.BoardID = other.ID + 1000
+	_, err = s.CreateRule(input)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testAutomationRuns(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	other := mustBoard(t, s, "other")
+	rule, err := s.CreateRule(model.AutomationRuleInput{
+		BoardID: b.ID,
+		Name:    "r",
+		Trigger: model.AutomationTrigger{Type: model.EventCardCreated},
+		Actions: []model.AutomationAction{{Type: model.ActionSetStatus, Status: "new"}},
+	})
+	require.NoError(t, err)
+
+	var created []model.AutomationRun
+	for i, status := range []string{model.RunStatusOK, model.RunStatusFailed, model.RunStatusSkipped} {
+		run, err := s.CreateRun(model.AutomationRun{RuleID: rule.ID, BoardID: b.ID, CardID: i + 1, Event: model.EventCardCreated, Status: status, Error: status + " error"})
+		require.NoError(t, err)
+		require.NotZero(t, run.ID)
+		require.False(t, run.CreatedAt.IsZero())
+		created = append(created, run)
+	}
+
+	runs, err := s.GetRuns(b.ID, 2)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, created[2].ID, runs[0].ID, "newest first")
+	require.Equal(t, created[1].ID, runs[1].ID)
+	require.Equal(t, model.RunStatusFailed, runs[1].Status)
+	require.Equal(t, "failed error", runs[1].Error)
+	require.Equal(t, 2, runs[1].CardID)
+
+	none, err := s.GetRuns(other.ID, 10)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	require.NoError(t, s.DeleteRule(rule.ID))
+	runs, err = s.GetRuns(b.ID, 10)
+	require.NoError(t, err)
+	require.Empty(t, runs, "runs are deleted with their rule")
+}
+
+func testRecurrences(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	todo := mustList(t, s, b.ID, "todo")
+	done := mustList(t, s, b.ID, "done")
+	weekly := mustCard(t, s, todo.ID, "weekly")
+	daily := mustCard(t, s, todo.ID, "daily")
+	trashed := mustCard(t, s, todo.ID, "trashed")
+	archived := mustCard(t, s, todo.ID, "archived")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, err := s.GetRecurrence(weekly.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	r, err := s.SaveRecurrence(model.CardRecurrence{
+		CardID:    weekly.ID,
+		ListID:    done.ID,
+		Frequency: model.RecurrenceWeekly,
+		Interval:  2,
+		StartsAt:  now.Add(-time.Hour),
+		NextRunAt: now.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, weekly.ID, r.CardID)
+	require.Equal(t, done.ID, r.ListID)
+	require.Equal(t, 2, r.Interval)
+	require.True(t, now.Add(-time.Minute).Equal(r.NextRunAt))
+	require.Nil(t, r.LastRunAt)
+	got, err := s.GetRecurrence(weekly.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.RecurrenceWeekly, got.Frequency)
+	require.True(t, r.StartsAt.Equal(got.StartsAt))
+
+	for _, c := range []model.Card{daily, trashed, archived} {
+		_, err = s.SaveRecurrence(model.CardRecurrence{
+			CardID:    c.ID,
+			ListID:    todo.ID,
+			Frequency: model.RecurrenceCron,
+			Cron:      "0 9 * * *",
+			StartsAt:  now,
+			NextRunAt: now.Add(-2 * time.Minute),
+		})
+		require.NoError(t, err)
+	}
+	_, err = s.DeleteCard(todo.ID, trashed.ID)
+	require.NoError(t, err)
+	_, err = s.GetRecurrence(trashed.ID)
+	require.ErrorIs(t, err, model.ErrNotFound, "recurrence of a trashed card is hidden")
+	_, err = s.ArchiveCard(archived.ID)
+	require.NoError(t, err)
+
+	due, err := s.GetDueRecurrences(now)
+	require.NoError(t, err)
+	require.Len(t, due, 2, "archived and trashed templates are not due")
+	require.Equal(t, daily.ID, due[0].CardID, "earliest first")
+	require.Equal(t, weekly.ID, due[1].CardID)
+
+	next := now.Add(14 * 24 * time.Hour)
+	ok, err := s.AdvanceRecurrence(weekly.ID, due[1].NextRunAt, next, now)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s.AdvanceRecurrence(weekly.ID, due[1].NextRunAt, next, now)
+	require.NoError(t, err)
+	require.False(t, ok, "the same run is claimed only once")
+	got, err = s.GetRecurrence(weekly.ID)
+	require.NoError(t, err)
+	require.True(t, next.Equal(got.NextRunAt))
+	require.NotNil(t, got.LastRunAt)
+	require.True(t, now.Equal(*got.LastRunAt))
+
+	ok, err = s.ReleaseRecurrence(due[1], now)
+	require.NoError(t, err)
+	require.False(t, ok, "a run claimed for another time is not released")
+	ok, err = s.ReleaseRecurrence(due[1], next)
+	require.NoError(t, err)
+	require.True(t, ok)
+	got, err = s.GetRecurrence(weekly.ID)
+	require.NoError(t, err)
+	require.True(t, due[1].NextRunAt.Equal(got.NextRunAt))
+	require.Nil(t, got.LastRunAt)
+	ok, err = s.AdvanceRecurrence(weekly.ID, due[1].NextRunAt, next, now)
+	require.NoError(t, err)
+	require.True(t, ok, "a released run can be claimed again")
+
+	due, err = s.GetDueRecurrences(now)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, daily.ID, due[0].CardID)
+
+	replaced, err := s.SaveRecurrence(model.CardRecurrence{
+		CardID:    weekly.ID,
+		ListID:    todo.ID,
+		Frequency: model.RecurrenceDaily,
+		Interval:  1,
+		StartsAt:  now,
+		NextRunAt: now.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.RecurrenceDaily, replaced.Frequency)
+	require.Equal(t, todo.ID, replaced.ListID)
+	require.NotNil(t, replaced.LastRunAt, "replacing keeps the last run")
+
+	require.NoError(t, s.DeleteRecurrence(weekly.ID))
+	_, err = s.GetRecurrence(weekly.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+	require.ErrorIs(t, s.DeleteRecurrence(weekly.ID), model.ErrNotFound)
+}
+
+func testCardDueSoon(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+	now := time.Now().UTC().Truncate(time.Second)
+	_, err := s.SetCardDue(c.ID, helperTime(now.Add(time.Hour)))
+	require.NoError(t, err)
+
+	soon, err := s.GetDueSoonCards(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []int{c.ID}, cardIDs(soon))
+	require.NoError(t, s.MarkDueSoonTriggered(c.ID))
+	soon, err = s.GetDueSoonCards(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, soon)
+	due, err := s.GetDueCards(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []int{c.ID}, cardIDs(due), "due soon and due passed are tracked separately")
+
+	_, err = s.SetCardDue(c.ID, helperTime(now.Add(90*time.Minute)))
+	require.NoError(t, err)
+	soon, err = s.GetDueSoonCards(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []int{c.ID}, cardIDs(soon), "a new due date warns again")
+}
+
+func testNotifications(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+	other := mustCard(t, s, l.ID, "other")
+
+	var created []model.Notification
+	for _, n := range []model.Notification{
+		{Username: "anna", Kind: model.NotificationAssigned, Event: model.EventCardAssigned, CardID: c.ID},
+		{Username: "boris", Kind: model.NotificationAssigned, Event: model.EventCardAssigned, CardID: c.ID},
+		{Username: "anna", Kind: model.NotificationCardChanged, Event: model.EventCardMoved, CardID: other.ID},
+		{Username: "anna", Kind: model.NotificationDueSoon, Event: model.EventDueSoon, CardID: c.ID},
+	} {
+		n.BoardID = b.ID
+		n.CardTitle = "title"
+		got, err := s.CreateNotification(n)
+		require.NoError(t, err)
+		require.NotZero(t, got.ID)
+		require.Equal(t, n.Kind, got.Kind)
+		require.Equal(t, n.Event, got.Event)
+		require.Equal(t, "title", got.CardTitle)
+		require.Nil(t, got.ReadAt)
+		require.False(t, got.CreatedAt.IsZero())
+		created = append(created, got)
+	}
+
+	inbox, err := s.GetNotifications(model.NotificationFilter{Username: "anna", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, inbox, 2)
+	require.Equal(t, created[3].ID, inbox[0].ID, "newest first")
+	require.Equal(t, created[2].ID, inbox[1].ID)
+	unread, err := s.CountUnread("anna")
+	require.NoError(t, err)
+	require.Equal(t, 3, unread)
+
+	read, err := s.MarkNotificationRead(created[3].ID, "anna")
+	require.NoError(t, err)
+	require.NotNil(t, read.ReadAt)
+	_, err = s.MarkNotificationRead(created[1].ID, "anna")
+	require.ErrorIs(t, err, model.ErrNotFound, "someone else's notification")
+	_, err = s.MarkNotificationRead(created[3].ID+100, "anna")
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	inbox, err = s.GetNotifications(model.NotificationFilter{Username: "anna", UnreadOnly: true, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, inbox, 2)
+	require.Equal(t, created[2].ID, inbox[0].ID)
+
+	marked, err := s.MarkAllNotificationsRead("anna")
+	require.NoError(t, err)
+	require.Equal(t, 2, marked)
+	unread, err = s.CountUnread("anna")
+	require.NoError(t, err)
+	require.Zero(t, unread)
+	unread, err = s.CountUnread("boris")
+	require.NoError(t, err)
+	require.Equal(t, 1, unread)
+}
+
+func testNotificationPreferences(t *testing.T, s Store) {
+	prefs, err := s.GetNotificationPreferences("anna")
+	require.NoError(t, err)
+	require.Empty(t, prefs)
+
+	require.NoError(t, s.SetNotificationPreference("anna", model.NotificationPreference{Kind: model.NotificationDueSoon, InApp: false}))
+	require.NoError(t, s.SetNotificationPreference("anna", model.NotificationPreference{Kind: model.NotificationAssigned, InApp: false}))
+	require.NoError(t, s.SetNotificationPreference("anna", model.NotificationPreference{Kind: model.NotificationAssigned, InApp: true, Email: true}))
+	require.NoError(t, s.SetNotificationPreference("boris", model.NotificationPreference{Kind: model.NotificationOverdue, InApp: false}))
+
+	prefs, err = s.GetNotificationPreferences("anna")
+	require.NoError(t, err)
+	require.Equal(t, []model.NotificationPreference{
+		{Kind: model.NotificationAssigned, InApp: true, Email: true},
+		{Kind: model.NotificationDueSoon, InApp: false},
+	}, prefs)
+}
+
+func testEmailSettings(t *testing.T, s Store) {
+	_, err := s.GetEmailSettings("anna")
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	saved, err := s.SaveEmailSettings(model.EmailSettings{Username: "anna", Address: "anna@example.com", Digest: true})
+	require.NoError(t, err)
+	require.Equal(t, model.EmailSettings{Username: "anna", Address: "anna@example.com", Digest: true}, saved)
+	_, err = s.SaveEmailSettings(model.EmailSettings{Username: "boris", Address: "boris@example.com"})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	recipients, err := s.GetDigestRecipients(now)
+	require.NoError(t, err)
+	require.Len(t, recipients, 1, "only digest subscribers")
+	require.Equal(t, "anna", recipients[0].Username)
+
+	require.NoError(t, s.SetLastDigestAt("anna", now))
+	recipients, err = s.GetDigestRecipients(now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, recipients, "digest was sent recently")
+	recipients, err = s.GetDigestRecipients(now)
+	require.NoError(t, err)
+	require.Len(t, recipients, 1)
+
+	saved, err = s.SaveEmailSettings(model.EmailSettings{Username: "anna", Address: "a@example.com", Digest: true})
+	require.NoError(t, err)
+	require.Equal(t, "a@example.com", saved.Address)
+	require.NotNil(t, saved.LastDigestAt, "saving settings keeps the digest schedule")
+	require.True(t, now.Equal(*saved.LastDigestAt))
+}
+
+func testDigestItems(t *testing.T, s Store) {
+	for _, item := range []model.DigestItem{
+		{Username: "anna", Kind: model.NotificationAssigned, Event: model.EventCardAssigned, BoardID: 1, CardID: 1, CardTitle: "one"},
+		{Username: "boris", Kind: model.NotificationOverdue, Event: model.EventDuePassed, BoardID: 1, CardID: 2, CardTitle: "two"},
+		{Username: "anna", Kind: model.NotificationDueSoon, Event: model.EventDueSoon, BoardID: 1, CardID: 3, CardTitle: "three"},
+	} {
+		require.NoError(t, s.AddDigestItem(item))
+	}
+	items, err := s.GetDigestItems("anna")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, "one", items[0].CardTitle)
+	require.Equal(t, "three", items[1].CardTitle)
+	require.False(t, items[0].CreatedAt.IsZero())
+
+	require.NoError(t, s.AddDigestItem(model.DigestItem{Username: "anna", Kind: model.NotificationMentioned, Event: model.EventMentioned, BoardID: 1, CardID: 4, CardTitle: "four"}))
+	require.NoError(t, s.DeleteDigestItems("anna", items[1].ID))
+	items, err = s.GetDigestItems("anna")
+	require.NoError(t, err)
+	require.Len(t, items, 1, "items added after the digest stay")
+	require.Equal(t, "four", items[0].CardTitle)
+	items, err = s.GetDigestItems("boris")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+}
+
+func testEmailOutbox(t *testing.T, s Store) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	first, err := s.EnqueueEmail(model.Email{Recipient: "anna@example.com", Subject: "one", TextBody: "text", HTMLBody: "<p>html</p>", NextAttemptAt: now})
+	require.NoError(t, err)
+	require.Equal(t, model.EmailPending, first.Status)
+	require.Zero(t, first.Attempts)
+	later, err := s.EnqueueEmail(model.Email{Recipient: "boris@example.com", Subject: "two", NextAttemptAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	pending, err := s.GetPendingEmails(now, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, first.ID, pending[0].ID)
+	require.Equal(t, "<p>html</p>", pending[0].HTMLBody)
+
+	retry := now.Add(time.Minute)
+	require.NoError(t, s.MarkEmailFailed(first.ID, "connection refused", &retry))
+	pending, err = s.GetPendingEmails(now, 10)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+	pending, err = s.GetPendingEmails(now.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.Equal(t, first.ID, pending[0].ID, "ordered by next attempt")
+	require.Equal(t, 1, pending[0].Attempts)
+	require.Equal(t, "connection refused", pending[0].LastError)
+	pending, err = s.GetPendingEmails(now.Add(2*time.Hour), 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	require.NoError(t, s.MarkEmailSent(first.ID, retry))
+	require.NoError(t, s.MarkEmailFailed(later.ID, "mailbox unavailable", nil))
+	pending, err = s.GetPendingEmails(now.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Empty(t, pending, "sent and failed emails leave the queue")
+
+	require.ErrorIs(t, s.MarkEmailSent(999, now), model.ErrNotFound)
+	require.ErrorIs(t, s.MarkEmailFailed(999, "x", nil), model.ErrNotFound)
+}
+
+func testBoardMembers(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	other := mustBoard(t, s, "other")
+	members, err := s.GetBoardMembers(b.ID)
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	for _, u := range []string{"vera", "anna", "anna"} {
+		member, err := s.AddBoardMember(b.ID, u)
+		require.NoError(t, err)
+		require.Equal(t, u, member.Username)
+		require.False(t, member.AddedAt.IsZero())
+	}
+	_, err = s.AddBoardMember(other.ID, "boris")
+	require.NoError(t, err)
+	_, err = s.AddBoardMember(999, "anna")
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	members, err = s.GetBoardMembers(b.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2, "adding twice keeps one member")
+	require.Equal(t, "anna", members[0].Username)
+	require.Equal(t, "vera", members[1].Username)
+
+	require.NoError(t, s.RemoveBoardMember(b.ID, "anna"))
+	require.ErrorIs(t, s.RemoveBoardMember(b.ID, "anna"), model.ErrNotFound)
+	members, err = s.GetBoardMembers(b.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+}
+
+func testMentions(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+	other := mustCard(t, s, l.ID, "other")
+	comment, err := s.CreateComment(model.CommentInputCreate{CardID: c.ID, Author: "anna", Text: "@vera"})
+	require.NoError(t, err)
+
+	described, err := s.CreateMention(model.Mention{CardID: c.ID, Username: "boris"})
+	require.NoError(t, err)
+	require.Nil(t, described.CommentID)
+	require.False(t, described.CreatedAt.IsZero())
+	commented, err := s.CreateMention(model.Mention{CardID: c.ID, CommentID: &comment.ID, Username: "vera", Author: "anna"})
+	require.NoError(t, err)
+	_, err = s.CreateMention(model.Mention{CardID: other.ID, Username: "anna"})
+	require.NoError(t, err)
+
+	mentions, err := s.GetMentions(c.ID)
+	require.NoError(t, err)
+	require.Len(t, mentions, 2)
+	require.Equal(t, described.ID, mentions[0].ID)
+	require.Equal(t, commented, mentions[1])
+	require.Equal(t, comment.ID, *mentions[1].CommentID)
+
+	require.NoError(t, s.DeleteMention(described.ID))
+	mentions, err = s.GetMentions(c.ID)
+	require.NoError(t, err)
+	require.Len(t, mentions, 1)
+}
+
+func testWatchers(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	done := mustList(t, s, b.ID, "done")
+	c := mustCard(t, s, l.ID, "c")
+	other := mustCard(t, s, done.ID, "other")
+	watch := func(username, kind string, id int) model.Watch {
+		t.Helper()
+		w, err := s.Watch(username, model.WatchTarget{Kind: kind, ID: id})
+		require.NoError(t, err)
+		return w
+	}
+
+	w := watch("anna", model.WatchCard, c.ID)
+	require.Equal(t, model.Watch{Username: "anna", Kind: model.WatchCard, TargetID: c.ID, CreatedAt: w.CreatedAt}, w)
+	require.False(t, w.CreatedAt.IsZero())
+	require.Equal(t, w, watch("anna", model.WatchCard, c.ID), "watching twice is idempotent")
+	watch("anna", model.WatchBoard, b.ID)
+	watch("boris", model.WatchList, l.ID)
+	watch("vera", model.WatchBoard, b.ID)
+	watch("gleb", model.WatchCard, other.ID)
+
+	watchers, err := s.GetCardWatchers(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna", "boris", "vera"}, watchers)
+	watchers, err = s.GetCardWatchers(other.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna", "gleb", "vera"}, watchers)
+
+	watching, err := s.GetWatching("anna")
+	require.NoError(t, err)
+	require.Len(t, watching, 2)
+	require.Equal(t, model.WatchBoard, watching[0].Kind)
+	require.Equal(t, model.WatchCard, watching[1].Kind)
+
+	for _, target := range []model.WatchTarget{{Kind: model.WatchCard, ID: 4242}, {Kind: model.WatchList, ID: 4242}, {Kind: model.WatchBoard, ID: 4242}} {
+		_, err = s.Watch("anna", target)
+		require.ErrorIs(t, err, model.ErrNotFound, target.Kind)
+	}
+	_, err = s.DeleteCard(done.ID, other.ID)
+	require.NoError(t, err)
+	_, err = s.Watch("anna", model.WatchTarget{Kind: model.WatchCard, ID: other.ID})
+	require.ErrorIs(t, err, model.ErrNotFound, "trashed cards cannot be watched")
+
+	require.NoError(t, s.Unwatch("anna", model.WatchTarget{Kind: model.WatchBoard, ID: b.ID}))
+	err = s.Unwatch("anna", model.WatchTarget{Kind: model.WatchBoard, ID: b.ID})
+	require.ErrorIs(t, err, model.ErrNotFound)
+	watchers, err = s.GetCardWatchers(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna", "boris", "vera"}, watchers, "anna still watches the card itself")
+}
+
+func testCardLinks(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c1 := mustCard(t, s, l.ID, "c1")
+	c2 := mustCard(t, s, l.ID, "c2")
+	c3 := mustCard(t, s, l.ID, "c3")
+
+	blocks, err := s.CreateCardLink(model.CardLink{SourceID: c1.ID, TargetID: c2.ID, Type: model.LinkBlocks})
+	require.NoError(t, err)
+	require.Equal(t, model.CardLink{ID: blocks.ID, SourceID: c1.ID, TargetID: c2.ID, Type: model.LinkBlocks, CreatedAt: blocks.CreatedAt}, blocks)
+	require.False(t, blocks.CreatedAt.IsZero())
+	again, err := s.CreateCardLink(model.CardLink{SourceID: c1.ID, TargetID: c2.ID, Type: model.LinkBlocks})
+	require.NoError(t, err)
+	require.Equal(t, blocks, again, "creating a link twice is idempotent")
+	parent, err := s.CreateCardLink(model.CardLink{SourceID: c3.ID, TargetID: c2.ID, Type: model.LinkParentOf})
+	require.NoError(t, err)
+	_, err = s.CreateCardLink(model.CardLink{SourceID: c1.ID, TargetID: 4242, Type: model.LinkRelatesTo})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	links, err := s.GetCardLinks(c2.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.CardLink{blocks, parent}, links, "both directions in creation order")
+	links, err = s.GetCardLinks(c1.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.CardLink{blocks}, links)
+
+	require.NoError(t, s.DeleteCardLink(blocks.ID))
+	require.ErrorIs(t, s.DeleteCardLink(blocks.ID), model.ErrNotFound)
+	links, err = s.GetCardLinks(c1.ID)
+	require.NoError(t, err)
+	require.Empty(t, links)
+}
+
+func testCustomFields(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	other := mustBoard(t, s, "other")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+
+	points, err := s.CreateField(model.CustomFieldInputCreate{BoardID: b.ID, Name: "points", Type: model.FieldNumber})
+	require.NoError(t, err)
+	require.Empty(t, points.Options)
+	require.False(t, points.CreatedAt.IsZero())
+	priority, err := s.CreateField(model.CustomFieldInputCreate{BoardID: b.ID, Name: "priority", Type: model.FieldDropdown, Options: []string{"low", "high"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"low", "high"}, priority.Options)
+	deadline, err := s.CreateField(model.CustomFieldInputCreate{BoardID: b.ID, Name: "deadline", Type: model.FieldDate})
+	require.NoError(t, err)
+	done, err := s.CreateField(model.CustomFieldInputCreate{BoardID: b.ID, Name: "done", Type: model.FieldCheckbox})
+	require.NoError(t, err)
+	_, err = s.CreateField(model.CustomFieldInputCreate{BoardID: other.ID, Name: "customer", Type: model.FieldText})
+	require.NoError(t, err)
+	_, err = s.CreateField(model.CustomFieldInputCreate{BoardID: 4242, Name: "x", Type: model.FieldText})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	fields, err := s.GetFields(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.CustomField{points, priority, deadline, done}, fields)
+	updated, err := s.UpdateField(priority.ID, model.CustomFieldUpdate{Name: "Priority", Options: []string{"low", "mid", "high"}})
+	require.NoError(t, err)
+	require.Equal(t, "Priority", updated.Name)
+	got, err := s.GetField(priority.ID)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+	_, err = s.GetField(4242)
+	require.ErrorIs(t, err, model.ErrNotFound)
+	_, err = s.UpdateField(4242, model.CustomFieldUpdate{Name: "x"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	num, text, checked := 2.5, "high", true
+	date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, v := range []model.CardFieldValue{
+		{CardID: c.ID, FieldID: points.ID, Number: &num},
+		{CardID: c.ID, FieldID: priority.ID, Text: &text},
+		{CardID: c.ID, FieldID: deadline.ID, Date: &date},
+		{CardID: c.ID, FieldID: done.ID, Bool: &checked},
+	} {
+		saved, err := s.SetCardFieldValue(v)
+		require.NoError(t, err)
+		require.Equal(t, v, saved)
+	}
+	num = 5
+	_, err = s.SetCardFieldValue(model.CardFieldValue{CardID: c.ID, FieldID: points.ID, Number: &num})
+	require.NoError(t, err, "value is replaced")
+
+	values, err := s.GetCardFieldValues(c.ID)
+	require.NoError(t, err)
+	require.Len(t, values, 4)
+	require.Equal(t, 5.0, *values[0].Number)
+	require.Equal(t, "high", *values[1].Text)
+	require.True(t, date.Equal(*values[2].Date))
+	require.True(t, *values[3].Bool)
+	require.Nil(t, values[3].Text)
+
+	require.NoError(t, s.DeleteCardFieldValue(c.ID, done.ID))
+	require.NoError(t, s.DeleteField(points.ID))
+	require.ErrorIs(t, s.DeleteField(points.ID), model.ErrNotFound)
+	values, err = s.GetCardFieldValues(c.ID)
+	require.NoError(t, err)
+	require.Len(t, values, 2, "values of deleted fields are removed")
+}
+
+func testCardsByField(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	points, err := s.CreateField(model.CustomFieldInputCreate{BoardID: b.ID, Name: "points", Type: model.FieldNumber})
+	require.NoError(t, err)
+	customer, err := s.CreateField(model.CustomFieldInputCreate{BoardID: b.ID, Name: "customer", Type: model.FieldText})
+	require.NoError(t, err)
+	var cards []model.Card
+	for i, p := range []float64{3, 1, 0, 8} {
+		c := mustCard(t, s, l.ID, fmt.Sprintf("c%d", i))
+		cards = append(cards, c)
+		if p == 0 {
+			continue
+		}
+		_, err := s.SetCardFieldValue(model.CardFieldValue{CardID: c.ID, FieldID: points.ID, Number: &p})
+		require.NoError(t, err)
+	}
+	acme := "acme"
+	for _, c := range []model.Card{cards[0], cards[2]} {
+		_, err := s.SetCardFieldValue(model.CardFieldValue{CardID: c.ID, FieldID: customer.ID, Text: &acme})
+		require.NoError(t, err)
+	}
+	ids := func(filter model.CardFilter) []int {
+		t.Helper()
+		got, err := s.GetCards(filter)
+		require.NoError(t, err)
+		var result []int
+		for _, c := range got {
+			result = append(result, c.ID)
+		}
+		return result
+	}
+
+	three := 3.0
+	require.Equal(t, []int{cards[0].ID}, ids(model.CardFilter{Fields: []model.CardFieldFilter{{FieldID: points.ID, Match: model.CardFieldValue{Number: &three}}}}))
+	require.Equal(t, []int{cards[0].ID, cards[2].ID}, ids(model.CardFilter{Fields: []model.CardFieldFilter{{FieldID: customer.ID, Match: model.CardFieldValue{Text: &acme}}}}))
+	require.Equal(t, []int{cards[1].ID, cards[0].ID, cards[3].ID, cards[2].ID},
+		ids(model.CardFilter{Sort: &model.CardSort{By: model.CardSortField, FieldID: points.ID, Type: model.FieldNumber}}), "cards without value go last")
+	require.Equal(t, []int{cards[3].ID, cards[0].ID, cards[1].ID, cards[2].ID},
+		ids(model.CardFilter{Sort: &model.CardSort{By: model.CardSortField, FieldID: points.ID, Type: model.FieldNumber, Desc: true}}))
+	require.Equal(t, []int{cards[0].ID, cards[2].ID},
+		ids(model.CardFilter{
+			Fields: []model.CardFieldFilter{{FieldID: customer.ID, Match: model.CardFieldValue{Text: &acme}}},
+			Sort:   &model.CardSort{By: model.CardSortField, FieldID: points.ID, Type: model.FieldNumber, Desc: true},
+		}))
+}
+
+func testCardQuery(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	other := mustBoard(t, s, "other")
+	todo := mustList(t, s, b.ID, "todo")
+	otherList := mustList(t, s, other.ID, "todo")
+	bug := mustLabel(t, s, b.ID, "Bug")
+	ui := mustLabel(t, s, b.ID, "ui")
+	login := mustCard(t, s, todo.ID, "Login fails")
+	report := mustCard(t, s, todo.ID, "Report 50% done")
+	cleanup := mustCard(t, s, todo.ID, "cleanup")
+	foreign := mustCard(t, s, otherList.ID, "Login page")
+
+	require.NoError(t, s.AddCardLabel(login.ID, bug.ID))
+	require.NoError(t, s.AddCardLabel(login.ID, ui.ID))
+	require.NoError(t, s.AddCardLabel(report.ID, bug.ID))
+	require.NoError(t, s.AddCardAssignee(login.ID, "anna"))
+	require.NoError(t, s.AddCardAssignee(cleanup.ID, "anna"))
+	require.NoError(t, s.AddCardAssignee(cleanup.ID, "boris"))
+	_, err := s.SetCardStatus(report.ID, "done")
+	require.NoError(t, err)
+	march := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	april := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	_, err = s.SetCardDue(login.ID, &april)
+	require.NoError(t, err)
+	_, err = s.SetCardDue(cleanup.ID, &march)
+	require.NoError(t, err)
+
+	ids := func(filter model.CardFilter) []int {
+		t.Helper()
+		filter.BoardID = &b.ID
+		got, err := s.GetCards(filter)
+		require.NoError(t, err)
+		return cardIDs(got)
+	}
+	tests := []struct {
+		name   string
+		filter model.CardFilter
+		want   []int
+	}{
+		{"board", model.CardFilter{}, []int{login.ID, report.ID, cleanup.ID}},
+		{"label ignores case", model.CardFilter{Labels: []string{"bug"}}, []int{login.ID, report.ID}},
+		{"all labels", model.CardFilter{Labels: []string{"bug", "UI"}}, []int{login.ID}},
+		{"assignees", model.CardFilter{Assignees: []string{"anna", "boris"}}, []int{cleanup.ID}},
+		{"statuses", model.CardFilter{Statuses: []string{"done", "review"}}, []int{report.ID}},
+		{"empty status", model.CardFilter{Statuses: []string{""}}, []int{login.ID, cleanup.ID}},
+		{"due from", model.CardFilter{DueFrom: &april}, []int{login.ID}},
+		{"due to is exclusive", model.CardFilter{DueTo: &april}, []int{cleanup.ID}},
+		{"text in title", model.CardFilter{Text: "LOGIN"}, []int{login.ID}},
+		{"text in description", model.CardFilter{Text: "cleanup desc"}, []int{cleanup.ID}},
+		{"text is not a pattern", model.CardFilter{Text: "0%"}, []int{report.ID}},
+		{"text underscore", model.CardFilter{Text: "_"}, []int{}},
+		{"sort by title desc", model.CardFilter{Sort: &model.CardSort{By: model.CardSortTitle, Desc: true}}, []int{report.ID, login.ID, cleanup.ID}},
+		{"sort by due", model.CardFilter{Sort: &model.CardSort{By: model.CardSortDue}}, []int{cleanup.ID, login.ID, report.ID}},
+		{"sort by due desc", model.CardFilter{Sort: &model.CardSort{By: model.CardSortDue, Desc: true}}, []int{login.ID, cleanup.ID, report.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ids(tt.filter))
+		})
+	}
+	_, err = s.GetCards(model.CardFilter{Sort: &model.CardSort{By: "priority"}})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+	all, err := s.GetCards(model.CardFilter{Text: "login"})
+	require.NoError(t, err)
+	require.Equal(t, []int{login.ID, foreign.ID}, cardIDs(all))
+}
+
+func testViews(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	other := mustBoard(t, s, "other")
+	mine, err := s.CreateView(model.ViewInput{BoardID: b.ID, Owner: "anna", Name: "My bugs", Query: "label:bug assignee:me", Sort: "due_at", Desc: true, Group: model.GroupList})
+	require.NoError(t, err)
+	require.Equal(t, "label:bug assignee:me", mine.Query)
+	require.True(t, mine.Desc)
+	require.False(t, mine.Shared)
+	require.False(t, mine.CreatedAt.IsZero())
+	shared, err := s.CreateView(model.ViewInput{BoardID: b.ID, Owner: "boris", Name: "Release", Shared: true})
+	require.NoError(t, err)
+	_, err = s.CreateView(model.ViewInput{BoardID: b.ID, Owner: "boris", Name: "Private"})
+	require.NoError(t, err)
+	_, err = s.CreateView(model.ViewInput{BoardID: other.ID, Owner: "anna", Name: "Elsewhere"})
+	require.NoError(t, err)
+	_, err = s.CreateView(model.ViewInput{BoardID: 4242, Owner: "anna", Name: "x"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	views, err := s.GetViews(b.ID, "anna")
+	require.NoError(t, err)
+	require.Equal(t, []model.View{mine, shared}, views, "own and shared views")
+	views, err = s.GetViews(b.ID, "vera")
+	require.NoError(t, err)
+	require.Equal(t, []model.View{shared}, views)
+
+	updated, err := s.UpdateView(mine.ID, model.ViewInput{Name: "Open bugs", Query: "label:bug", Shared: true})
+	require.NoError(t, err)
+	require.Equal(t, "Open bugs", updated.Name)
+	require.Equal(t, "anna", updated.Owner)
+	require.Empty(t, updated.Group)
+	got, err := s.GetView(mine.ID)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+	_, err = s.UpdateView(4242, model.ViewInput{Name: "x"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	require.NoError(t, s.DeleteView(mine.ID))
+	require.ErrorIs(t, s.DeleteView(mine.ID), model.ErrNotFound)
+	_, err = s.GetView(mine.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testCalendarTokens(t *testing.T, s Store) {
+	issued, err := s.SetCalendarToken("anna", "hash-1")
+	require.NoError(t, err)
+	require.Equal(t, "anna", issued.Username)
+	require.False(t, issued.CreatedAt.IsZero())
+	_, err = s.SetCalendarToken("boris", "hash-2")
+	require.NoError(t, err)
+
+	username, err := s.GetCalendarTokenUser("hash-1")
+	require.NoError(t, err)
+	require.Equal(t, "anna", username)
+	_, err = s.SetCalendarToken("anna", "hash-3")
+	require.NoError(t, err, "token is replaced")
+	_, err = s.GetCalendarTokenUser("hash-1")
+	require.ErrorIs(t, err, model.ErrNotFound)
+	username, err = s.GetCalendarTokenUser("hash-3")
+	require.NoError(t, err)
+	require.Equal(t, "anna", username)
+
+	require.NoError(t, s.DeleteCalendarToken("anna"))
+	require.ErrorIs(t, s.DeleteCalendarToken("anna"), model.ErrNotFound)
+	_, err = s.GetCalendarTokenUser("hash-3")
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testMemberBoards(t *testing.T, s Store) {
+	first := mustBoard(t, s, "first")
+	second := mustBoard(t, s, "second")
+	mustBoard(t, s, "third")
+	for _, b := range []model.Board{second, first} {
+		_, err := s.AddBoardMember(b.ID, "anna")
+		require.NoError(t, err)
+	}
+	_, err := s.AddBoardMember(second.ID, "boris")
+	require.NoError(t, err)
+
+	boards, err := s.GetMemberBoards("anna")
+	require.NoError(t, err)
+	require.Equal(t, []model.Board{first, second}, boards)
+	boards, err = s.GetMemberBoards("vera")
+	require.NoError(t, err)
+	require.Empty(t, boards)
+}
+
+func helperTime(t time.Time) *time.Time {
+	return &t
+}
+
+func testCardPurgeCascade(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+	label := mustLabel(t, s, b.ID, "bug")
+	require.NoError(t, s.AddCardLabel(c.ID, label.ID))
+	require.NoError(t, s.AddCardAssignee(c.ID, "anna"))
+	checklist, err := s.CreateChecklist(model.ChecklistInputCreate{CardID: c.ID, Title: "todo"})
+	require.NoError(t, err)
+	_, err = s.CreateChecklistItem(model.ChecklistItemInputCreate{ChecklistID: checklist.ID, Text: "item"})
+	require.NoError(t, err)
+	comment, err := s.CreateComment(model.CommentInputCreate{CardID: c.ID, Text: "comment"})
+	require.NoError(t, err)
+	_, err = s.CreateMention(model.Mention{CardID: c.ID, CommentID: &comment.ID, Username: "anna"})
+	require.NoError(t, err)
+	_, err = s.Watch("anna", model.WatchTarget{Kind: model.WatchCard, ID: c.ID})
+	require.NoError(t, err)
+	field, err := s.CreateField(model.CustomFieldInputCreate{BoardID: b.ID, Name: "points", Type: model.FieldNumber})
+	require.NoError(t, err)
+	points := 3.0
+	_, err = s.SetCardFieldValue(model.CardFieldValue{CardID: c.ID, FieldID: field.ID, Number: &points})
+	require.NoError(t, err)
+	blocker := mustCard(t, s, l.ID, "blocker")
+	_, err = s.CreateCardLink(model.CardLink{SourceID: blocker.ID, TargetID: c.ID, Type: model.LinkBlocks})
+	require.NoError(t, err)
+
+	_, err = s.DeleteCard(l.ID, c.ID)
+	require.NoError(t, err)
+	labels, err := s.GetCardLabels(c.ID)
+	require.NoError(t, err)
+	require.Len(t, labels, 1, "trashed card keeps its labels until purge")
+	_, err = s.PurgeDeletedCards(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	labels, err = s.GetCardLabels(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, labels)
+	assignees, err := s.GetCardAssignees(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, assignees)
+	checklists, err := s.GetChecklists(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, checklists)
+	comments, err := s.GetComments(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, comments)
+	mentions, err := s.GetMentions(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, mentions)
+	watching, err := s.GetWatching("anna")
+	require.NoError(t, err)
+	require.Empty(t, watching)
+	links, err := s.GetCardLinks(blocker.ID)
+	require.NoError(t, err)
+	require.Empty(t, links)
+	values, err := s.GetCardFieldValues(c.ID)
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	boardLabels, err := s.GetLabels(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.Label{label}, boardLabels, "board labels survive card deletion")
+}
+
+func testGetCard(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	created := mustCard(t, s, l.ID, "c")
+
+	got, err := s.GetCard(created.ID)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, got.ID)
+	require.Equal(t, b.ID, got.BoardID)
+	require.Equal(t, l.ID, got.ListID)
+	require.Equal(t, "c", got.Title)
+
+	_, err = s.GetCard(created.ID + 1000)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func testTxCommit(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	todo := mustList(t, s, b.ID, "todo")
+	done := mustList(t, s, b.ID, "done")
+	c := mustCard(t, s, todo.ID, "c")
+
+	err := s.InTx(func(tx service.Tx) error {
+		card, err := tx.GetCard(c.ID)
+		if err != nil {
+			return err
+		}
+		card.ListID = done.ID
+		if _, err := tx.UpdateCard(card); err != nil {
+			return err
+		}
+		if _, err := tx.CreateCard(model.CardInputCreate{ListID: done.ID, Title: "new"}); err != nil {
+			return err
+		}
+		return tx.AddCardAssignee(c.ID, "anna")
+	})
+	require.NoError(t, err)
+
+	cards, err := s.GetCards(model.CardFilter{ListID: &done.ID})
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+	require.Equal(t, c.ID, cards[0].ID)
+	assignees, err := s.GetCardAssignees(c.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna"}, assignees)
+
+	// Счётчики идентификаторов тоже переживают транзакцию.
+	next := mustCard(t, s, todo.ID, "next")
+	require.Greater(t, next.ID, cards[1].ID)
+}
+
+func testTxRollback(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	l := mustList(t, s, b.ID, "todo")
+	c := mustCard(t, s, l.ID, "c")
+
+	failure := errors.New("abort")
+	err := s.InTx(func(tx service.Tx) error {
+		if _, err := tx.DeleteCard(l.ID, c.ID); err != nil {
+			return err
+		}
+		if _, err := tx.CreateCard(model.CardInputCreate{ListID: l.ID, Title: "new"}); err != nil {
+			return err
+		}
+		if _, err := tx.GetCard(c.ID); !errors.Is(err, model.ErrNotFound) {
+			return errors.New("deleted card must not be visible inside the transaction")
+		}
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+
+	cards, err := s.GetCards(model.CardFilter{ListID: &l.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int{c.ID}, cardIDs(cards))
+}
+
+func mustLabel(t *testing.T, s Store, boardID int, name string) model.Label {
+	t.Helper()
+	l, err := s.CreateLabel(model.LabelInputCreate{BoardID: boardID, Name: name, Color: name + "-color"})
+	require.NoError(t, err)
+	return l
+}
+
+func testListWIPLimit(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	three := 3
+	limited, err := s.CreateList(model.ListInputCreate{BoardID: b.ID, Title: "doing", WIPLimit: &three, WIPSoft: true})
+	require.NoError(t, err)
+	require.Equal(t, &three, limited.WIPLimit)
+	require.True(t, limited.WIPSoft)
+	open := mustList(t, s, b.ID, "todo")
+	require.Nil(t, open.WIPLimit)
+
+	mustCard(t, s, limited.ID, "first")
+	archived := mustCard(t, s, limited.ID, "archived")
+	_, err = s.ArchiveCard(archived.ID)
+	require.NoError(t, err)
+	deleted := mustCard(t, s, limited.ID, "deleted")
+	_, err = s.DeleteCard(limited.ID, deleted.ID)
+	require.NoError(t, err)
+	mustCard(t, s, open.ID, "other")
+
+	got, err := s.GetList(limited.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, got.CardCount, "archived and deleted cards do not count")
+	lists, err := s.GetLists(&b.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 1}, []int{lists[0].CardCount, lists[1].CardCount})
+
+	two := 2
+	updated, err := s.SetListWIPLimit(open.ID, model.ListWIPLimit{Limit: &two})
+	require.NoError(t, err)
+	require.Equal(t, &two, updated.WIPLimit)
+	require.False(t, updated.WIPSoft)
+	require.Equal(t, 1, updated.CardCount)
+	updated, err = s.SetListWIPLimit(limited.ID, model.ListWIPLimit{})
+	require.NoError(t, err)
+	require.Nil(t, updated.WIPLimit)
+	require.False(t, updated.WIPSoft)
+	_, err = s.SetListWIPLimit(100, model.ListWIPLimit{})
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+// testWIPLimitConcurrent проверяет, что LockList сериализует добавления в
+// список с жёстким лимитом: параллельные транзакции не должны все увидеть
+// свободное место.
+func testWIPLimitConcurrent(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	three := 3
+	limited, err := s.CreateList(model.ListInputCreate{BoardID: b.ID, Title: "doing", WIPLimit: &three})
+	require.NoError(t, err)
+	todo := mustList(t, s, b.ID, "todo")
+	cards := service.NewCardService(s, s, nil, zap.NewNop())
+
+	const workers = 8
+	errs := make(chan error, 2*workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := cards.CreateCard(model.CardInputCreate{ListID: limited.ID, Title: fmt.Sprintf("new %d", i)})
+			errs <- err
+		}()
+		card := mustCard(t, s, todo.ID, fmt.Sprintf("moved %d", i))
+		go func() {
+			defer wg.Done()
+			_, err := cards.MoveCard(card.ID, model.CardMoveInput{ListID: limited.ID})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	added := 0
+	for err := range errs {
+		if err == nil {
+			added++
+			continue
+		}
+		require.ErrorIs(t, err, model.ErrConflict)
+	}
+	require.Equal(t, three, added)
+	got, err := s.GetList(limited.ID)
+	require.NoError(t, err)
+	require.Equal(t, three, got.CardCount)
+}
+
+func testCardMoves(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	todo := mustList(t, s, b.ID, "todo")
+	done := mustList(t, s, b.ID, "done")
+	other := mustList(t, s, mustBoard(t, s, "other").ID, "todo")
+	first := mustCard(t, s, todo.ID, "first")
+	second := mustCard(t, s, todo.ID, "second")
+	away := mustCard(t, s, other.ID, "away")
+	created := time.Now().UTC().Truncate(time.Second)
+
+	record := func(card model.Card, from, to int, at time.Time) {
+		t.Helper()
+		m, err := s.AddCardMove(model.CardMove{CardID: card.ID, FromListID: from, ToListID: to, MovedAt: at})
+		require.NoError(t, err)
+		require.NotZero(t, m.ID)
+		require.Equal(t, from, m.FromListID)
+		require.True(t, at.Equal(m.MovedAt))
+	}
+	record(second, 0, todo.ID, created)
+	record(first, 0, todo.ID, created)
+	record(away, 0, other.ID, created)
+	record(first, todo.ID, done.ID, created.Add(time.Hour))
+
+	moves, err := s.GetCardMoves(b.ID)
+	require.NoError(t, err)
+	type move struct{ card, from, to int }
+	got := func(moves []model.CardMove) []move {
+		result := []move{}
+		for _, m := range moves {
+			result = append(result, move{m.CardID, m.FromListID, m.ToListID})
+		}
+		return result
+	}
+	require.Equal(t, []move{{first.ID, 0, todo.ID}, {first.ID, todo.ID, done.ID}, {second.ID, 0, todo.ID}}, got(moves), "by card, then in order of recording")
+	require.True(t, created.Add(time.Hour).Equal(moves[1].MovedAt))
+
+	_, err = s.ArchiveCard(first.ID)
+	require.NoError(t, err)
+	_, err = s.DeleteCard(todo.ID, second.ID)
+	require.NoError(t, err)
+	moves, err = s.GetCardMoves(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, []move{{first.ID, 0, todo.ID}, {first.ID, todo.ID, done.ID}}, got(moves), "archived cards stay, deleted are hidden")
+}
+
+func testSwimlanes(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	todo := mustList(t, s, b.ID, "todo")
+	other := mustList(t, s, mustBoard(t, s, "other").ID, "todo")
+	first := mustCard(t, s, todo.ID, "first")
+	second := mustCard(t, s, todo.ID, "second")
+	away := mustCard(t, s, other.ID, "away")
+
+	swimlanes, err := s.GetSwimlanes(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.Swimlanes{BoardID: b.ID}, swimlanes)
+	_, err = s.GetSwimlanes(999)
+	require.ErrorIs(t, err, model.ErrNotFound)
+	_, err = s.SetSwimlanes(model.Swimlanes{BoardID: 999, Mode: model.LaneLabel})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	field, err := s.CreateField(model.CustomFieldInputCreate{BoardID: b.ID, Name: "Team", Type: model.FieldText})
+	require.NoError(t, err)
+	byField := model.Swimlanes{BoardID: b.ID, Mode: model.LaneField, FieldID: &field.ID}
+	swimlanes, err = s.SetSwimlanes(byField)
+	require.NoError(t, err)
+	require.Equal(t, byField, swimlanes)
+	require.NoError(t, s.DeleteField(field.ID))
+	swimlanes, err = s.GetSwimlanes(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.Swimlanes{BoardID: b.ID}, swimlanes, "deleting the field turns lanes off")
+
+	explicit := model.Swimlanes{BoardID: b.ID, Mode: model.LaneExplicit}
+	swimlanes, err = s.SetSwimlanes(explicit)
+	require.NoError(t, err)
+	require.Equal(t, explicit, swimlanes)
+
+	urgent, err := s.CreateLane(model.LaneInputCreate{BoardID: b.ID, Title: "Urgent"})
+	require.NoError(t, err)
+	later, err := s.CreateLane(model.LaneInputCreate{BoardID: b.ID, Title: "Later"})
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1}, []int{urgent.Position, later.Position})
+	_, err = s.CreateLane(model.LaneInputCreate{BoardID: 999, Title: "x"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+	renamed, err := s.RenameLane(later.ID, "Someday")
+	require.NoError(t, err)
+	require.Equal(t, "Someday", renamed.Title)
+	_, err = s.RenameLane(999, "x")
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	require.NoError(t, s.SetLanePositions(b.ID, []int{later.ID, urgent.ID}))
+	lanes, err := s.GetLanes(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int{later.ID, urgent.ID}, []int{lanes[0].ID, lanes[1].ID})
+	got, err := s.GetLane(urgent.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, got.Position)
+
+	require.NoError(t, s.SetCardLane(first.ID, &urgent.ID))
+	require.NoError(t, s.SetCardLane(second.ID, &urgent.ID))
+	require.NoError(t, s.SetCardLane(second.ID, &later.ID))
+	require.ErrorIs(t, s.SetCardLane(999, &urgent.ID), model.ErrNotFound)
+	cardLanes, err := s.GetCardLanes(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.CardLane{{CardID: first.ID, LaneID: urgent.ID}, {CardID: second.ID, LaneID: later.ID}}, cardLanes)
+	cardLanes, err = s.GetCardLanes(away.BoardID)
+	require.NoError(t, err)
+	require.Empty(t, cardLanes)
+
+	_, err = s.DeleteCard(todo.ID, second.ID)
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteLane(urgent.ID))
+	require.ErrorIs(t, s.DeleteLane(urgent.ID), model.ErrNotFound)
+	cardLanes, err = s.GetCardLanes(b.ID)
+	require.NoError(t, err)
+	require.Empty(t, cardLanes, "deleted lanes release cards, trashed cards are hidden")
+
+	require.NoError(t, s.SetCardLane(first.ID, &later.ID))
+	require.NoError(t, s.SetCardLane(first.ID, nil))
+	cardLanes, err = s.GetCardLanes(b.ID)
+	require.NoError(t, err)
+	require.Empty(t, cardLanes)
+}
+
+func testSprintStartConcurrent(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	const workers = 4
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		sprint, err := s.CreateSprint(model.Sprint{BoardID: b.ID, Name: fmt.Sprintf("Sprint %d", i), StartDate: start, EndDate: start.AddDate(0, 0, 14)})
+		require.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.SetSprintStatus(sprint.ID, model.SprintActive)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	started := 0
+	for err := range errs {
+		if err == nil {
+			started++
+			continue
+		}
+		require.ErrorIs(t, err, model.ErrConflict)
+	}
+	require.Equal(t, 1, started)
+}
+
+func testSprints(t *testing.T, s Store) {
+	b := mustBoard(t, s, "b")
+	todo := mustList(t, s, b.ID, "todo")
+	first := mustCard(t, s, todo.ID, "first")
+	second := mustCard(t, s, todo.ID, "second")
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	later, err := s.CreateSprint(model.Sprint{BoardID: b.ID, Name: "Sprint 2", StartDate: day(16), EndDate: day(27)})
+	require.NoError(t, err)
+	current, err := s.CreateSprint(model.Sprint{BoardID: b.ID, Name: "Sprint 1", Goal: "Login", StartDate: day(2), EndDate: day(13)})
+	require.NoError(t, err)
+	require.Equal(t, model.SprintPlanned, current.Status)
+	require.True(t, day(2).Equal(current.StartDate))
+	require.Nil(t, current.StartedAt)
+	_, err = s.CreateSprint(model.Sprint{BoardID: 999, Name: "x", StartDate: day(2), EndDate: day(13)})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	sprints, err := s.GetSprints(b.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int{current.ID, later.ID}, []int{sprints[0].ID, sprints[1].ID})
+	updated, err := s.UpdateSprint(model.Sprint{ID: later.ID, Name: "Sprint 2b", Goal: "Report", StartDate: day(16), EndDate: day(20)})
+	require.NoError(t, err)
+	require.Equal(t, "Sprint 2b", updated.Name)
+	require.Equal(t, b.ID, updated.BoardID)
+	require.True(t, day(20).Equal(updated.EndDate))
+	_, err = s.UpdateSprint(model.Sprint{ID: 999, Name: "x", StartDate: day(2), EndDate: day(13)})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	started, err := s.SetSprintStatus(current.ID, model.SprintActive)
+	require.NoError(t, err)
+	require.Equal(t, model.SprintActive, started.Status)
+	require.NotNil(t, started.StartedAt)
+	_, err = s.SetSprintStatus(later.ID, model.SprintActive)
+	require.ErrorIs(t, err, model.ErrConflict, "one active sprint per board")
+	_, err = s.SetSprintStatus(999, model.SprintActive)
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	require.NoError(t, s.SetCardSprint(first.ID, &current.ID))
+	require.NoError(t, s.SetCardSprint(first.ID, &current.ID))
+	require.NoError(t, s.SetCardSprint(second.ID, &current.ID))
+	require.NoError(t, s.SetCardSprint(second.ID, &later.ID))
+	require.ErrorIs(t, s.SetCardSprint(999, &current.ID), model.ErrNotFound)
+	entries, err := s.GetSprintCards(current.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "re-adding to the same sprint keeps one entry")
+	require.Equal(t, []int{first.ID, second.ID}, []int{entries[0].CardID, entries[1].CardID})
+	require.Nil(t, entries[0].RemovedAt)
+	require.NotNil(t, entries[1].RemovedAt)
+
+	completed, err := s.SetSprintStatus(current.ID, model.SprintCompleted)
+	require.NoError(t, err)
+	require.NotNil(t, completed.CompletedAt)
+	require.NotNil(t, completed.StartedAt)
+	_, err = s.SetSprintStatus(later.ID, model.SprintActive)
+	require.NoError(t, err)
+
+	_, err = s.DeleteCard(todo.ID, first.ID)
+	require.NoError(t, err)
+	entries, err = s.GetSprintCards(current.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "trashed cards are hidden")
+	require.NoError(t, s.SetCardSprint(second.ID, nil))
+	entries, err = s.GetSprintCards(later.ID)
+	require.NoError(t, err)
+	require.NotNil(t, entries[0].RemovedAt)
+
+	require.NoError(t, s.DeleteSprint(later.ID))
+	require.ErrorIs(t, s.DeleteSprint(later.ID), model.ErrNotFound)
+	_, err = s.GetSprint(later.ID)
+	require.ErrorIs(t, err, model.ErrNotFound)
+	got, err := s.GetSprint(current.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.SprintCompleted, got.Status)
+}
+
+func mustBoard(t *testing.T, s Store, title string) model.Board {
+	t.Helper()
+	b, err := s.CreateBoard(title)
+	require.NoError(t, err)
+	return b
+}
+
+func mustList(t *testing.T, s Store, boardID int, title string) model.List {
+	t.Helper()
+	l, err := s.CreateList(model.ListInputCreate{BoardID: boardID, Title: title})
+	require.NoError(t, err)
+	return l
+}
+
+func mustCard(t *testing.T, s Store, listID int, title string) model.Card {
+	t.Helper()
+	c, err := s.CreateCard(model.CardInputCreate{ListID: listID, Title: title, Description: title + " description"})
+	require.NoError(t, err)
+	return c
+}
+
+func listIDs(lists []model.List) []int {
+	ids := []int{}
+	for _, l := range lists {
+		ids = append(ids, l.ID)
+	}
+	return ids
+}
+
+func cardIDs(cards []model.Card) []int {
+	ids := []int{}
+	for _, c := range cards {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
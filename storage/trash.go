@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"time"
+)
+
+func (s *Storage) ArchiveCard(id int) (model.Card, error) {
+	return s.updateLiveCard(id, func(c *model.Card, now time.Time) {
+		if c.ArchivedAt == nil {
+			c.ArchivedAt = &now
+		}
+	})
+}
+
+func (s *Storage) UnarchiveCard(id int) (model.Card, error) {
+	return s.updateLiveCard(id, func(c *model.Card, _ time.Time) {
+		c.ArchivedAt = nil
+	})
+}
+
+func (s *Storage) updateLiveCard(id int, update func(c *model.Card, now time.Time)) (model.Card, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	card, ok := s.cards[id]
+	if !ok || card.DeletedAt != nil {
+		return model.Card{}, fmt.Errorf("card %d: %w", id, model.ErrNotFound)
+	}
+	now := s.now()
+	update(&card, now)
+	card.UpdatedAt = now
+	s.cards[id] = card
+	return card, nil
+}
+
+func (s *Storage) RestoreCard(id int) (model.Card, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	card, ok := s.cards[id]
+	if !ok || card.DeletedAt == nil {
+		return model.Card{}, fmt.Errorf("deleted card %d: %w", id, model.ErrNotFound)
+	}
+	card.DeletedAt = nil
+	card.UpdatedAt = s.now()
+	s.cards[id] = card
+	return card, nil
+}
+
+func (s *Storage) GetDeletedCards(boardID int) ([]model.Card, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var cards []model.Card
+	for _, c := range s.cards {
+		if c.BoardID == boardID && c.DeletedAt != nil {
+			cards = append(cards, c)
+		}
+	}
+	slices.SortFunc(cards, func(a, b model.Card) int {
+		if c := b.DeletedAt.Compare(*a.DeletedAt); c != 0 {
+			return c
+		}
+		return b.ID - a.ID
+	})
+	return cards, nil
+}
+
+func (s *Storage) PurgeDeletedCards(before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	purged := 0
+	for id, c := range s.cards {
+		if c.DeletedAt != nil && c.DeletedAt.Before(before) {
+			delete(s.cards, id)
+			s.deleteCardChildren(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/service"
+	"maps"
+	"slices"
+)
+
+// InTx выполняет fn над копией данных и переносит её обратно только при
+// успехе. Всё это время хранилище заблокировано на запись, поэтому
+// параллельные изменения не теряются; копирование целиком допустимо,
+// пока хранилище в памяти используется для разработки и тестов.
+func (s *Storage) InTx(fn func(tx service.Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := &Storage{}
+	copyState(tx, s)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	copyState(s, tx)
+	return nil
+}
+
+// copyState копирует данные src в dst. Вызывающий держит блокировки.
+func copyState(dst, src *Storage) {
+	dst.boards = maps.Clone(src.boards)
+	dst.lists = maps.Clone(src.lists)
+	dst.cards = maps.Clone(src.cards)
+	dst.labels = maps.Clone(src.labels)
+	dst.cardLabels = cloneSlices(src.cardLabels)
+	dst.assignees = cloneSlices(src.assignees)
+	dst.checklists = maps.Clone(src.checklists)
+	dst.items = maps.Clone(src.items)
+	dst.comments = maps.Clone(src.comments)
+	dst.dueTriggered = maps.Clone(src.dueTriggered)
+	dst.dueSoonTriggered = maps.Clone(src.dueSoonTriggered)
+	dst.rules = maps.Clone(src.rules)
+	dst.runs = maps.Clone(src.runs)
+	dst.recurrences = maps.Clone(src.recurrences)
+	dst.notifications = maps.Clone(src.notifications)
+	dst.preferences = maps.Clone(src.preferences)
+	dst.emailSettings = maps.Clone(src.emailSettings)
+	dst.digestItems = maps.Clone(src.digestItems)
+	dst.outbox = maps.Clone(src.outbox)
+	dst.members = cloneSlices(src.members)
+	dst.mentions = maps.Clone(src.mentions)
+	dst.watches = maps.Clone(src.watches)
+	dst.links = maps.Clone(src.links)
+	dst.fields = maps.Clone(src.fields)
+	dst.fieldValues = maps.Clone(src.fieldValues)
+	dst.views = maps.Clone(src.views)
+	dst.calendarTokens = maps.Clone(src.calendarTokens)
+	dst.cardMoves = maps.Clone(src.cardMoves)
+	dst.swimlanes = maps.Clone(src.swimlanes)
+	dst.lanes = maps.Clone(src.lanes)
+	dst.cardLanes = maps.Clone(src.cardLanes)
+	dst.sprints = maps.Clone(src.sprints)
+	dst.sprintCards = maps.Clone(src.sprintCards)
+	dst.boardID = src.boardID
+	dst.listID = src.listID
+	dst.cardID = src.cardID
+	dst.labelID = src.labelID
+	dst.checkID = src.checkID
+	dst.itemID = src.itemID
+	dst.commentID = src.commentID
+	dst.ruleID = src.ruleID
+	dst.runID = src.runID
+	dst.notificationID = src.notificationID
+	dst.digestItemID = src.digestItemID
+	dst.emailID = src.emailID
+	dst.mentionID = src.mentionID
+	dst.linkID = src.linkID
+	dst.fieldID = src.fieldID
+	dst.viewID = src.viewID
+	dst.moveID = src.moveID
+	dst.laneID = src.laneID
+	dst.sprintID = src.sprintID
+	dst.sprintCardID = src.sprintCardID
+	dst.now = src.now
+}
+
+func cloneSlices[T any](m map[int][]T) map[int][]T {
+	result := make(map[int][]T, len(m))
+	for k, v := range m {
+		result[k] = slices.Clone(v)
+	}
+	return result
+}
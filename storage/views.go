@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"time"
+)
+
+func (s *Storage) GetViews(boardID int, username string) ([]model.View, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	views := sortedByID(s.views, func(v model.View) int { return v.ID })
+	return slices.DeleteFunc(views, func(v model.View) bool {
+		return v.BoardID != boardID || (v.Owner != username && !v.Shared)
+	}), nil
+}
+
+func (s *Storage) GetView(id int) (model.View, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	view, ok := s.views[id]
+	if !ok {
+		return model.View{}, fmt.Errorf("view %d: %w", id, model.ErrNotFound)
+	}
+	return view, nil
+}
+
+func (s *Storage) CreateView(input model.ViewInput) (model.View, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.boards[input.BoardID]; !ok {
+		return model.View{}, fmt.Errorf("board %d: %w", input.BoardID, model.ErrNotFound)
+	}
+	s.viewID++
+	now := s.now()
+	view := model.View{ID: s.viewID, BoardID: input.BoardID, Owner: input.Owner, CreatedAt: now}
+	s.views[view.ID] = applyView(view, input, now)
+	return s.views[view.ID], nil
+}
+
+func (s *Storage) UpdateView(id int, input model.ViewInput) (model.View, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	view, ok := s.views[id]
+	if !ok {
+		return model.View{}, fmt.Errorf("view %d: %w", id, model.ErrNotFound)
+	}
+	s.views[id] = applyView(view, input, s.now())
+	return s.views[id], nil
+}
+
+func (s *Storage) DeleteView(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.views[id]; !ok {
+		return fmt.Errorf("view %d: %w", id, model.ErrNotFound)
+	}
+	delete(s.views, id)
+	return nil
+}
+
+func applyView(view model.View, input model.ViewInput, now time.Time) model.View {
+	view.Name = input.Name
+	view.Query = input.Query
+	view.Sort = input.Sort
+	view.Desc = input.Desc
+	view.Group = input.Group
+	view.Shared = input.Shared
+	view.UpdatedAt = now
+	return view
+}
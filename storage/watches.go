@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"awesomeProject2/cmd/model"
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type watchKey struct {
+	kind     string
+	targetID int
+	username string
+}
+
+func (s *Storage) Watch(username string, target model.WatchTarget) (model.Watch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var exists bool
+	switch target.Kind {
+	case model.WatchCard:
+		card, ok := s.cards[target.ID]
+		exists = ok && card.DeletedAt == nil
+	case model.WatchList:
+		_, exists = s.lists[target.ID]
+	case model.WatchBoard:
+		_, exists = s.boards[target.ID]
+	default:
+		return model.Watch{}, fmt.Errorf("watch kind %q: %w", target.Kind, model.ErrInvalidInput)
+	}
+	if !exists {
+		return model.Watch{}, fmt.Errorf("%s %d: %w", target.Kind, target.ID, model.ErrNotFound)
+	}
+	key := watchKey{target.Kind, target.ID, username}
+	if watch, ok := s.watches[key]; ok {
+		return watch, nil
+	}
+	watch := model.Watch{Username: username, Kind: target.Kind, TargetID: target.ID, CreatedAt: s.now()}
+	s.watches[key] = watch
+	return watch, nil
+}
+
+func (s *Storage) Unwatch(username string, target model.WatchTarget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.Contains(model.WatchKinds, target.Kind) {
+		return fmt.Errorf("watch kind %q: %w", target.Kind, model.ErrInvalidInput)
+	}
+	key := watchKey{target.Kind, target.ID, username}
+	if _, ok := s.watches[key]; !ok {
+		return fmt.Errorf("watch of %s %d by %s: %w", target.Kind, target.ID, username, model.ErrNotFound)
+	}
+	delete(s.watches, key)
+	return nil
+}
+
+func (s *Storage) GetWatching(username string) ([]model.Watch, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var watches []model.Watch
+	for key, watch := range s.watches {
+		if key.username == username {
+			watches = append(watches, watch)
+		}
+	}
+	slices.SortFunc(watches, func(a, b model.Watch) int {
+		return cmp.Or(strings.Compare(a.Kind, b.Kind), a.TargetID-b.TargetID)
+	})
+	return watches, nil
+}
+
+func (s *Storage) GetCardWatchers(cardID int) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	card, ok := s.cards[cardID]
+	if !ok {
+		return nil, nil
+	}
+	targets := map[string]int{
+		model.WatchCard:  card.ID,
+		model.WatchList:  card.ListID,
+		model.WatchBoard: card.BoardID,
+	}
+	var watchers []string
+	for key := range maps.Keys(s.watches) {
+		if targets[key.kind] == key.targetID {
+			watchers = append(watchers, key.username)
+		}
+	}
+	slices.Sort(watchers)
+	return slices.Compact(watchers), nil
+}
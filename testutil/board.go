@@ -0,0 +1,42 @@
+// Package testutil содержит общую для тестов сервисов доску-образец в
+// хранилище в памяти.
+package testutil
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// BoardFixture — доска b со списками todo (карточки Login и Report) и done
+// (карточка Cleanup). Метки, исполнителей и поля тесты добавляют сами.
+type BoardFixture struct {
+	Store                  *storage.Storage
+	Board                  model.Board
+	Todo, Done             model.List
+	Login, Report, Cleanup model.Card
+}
+
+func NewBoardFixture(t *testing.T) BoardFixture {
+	t.Helper()
+	b := BoardFixture{Store: storage.NewStorage()}
+	var err error
+	b.Board, err = b.Store.CreateBoard("b")
+	require.NoError(t, err)
+	b.Todo, err = b.Store.CreateList(model.ListInputCreate{BoardID: b.Board.ID, Title: "todo"})
+	require.NoError(t, err)
+	b.Done, err = b.Store.CreateList(model.ListInputCreate{BoardID: b.Board.ID, Title: "done"})
+	require.NoError(t, err)
+	b.Login = b.card(t, b.Todo.ID, "Login")
+	b.Report = b.card(t, b.Todo.ID, "Report")
+	b.Cleanup = b.card(t, b.Done.ID, "Cleanup")
+	return b
+}
+
+func (b BoardFixture) card(t *testing.T, listID int, title string) model.Card {
+	t.Helper()
+	c, err := b.Store.CreateCard(model.CardInputCreate{ListID: listID, Title: title})
+	require.NoError(t, err)
+	return c
+}
@@ -0,0 +1,29 @@
+package view
+
+import "awesomeProject2/cmd/model"
+
+type Storage interface {
+	GetViews(boardID int, username string) ([]model.View, error)
+	GetView(id int) (model.View, error)
+	CreateView(input model.ViewInput) (model.View, error)
+	UpdateView(id int, input model.ViewInput) (model.View, error)
+	DeleteView(id int) error
+}
+
+type BoardReader interface {
+	GetBoard(id int) (model.Board, error)
+	GetLists(boardID *int) ([]model.List, error)
+	GetFields(boardID int) ([]model.CustomField, error)
+}
+
+// CardFinder — выборка карточек; в приложении это service.CardService,
+// который разбирает значения полей и заполняет карточки.
+type CardFinder interface {
+	GetCards(filter model.CardFilter) ([]model.Card, error)
+}
+
+// CardDetails нужен для группировки по меткам и исполнителям.
+type CardDetails interface {
+	GetCardLabels(cardID int) ([]model.Label, error)
+	GetCardAssignees(cardID int) ([]string, error)
+}
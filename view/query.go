@@ -0,0 +1,167 @@
+package view
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query — разобранный запрос на языке фильтров карточек. Термы
+// разделяются пробелами и объединяются через И:
+//
+//	label:bug label:"needs review"  карточка с обеими метками
+//	assignee:anna assignee:me       me — тот, кто смотрит
+//	status:done,review              любой из статусов
+//	due:2026-03-01..2026-03-31      срок в днях включительно; границу
+//	due:..2026-03-31 due:2026-03-05 можно опустить, один день — без ..
+//	field:Priority=high             пользовательское поле по названию
+//	field:"Story points"=3
+//
+// Остальные слова и фразы в кавычках ищутся в названии и описании.
+type Query struct {
+	Labels    []string
+	Assignees []string
+	Statuses  []string
+	DueFrom   *time.Time
+	// DueTo — начало дня после последнего дня диапазона.
+	DueTo  *time.Time
+	Text   string
+	Fields []FieldTerm
+}
+
+type FieldTerm struct {
+	Name  string
+	Value string
+}
+
+// Me в assignee: заменяется на текущего пользователя.
+const Me = "me"
+
+func Parse(q string) (Query, error) {
+	var query Query
+	tokens, err := tokenize(q)
+	if err != nil {
+		return Query{}, err
+	}
+	var text []string
+	for _, token := range tokens {
+		key, value, ok := strings.Cut(token, ":")
+		if strings.HasPrefix(token, `"`) || !ok {
+			text = append(text, unquote(token))
+			continue
+		}
+		if unquote(value) == "" {
+			return Query{}, fmt.Errorf("%w: %s: needs a value", model.ErrInvalidInput, key)
+		}
+		switch strings.ToLower(key) {
+		case "label":
+			query.Labels = append(query.Labels, unquote(value))
+		case "assignee":
+			query.Assignees = append(query.Assignees, unquote(value))
+		case "status":
+			for _, status := range strings.Split(unquote(value), ",") {
+				query.Statuses = append(query.Statuses, strings.TrimSpace(status))
+			}
+		case "due":
+			if query.DueFrom, query.DueTo, err = parseDue(unquote(value)); err != nil {
+				return Query{}, err
+			}
+		case "field":
+			name, fieldValue, ok := cutOutsideQuotes(value, '=')
+			if !ok || unquote(name) == "" {
+				return Query{}, fmt.Errorf("%w: field: expects name=value", model.ErrInvalidInput)
+			}
+			query.Fields = append(query.Fields, FieldTerm{Name: unquote(name), Value: unquote(fieldValue)})
+		default:
+			return Query{}, fmt.Errorf("%w: unknown filter %q; quote the text to search for it", model.ErrInvalidInput, key)
+		}
+	}
+	query.Text = strings.Join(text, " ")
+	return query, nil
+}
+
+// tokenize делит запрос по пробелам вне кавычек. Кавычки остаются в
+// термах, чтобы отличать "a:b" от фильтра.
+func tokenize(q string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", model.ErrInvalidInput)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+func cutOutsideQuotes(s string, sep rune) (string, string, bool) {
+	quoted := false
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == sep && !quoted:
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+func unquote(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
+
+// parseDue разбирает день или диапазон дней в полуинтервал [from, to)
+// в UTC.
+func parseDue(value string) (*time.Time, *time.Time, error) {
+	first, last, isRange := strings.Cut(value, "..")
+	if !isRange {
+		last = first
+	}
+	day := func(s string) (*time.Time, error) {
+		if s == "" {
+			return nil, nil
+		}
+		t, err := time.Parse(model.FieldDateLayout, s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: due: %q is not a date %s", model.ErrInvalidInput, s, model.FieldDateLayout)
+		}
+		return &t, nil
+	}
+	from, err := day(first)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := day(last)
+	if err != nil {
+		return nil, nil, err
+	}
+	if from == nil && to == nil {
+		return nil, nil, fmt.Errorf("%w: due: needs a date", model.ErrInvalidInput)
+	}
+	if to != nil {
+		next := to.AddDate(0, 0, 1)
+		to = &next
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, fmt.Errorf("%w: due: range ends before it starts", model.ErrInvalidInput)
+	}
+	return from, to, nil
+}
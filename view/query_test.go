@@ -0,0 +1,58 @@
+package view
+
+import (
+	"awesomeProject2/cmd/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	day := func(s string) *time.Time {
+		d, err := time.Parse(model.FieldDateLayout, s)
+		require.NoError(t, err)
+		return &d
+	}
+	tests := []struct {
+		name        string
+		q           string
+		want        Query
+		expectError bool
+	}{
+		{name: "empty", q: "  ", want: Query{}},
+		{name: "text", q: `login  "fails on" safari`, want: Query{Text: "login fails on safari"}},
+		{
+			name: "filters",
+			q:    `label:bug Label:"needs review" assignee:me status:done,review`,
+			want: Query{Labels: []string{"bug", "needs review"}, Assignees: []string{"me"}, Statuses: []string{"done", "review"}},
+		},
+		{name: "due range", q: "due:2026-03-01..2026-03-31", want: Query{DueFrom: day("2026-03-01"), DueTo: day("2026-04-01")}},
+		{name: "due day", q: "due:2026-03-05", want: Query{DueFrom: day("2026-03-05"), DueTo: day("2026-03-06")}},
+		{name: "due until", q: "due:..2026-03-05", want: Query{DueTo: day("2026-03-06")}},
+		{name: "due since", q: "due:2026-03-05..", want: Query{DueFrom: day("2026-03-05")}},
+		{
+			name: "fields",
+			q:    `field:Priority=high field:"Story points"=3 field:Note="a=b c"`,
+			want: Query{Fields: []FieldTerm{{"Priority", "high"}, {"Story points", "3"}, {"Note", "a=b c"}}},
+		},
+		{name: "quoted colon is text", q: `"12:30"`, want: Query{Text: "12:30"}},
+		{name: "unknown filter", q: "lable:bug", expectError: true},
+		{name: "missing value", q: "label:", expectError: true},
+		{name: "unterminated quote", q: `label:"bug`, expectError: true},
+		{name: "bad date", q: "due:tomorrow", expectError: true},
+		{name: "empty range", q: "due:..", expectError: true},
+		{name: "reversed range", q: "due:2026-03-05..2026-03-01", expectError: true},
+		{name: "field without value", q: "field:Priority", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.q)
+			if tt.expectError {
+				require.ErrorIs(t, err, model.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
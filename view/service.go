@@ -0,0 +1,281 @@
+package view
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxViewNameLength  = 100
+	maxViewQueryLength = 1000
+	// fieldSortPrefix начинает сортировку по пользовательскому полю:
+	// field:<название>.
+	fieldSortPrefix = model.CardSortField + ":"
+)
+
+// Service ищет карточки доски по запросу и хранит именованные
+// представления: запрос, сортировку и группировку.
+type Service struct {
+	Storage Storage
+	Boards  BoardReader
+	Cards   CardFinder
+	Details CardDetails
+}
+
+func NewService(storage Storage, boards BoardReader, cards CardFinder, details CardDetails) *Service {
+	return &Service{
+		Storage: storage,
+		Boards:  boards,
+		Cards:   cards,
+		Details: details,
+	}
+}
+
+// Search возвращает карточки доски по запросу q. sort — одно из
+// model.CardSorts, кроме field, или field:<название поля>; пустая
+// сортировка — по id.
+func (s Service) Search(boardID int, username, q, sort string, desc bool) ([]model.Card, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	filter, err := s.filter(boardID, username, q, sort, desc)
+	if err != nil {
+		return nil, err
+	}
+	return s.Cards.GetCards(filter)
+}
+
+// GetViews возвращает свои и общие представления доски.
+func (s Service) GetViews(boardID int, username string) ([]model.View, error) {
+	if _, err := s.Boards.GetBoard(boardID); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetViews(boardID, username)
+}
+
+func (s Service) CreateView(input model.ViewInput) (model.View, error) {
+	if _, err := s.Boards.GetBoard(input.BoardID); err != nil {
+		return model.View{}, err
+	}
+	input, err := s.validate(0, input)
+	if err != nil {
+		return model.View{}, err
+	}
+	return s.Storage.CreateView(input)
+}
+
+// UpdateView заменяет представление целиком; менять его может только
+// владелец, доска и владелец не меняются.
+func (s Service) UpdateView(id int, username string, input model.ViewInput) (model.View, error) {
+	view, err := s.ownView(id, username)
+	if err != nil {
+		return model.View{}, err
+	}
+	input.BoardID, input.Owner = view.BoardID, view.Owner
+	if input, err = s.validate(id, input); err != nil {
+		return model.View{}, err
+	}
+	return s.Storage.UpdateView(id, input)
+}
+
+func (s Service) DeleteView(id int, username string) error {
+	if _, err := s.ownView(id, username); err != nil {
+		return err
+	}
+	return s.Storage.DeleteView(id)
+}
+
+// ViewCards выполняет представление для username и раскладывает карточки
+// по группам. assignee:me в общем представлении — тот, кто смотрит.
+func (s Service) ViewCards(id int, username string) (model.View, []model.CardGroup, error) {
+	view, err := s.visibleView(id, username)
+	if err != nil {
+		return model.View{}, nil, err
+	}
+	cards, err := s.Search(view.BoardID, username, view.Query, view.Sort, view.Desc)
+	if err != nil {
+		return model.View{}, nil, err
+	}
+	groups, err := s.group(view, cards)
+	return view, groups, err
+}
+
+func (s Service) visibleView(id int, username string) (model.View, error) {
+	view, err := s.Storage.GetView(id)
+	if err != nil {
+		return model.View{}, err
+	}
+	if !view.Shared && view.Owner != username {
+		return model.View{}, fmt.Errorf("view %d: %w", id, model.ErrNotFound)
+	}
+	return view, nil
+}
+
+func (s Service) ownView(id int, username string) (model.View, error) {
+	view, err := s.visibleView(id, username)
+	if err != nil {
+		return model.View{}, err
+	}
+	if view.Owner != username {
+		return model.View{}, fmt.Errorf("%w: view %d belongs to %s", model.ErrForbidden, id, view.Owner)
+	}
+	return view, nil
+}
+
+// validate проверяет название (уникальное среди представлений владельца
+// на доске), группировку, а запрос и сортировку — построив по ним
+// фильтр, чтобы ошибки в названиях полей были видны сразу.
+func (s Service) validate(id int, input model.ViewInput) (model.ViewInput, error) {
+	if err := model.ValidateUsername(input.Owner); err != nil {
+		return model.ViewInput{}, err
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || utf8.RuneCountInString(input.Name) > maxViewNameLength {
+		return model.ViewInput{}, fmt.Errorf("%w: view name must be 1 to %d characters", model.ErrInvalidInput, maxViewNameLength)
+	}
+	input.Query = strings.TrimSpace(input.Query)
+	if utf8.RuneCountInString(input.Query) > maxViewQueryLength {
+		return model.ViewInput{}, fmt.Errorf("%w: query is longer than %d characters", model.ErrInvalidInput, maxViewQueryLength)
+	}
+	if !slices.Contains(model.ViewGroups, input.Group) {
+		return model.ViewInput{}, fmt.Errorf("%w: group_by must be empty or one of %s", model.ErrInvalidInput, strings.Join(model.ViewGroups[1:], ", "))
+	}
+	if _, err := s.filter(input.BoardID, input.Owner, input.Query, input.Sort, input.Desc); err != nil {
+		return model.ViewInput{}, err
+	}
+	views, err := s.Storage.GetViews(input.BoardID, input.Owner)
+	if err != nil {
+		return model.ViewInput{}, err
+	}
+	for _, v := range views {
+		if v.ID != id && v.Owner == input.Owner && strings.EqualFold(v.Name, input.Name) {
+			return model.ViewInput{}, fmt.Errorf("%w: view %q already exists", model.ErrInvalidInput, input.Name)
+		}
+	}
+	return input, nil
+}
+
+// filter строит выборку карточек доски по запросу, подставляя
+// пользователя вместо me и id полей вместо их названий.
+func (s Service) filter(boardID int, username, q, sort string, desc bool) (model.CardFilter, error) {
+	query, err := Parse(q)
+	if err != nil {
+		return model.CardFilter{}, err
+	}
+	filter := model.CardFilter{
+		BoardID:  &boardID,
+		Labels:   query.Labels,
+		Statuses: query.Statuses,
+		DueFrom:  query.DueFrom,
+		DueTo:    query.DueTo,
+		Text:     query.Text,
+	}
+	for _, a := range query.Assignees {
+		if strings.EqualFold(a, Me) {
+			a = username
+		}
+		filter.Assignees = append(filter.Assignees, a)
+	}
+	var fields []model.CustomField
+	fieldID := func(name string) (int, error) {
+		if fields == nil {
+			if fields, err = s.Boards.GetFields(boardID); err != nil {
+				return 0, err
+			}
+		}
+		i := slices.IndexFunc(fields, func(f model.CustomField) bool { return strings.EqualFold(f.Name, name) })
+		if i < 0 {
+			return 0, fmt.Errorf("%w: board has no field %q", model.ErrInvalidInput, name)
+		}
+		return fields[i].ID, nil
+	}
+	for _, term := range query.Fields {
+		id, err := fieldID(term.Name)
+		if err != nil {
+			return model.CardFilter{}, err
+		}
+		filter.Fields = append(filter.Fields, model.CardFieldFilter{FieldID: id, Value: term.Value})
+	}
+	switch {
+	case sort == "":
+	case strings.HasPrefix(sort, fieldSortPrefix):
+		id, err := fieldID(strings.TrimPrefix(sort, fieldSortPrefix))
+		if err != nil {
+			return model.CardFilter{}, err
+		}
+		filter.Sort = &model.CardSort{By: model.CardSortField, FieldID: id, Desc: desc}
+	case sort != model.CardSortField && slices.Contains(model.CardSorts, sort):
+		filter.Sort = &model.CardSort{By: sort, Desc: desc}
+	default:
+		return model.CardFilter{}, fmt.Errorf("%w: sort must be one of %s or field:<name>", model.ErrInvalidInput, strings.Join(model.CardSorts[:len(model.CardSorts)-1], ", "))
+	}
+	return filter, nil
+}
+
+// group раскладывает карточки по группам представления, сохраняя их
+// порядок внутри группы. Списки идут в порядке доски, остальные группы —
+// в порядке первой карточки; группа без ключа — последней.
+func (s Service) group(view model.View, cards []model.Card) ([]model.CardGroup, error) {
+	var keys func(card model.Card) ([]string, error)
+	var order []string
+	switch view.Group {
+	case model.GroupNone:
+		return []model.CardGroup{{Cards: cards}}, nil
+	case model.GroupList:
+		lists, err := s.Boards.GetLists(&view.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		titles := map[int]string{}
+		for _, l := range lists {
+			titles[l.ID] = l.Title
+			order = append(order, l.Title)
+		}
+		keys = func(card model.Card) ([]string, error) { return []string{titles[card.ListID]}, nil }
+	case model.GroupStatus:
+		keys = func(card model.Card) ([]string, error) { return []string{card.Status}, nil }
+	case model.GroupLabel:
+		keys = func(card model.Card) ([]string, error) {
+			labels, err := s.Details.GetCardLabels(card.ID)
+			names := make([]string, 0, len(labels))
+			for _, l := range labels {
+				names = append(names, l.Name)
+			}
+			return names, err
+		}
+	case model.GroupAssignee:
+		keys = func(card model.Card) ([]string, error) { return s.Details.GetCardAssignees(card.ID) }
+	default:
+		return nil, fmt.Errorf("unknown group %q", view.Group)
+	}
+
+	byKey := map[string][]model.Card{}
+	for _, card := range cards {
+		cardKeys, err := keys(card)
+		if err != nil {
+			return nil, err
+		}
+		if len(cardKeys) == 0 {
+			cardKeys = []string{""}
+		}
+		for _, key := range cardKeys {
+			if !slices.Contains(order, key) {
+				order = append(order, key)
+			}
+			byKey[key] = append(byKey[key], card)
+		}
+	}
+	groups := []model.CardGroup{}
+	for _, key := range order {
+		if key != "" && len(byKey[key]) > 0 {
+			groups = append(groups, model.CardGroup{Key: key, Cards: byKey[key]})
+		}
+	}
+	if cards := byKey[""]; len(cards) > 0 {
+		groups = append(groups, model.CardGroup{Cards: cards})
+	}
+	return groups, nil
+}
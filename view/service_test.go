@@ -0,0 +1,162 @@
+package view
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+// fixture — доска testutil.BoardFixture с метками, исполнителями и полем Points
+// для поиска и группировки.
+type fixture struct {
+	testutil.BoardFixture
+	svc *Service
+}
+
+func newFixture(t *testing.T) fixture {
+	f := fixture{BoardFixture: testutil.NewBoardFixture(t)}
+	store := f.Store
+	bug, err := store.CreateLabel(model.LabelInputCreate{BoardID: f.Board.ID, Name: "bug"})
+	require.NoError(t, err)
+	require.NoError(t, store.AddCardLabel(f.Login.ID, bug.ID))
+	require.NoError(t, store.AddCardLabel(f.Cleanup.ID, bug.ID))
+	require.NoError(t, store.AddCardAssignee(f.Login.ID, "anna"))
+	require.NoError(t, store.AddCardAssignee(f.Report.ID, "boris"))
+	points, err := store.CreateField(model.CustomFieldInputCreate{BoardID: f.Board.ID, Name: "Points", Type: model.FieldNumber})
+	require.NoError(t, err)
+
+	cards := service.NewCardService(store, store, nil, zap.NewNop())
+	cards.Fields = store
+	for c, p := range map[int]float64{f.Login.ID: 5, f.Report.ID: 1} {
+		_, err := cards.SetFieldValue(c, points.ID, p)
+		require.NoError(t, err)
+	}
+	f.svc = NewService(store, store, cards, store)
+	return f
+}
+
+func TestSearch(t *testing.T) {
+	f := newFixture(t)
+	tests := []struct {
+		name        string
+		q           string
+		sort        string
+		desc        bool
+		want        []int
+		expectError bool
+	}{
+		{name: "all", want: []int{f.Login.ID, f.Report.ID, f.Cleanup.ID}},
+		{name: "my bugs", q: "label:bug assignee:me", want: []int{f.Login.ID}},
+		{name: "text", q: "report", want: []int{f.Report.ID}},
+		{name: "field by name", q: "field:points=1", want: []int{f.Report.ID}},
+		{name: "sort by field", sort: "field:Points", desc: true, want: []int{f.Login.ID, f.Report.ID, f.Cleanup.ID}},
+		{name: "sort by title", sort: model.CardSortTitle, want: []int{f.Cleanup.ID, f.Login.ID, f.Report.ID}},
+		{name: "unknown field", q: "field:Size=3", expectError: true},
+		{name: "invalid field value", q: "field:Points=many", expectError: true},
+		{name: "unknown sort", sort: "priority", expectError: true},
+		{name: "bare field sort", sort: model.CardSortField, expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, err := f.svc.Search(f.Board.ID, "anna", tt.q, tt.sort, tt.desc)
+			if tt.expectError {
+				require.ErrorIs(t, err, model.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			var ids []int
+			for _, c := range cards {
+				ids = append(ids, c.ID)
+			}
+			require.Equal(t, tt.want, ids)
+		})
+	}
+	_, err := f.svc.Search(4242, "anna", "", "", false)
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestViews(t *testing.T) {
+	f := newFixture(t)
+	mine, err := f.svc.CreateView(model.ViewInput{BoardID: f.Board.ID, Owner: "anna", Name: " My bugs ", Query: "label:bug assignee:me", Shared: true})
+	require.NoError(t, err)
+	require.Equal(t, "My bugs", mine.Name)
+	private, err := f.svc.CreateView(model.ViewInput{BoardID: f.Board.ID, Owner: "anna", Name: "Private"})
+	require.NoError(t, err)
+	_, err = f.svc.CreateView(model.ViewInput{BoardID: f.Board.ID, Owner: "boris", Name: "my bugs"})
+	require.NoError(t, err, "names are unique per owner")
+
+	for name, input := range map[string]model.ViewInput{
+		"duplicate name": {Name: "MY BUGS"},
+		"empty name":     {Name: " "},
+		"bad query":      {Name: "x", Query: "lable:bug"},
+		"unknown field":  {Name: "x", Sort: "field:Size"},
+		"bad group":      {Name: "x", Group: "color"},
+	} {
+		input.BoardID, input.Owner = f.Board.ID, "anna"
+		_, err := f.svc.CreateView(input)
+		require.ErrorIs(t, err, model.ErrInvalidInput, name)
+	}
+
+	views, err := f.svc.GetViews(f.Board.ID, "vera")
+	require.NoError(t, err)
+	require.Equal(t, []model.View{mine}, views, "others see only shared views")
+	_, _, err = f.svc.ViewCards(private.ID, "vera")
+	require.ErrorIs(t, err, model.ErrNotFound)
+	_, err = f.svc.UpdateView(mine.ID, "vera", model.ViewInput{Name: "Hijacked"})
+	require.ErrorIs(t, err, model.ErrForbidden)
+	require.ErrorIs(t, f.svc.DeleteView(mine.ID, "vera"), model.ErrForbidden)
+
+	updated, err := f.svc.UpdateView(mine.ID, "anna", model.ViewInput{Name: "My bugs", Query: "label:bug assignee:me", Group: model.GroupList, Shared: true})
+	require.NoError(t, err)
+	require.Equal(t, "anna", updated.Owner)
+	require.NoError(t, f.svc.DeleteView(private.ID, "anna"))
+}
+
+func TestViewCards(t *testing.T) {
+	f := newFixture(t)
+	require.NoError(t, f.Store.AddCardAssignee(f.Login.ID, "boris"))
+	keys := func(groups []model.CardGroup) map[string][]int {
+		result := map[string][]int{}
+		for _, g := range groups {
+			for _, c := range g.Cards {
+				result[g.Key] = append(result[g.Key], c.ID)
+			}
+		}
+		return result
+	}
+	tests := []struct {
+		group string
+		want  map[string][]int
+		order []string
+	}{
+		{model.GroupNone, map[string][]int{"": {f.Login.ID, f.Report.ID, f.Cleanup.ID}}, []string{""}},
+		{model.GroupList, map[string][]int{"todo": {f.Login.ID, f.Report.ID}, "done": {f.Cleanup.ID}}, []string{"todo", "done"}},
+		{model.GroupLabel, map[string][]int{"bug": {f.Login.ID, f.Cleanup.ID}, "": {f.Report.ID}}, []string{"bug", ""}},
+		{model.GroupAssignee, map[string][]int{"anna": {f.Login.ID}, "boris": {f.Login.ID, f.Report.ID}, "": {f.Cleanup.ID}}, []string{"anna", "boris", ""}},
+		{model.GroupStatus, map[string][]int{"": {f.Login.ID, f.Report.ID, f.Cleanup.ID}}, []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run("group "+tt.group, func(t *testing.T) {
+			v, err := f.svc.CreateView(model.ViewInput{BoardID: f.Board.ID, Owner: "anna", Name: "by " + tt.group, Group: tt.group})
+			require.NoError(t, err)
+			_, groups, err := f.svc.ViewCards(v.ID, "anna")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, keys(groups))
+			var order []string
+			for _, g := range groups {
+				order = append(order, g.Key)
+			}
+			require.Equal(t, tt.order, order)
+		})
+	}
+
+	// assignee:me в общем представлении — тот, кто смотрит.
+	v, err := f.svc.CreateView(model.ViewInput{BoardID: f.Board.ID, Owner: "anna", Name: "Mine", Query: "assignee:me", Shared: true})
+	require.NoError(t, err)
+	_, groups, err := f.svc.ViewCards(v.ID, "boris")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]int{"": {f.Login.ID, f.Report.ID}}, keys(groups))
+}
@@ -0,0 +1,41 @@
+package watch
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"go.uber.org/zap"
+)
+
+// AutoWatcher подписывает на карточку того, кто её создал,
+// прокомментировал или стал её исполнителем. Отписаться можно как
+// обычно; следующее такое действие подпишет снова.
+type AutoWatcher struct {
+	Storage Storage
+	logger  *zap.Logger
+}
+
+var _ service.EventPublisher = (*AutoWatcher)(nil)
+
+func NewAutoWatcher(storage Storage, logger *zap.Logger) *AutoWatcher {
+	return &AutoWatcher{Storage: storage, logger: logger}
+}
+
+func (w *AutoWatcher) Publish(event model.Event) {
+	var username string
+	switch event.Type {
+	case model.EventCardCreated:
+		username = event.Actor
+	case model.EventCommentAdded:
+		if event.Comment != nil {
+			username = event.Comment.Author
+		}
+	case model.EventCardAssigned:
+		username = event.Username
+	}
+	if username == "" {
+		return
+	}
+	if _, err := w.Storage.Watch(username, model.WatchTarget{Kind: model.WatchCard, ID: event.Card.ID}); err != nil {
+		w.logger.Error("Не удалось подписать на карточку", zap.Error(err), zap.String("username", username), zap.Int("cardID", event.Card.ID))
+	}
+}
@@ -0,0 +1,47 @@
+package watch
+
+import (
+	"awesomeProject2/cmd/model"
+	"awesomeProject2/cmd/service"
+	"awesomeProject2/cmd/storage"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestAutoWatcher(t *testing.T) {
+	store := storage.NewStorage()
+	board, err := store.CreateBoard("b")
+	require.NoError(t, err)
+	list, err := store.CreateList(model.ListInputCreate{BoardID: board.ID, Title: "todo"})
+	require.NoError(t, err)
+	listeners := service.NewDispatcher(NewAutoWatcher(store, zap.NewNop()))
+	cards := service.NewCardService(store, store, listeners, zap.NewNop())
+	comments := service.NewCommentService(store, store, listeners, zap.NewNop())
+
+	card, err := cards.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "Релиз", Creator: "anna"})
+	require.NoError(t, err)
+	anonymous, err := cards.CreateCard(model.CardInputCreate{ListID: list.ID, Title: "Без автора"})
+	require.NoError(t, err)
+	_, err = comments.AddComment(card.ID, "boris", "посмотрю")
+	require.NoError(t, err)
+	listeners.Publish(model.Event{Type: model.EventCardAssigned, Card: card, Username: "vera"})
+	listeners.Publish(model.Event{Type: model.EventCardMoved, Card: card, Actor: "gleb"})
+
+	watchers, err := store.GetCardWatchers(card.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"anna", "boris", "vera"}, watchers)
+	watchers, err = store.GetCardWatchers(anonymous.ID)
+	require.NoError(t, err)
+	require.Empty(t, watchers)
+}
+
+func TestServiceValidation(t *testing.T) {
+	s := NewService(storage.NewStorage())
+	_, err := s.Watch("anna", model.WatchTarget{Kind: "column", ID: 1})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+	_, err = s.Watch("", model.WatchTarget{Kind: model.WatchCard, ID: 1})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+	err = s.Unwatch("an na", model.WatchTarget{Kind: model.WatchCard, ID: 1})
+	require.ErrorIs(t, err, model.ErrInvalidInput)
+}
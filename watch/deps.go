@@ -0,0 +1,10 @@
+package watch
+
+import "awesomeProject2/cmd/model"
+
+type Storage interface {
+	Watch(username string, target model.WatchTarget) (model.Watch, error)
+	Unwatch(username string, target model.WatchTarget) error
+	GetWatching(username string) ([]model.Watch, error)
+	GetCardWatchers(cardID int) ([]string, error)
+}
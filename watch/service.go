@@ -0,0 +1,54 @@
+package watch
+
+import (
+	"awesomeProject2/cmd/model"
+	"fmt"
+	"slices"
+)
+
+// Service управляет подписками на карточки, списки и доски. Наблюдатели
+// — аудитория уведомлений об изменениях карточки.
+type Service struct {
+	Storage Storage
+}
+
+func NewService(storage Storage) *Service {
+	return &Service{Storage: storage}
+}
+
+func (s Service) Watch(username string, target model.WatchTarget) (model.Watch, error) {
+	if err := validate(username, target); err != nil {
+		return model.Watch{}, err
+	}
+	return s.Storage.Watch(username, target)
+}
+
+func (s Service) Unwatch(username string, target model.WatchTarget) error {
+	if err := validate(username, target); err != nil {
+		return err
+	}
+	return s.Storage.Unwatch(username, target)
+}
+
+func (s Service) GetWatching(username string) ([]model.Watch, error) {
+	if err := model.ValidateUsername(username); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetWatching(username)
+}
+
+// GetCardWatchers возвращает всех, кто получит уведомление об изменении
+// карточки, включая наблюдателей её списка и доски.
+func (s Service) GetCardWatchers(cardID int) ([]string, error) {
+	return s.Storage.GetCardWatchers(cardID)
+}
+
+func validate(username string, target model.WatchTarget) error {
+	if err := model.ValidateUsername(username); err != nil {
+		return err
+	}
+	if !slices.Contains(model.WatchKinds, target.Kind) {
+		return fmt.Errorf("%w: unknown watch kind %q", model.ErrInvalidInput, target.Kind)
+	}
+	return nil
+}